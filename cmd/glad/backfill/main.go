@@ -0,0 +1,92 @@
+// Command backfill brings items written by earlier releases in line with the current schema.
+//
+// Usage:
+//
+//	go run ./cmd/glad/backfill [--only skill-counts] [--dry-run]
+//
+// Without --only every backfill runs, in the order listed by --help. Each one only rewrites items
+// that differ from what they should hold, so it is safe to run again after an interruption.
+// It uses the same configuration as the Lambda (DYNAMODB_TABLE, AWS_REGION, ENVIRONMENT,
+// DB_MOCK, LOG_LEVEL).
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/database"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/service"
+	"github.com/hackmajoris/glad-stack/pkg/config"
+	"github.com/hackmajoris/glad-stack/pkg/logger"
+)
+
+// backfill is one named step of the command
+type backfill struct {
+	name string
+	run  func(s *service.BackfillService, dryRun bool) (*service.BackfillReport, error)
+}
+
+// backfills lists every step in the order they run
+var backfills = []backfill{
+	{name: "skill-counts", run: (*service.BackfillService).BackfillSkillCounts},
+	{name: "skill-name-lower", run: (*service.BackfillService).BackfillSkillNameLower},
+	{name: "skill-name-claims", run: (*service.BackfillService).BackfillSkillNameClaims},
+	{name: "user-search-names", run: (*service.BackfillService).BackfillUserSearchNames},
+	{name: "email-claims", run: (*service.BackfillService).BackfillEmailClaims},
+}
+
+func main() {
+	names := make([]string, len(backfills))
+	for i, b := range backfills {
+		names[i] = b.name
+	}
+
+	only := flag.String("only", "", "run just this backfill: "+strings.Join(names, ", "))
+	dryRun := flag.Bool("dry-run", false, "report what would change without writing")
+	flag.Parse()
+
+	cfg := config.Load()
+	if err := logger.Configure(cfg.Log.Level, cfg.Log.Format); err != nil {
+		log.Fatalf("Invalid logging configuration: %v", err)
+	}
+
+	repo := database.NewRepository(cfg)
+	if err := run(repo, *only, *dryRun, os.Stdout); err != nil {
+		log.Fatalf("Backfill failed: %v", err)
+	}
+}
+
+// run executes the backfill named only, or all of them, against repo and writes a line per backfill to out
+func run(repo database.Repository, only string, dryRun bool, out io.Writer) error {
+	s := service.NewBackfillService(repo, repo, repo)
+
+	ran := false
+	for _, b := range backfills {
+		if only != "" && only != b.name {
+			continue
+		}
+		ran = true
+
+		report, err := b.run(s, dryRun)
+		if err != nil {
+			return fmt.Errorf("%s: %w", b.name, err)
+		}
+
+		verb := "updated"
+		if report.DryRun {
+			verb = "would update"
+		}
+		if _, err := fmt.Fprintf(out, "%s: scanned %d, %s %d\n", report.Name, report.Scanned, verb, report.Updated); err != nil {
+			return err
+		}
+	}
+
+	if !ran {
+		return fmt.Errorf("unknown backfill %q", only)
+	}
+	return nil
+}
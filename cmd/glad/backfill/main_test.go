@@ -0,0 +1,197 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/database"
+	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/service"
+)
+
+func TestRun_SkillCounts(t *testing.T) {
+	repo := database.NewMockRepository()
+
+	// alice's count drifted; bob's is already right
+	for _, username := range []string{"alice", "bob"} {
+		user, _ := models.NewUser(username, "Test User", "password123")
+		if err := repo.CreateUser(user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+		skill, _ := models.NewUserSkill(username, "go", "Go", "Programming", models.ProficiencyBeginner, 1)
+		if err := repo.CreateSkill(skill); err != nil {
+			t.Fatalf("Failed to create skill: %v", err)
+		}
+	}
+	if err := repo.AdjustSkillCount("alice", 4); err != nil {
+		t.Fatalf("Failed to adjust skill count: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := run(repo, "skill-counts", true, &out); err != nil {
+		t.Fatalf("Dry run failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "skill-counts: scanned 2, would update 1") {
+		t.Errorf("Unexpected dry run output: %s", out.String())
+	}
+	if count, _ := repo.CountSkillsForUser("alice"); count != 5 {
+		t.Errorf("Expected the dry run to leave the count at 5, got %d", count)
+	}
+
+	out.Reset()
+	if err := run(repo, "", false, &out); err != nil {
+		t.Fatalf("Backfill failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "skill-counts: scanned 2, updated 1") {
+		t.Errorf("Unexpected output: %s", out.String())
+	}
+	for _, username := range []string{"alice", "bob"} {
+		if count, _ := repo.CountSkillsForUser(username); count != 1 {
+			t.Errorf("Expected %s to have a count of 1, got %d", username, count)
+		}
+	}
+
+	// A second run has nothing left to do
+	out.Reset()
+	if err := run(repo, "", false, &out); err != nil {
+		t.Fatalf("Second backfill failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "skill-counts: scanned 2, updated 0") {
+		t.Errorf("Expected nothing to update on a second run, got %s", out.String())
+	}
+
+	if err := run(repo, "nonsense", false, &out); err == nil {
+		t.Error("Expected an unknown backfill to fail")
+	}
+}
+
+func TestRun_SkillNameLower(t *testing.T) {
+	repo := database.NewMockRepository()
+	for _, id := range []string{"go", "rust"} {
+		skill, _ := models.NewSkill(id, strings.ToUpper(id[:1])+id[1:], "", "Programming", nil)
+		if err := repo.CreateMasterSkill(skill); err != nil {
+			t.Fatalf("Failed to create master skill: %v", err)
+		}
+	}
+
+	// go was written before SkillNameLower existed
+	legacy, _ := repo.GetMasterSkill("go")
+	legacy.SkillNameLower = ""
+
+	var out bytes.Buffer
+	if err := run(repo, "skill-name-lower", false, &out); err != nil {
+		t.Fatalf("Backfill failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "skill-name-lower: scanned 2, updated 1") {
+		t.Errorf("Unexpected output: %s", out.String())
+	}
+	if skill, _ := repo.GetMasterSkill("go"); skill.SkillNameLower != "go" {
+		t.Errorf("Expected SkillNameLower go, got %q", skill.SkillNameLower)
+	}
+}
+
+func TestRun_SkillNameClaims(t *testing.T) {
+	// Seeded skills hold no name claims, like skills created before claims existed
+	golang, _ := models.NewSkill("go", "Go", "", "Programming", nil)
+	duplicate, _ := models.NewSkill("golang", "GO", "", "Programming", nil)
+	duplicate.CreatedAt = golang.CreatedAt.Add(time.Second)
+	retired, _ := models.NewSkill("cobol", "COBOL", "", "Programming", nil)
+	retired.MarkDeleted()
+	repo, err := database.NewMockRepositoryWithData(nil, nil, []*models.Skill{golang, duplicate, retired})
+	if err != nil {
+		t.Fatalf("Failed to seed repository: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := run(repo, "skill-name-claims", true, &out); err != nil {
+		t.Fatalf("Dry run failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "skill-name-claims: scanned 3, would update 1") {
+		t.Errorf("Unexpected dry run output: %s", out.String())
+	}
+	if owner, _ := repo.SkillNameOwner("go"); owner != "" {
+		t.Errorf("Expected the dry run to claim nothing, got %q", owner)
+	}
+
+	out.Reset()
+	if err := run(repo, "skill-name-claims", false, &out); err != nil {
+		t.Fatalf("Backfill failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "skill-name-claims: scanned 3, updated 1") {
+		t.Errorf("Unexpected output: %s", out.String())
+	}
+	// The older skill keeps the contested name
+	if owner, _ := repo.SkillNameOwner("go"); owner != "go" {
+		t.Errorf("Expected go to hold its name, got %q", owner)
+	}
+	if owner, _ := repo.SkillNameOwner("cobol"); owner != "" {
+		t.Errorf("Expected the soft-deleted skill's name to stay unclaimed, got %q", owner)
+	}
+
+	if _, err := service.NewMasterSkillService(repo, repo, repo, repo).CreateMasterSkill("go-lang", "go", "", "Programming", nil); !errors.Is(err, apperrors.ErrMasterSkillNameTaken) {
+		t.Errorf("Expected the claimed name to be rejected, got %v", err)
+	}
+}
+
+func TestRun_UserSearchNames(t *testing.T) {
+	repo := database.NewMockRepository()
+	for _, username := range []string{"alice", "bob"} {
+		user, _ := models.NewUser(username, "Test User", "password123")
+		if err := repo.CreateUser(user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+
+	// alice was written before the search attributes existed
+	legacy, _ := repo.GetUser("alice")
+	legacy.UsernameLower = ""
+	legacy.NameLower = ""
+
+	var out bytes.Buffer
+	if err := run(repo, "user-search-names", false, &out); err != nil {
+		t.Fatalf("Backfill failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "user-search-names: scanned 2, updated 1") {
+		t.Errorf("Unexpected output: %s", out.String())
+	}
+	if user, _ := repo.GetUser("alice"); user.UsernameLower != "alice" || user.NameLower != "test user" {
+		t.Errorf("Expected the search names to be set, got %q and %q", user.UsernameLower, user.NameLower)
+	}
+}
+
+func TestRun_EmailClaims(t *testing.T) {
+	// Seeded users hold no email claims, like users who set an email before claims existed
+	var users []*models.User
+	for i, username := range []string{"alice", "bob", "carol"} {
+		user, _ := models.NewUser(username, "Test User", "password123")
+		user.CreatedAt = user.CreatedAt.Add(time.Duration(i) * time.Second)
+		users = append(users, user)
+	}
+	users[0].Email = "shared@example.com"
+	users[1].Email = "Shared@Example.com"
+	repo, err := database.NewMockRepositoryWithData(users, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to seed repository: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := run(repo, "email-claims", false, &out); err != nil {
+		t.Fatalf("Backfill failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "email-claims: scanned 3, updated 1") {
+		t.Errorf("Unexpected output: %s", out.String())
+	}
+	// The older user keeps the contested address
+	if owner, _ := repo.EmailOwner("shared@example.com"); owner != "alice" {
+		t.Errorf("Expected alice to hold the address, got %q", owner)
+	}
+
+	email := "shared@example.com"
+	if err := service.NewUserService(repo, nil).UpdateUser("carol", nil, nil, &email); !errors.Is(err, apperrors.ErrEmailInUse) {
+		t.Errorf("Expected the claimed address to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+// Command gen-openapi writes an OpenAPI 3.0 description of the API as JSON.
+//
+// Usage:
+//
+//	go run ./cmd/glad/gen-openapi [--out openapi.json]
+//
+// The paths and methods come from the application's router, so every registered route is
+// listed; request and response schemas are derived from the DTO structs named in the
+// endpoints table in spec.go. It lives under cmd/glad so it can share the application's
+// internal packages, and builds the router on the mock repository, so no AWS access is needed.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"log"
+	"os"
+
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/app"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/database"
+	"github.com/hackmajoris/glad-stack/pkg/config"
+	"github.com/hackmajoris/glad-stack/pkg/logger"
+)
+
+func main() {
+	out := flag.String("out", "", "file to write the spec to (default stdout)")
+	flag.Parse()
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			log.Fatalf("Failed to create %s: %v", *out, err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if err := run(w); err != nil {
+		log.Fatalf("Failed to generate OpenAPI spec: %v", err)
+	}
+}
+
+// run builds the application router and writes its OpenAPI description to w
+func run(w io.Writer) error {
+	cfg := config.Load()
+	// Logs share stdout with the spec, so only errors are let through
+	cfg.Log.Level = "error"
+	cfg.Log.AccessLog = false
+	if err := logger.Configure(cfg.Log.Level, cfg.Log.Format); err != nil {
+		return err
+	}
+
+	r, err := app.New(cfg, database.NewMockRepository())
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(buildSpec(r.Routes()))
+}
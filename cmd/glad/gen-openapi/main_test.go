@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRun_WritesSpecForRegisteredRoutes(t *testing.T) {
+	var buf bytes.Buffer
+	if err := run(&buf); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !json.Valid(buf.Bytes()) {
+		t.Fatalf("Expected only JSON on the output, got %s", buf.String())
+	}
+
+	var spec Document
+	if err := json.Unmarshal(buf.Bytes(), &spec); err != nil {
+		t.Fatalf("Failed to decode spec: %v", err)
+	}
+
+	expected := map[string][]string{
+		"/health":                        {"get"},
+		"/register":                      {"post"},
+		"/login":                         {"post"},
+		"/users":                         {"get"},
+		"/users/{username}/skills":       {"get", "post"},
+		"/master-skills/{skillID}":       {"get", "put", "delete"},
+		"/users/{username}/skills/stale": {"get"},
+	}
+	for path, methods := range expected {
+		item, ok := spec.Paths[path]
+		if !ok {
+			t.Errorf("Expected path %s in spec", path)
+			continue
+		}
+		for _, method := range methods {
+			if _, ok := item[method]; !ok {
+				t.Errorf("Expected %s %s in spec", strings.ToUpper(method), path)
+			}
+		}
+	}
+
+	for path := range spec.Paths {
+		if strings.HasPrefix(path, "/v1/") {
+			t.Errorf("Expected versioned alias %s to be left out", path)
+		}
+	}
+
+	register := spec.Paths["/register"]["post"]
+	if register.RequestBody == nil || register.RequestBody.Content["application/json"].Schema.Ref != "#/components/schemas/RegisterRequest" {
+		t.Errorf("Expected POST /register to reference RegisterRequest, got %+v", register.RequestBody)
+	}
+	if len(register.Security) != 0 {
+		t.Errorf("Expected POST /register to be public, got %v", register.Security)
+	}
+
+	listSkills := spec.Paths["/users/{username}/skills"]["get"]
+	if len(listSkills.Security) == 0 {
+		t.Error("Expected GET /users/{username}/skills to require bearerAuth")
+	}
+	if _, ok := spec.Components.Schemas["SkillResponse"]; !ok {
+		t.Error("Expected SkillResponse in components")
+	}
+}
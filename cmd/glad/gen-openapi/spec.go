@@ -0,0 +1,341 @@
+package main
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/dto"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/router"
+)
+
+// Document is the subset of an OpenAPI 3.0 document the generator emits
+type Document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Paths      map[string]map[string]Operation `json:"paths"`
+	Components Components                      `json:"components"`
+}
+
+// Info describes the API
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Operation is one method on a path
+type Operation struct {
+	OperationID string                `json:"operationId"`
+	Tags        []string              `json:"tags,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+// Parameter is a path parameter
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *Schema `json:"schema"`
+}
+
+// RequestBody is a JSON request body
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response is one response of an operation
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType holds the schema of a body
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Schema is a JSON schema, either inline or a reference to a component
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// Components holds the shared schemas and security schemes
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes"`
+}
+
+// SecurityScheme describes how protected routes authenticate
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+// endpoint describes the bodies of one route; routes without an entry are documented
+// with a bare 200 response
+type endpoint struct {
+	request  any // zero value of the request DTO, nil for no body
+	response any // zero value of the response DTO; a slice for list endpoints, nil for no body
+	status   int // success status, 200 when zero
+}
+
+// endpoints maps "METHOD path" to the DTOs the handler reads and writes
+// It is maintained by hand next to the routes, so new routes should add an entry here.
+var endpoints = map[string]endpoint{
+	"POST /register": {request: dto.RegisterRequest{}, response: dto.MessageResponse{}, status: http.StatusCreated},
+	"POST /login":    {request: dto.LoginRequest{}, response: dto.TokenResponse{}},
+	"POST /refresh":  {request: dto.RefreshRequest{}, response: dto.TokenResponse{}},
+	"GET /health":    {response: dto.HealthResponse{}},
+
+	"GET /protected":                {response: dto.ProtectedResponse{}},
+	"GET /me":                       {response: dto.CurrentUserResponse{}},
+	"DELETE /me":                    {status: http.StatusNoContent},
+	"POST /me/deactivate":           {response: dto.MessageResponse{}},
+	"GET /me/export":                {response: dto.ProfileExportResponse{}},
+	"GET /me/skills":                {response: []dto.SkillResponse{}},
+	"POST /me/skills":               {request: dto.CreateSkillRequest{}, response: dto.SkillResponse{}, status: http.StatusCreated},
+	"DELETE /me/skills":             {request: dto.BatchDeleteSkillsRequest{}, response: dto.BatchDeleteSkillsResponse{}},
+	"PUT /user":                     {request: dto.UpdateUserRequest{}, response: dto.MessageResponse{}},
+	"GET /users":                    {response: []dto.UserListResponse{}},
+	"DELETE /me/skills/{skillName}": {response: dto.MessageResponse{}},
+
+	"POST /master-skills":                                 {request: dto.CreateMasterSkillRequest{}, response: dto.MasterSkillResponse{}, status: http.StatusCreated},
+	"GET /master-skills":                                  {response: []dto.MasterSkillResponse{}},
+	"POST /master-skills/import":                          {request: []dto.CreateMasterSkillRequest{}, response: dto.ImportMasterSkillsResponse{}, status: http.StatusCreated},
+	"GET /master-skills/search":                           {response: []dto.MasterSkillResponse{}},
+	"GET /master-skills/suggest-category":                 {response: []dto.CategorySuggestionResponse{}},
+	"GET /master-skills/featured":                         {response: []dto.MasterSkillResponse{}},
+	"GET /master-skills/{skillID}":                        {response: dto.MasterSkillResponse{}},
+	"GET /master-skills/{skillID}/overview":               {response: dto.MasterSkillOverviewResponse{}},
+	"PUT /master-skills/{skillID}":                        {request: dto.UpdateMasterSkillRequest{}, response: dto.MasterSkillResponse{}},
+	"DELETE /master-skills/{skillID}":                     {request: dto.ConfirmRequest{}, response: dto.MessageResponse{}},
+	"POST /master-skills/{skillID}/resync":                {response: dto.ResyncUserSkillsResponse{}},
+	"PUT /master-skills/{skillID}/feature":                {response: dto.MasterSkillResponse{}},
+	"PUT /master-skills/{skillID}/unfeature":              {response: dto.MasterSkillResponse{}},
+	"POST /master-skills/{skillID}/merge-into/{targetID}": {response: dto.MasterSkillMergeResponse{}},
+	"GET /categories":                                     {response: []string{}},
+
+	"POST /users/skills/bulk":                               {request: dto.BulkSkillsRequest{}, response: map[string][]dto.SkillResponse{}},
+	"GET /users/{username}/activity":                        {response: dto.ActivityPageResponse{}},
+	"POST /users/{username}/skills":                         {request: dto.CreateSkillRequest{}, response: dto.SkillResponse{}, status: http.StatusCreated},
+	"GET /users/{username}/skills":                          {response: []dto.SkillResponse{}},
+	"DELETE /users/{username}/skills":                       {request: dto.BatchDeleteSkillsRequest{}, response: dto.BatchDeleteSkillsResponse{}},
+	"POST /users/{username}/skills/batch":                   {request: dto.BatchCreateSkillsRequest{}, response: dto.BatchSkillResponse{}, status: http.StatusCreated},
+	"GET /users/{username}/skills/{skillName}":              {response: dto.SkillResponse{}},
+	"PUT /users/{username}/skills/{skillName}":              {request: dto.UpdateSkillRequest{}, response: dto.SkillResponse{}},
+	"PATCH /users/{username}/skills/{skillName}":            {request: dto.UpdateSkillRequest{}, response: dto.SkillResponse{}},
+	"DELETE /users/{username}/skills/{skillName}":           {response: dto.MessageResponse{}},
+	"POST /users/{username}/skills/{skillName}/endorse":     {response: dto.SkillResponse{}},
+	"GET /users/{username}/skills/{skillName}/endorsements": {response: []dto.EndorsementResponse{}},
+	"GET /users/{username}/skills/{skillName}/history":      {response: []dto.SkillHistoryResponse{}},
+	"POST /users/{username}/skills/{skillName}/touch":       {response: dto.SkillResponse{}},
+	"POST /users/{username}/skills/{skillName}/promote":     {response: dto.SkillResponse{}},
+	"POST /users/{username}/skills/snapshot":                {response: dto.SkillSnapshotResponse{}, status: http.StatusCreated},
+	"GET /users/{username}/skills/diff":                     {response: dto.SkillDiffResponse{}},
+	"GET /users/{username}/skills/gap":                      {response: dto.SkillGapResponse{}},
+	"GET /users/{username}/skills/stale":                    {response: []dto.SkillResponse{}},
+
+	"GET /skills/recent":                     {response: []dto.RecentSkillResponse{}},
+	"GET /skills/{skillName}/users":          {response: []dto.UserSkillResponse{}},
+	"GET /skills/{skillName}/related":        {response: []dto.RelatedSkillResponse{}},
+	"POST /skills/{skillName}/endorse-users": {request: dto.BulkEndorseRequest{}, response: dto.BulkEndorseResponse{}},
+}
+
+// versionPrefix matches the /v1-style prefixes under which every route is mirrored
+var versionPrefix = regexp.MustCompile(`^/v[0-9]+/`)
+
+// buildSpec documents routes as an OpenAPI 3.0 document
+// Versioned mirrors of unversioned routes are left out; request and response schemas come
+// from the endpoints table, derived from the DTO structs by reflection.
+func buildSpec(routes []router.RouteInfo) *Document {
+	doc := &Document{
+		OpenAPI: "3.0.3",
+		Info: Info{
+			Title:       "GLAD API",
+			Version:     "1.0.0",
+			Description: "Every path is also served under /v1, or with Accept: application/vnd.glad.v1+json.",
+		},
+		Paths: make(map[string]map[string]Operation),
+		Components: Components{
+			Schemas: make(map[string]*Schema),
+			SecuritySchemes: map[string]SecurityScheme{
+				"bearerAuth": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+	}
+
+	for _, route := range routes {
+		if versionPrefix.MatchString(route.Path) {
+			continue
+		}
+		if doc.Paths[route.Path] == nil {
+			doc.Paths[route.Path] = make(map[string]Operation)
+		}
+		doc.Paths[route.Path][strings.ToLower(route.Method)] = doc.operation(route)
+	}
+
+	return doc
+}
+
+// operation documents a single route
+func (d *Document) operation(route router.RouteInfo) Operation {
+	op := Operation{
+		OperationID: operationID(route.Method, route.Path),
+		Tags:        []string{strings.Split(strings.Trim(route.Path, "/"), "/")[0]},
+		Responses:   make(map[string]Response),
+	}
+
+	for _, segment := range strings.Split(route.Path, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			op.Parameters = append(op.Parameters, Parameter{
+				Name:     segment[1 : len(segment)-1],
+				In:       "path",
+				Required: true,
+				Schema:   &Schema{Type: "string"},
+			})
+		}
+	}
+
+	if route.AuthRequired {
+		op.Security = []map[string][]string{{"bearerAuth": {}}}
+		for _, name := range route.Middleware {
+			if strings.HasSuffix(name, ".RequireAdmin") {
+				op.Description = "Requires the admin role."
+			}
+		}
+	}
+
+	ep := endpoints[route.Method+" "+route.Path]
+	if ep.request != nil {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{"application/json": {Schema: d.schemaFor(reflect.TypeOf(ep.request))}},
+		}
+	}
+
+	status := ep.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success := Response{Description: http.StatusText(status)}
+	if ep.response != nil {
+		success.Content = map[string]MediaType{"application/json": {Schema: d.schemaFor(reflect.TypeOf(ep.response))}}
+	}
+	op.Responses[strconv.Itoa(status)] = success
+	op.Responses["default"] = Response{
+		Description: "Error",
+		Content:     map[string]MediaType{"application/json": {Schema: d.schemaFor(reflect.TypeOf(dto.ErrorResponse{}))}},
+	}
+
+	return op
+}
+
+// schemaFor returns the schema of t, registering named structs as components
+func (d *Document) schemaFor(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: d.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: d.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t.PkgPath() == "time" && t.Name() == "Time" {
+			return &Schema{Type: "string", Format: "date-time"}
+		}
+		name := componentName(t)
+		if _, ok := d.Components.Schemas[name]; !ok {
+			// Register before walking the fields so recursive types terminate
+			d.Components.Schemas[name] = &Schema{}
+			*d.Components.Schemas[name] = *d.structSchema(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + name}
+	default:
+		return &Schema{}
+	}
+}
+
+// structSchema describes the JSON fields of a struct
+// Fields without omitempty, or validated as required, are listed as required.
+func (d *Document) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+		schema.Properties[name] = d.schemaFor(field.Type)
+
+		optional := strings.Contains(opts, "omitempty")
+		if strings.HasPrefix(field.Tag.Get("validate"), "required") {
+			optional = false
+		}
+		if !optional {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	sort.Strings(schema.Required)
+	return schema
+}
+
+// componentName names a schema component after its Go type, flattening generic
+// instantiations such as BatchResult[...SkillResponse] to BatchResultSkillResponse
+func componentName(t reflect.Type) string {
+	name := t.Name()
+	if open := strings.Index(name, "["); open >= 0 {
+		arg := strings.TrimSuffix(name[open+1:], "]")
+		arg = arg[strings.LastIndex(arg, ".")+1:]
+		name = name[:open] + arg
+	}
+	return name
+}
+
+// operationID derives an identifier such as getUsersUsernameSkills from a route
+func operationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, segment := range strings.Split(path, "/") {
+		segment = strings.Trim(segment, "{}")
+		for _, word := range strings.Split(segment, "-") {
+			if word != "" {
+				b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+			}
+		}
+	}
+	return b.String()
+}
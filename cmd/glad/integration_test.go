@@ -49,11 +49,11 @@ func SetupIntegrationTest() *IntegrationTestSuite {
 	userSkillsRepo := database.NewMockRepository()
 	tokenService := auth.NewTokenService(testConfig())
 	userService := service.NewUserService(userRepo, tokenService)
-	userSkillsService := service.NewSkillService(userSkillsRepo, userSkillsRepo, userRepo)
+	userSkillsService := service.NewSkillService(userSkillsRepo, userSkillsRepo, userRepo, userSkillsRepo, userSkillsRepo, userSkillsRepo)
 	apiHandler := handler.New(userService, userSkillsService)
 	authMiddleware := middleware.NewAuthMiddleware(tokenService)
 
-	// Create HTTP server with the same routing as local-server.go
+	// Create HTTP server converting requests the same way as cmd/glad/local-server
 	mux := http.NewServeMux()
 	mux.HandleFunc("/register", handleIntegrationRequest(apiHandler.Register))
 	mux.HandleFunc("/login", handleIntegrationRequest(apiHandler.Login))
@@ -375,7 +375,7 @@ type HTTPResponse struct {
 	Headers    http.Header
 }
 
-// Copy the handleRequest function from local-server.go for integration testing
+// A trimmed-down copy of the request conversion in cmd/glad/local-server for integration testing
 // This tests the actual HTTP to Lambda event conversion
 func handleIntegrationRequest(handler func(events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,107 @@
+// Package app wires the repositories, services, handlers and routes shared by the
+// Lambda entry point and the local server.
+package app
+
+import (
+	"fmt"
+
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/database"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/handler"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/router"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/service"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/validation"
+	"github.com/hackmajoris/glad-stack/pkg/auth"
+	"github.com/hackmajoris/glad-stack/pkg/config"
+	"github.com/hackmajoris/glad-stack/pkg/logger"
+	"github.com/hackmajoris/glad-stack/pkg/middleware"
+)
+
+// New builds the application router on top of repo
+// It also applies the process-wide settings from cfg (logging, password hasher, skill name
+// lengths), so it is meant to be called once at startup.
+func New(cfg *config.Config, repo database.Repository) (*router.Router, error) {
+	if err := logger.Configure(cfg.Log.Level, cfg.Log.Format); err != nil {
+		return nil, fmt.Errorf("invalid logging configuration: %w", err)
+	}
+
+	// Select the algorithm for new password hashes; existing hashes keep verifying
+	passwordHasher, err := auth.NewPasswordHasher(cfg.Auth.PasswordHasher)
+	if err != nil {
+		return nil, fmt.Errorf("invalid password hasher configuration: %w", err)
+	}
+	models.SetPasswordHasher(passwordHasher)
+
+	if err := models.SetSkillNameMinLengths(cfg.Skills.NameMinLengths); err != nil {
+		return nil, fmt.Errorf("invalid skill name configuration: %w", err)
+	}
+
+	defaultProficiency, err := models.ParseProficiencyLevel(cfg.Skills.DefaultProficiency)
+	if err != nil {
+		return nil, fmt.Errorf("invalid skill configuration: %w", err)
+	}
+
+	promotionPolicy, err := models.NewPromotionPolicy(cfg.Promotion.AutoPromote, cfg.Promotion.Thresholds)
+	if err != nil {
+		return nil, fmt.Errorf("invalid promotion configuration: %w", err)
+	}
+
+	if err := auth.ValidateTokenType(cfg.JWT.TokenType); err != nil {
+		return nil, fmt.Errorf("invalid JWT configuration: %w", err)
+	}
+	if err := auth.ValidateSigningConfig(cfg.JWT); err != nil {
+		return nil, fmt.Errorf("invalid JWT configuration: %w", err)
+	}
+
+	// Initialize dependencies
+	tokenService := auth.NewTokenService(cfg)
+
+	// Initialize services
+	userService := service.NewUserService(repo, tokenService, service.WithPurgeService(service.NewPurgeService(repo, repo, repo, repo, repo, repo)))
+	skillService := service.NewSkillService(repo, repo, repo, repo, repo, repo, service.WithPromotionPolicy(promotionPolicy), service.WithDefaultProficiency(defaultProficiency)) // repo implements SkillRepository, MasterSkillRepository, UserRepository, SkillHistoryRepository, EndorsementRepository, and ActivityRepository
+	masterSkillService := service.NewMasterSkillService(repo, repo, repo, repo)
+	snapshotService := service.NewSkillSnapshotService(repo, repo, repo)
+
+	// Initialize handlers
+	apiHandler := handler.New(userService, skillService, handler.WithPasswordPolicy(validation.PasswordPolicy{
+		MinLength:     cfg.Password.MinLength,
+		RequireUpper:  cfg.Password.RequireUpper,
+		RequireLower:  cfg.Password.RequireLower,
+		RequireDigit:  cfg.Password.RequireDigit,
+		RequireSymbol: cfg.Password.RequireSymbol,
+	}), handler.WithRequestTimeout(cfg.Request.Timeout))
+	masterSkillHandler := handler.NewMasterSkillHandler(masterSkillService, handler.WithDestructiveConfirmation(cfg.Auth.ConfirmDestructive))
+	healthHandler := handler.NewHealthHandler(repo)
+	snapshotHandler := handler.NewSkillSnapshotHandler(snapshotService)
+	authMiddleware := middleware.NewAuthMiddleware(tokenService, middleware.WithCookieFallback(cfg.JWT.CookieFallback), middleware.WithAccountCheck(userService.CheckAccountActive))
+	corsMiddleware, err := middleware.NewCORSMiddleware(cfg.CORS.AllowedOrigins, cfg.CORS.AllowCredentials, cfg.IsProduction())
+	if err != nil {
+		return nil, fmt.Errorf("invalid CORS configuration: %w", err)
+	}
+
+	// Rate limit write endpoints; a non-positive limit disables it
+	rateLimit := router.Middleware(func(next middleware.HandlerFunc) middleware.HandlerFunc { return next })
+	if cfg.RateLimit.PerMinute > 0 {
+		rateLimit = middleware.NewRateLimitMiddleware(cfg.RateLimit.PerMinute).Handler
+	}
+
+	// Setup router
+	r := setupRouter(apiHandler, masterSkillHandler, snapshotHandler, healthHandler, authMiddleware, rateLimit)
+
+	// The access log is registered first so it wraps every other middleware
+	if cfg.Log.AccessLog {
+		r.Use(middleware.NewAccessLogMiddleware().Handler)
+	}
+
+	// In-process metrics only span one warm Lambda instance, so they are opt-in for the local server
+	if cfg.Metrics.Enabled {
+		metrics := middleware.NewMetricsRegistry()
+		r.Use(middleware.NewMetricsMiddleware(metrics).Handler)
+		r.GET("/metrics", metrics.Handler)
+	}
+	r.Use(corsMiddleware.Handler)
+	r.Preflight(corsMiddleware.PreflightHeaders)
+	r.Use(middleware.NewBodySizeLimitMiddleware(cfg.Request.MaxBodyBytes).Handler)
+
+	return r, nil
+}
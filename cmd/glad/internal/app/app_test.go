@@ -0,0 +1,145 @@
+package app
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/database"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
+	"github.com/hackmajoris/glad-stack/pkg/auth"
+	"github.com/hackmajoris/glad-stack/pkg/config"
+	"github.com/hackmajoris/glad-stack/pkg/middleware"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// TestNew_DeployedEnvironment builds the app with only the variables the CDK stack sets
+func TestNew_DeployedEnvironment(t *testing.T) {
+	t.Setenv("ENVIRONMENT", "production")
+	t.Setenv("DYNAMODB_TABLE", "entities-table-production")
+
+	if _, err := New(config.Load(), database.NewMockRepository()); err != nil {
+		t.Fatalf("Expected the deployed configuration to start, got %v", err)
+	}
+}
+
+func TestNew_RejectsCredentialedWildcardInProduction(t *testing.T) {
+	t.Setenv("ENVIRONMENT", "production")
+	t.Setenv("CORS_ALLOW_CREDENTIALS", "true")
+
+	_, err := New(config.Load(), database.NewMockRepository())
+	if !errors.Is(err, middleware.ErrWildcardOriginNotAllowed) {
+		t.Fatalf("Expected ErrWildcardOriginNotAllowed, got %v", err)
+	}
+}
+
+func TestNew_ResyncRequiresAdmin(t *testing.T) {
+	cfg := config.Load()
+	repo := database.NewMockRepository()
+	r, err := New(cfg, repo)
+	if err != nil {
+		t.Fatalf("Failed to build app: %v", err)
+	}
+
+	user, _ := models.NewUser("regular", "Regular User", "password123")
+	if err := repo.CreateUser(user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	token, err := auth.NewTokenService(cfg).GenerateToken(user)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	response, err := r.Route(events.APIGatewayProxyRequest{
+		HTTPMethod:     "POST",
+		Resource:       "/master-skills/{skillID}/resync",
+		Path:           "/master-skills/go/resync",
+		PathParameters: map[string]string{"skillID": "go"},
+		Headers:        map[string]string{"Authorization": "Bearer " + token},
+	})
+	if err != nil {
+		t.Fatalf("Router returned unexpected error: %v", err)
+	}
+	if response.StatusCode != 403 {
+		t.Errorf("Expected status 403 for a non-admin, got %d: %s", response.StatusCode, response.Body)
+	}
+}
+
+// TestRoutes_SkillSiblingSegmentsReserved checks that no skill can be named like a static path
+// segment registered beside a skill parameter, since the router would match the static route
+func TestRoutes_SkillSiblingSegmentsReserved(t *testing.T) {
+	r, err := New(config.Load(), database.NewMockRepository())
+	if err != nil {
+		t.Fatalf("Failed to build app: %v", err)
+	}
+
+	// Static segments by the path prefix they follow
+	statics := make(map[string]map[string]bool)
+	skillPrefixes := make(map[string]bool)
+	for _, route := range r.Routes() {
+		segments := strings.Split(strings.Trim(route.Path, "/"), "/")
+		for i, segment := range segments {
+			prefix := strings.Join(segments[:i], "/")
+			switch {
+			case segment == "{skillName}" || segment == "{skillID}":
+				skillPrefixes[prefix] = true
+			case !strings.HasPrefix(segment, "{"):
+				if statics[prefix] == nil {
+					statics[prefix] = make(map[string]bool)
+				}
+				statics[prefix][segment] = true
+			}
+		}
+	}
+
+	for prefix := range skillPrefixes {
+		for segment := range statics[prefix] {
+			if !models.IsReservedSkillName(segment) {
+				t.Errorf("Expected %q beside /%s/{skill} to be a reserved skill name", segment, prefix)
+			}
+		}
+	}
+}
+
+func TestNew_DeactivatedAccountTokensRejected(t *testing.T) {
+	cfg := config.Load()
+	repo := database.NewMockRepository()
+	r, err := New(cfg, repo)
+	if err != nil {
+		t.Fatalf("Failed to build app: %v", err)
+	}
+
+	user, _ := models.NewUser("leaver", "Leaving User", "password123")
+	if err := repo.CreateUser(user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	token, err := auth.NewTokenService(cfg).GenerateToken(user)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	route := func(method, resource string) int {
+		t.Helper()
+		response, err := r.Route(events.APIGatewayProxyRequest{
+			HTTPMethod: method,
+			Resource:   resource,
+			Path:       resource,
+			Headers:    map[string]string{"Authorization": "Bearer " + token},
+		})
+		if err != nil {
+			t.Fatalf("Router returned unexpected error: %v", err)
+		}
+		return response.StatusCode
+	}
+
+	if status := route("GET", "/me"); status != 200 {
+		t.Fatalf("Expected status 200 before deactivation, got %d", status)
+	}
+	if status := route("POST", "/me/deactivate"); status != 200 {
+		t.Fatalf("Expected deactivation to succeed, got %d", status)
+	}
+	if status := route("GET", "/me"); status != 401 {
+		t.Errorf("Expected status 401 for the deactivated account's token, got %d", status)
+	}
+}
@@ -0,0 +1,104 @@
+package app
+
+import (
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/handler"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/router"
+	"github.com/hackmajoris/glad-stack/pkg/middleware"
+)
+
+func setupRouter(h *handler.Handler, msh *handler.MasterSkillHandler, ssh *handler.SkillSnapshotHandler, hh *handler.HealthHandler, auth *middleware.AuthMiddleware, rateLimit router.Middleware) *router.Router {
+	r := router.New()
+	rh := handler.NewRoutesHandler(r)
+
+	// Unversioned routes are kept for existing clients; /v1 mirrors them and is
+	// also selectable via Accept: application/vnd.glad.v1+json
+	registerRoutes(r, h, msh, ssh, hh, rh, auth, rateLimit)
+	registerRoutes(r.Version("v1"), h, msh, ssh, hh, rh, auth, rateLimit)
+
+	return r
+}
+
+func registerRoutes(r router.Registrar, h *handler.Handler, msh *handler.MasterSkillHandler, ssh *handler.SkillSnapshotHandler, hh *handler.HealthHandler, rh *handler.RoutesHandler, auth *middleware.AuthMiddleware, rateLimit router.Middleware) {
+	// Public routes
+	r.POST("/register", h.Register, rateLimit)
+	r.POST("/login", h.Login, rateLimit)
+	r.POST("/refresh", h.Refresh, rateLimit)
+	r.GET("/health", hh.Check)
+
+	// Protected routes - User Management
+	r.GET("/protected", h.Protected, auth.RequireAuth())
+	r.GET("/me", h.GetCurrentUser, auth.RequireAuth())
+	r.DELETE("/me", h.DeleteCurrentUser, auth.RequireAuth(), rateLimit)
+	r.POST("/me/deactivate", h.DeactivateCurrentUser, auth.RequireAuth(), rateLimit)
+	r.GET("/me/export", h.ExportProfile, auth.RequireAuth())
+	r.GET("/me/skills", h.ListMySkills, auth.RequireAuth())
+	r.POST("/me/skills", h.AddMySkill, auth.RequireAuth(), rateLimit)
+	r.DELETE("/me/skills", h.DeleteMySkills, auth.RequireAuth(), rateLimit)
+	r.DELETE("/me/skills/{skillName}", h.DeleteMySkill, auth.RequireAuth(), rateLimit)
+	r.PUT("/user", h.UpdateUser, auth.RequireAuth(), rateLimit)
+	r.GET("/users", h.ListUsers, auth.RequireAuth())
+
+	// Protected routes - Master Skill Management
+	r.POST("/master-skills", msh.CreateMasterSkill, auth.RequireAuth(), rateLimit)
+	r.GET("/master-skills", msh.ListMasterSkills, auth.RequireAuth())
+	r.POST("/master-skills/import", msh.ImportMasterSkills, auth.RequireAdmin(), rateLimit)
+	r.GET("/master-skills/search", msh.SearchMasterSkills, auth.RequireAuth())
+	r.GET("/master-skills/stats", msh.GetMasterSkillStats, auth.RequireAuth())
+	r.GET("/master-skills/suggest-category", msh.SuggestCategory, auth.RequireAuth())
+	r.GET("/master-skills/featured", msh.ListFeaturedMasterSkills, auth.RequireAuth())
+	r.GET("/master-skills/{skillID}", msh.GetMasterSkill, auth.RequireAuth())
+	r.GET("/master-skills/{skillID}/overview", msh.GetMasterSkillOverview, auth.RequireAuth())
+	r.PUT("/master-skills/{skillID}", msh.UpdateMasterSkill, auth.RequireAuth(), rateLimit)
+	r.DELETE("/master-skills/{skillID}", msh.DeleteMasterSkill, auth.RequireAuth(), rateLimit)
+	r.POST("/master-skills/{skillID}/resync", h.ResyncUserSkills, auth.RequireAdmin(), rateLimit)
+	r.PUT("/master-skills/{skillID}/feature", msh.FeatureMasterSkill, auth.RequireAdmin(), rateLimit)
+	r.PUT("/master-skills/{skillID}/unfeature", msh.UnfeatureMasterSkill, auth.RequireAdmin(), rateLimit)
+	r.POST("/master-skills/{skillID}/merge-into/{targetID}", msh.MergeMasterSkill, auth.RequireAdmin(), rateLimit)
+	r.GET("/categories", msh.ListCategories, auth.RequireAuth())
+
+	// Protected routes - User Skill Management
+	// Activity log of a user's skill changes
+	r.GET("/users/{username}/activity", h.ListActivity, auth.RequireAuth())
+
+	// Skills of several users at once, for team views
+	r.POST("/users/skills/bulk", h.ListSkillsForUsers, auth.RequireAuth())
+
+	// Manage skills for a specific user
+	r.POST("/users/{username}/skills", h.AddSkill, auth.RequireAuth(), rateLimit)
+	r.GET("/users/{username}/skills", h.ListSkillsForUser, auth.RequireAuth())
+	r.DELETE("/users/{username}/skills", h.DeleteSkillsBatch, auth.RequireAuth(), rateLimit)
+	r.POST("/users/{username}/skills/batch", h.AddSkillsBatch, auth.RequireAuth(), rateLimit)
+	r.GET("/users/{username}/skills/{skillName}", h.GetSkill, auth.RequireAuth())
+	r.PUT("/users/{username}/skills/{skillName}", h.UpdateSkill, auth.RequireAuth(), rateLimit)
+	r.PATCH("/users/{username}/skills/{skillName}", h.PatchSkill, auth.RequireAuth(), rateLimit)
+	r.DELETE("/users/{username}/skills/{skillName}", h.DeleteSkill, auth.RequireAuth(), rateLimit)
+	r.POST("/users/{username}/skills/{skillName}/endorse", h.EndorseSkill, auth.RequireAuth(), rateLimit)
+	r.DELETE("/users/{username}/skills/{skillName}/endorse", h.RemoveEndorsement, auth.RequireAuth(), rateLimit)
+	r.GET("/users/{username}/skills/{skillName}/endorsements", h.ListEndorsements, auth.RequireAuth())
+	r.GET("/users/{username}/skills/{skillName}/history", h.GetSkillHistory, auth.RequireAuth())
+	r.POST("/users/{username}/skills/{skillName}/touch", h.TouchSkill, auth.RequireAuth(), rateLimit)
+	r.POST("/users/{username}/skills/{skillName}/promote", h.PromoteSkill, auth.RequireAuth(), rateLimit)
+
+	// Skill snapshots for performance reviews
+	r.POST("/users/{username}/skills/snapshot", ssh.CreateSnapshot, auth.RequireAdmin(), rateLimit)
+	r.GET("/users/{username}/skills/diff", ssh.GetSkillDiff, auth.RequireAuth())
+
+	// Skill comparison for mentorship matching
+	r.GET("/users/{username}/skills/gap", h.GetSkillGap, auth.RequireAuth())
+
+	// Skills gone unused for a number of months
+	r.GET("/users/{username}/skills/stale", h.GetStaleSkills, auth.RequireAuth())
+
+	// Query users by skill (cross-user queries using GSI)
+	r.GET("/skills/recent", h.GetRecentSkills, auth.RequireAuth())
+	r.GET("/skills/{skillName}/users", h.ListUsersBySkill, auth.RequireAuth())
+	r.GET("/skills/{skillName}/users/stats", h.GetSkillLevelStats, auth.RequireAuth())
+	r.GET("/skills/{skillName}/related", h.GetRelatedSkills, auth.RequireAuth())
+	r.POST("/skills/{skillName}/endorse-users", h.EndorseUsersForSkill, auth.RequireAdmin(), rateLimit)
+	r.GET("/tags/{tag}/users", h.ListUsersByTag, auth.RequireAuth())
+
+	// Admin routes
+	r.POST("/admin/users/{username}/reactivate", h.ReactivateUser, auth.RequireAdmin())
+	r.POST("/analytics/team-matrix", h.ExportTeamMatrix, auth.RequireAdmin())
+	r.GET("/routes", rh.ListRoutes, auth.RequireAdmin())
+}
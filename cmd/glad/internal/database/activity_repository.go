@@ -0,0 +1,13 @@
+package database
+
+import "github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
+
+// ActivityRepository defines operations for a user's activity log
+type ActivityRepository interface {
+	CreateActivity(activity *models.ActivityLog) error
+	// ListActivity returns one page of a user's activity, newest first
+	// A limit of 0 leaves the page size to DynamoDB. The returned token is empty on the last page.
+	ListActivity(username string, limit int, nextToken string) ([]*models.ActivityLog, string, error)
+	// DeleteActivityForUser removes a user's activity log and returns how many entries were removed
+	DeleteActivityForUser(username string) (int, error)
+}
@@ -0,0 +1,115 @@
+package database
+
+import (
+	"time"
+
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
+	"github.com/hackmajoris/glad-stack/pkg/logger"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+)
+
+// CreateActivity inserts a new activity log entry into DynamoDB
+func (r *DynamoDBRepository) CreateActivity(activity *models.ActivityLog) error {
+	log := logger.WithComponent("database").With("operation", "CreateActivity", "username", activity.Username, "action", activity.Action, "skill_id", activity.SkillID)
+	start := time.Now()
+
+	log.Debug("Starting activity creation")
+
+	// Ensure keys are set
+	activity.SetKeys()
+
+	item, err := dynamodbattribute.MarshalMap(activity)
+	if err != nil {
+		log.Error("Failed to marshal activity data", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
+
+	input := &dynamodb.PutItemInput{
+		TableName:           aws.String(r.names.TableName),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(entity_id)"),
+	}
+
+	if _, err := r.putItem("CreateActivity", input); err != nil {
+		log.Error("Failed to create activity in DynamoDB", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
+
+	log.Info("Activity created successfully", "duration", time.Since(start))
+	return nil
+}
+
+// ListActivity retrieves one page of a user's activity, newest first
+// Queries the base table on the EntityType partition with a begins_with condition on entity_id;
+// timestamps in the entity_id sort chronologically, so a descending scan yields newest first.
+func (r *DynamoDBRepository) ListActivity(username string, limit int, nextToken string) ([]*models.ActivityLog, string, error) {
+	log := logger.WithComponent("database").With("operation", "ListActivity", "username", username, "limit", limit)
+	start := time.Now()
+
+	log.Debug("Starting activity retrieval")
+
+	startKey, err := decodePageToken(nextToken)
+	if err != nil {
+		log.Info("Invalid pagination token", "duration", time.Since(start))
+		return nil, "", err
+	}
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(r.names.TableName),
+		KeyConditionExpression: aws.String("EntityType = :entityType AND begins_with(entity_id, :prefix)"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":entityType": {S: aws.String("ActivityLog")},
+			":prefix":     {S: aws.String(models.BuildUserActivityPrefix(username))},
+		},
+		ScanIndexForward:  aws.Bool(false),
+		ExclusiveStartKey: startKey,
+	}
+	if limit > 0 {
+		input.Limit = aws.Int64(int64(limit))
+	}
+
+	result, err := r.query("ListActivity", input)
+	if err != nil {
+		log.Error("Failed to query activity", "error", err.Error(), "duration", time.Since(start))
+		return nil, "", err
+	}
+
+	var entries []*models.ActivityLog
+	for i, item := range result.Items {
+		var entry models.ActivityLog
+		if err := dynamodbattribute.UnmarshalMap(item, &entry); err != nil {
+			log.Error("Failed to unmarshal activity data", "error", err.Error(), "item_index", i, "duration", time.Since(start))
+			continue
+		}
+		entries = append(entries, &entry)
+	}
+
+	token, err := encodePageToken(result.LastEvaluatedKey)
+	if err != nil {
+		log.Error("Failed to encode pagination token", "error", err.Error(), "duration", time.Since(start))
+		return nil, "", err
+	}
+
+	log.Info("Activity retrieved successfully", "count", len(entries), "has_more", token != "", "duration", time.Since(start))
+	return entries, token, nil
+}
+
+// DeleteActivityForUser removes all of a user's activity log entries
+func (r *DynamoDBRepository) DeleteActivityForUser(username string) (int, error) {
+	log := logger.WithComponent("database").With("operation", "DeleteActivityForUser", "username", username)
+	start := time.Now()
+
+	log.Debug("Starting user activity deletion")
+
+	deleted, err := r.deleteByPrefix("DeleteActivity", "ActivityLog", models.BuildUserActivityPrefix(username))
+	if err != nil {
+		log.Error("Failed to delete activity", "error", err.Error(), "duration", time.Since(start))
+		return 0, err
+	}
+
+	log.Info("User activity deleted successfully", "count", deleted, "duration", time.Since(start))
+	return deleted, nil
+}
@@ -0,0 +1,92 @@
+package database
+
+import (
+	"encoding/base64"
+	"sort"
+	"strings"
+	"time"
+
+	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
+	"github.com/hackmajoris/glad-stack/pkg/logger"
+)
+
+// CreateActivity stores an activity log entry in memory
+func (m *MockRepository) CreateActivity(activity *models.ActivityLog) error {
+	log := logger.WithComponent("database").With("operation", "CreateActivity", "username", activity.Username, "action", activity.Action, "skill_id", activity.SkillID, "repository", "mock")
+	start := time.Now()
+
+	log.Debug("Starting activity creation in mock repository")
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	activity.SetKeys()
+	m.activities[activity.EntityID] = activity
+
+	log.Info("Activity created successfully in mock repository", "duration", time.Since(start))
+	return nil
+}
+
+// ListActivity retrieves one page of a user's activity from memory, newest first
+func (m *MockRepository) ListActivity(username string, limit int, nextToken string) ([]*models.ActivityLog, string, error) {
+	log := logger.WithComponent("database").With("operation", "ListActivity", "username", username, "limit", limit, "repository", "mock")
+	start := time.Now()
+
+	log.Debug("Starting activity retrieval from mock repository")
+
+	// Tokens encode the last entity_id returned, mirroring DynamoDB's ExclusiveStartKey
+	var before string
+	if nextToken != "" {
+		decoded, err := base64.RawURLEncoding.DecodeString(nextToken)
+		if err != nil || len(decoded) == 0 {
+			log.Info("Invalid pagination token", "duration", time.Since(start))
+			return nil, "", apperrors.ErrInvalidPageToken
+		}
+		before = string(decoded)
+	}
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	prefix := models.BuildUserActivityPrefix(username)
+	var entries []*models.ActivityLog
+	for entityID, entry := range m.activities {
+		if strings.HasPrefix(entityID, prefix) && (before == "" || entityID < before) {
+			entries = append(entries, entry)
+		}
+	}
+
+	// Match the DynamoDB descending sort on entity_id
+	sort.Slice(entries, func(i, j int) bool { return entries[i].EntityID > entries[j].EntityID })
+
+	var token string
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+		token = base64.RawURLEncoding.EncodeToString([]byte(entries[limit-1].EntityID))
+	}
+
+	log.Info("Activity retrieved successfully from mock repository", "count", len(entries), "has_more", token != "", "duration", time.Since(start))
+	return entries, token, nil
+}
+
+// DeleteActivityForUser removes all of a user's activity log entries from memory
+func (m *MockRepository) DeleteActivityForUser(username string) (int, error) {
+	log := logger.WithComponent("database").With("operation", "DeleteActivityForUser", "username", username, "repository", "mock")
+	start := time.Now()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	prefix := models.BuildUserActivityPrefix(username)
+	deleted := 0
+	for entityID := range m.activities {
+		if strings.HasPrefix(entityID, prefix) {
+			delete(m.activities, entityID)
+			deleted++
+		}
+	}
+
+	log.Info("User activity deleted successfully from mock repository", "count", deleted, "duration", time.Since(start))
+	return deleted, nil
+}
@@ -0,0 +1,133 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hackmajoris/glad-stack/pkg/logger"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+const (
+	// batchWriteLimit is the maximum number of write requests DynamoDB accepts per BatchWriteItem call
+	batchWriteLimit = 25
+
+	// batchWriteAttempts bounds how many times unprocessed items are resubmitted
+	batchWriteAttempts = 3
+
+	// transactItemLimit is the maximum number of items DynamoDB accepts per write transaction
+	transactItemLimit = 100
+
+	// transactSkillLimit is how many skills one batch create or delete transaction writes,
+	// leaving the last item of the transaction for the owner's SkillCount update
+	transactSkillLimit = transactItemLimit - 1
+)
+
+// BatchWriteError reports the items of a batch write that could not be persisted
+type BatchWriteError struct {
+	Failed map[int]error // index into the input slice -> cause
+}
+
+func (e *BatchWriteError) Error() string {
+	return fmt.Sprintf("batch write failed for %d item(s)", len(e.Failed))
+}
+
+// deleteByPrefix removes every item of entityType whose entity_id starts with prefix and returns how many were removed
+// Keys are collected across all query pages before being deleted in batches.
+func (r *DynamoDBRepository) deleteByPrefix(operation, entityType, prefix string) (int, error) {
+	var requests []*dynamodb.WriteRequest
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(r.names.TableName),
+		KeyConditionExpression: aws.String("EntityType = :entityType AND begins_with(entity_id, :prefix)"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":entityType": {S: aws.String(entityType)},
+			":prefix":     {S: aws.String(prefix)},
+		},
+		ProjectionExpression: aws.String("EntityType, entity_id"),
+	}
+	for {
+		result, err := r.query(operation, input)
+		if err != nil {
+			return 0, err
+		}
+		for _, item := range result.Items {
+			requests = append(requests, &dynamodb.WriteRequest{
+				DeleteRequest: &dynamodb.DeleteRequest{Key: item},
+			})
+		}
+		if len(result.LastEvaluatedKey) == 0 {
+			break
+		}
+		input.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+
+	if len(requests) == 0 {
+		return 0, nil
+	}
+
+	keyOf := func(req *dynamodb.WriteRequest) string {
+		return aws.StringValue(req.DeleteRequest.Key["entity_id"].S)
+	}
+	if err := r.batchWrite(operation, requests, keyOf); err != nil {
+		return 0, err
+	}
+	return len(requests), nil
+}
+
+// batchWrite submits write requests in chunks of 25, resubmitting unprocessed items.
+// keyOf must return the entity_id of each request so failures can be mapped back to input indexes.
+func (r *DynamoDBRepository) batchWrite(operation string, requests []*dynamodb.WriteRequest, keyOf func(*dynamodb.WriteRequest) string) error {
+	log := logger.WithComponent("database").With("operation", operation, "count", len(requests))
+	start := time.Now()
+
+	indexByKey := make(map[string]int, len(requests))
+	for i, req := range requests {
+		indexByKey[keyOf(req)] = i
+	}
+
+	failed := make(map[int]error)
+	for chunkStart := 0; chunkStart < len(requests); chunkStart += batchWriteLimit {
+		chunkEnd := chunkStart + batchWriteLimit
+		if chunkEnd > len(requests) {
+			chunkEnd = len(requests)
+		}
+
+		pending := map[string][]*dynamodb.WriteRequest{r.names.TableName: requests[chunkStart:chunkEnd]}
+		for attempt := 1; attempt <= batchWriteAttempts && len(pending[r.names.TableName]) > 0; attempt++ {
+			var result *dynamodb.BatchWriteItemOutput
+			err := r.withRetry(context.Background(), operation, func() (err error) {
+				result, err = r.client.BatchWriteItem(&dynamodb.BatchWriteItemInput{RequestItems: pending})
+				return err
+			})
+			if err != nil {
+				log.Error("Batch write chunk failed", "error", err.Error(), "chunk_start", chunkStart, "attempt", attempt)
+				for _, req := range pending[r.names.TableName] {
+					failed[indexByKey[keyOf(req)]] = err
+				}
+				pending = nil
+				break
+			}
+
+			pending = result.UnprocessedItems
+			if len(pending[r.names.TableName]) > 0 && attempt < batchWriteAttempts {
+				log.Warn("Retrying unprocessed batch items", "unprocessed", len(pending[r.names.TableName]), "attempt", attempt)
+				time.Sleep(time.Duration(attempt*50) * time.Millisecond)
+			}
+		}
+
+		for _, req := range pending[r.names.TableName] {
+			failed[indexByKey[keyOf(req)]] = fmt.Errorf("item left unprocessed after %d attempts", batchWriteAttempts)
+		}
+	}
+
+	if len(failed) > 0 {
+		log.Error("Batch write completed with failures", "failed", len(failed), "duration", time.Since(start))
+		return &BatchWriteError{Failed: failed}
+	}
+
+	log.Info("Batch write completed successfully", "duration", time.Since(start))
+	return nil
+}
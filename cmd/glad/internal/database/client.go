@@ -2,12 +2,15 @@ package database
 
 import (
 	"sync"
+	"time"
 
 	"github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
 	"github.com/hackmajoris/glad-stack/pkg/logger"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 )
 
 // DynamoDBRepository implements all repository interfaces using DynamoDB single table design
@@ -16,19 +19,64 @@ import (
 // - MasterSkillRepository (master skills)
 // - SkillRepository (user skills)
 type DynamoDBRepository struct {
-	client *dynamodb.DynamoDB
+	client dynamodbiface.DynamoDBAPI
+	names  RepositoryConfig
+
+	slowQueryThreshold  time.Duration // queries slower than this are logged at Warn; 0 disables
+	logConsumedCapacity bool          // request and log consumed capacity of queries, puts and gets
+	maxWriteAttempts    int           // tries per throttled write, including the first
 }
 
-// NewDynamoDBRepository creates a new DynamoDB repository
-func NewDynamoDBRepository() *DynamoDBRepository {
-	log := logger.WithComponent("database")
-	log.Info("Initializing DynamoDB repository", "table", TableName)
+// DynamoDBOption configures optional DynamoDBRepository behaviour
+type DynamoDBOption func(*DynamoDBRepository)
+
+// WithRepositoryConfig sets the table and index names; empty names keep their defaults
+func WithRepositoryConfig(names RepositoryConfig) DynamoDBOption {
+	return func(r *DynamoDBRepository) {
+		r.names = names.withDefaults()
+	}
+}
+
+// WithSlowQueryThreshold sets how long a query may take before it is logged as slow
+// A threshold of 0 disables slow-query logging.
+func WithSlowQueryThreshold(threshold time.Duration) DynamoDBOption {
+	return func(r *DynamoDBRepository) {
+		r.slowQueryThreshold = threshold
+	}
+}
+
+// WithConsumedCapacityLogging sets whether queries, puts and gets request and log their consumed capacity
+// It is off by default because returning consumed capacity is billed.
+func WithConsumedCapacityLogging(enabled bool) DynamoDBOption {
+	return func(r *DynamoDBRepository) {
+		r.logConsumedCapacity = enabled
+	}
+}
+
+// WithMaxWriteAttempts sets how many times a throttled write is tried before its error is returned
+// Values below 1 disable retrying.
+func WithMaxWriteAttempts(attempts int) DynamoDBOption {
+	return func(r *DynamoDBRepository) {
+		r.maxWriteAttempts = attempts
+	}
+}
 
+// NewDynamoDBRepository creates a new DynamoDB repository
+func NewDynamoDBRepository(opts ...DynamoDBOption) *DynamoDBRepository {
 	sess := session.Must(session.NewSession())
 	repo := &DynamoDBRepository{
-		client: dynamodb.New(sess),
+		client:             dynamodb.New(sess),
+		names:              DefaultRepositoryConfig(),
+		slowQueryThreshold: defaultSlowQueryThreshold,
+		maxWriteAttempts:   defaultMaxWriteAttempts,
+	}
+	for _, opt := range opts {
+		opt(repo)
 	}
 
+	log := logger.WithComponent("database")
+	log.Info("Initializing DynamoDB repository", "table", repo.names.TableName)
+
 	log.Info("DynamoDB repository initialized successfully")
 	return repo
 }
@@ -36,9 +84,15 @@ func NewDynamoDBRepository() *DynamoDBRepository {
 // MockRepository implements UserRepository, SkillRepository, and MasterSkillRepository for testing
 // This matches the DynamoDBRepository structure with unified implementation
 type MockRepository struct {
-	users        map[string]*models.User      // key: username
-	skills       map[string]*models.UserSkill // key: "username#skillname"
-	masterSkills map[string]*models.Skill     // key: skill_id
+	users        map[string]*models.User          // key: username
+	skills       map[string]*models.UserSkill     // key: "username#skillname"
+	masterSkills map[string]*models.Skill         // key: skill_id
+	skillNames   map[string]string                // key: lowercased skill name, value: skill_id holding it
+	emails       map[string]string                // key: lowercased email, value: username holding it
+	snapshots    map[string]*models.SkillSnapshot // key: snapshot entity_id
+	histories    map[string]*models.SkillHistory  // key: history entity_id
+	endorsements map[string]*models.Endorsement   // key: endorsement entity_id
+	activities   map[string]*models.ActivityLog   // key: activity entity_id
 	mutex        sync.RWMutex
 }
 
@@ -51,8 +105,36 @@ func NewMockRepository() *MockRepository {
 		users:        make(map[string]*models.User),
 		skills:       make(map[string]*models.UserSkill),
 		masterSkills: make(map[string]*models.Skill),
+		skillNames:   make(map[string]string),
+		emails:       make(map[string]string),
+		snapshots:    make(map[string]*models.SkillSnapshot),
+		histories:    make(map[string]*models.SkillHistory),
+		endorsements: make(map[string]*models.Endorsement),
+		activities:   make(map[string]*models.ActivityLog),
 	}
 
 	log.Info("Unified Mock repository initialized successfully")
 	return repo
 }
+
+// Ping verifies DynamoDB connectivity using a lightweight DescribeTable call
+func (r *DynamoDBRepository) Ping() error {
+	log := logger.WithComponent("database").With("operation", "Ping", "table", r.names.TableName)
+	start := time.Now()
+
+	_, err := r.client.DescribeTable(&dynamodb.DescribeTableInput{
+		TableName: aws.String(r.names.TableName),
+	})
+	if err != nil {
+		log.Error("DynamoDB ping failed", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
+
+	log.Debug("DynamoDB ping succeeded", "duration", time.Since(start))
+	return nil
+}
+
+// Ping always succeeds for the in-memory repository
+func (m *MockRepository) Ping() error {
+	return nil
+}
@@ -5,9 +5,13 @@ import (
 	"fmt"
 	"sync"
 	"testing"
+	"time"
 
 	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
 	"github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
 )
 
 func TestNewMockRepository(t *testing.T) {
@@ -372,10 +376,13 @@ func TestMockRepository_ListUsersBySkill(t *testing.T) {
 	repo.CreateSkill(skill3)
 
 	// Test list users with Go skill in Programming category
-	skills, err := repo.ListUsersBySkill("Programming", "Go")
+	skills, next, err := repo.ListUsersBySkill("Programming", "Go", 0, "")
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
+	if next != "" {
+		t.Errorf("Expected no next token without a limit, got %q", next)
+	}
 	if len(skills) != 2 {
 		t.Errorf("Expected 2 users with Go skill, got %d", len(skills))
 	}
@@ -430,3 +437,267 @@ func TestMockRepository_UnifiedInterface(t *testing.T) {
 		t.Errorf("Failed to get skill via Repository interface: %v", err)
 	}
 }
+
+func TestMockRepository_BatchCreateSkills(t *testing.T) {
+	repo := NewMockRepository()
+
+	skills := make([]*models.UserSkill, 0, 30)
+	for i := 0; i < 30; i++ {
+		skill, _ := models.NewUserSkill("testuser", fmt.Sprintf("skill-%d", i), fmt.Sprintf("Skill %d", i), "Programming", models.ProficiencyBeginner, 1)
+		skills = append(skills, skill)
+	}
+
+	if err := repo.BatchCreateSkills("testuser", skills); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	stored, err := repo.ListSkillsForUser("testuser")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(stored) != len(skills) {
+		t.Errorf("Expected %d skills, got %d", len(skills), len(stored))
+	}
+}
+
+func TestMockRepository_SkillCount(t *testing.T) {
+	repo := NewMockRepository()
+	user, _ := models.NewUser("testuser", "Test User", "password123")
+	if err := repo.CreateUser(user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	assertCount := func(want int) {
+		t.Helper()
+		got, err := repo.CountSkillsForUser("testuser")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if got != want {
+			t.Errorf("Expected skill count %d, got %d", want, got)
+		}
+	}
+
+	assertCount(0)
+
+	for _, id := range []string{"go", "rust", "python"} {
+		skill, _ := models.NewUserSkill("testuser", id, id, "Programming", models.ProficiencyBeginner, 1)
+		if err := repo.CreateSkill(skill); err != nil {
+			t.Fatalf("Failed to create skill %s: %v", id, err)
+		}
+	}
+	assertCount(3)
+
+	// Failed writes leave the count alone
+	duplicate, _ := models.NewUserSkill("testuser", "go", "go", "Programming", models.ProficiencyBeginner, 1)
+	if err := repo.CreateSkill(duplicate); !errors.Is(err, apperrors.ErrSkillAlreadyExists) {
+		t.Fatalf("Expected ErrSkillAlreadyExists, got %v", err)
+	}
+	if err := repo.DeleteSkill("testuser", "java"); !errors.Is(err, apperrors.ErrSkillNotFound) {
+		t.Fatalf("Expected ErrSkillNotFound, got %v", err)
+	}
+	assertCount(3)
+
+	if err := repo.DeleteSkill("testuser", "rust"); err != nil {
+		t.Fatalf("Failed to delete skill: %v", err)
+	}
+	if err := repo.DeleteSkill("testuser", "go"); err != nil {
+		t.Fatalf("Failed to delete skill: %v", err)
+	}
+	assertCount(1)
+
+	if err := repo.AdjustSkillCount("testuser", 2); err != nil {
+		t.Fatalf("Failed to adjust skill count: %v", err)
+	}
+	assertCount(3)
+
+	// Profile updates never write back a stale count
+	stale, _ := models.NewUser("testuser", "Renamed User", "password123")
+	if err := repo.UpdateUser(stale); err != nil {
+		t.Fatalf("Failed to update user: %v", err)
+	}
+	assertCount(3)
+
+	// Batch deletes only count the skills that were still there
+	var batchErr *BatchWriteError
+	if err := repo.BatchDeleteSkills("testuser", []string{"python", "rust"}); !errors.As(err, &batchErr) {
+		t.Fatalf("Expected a BatchWriteError, got %v", err)
+	}
+	if len(batchErr.Failed) != 1 || !errors.Is(batchErr.Failed[1], apperrors.ErrSkillNotFound) {
+		t.Errorf("Expected only rust to fail with ErrSkillNotFound, got %v", batchErr.Failed)
+	}
+	assertCount(2)
+
+	if err := repo.ReplaceSkillCount("testuser", 5, 0); !errors.Is(err, ErrSkillCountChanged) {
+		t.Errorf("Expected ErrSkillCountChanged for a stale count, got %v", err)
+	}
+	if err := repo.ReplaceSkillCount("testuser", 2, 0); err != nil {
+		t.Fatalf("Failed to replace skill count: %v", err)
+	}
+	assertCount(0)
+
+	if _, err := repo.CountSkillsForUser("nobody"); !errors.Is(err, apperrors.ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+}
+
+// ============================================================================
+// MASTER SKILL REPOSITORY TESTS
+// ============================================================================
+
+func TestMockRepository_ListMasterSkillsFiltered(t *testing.T) {
+	repo := NewMockRepository()
+
+	lambda, _ := models.NewSkill("aws-lambda", "AWS Lambda", "Serverless compute", "Cloud", []string{"aws", "serverless"})
+	s3, _ := models.NewSkill("aws-s3", "AWS S3", "Object storage", "Cloud", []string{"aws", "storage"})
+	golang, _ := models.NewSkill("go", "Go", "Go language", "Programming", []string{"serverless"})
+
+	repo.CreateMasterSkill(lambda)
+	repo.CreateMasterSkill(s3)
+	repo.CreateMasterSkill(golang)
+
+	tests := []struct {
+		name     string
+		category string
+		tags     []string
+		expected []string
+	}{
+		{name: "category only", category: "Cloud", expected: []string{"aws-lambda", "aws-s3"}},
+		{name: "tag only", tags: []string{"serverless"}, expected: []string{"aws-lambda", "go"}},
+		{name: "category and tag", category: "Cloud", tags: []string{"serverless"}, expected: []string{"aws-lambda"}},
+		{name: "multiple tags are ANDed", tags: []string{"aws", "storage"}, expected: []string{"aws-s3"}},
+		{name: "no match", category: "Security", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			skills, err := repo.ListMasterSkillsFiltered(tt.category, tt.tags)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			ids := make(map[string]bool)
+			for _, skill := range skills {
+				ids[skill.SkillID] = true
+			}
+			if len(ids) != len(tt.expected) {
+				t.Errorf("Expected %d skills, got %d", len(tt.expected), len(ids))
+			}
+			for _, id := range tt.expected {
+				if !ids[id] {
+					t.Errorf("Expected %s to be in the list", id)
+				}
+			}
+		})
+	}
+}
+
+// ============================================================================
+// SKILL HISTORY REPOSITORY TESTS
+// ============================================================================
+
+func TestMockRepository_ListSkillHistory(t *testing.T) {
+	repo := NewMockRepository()
+
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	changes := []struct {
+		skillID string
+		from    models.ProficiencyLevel
+		to      models.ProficiencyLevel
+		at      time.Time
+	}{
+		{"go", models.ProficiencyBeginner, models.ProficiencyIntermediate, base},
+		{"go", models.ProficiencyAdvanced, models.ProficiencyExpert, base.Add(48 * time.Hour)},
+		{"go", models.ProficiencyIntermediate, models.ProficiencyAdvanced, base.Add(24 * time.Hour)},
+		{"python", models.ProficiencyBeginner, models.ProficiencyExpert, base.Add(72 * time.Hour)},
+	}
+	for _, change := range changes {
+		history := models.NewSkillHistory("testuser", change.skillID, change.from, change.to)
+		history.ChangedAt = change.at
+		if err := repo.CreateSkillHistory(history); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	entries, err := repo.ListSkillHistory("testuser", "go")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []models.ProficiencyLevel{models.ProficiencyExpert, models.ProficiencyAdvanced, models.ProficiencyIntermediate}
+	if len(entries) != len(expected) {
+		t.Fatalf("Expected %d entries, got %d", len(expected), len(entries))
+	}
+	for i, level := range expected {
+		if entries[i].NewLevel != level {
+			t.Errorf("Expected entry %d to change to %s, got %s", i, level, entries[i].NewLevel)
+		}
+	}
+}
+
+func TestMockRepository_ListUsersBySkill_Pagination(t *testing.T) {
+	repo := NewMockRepository()
+
+	for _, username := range []string{"dave", "alice", "carol", "bob", "erin"} {
+		skill, _ := models.NewUserSkill(username, "go", "Go", "Programming", models.ProficiencyIntermediate, 3)
+		repo.CreateSkill(skill)
+	}
+
+	var pages [][]string
+	token := ""
+	for {
+		skills, next, err := repo.ListUsersBySkill("Programming", "Go", 2, token)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		var page []string
+		for _, skill := range skills {
+			page = append(page, skill.Username)
+		}
+		pages = append(pages, page)
+		if next == "" {
+			break
+		}
+		token = next
+	}
+
+	expected := [][]string{{"alice", "bob"}, {"carol", "dave"}, {"erin"}}
+	if fmt.Sprint(pages) != fmt.Sprint(expected) {
+		t.Errorf("Expected pages %v, got %v", expected, pages)
+	}
+
+	if _, _, err := repo.ListUsersBySkill("Programming", "Go", 2, "!!not-a-token!!"); !errors.Is(err, apperrors.ErrInvalidPageToken) {
+		t.Errorf("Expected ErrInvalidPageToken, got %v", err)
+	}
+}
+
+func TestPageToken_RoundTrip(t *testing.T) {
+	key := map[string]*dynamodb.AttributeValue{
+		"EntityType": {S: aws.String("UserSkill")},
+		"entity_id":  {S: aws.String("USERSKILL#alice#go")},
+		"Category":   {S: aws.String("Programming")},
+		"SkillName":  {S: aws.String("Go")},
+	}
+
+	token, err := encodePageToken(key)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	decoded, err := decodePageToken(token)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for name, value := range key {
+		if decoded[name] == nil || *decoded[name].S != *value.S {
+			t.Errorf("Expected %s to round-trip as %s, got %v", name, *value.S, decoded[name])
+		}
+	}
+
+	if token, _ := encodePageToken(nil); token != "" {
+		t.Errorf("Expected empty token for the last page, got %q", token)
+	}
+	if _, err := decodePageToken("e30"); !errors.Is(err, apperrors.ErrInvalidPageToken) {
+		t.Errorf("Expected ErrInvalidPageToken for an empty key, got %v", err)
+	}
+}
@@ -1,12 +1,41 @@
 package database
 
-import (
-	"github.com/hackmajoris/glad-stack/pkg/config"
+// Default DynamoDB resource names
+// The index names match deployments/glad; deployed stacks set the table name through DYNAMODB_TABLE.
+const (
+	DefaultTableName            = "entities-table"
+	DefaultBySkillIndex         = "BySkill"
+	DefaultRecentlyUpdatedIndex = "RecentlyUpdated"
 )
 
-var (
-	// TableName is the single table for all entities
-	TableName = config.Load().Database.TableName
+// RepositoryConfig names the DynamoDB table and indexes a DynamoDBRepository uses
+// Keeping these per repository lets one process talk to several environments' tables.
+type RepositoryConfig struct {
+	TableName            string // single table holding every entity
+	BySkillIndex         string // GSI on SkillName + Category for cross-user skill queries
+	RecentlyUpdatedIndex string // GSI on RecentFeed + UpdatedAt for the recent skills feed
+}
 
-	GSIBySkill = "BySkill"
-)
+// DefaultRepositoryConfig returns the table and index names used when none are configured
+func DefaultRepositoryConfig() RepositoryConfig {
+	return RepositoryConfig{
+		TableName:            DefaultTableName,
+		BySkillIndex:         DefaultBySkillIndex,
+		RecentlyUpdatedIndex: DefaultRecentlyUpdatedIndex,
+	}
+}
+
+// withDefaults fills any empty name from DefaultRepositoryConfig
+func (c RepositoryConfig) withDefaults() RepositoryConfig {
+	defaults := DefaultRepositoryConfig()
+	if c.TableName == "" {
+		c.TableName = defaults.TableName
+	}
+	if c.BySkillIndex == "" {
+		c.BySkillIndex = defaults.BySkillIndex
+	}
+	if c.RecentlyUpdatedIndex == "" {
+		c.RecentlyUpdatedIndex = defaults.RecentlyUpdatedIndex
+	}
+	return c
+}
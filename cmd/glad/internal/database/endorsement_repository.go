@@ -0,0 +1,24 @@
+package database
+
+import "github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
+
+// EndorsementRepository defines operations for per-endorser endorsement records
+type EndorsementRepository interface {
+	// CreateEndorsement stores a new endorsement; an existing one for the same endorser yields ErrAlreadyEndorsed
+	CreateEndorsement(endorsement *models.Endorsement) error
+	// DeleteEndorsement removes endorser's endorsement of a user's skill, or returns ErrEndorsementNotFound
+	DeleteEndorsement(username, skillID, endorser string) error
+	// AddEndorsement stores a new endorsement and increments the endorsed skill's Endorsements in a
+	// single write. It fails with ErrAlreadyEndorsed for an existing endorsement and with
+	// ErrSkillNotFound when the skill does not exist.
+	AddEndorsement(endorsement *models.Endorsement) error
+	// RemoveEndorsement deletes endorser's endorsement and decrements the skill's Endorsements in a
+	// single write. It fails with ErrEndorsementNotFound or ErrSkillNotFound, changing neither.
+	RemoveEndorsement(username, skillID, endorser string) error
+	// ListEndorsements returns the endorsements of a user's skill, ordered by endorser
+	ListEndorsements(username, skillID string) ([]*models.Endorsement, error)
+	// ListEndorsementsByEndorser returns every endorsement endorser has given
+	ListEndorsementsByEndorser(endorser string) ([]*models.Endorsement, error)
+	// DeleteEndorsementsForSkill removes all endorsements of a user's skill and returns how many were removed
+	DeleteEndorsementsForSkill(username, skillID string) (int, error)
+}
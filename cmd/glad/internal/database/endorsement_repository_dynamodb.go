@@ -0,0 +1,276 @@
+package database
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
+	"github.com/hackmajoris/glad-stack/pkg/logger"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+)
+
+// CreateEndorsement inserts a new endorsement into DynamoDB
+// The put is conditional on the entity_id being new, so an endorser can endorse a skill only once.
+func (r *DynamoDBRepository) CreateEndorsement(endorsement *models.Endorsement) error {
+	log := logger.WithComponent("database").With("operation", "CreateEndorsement", "username", endorsement.Username, "skill_id", endorsement.SkillID, "endorser", endorsement.Endorser)
+	start := time.Now()
+
+	log.Debug("Starting endorsement creation")
+
+	endorsement.SetKeys()
+
+	item, err := dynamodbattribute.MarshalMap(endorsement)
+	if err != nil {
+		log.Error("Failed to marshal endorsement data", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
+
+	input := &dynamodb.PutItemInput{
+		TableName:           aws.String(r.names.TableName),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(entity_id)"),
+	}
+
+	if _, err := r.putItem("CreateEndorsement", input); err != nil {
+		if isConditionFailed(err) {
+			log.Info("Endorsement already exists", "duration", time.Since(start))
+			return apperrors.ErrAlreadyEndorsed
+		}
+		log.Error("Failed to create endorsement in DynamoDB", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
+
+	log.Info("Endorsement created successfully", "duration", time.Since(start))
+	return nil
+}
+
+// DeleteEndorsement removes an endorsement, failing with ErrEndorsementNotFound when it does not exist
+func (r *DynamoDBRepository) DeleteEndorsement(username, skillID, endorser string) error {
+	log := logger.WithComponent("database").With("operation", "DeleteEndorsement", "username", username, "skill_id", skillID, "endorser", endorser)
+	start := time.Now()
+
+	log.Debug("Starting endorsement deletion")
+
+	input := &dynamodb.DeleteItemInput{
+		TableName: aws.String(r.names.TableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"EntityType": {S: aws.String("Endorsement")},
+			"entity_id":  {S: aws.String(models.BuildEndorsementEntityID(username, skillID, endorser))},
+		},
+		ConditionExpression: aws.String("attribute_exists(entity_id)"),
+	}
+
+	if _, err := r.deleteItem("DeleteEndorsement", input); err != nil {
+		if isConditionFailed(err) {
+			log.Info("Endorsement not found for deletion", "duration", time.Since(start))
+			return apperrors.ErrEndorsementNotFound
+		}
+		log.Error("Failed to delete endorsement from DynamoDB", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
+
+	log.Info("Endorsement deleted successfully", "duration", time.Since(start))
+	return nil
+}
+
+// AddEndorsement inserts a new endorsement and increments the skill's Endorsements
+// The put and the increment form one transaction, so concurrent endorsers never overwrite each
+// other's count and a rejected endorsement never moves it.
+func (r *DynamoDBRepository) AddEndorsement(endorsement *models.Endorsement) error {
+	log := logger.WithComponent("database").With("operation", "AddEndorsement", "username", endorsement.Username, "skill_id", endorsement.SkillID, "endorser", endorsement.Endorser)
+	start := time.Now()
+
+	log.Debug("Starting endorsement creation")
+
+	endorsement.SetKeys()
+
+	item, err := dynamodbattribute.MarshalMap(endorsement)
+	if err != nil {
+		log.Error("Failed to marshal endorsement data", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
+
+	input := &dynamodb.TransactWriteItemsInput{
+		TransactItems: []*dynamodb.TransactWriteItem{
+			{
+				Put: &dynamodb.Put{
+					TableName:           aws.String(r.names.TableName),
+					Item:                item,
+					ConditionExpression: aws.String("attribute_not_exists(entity_id)"),
+				},
+			},
+			{Update: r.endorsementCountUpdate(endorsement.Username, endorsement.SkillID, 1)},
+		},
+	}
+
+	if _, err := r.transactWriteItemsWithContext(context.Background(), "AddEndorsement", input); err != nil {
+		if failed, ok := failedCondition(err); ok {
+			if failed == 0 {
+				log.Info("Endorsement already exists", "duration", time.Since(start))
+				return apperrors.ErrAlreadyEndorsed
+			}
+			log.Info("Skill not found for endorsement", "duration", time.Since(start))
+			return apperrors.ErrSkillNotFound
+		}
+		log.Error("Failed to create endorsement in DynamoDB", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
+
+	log.Info("Endorsement created successfully", "duration", time.Since(start))
+	return nil
+}
+
+// RemoveEndorsement deletes an endorsement and decrements the skill's Endorsements
+// Like AddEndorsement, the delete and the decrement form one transaction.
+func (r *DynamoDBRepository) RemoveEndorsement(username, skillID, endorser string) error {
+	log := logger.WithComponent("database").With("operation", "RemoveEndorsement", "username", username, "skill_id", skillID, "endorser", endorser)
+	start := time.Now()
+
+	log.Debug("Starting endorsement deletion")
+
+	input := &dynamodb.TransactWriteItemsInput{
+		TransactItems: []*dynamodb.TransactWriteItem{
+			{
+				Delete: &dynamodb.Delete{
+					TableName:           aws.String(r.names.TableName),
+					Key:                 r.itemKey("Endorsement", models.BuildEndorsementEntityID(username, skillID, endorser)),
+					ConditionExpression: aws.String("attribute_exists(entity_id)"),
+				},
+			},
+			{Update: r.endorsementCountUpdate(username, skillID, -1)},
+		},
+	}
+
+	if _, err := r.transactWriteItemsWithContext(context.Background(), "RemoveEndorsement", input); err != nil {
+		if failed, ok := failedCondition(err); ok {
+			if failed == 0 {
+				log.Info("Endorsement not found for deletion", "duration", time.Since(start))
+				return apperrors.ErrEndorsementNotFound
+			}
+			log.Info("Skill not found for endorsement removal", "duration", time.Since(start))
+			return apperrors.ErrSkillNotFound
+		}
+		log.Error("Failed to delete endorsement from DynamoDB", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
+
+	log.Info("Endorsement deleted successfully", "duration", time.Since(start))
+	return nil
+}
+
+// endorsementCountUpdate builds the update adding delta to a user skill's Endorsements
+// The skill must exist, so an endorsement can never create a stub skill item. UpdatedAt is
+// written in the RFC 3339 form dynamodbattribute uses for time.Time.
+func (r *DynamoDBRepository) endorsementCountUpdate(username, skillID string, delta int) *dynamodb.Update {
+	return &dynamodb.Update{
+		TableName:           aws.String(r.names.TableName),
+		Key:                 r.itemKey("UserSkill", BuildUserSkillEntityID(username, skillID)),
+		UpdateExpression:    aws.String("ADD Endorsements :delta SET UpdatedAt = :updatedAt"),
+		ConditionExpression: aws.String("attribute_exists(entity_id)"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":delta":     {N: aws.String(strconv.Itoa(delta))},
+			":updatedAt": {S: aws.String(time.Now().Format(time.RFC3339Nano))},
+		},
+	}
+}
+
+// ListEndorsements retrieves the endorsements of a user's skill, ordered by endorser
+// Endorser is the last entity_id segment, so the ascending key order is endorser order.
+func (r *DynamoDBRepository) ListEndorsements(username, skillID string) ([]*models.Endorsement, error) {
+	log := logger.WithComponent("database").With("operation", "ListEndorsements", "username", username, "skill_id", skillID)
+	start := time.Now()
+
+	log.Debug("Starting endorsement retrieval")
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(r.names.TableName),
+		KeyConditionExpression: aws.String("EntityType = :entityType AND begins_with(entity_id, :prefix)"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":entityType": {S: aws.String("Endorsement")},
+			":prefix":     {S: aws.String(models.BuildSkillEndorsementPrefix(username, skillID))},
+		},
+	}
+
+	endorsements, err := r.queryEndorsements("ListEndorsements", input)
+	if err != nil {
+		log.Error("Failed to query endorsements", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	log.Info("Endorsements retrieved successfully", "count", len(endorsements), "duration", time.Since(start))
+	return endorsements, nil
+}
+
+// ListEndorsementsByEndorser retrieves every endorsement an endorser has given
+// Keys are prefixed by the endorsed user, so this filters the whole Endorsement partition.
+func (r *DynamoDBRepository) ListEndorsementsByEndorser(endorser string) ([]*models.Endorsement, error) {
+	log := logger.WithComponent("database").With("operation", "ListEndorsementsByEndorser", "endorser", endorser)
+	start := time.Now()
+
+	log.Debug("Starting endorsement retrieval by endorser")
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(r.names.TableName),
+		KeyConditionExpression: aws.String("EntityType = :entityType"),
+		FilterExpression:       aws.String("Endorser = :endorser"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":entityType": {S: aws.String("Endorsement")},
+			":endorser":   {S: aws.String(endorser)},
+		},
+	}
+
+	endorsements, err := r.queryEndorsements("ListEndorsementsByEndorser", input)
+	if err != nil {
+		log.Error("Failed to query endorsements by endorser", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	log.Info("Endorsements by endorser retrieved successfully", "count", len(endorsements), "duration", time.Since(start))
+	return endorsements, nil
+}
+
+// DeleteEndorsementsForSkill removes all endorsements of a user's skill
+func (r *DynamoDBRepository) DeleteEndorsementsForSkill(username, skillID string) (int, error) {
+	log := logger.WithComponent("database").With("operation", "DeleteEndorsementsForSkill", "username", username, "skill_id", skillID)
+	start := time.Now()
+
+	log.Debug("Starting skill endorsements deletion")
+
+	deleted, err := r.deleteByPrefix("DeleteEndorsementsForSkill", "Endorsement", models.BuildSkillEndorsementPrefix(username, skillID))
+	if err != nil {
+		log.Error("Failed to delete skill endorsements", "error", err.Error(), "duration", time.Since(start))
+		return 0, err
+	}
+
+	log.Info("Skill endorsements deleted successfully", "count", deleted, "duration", time.Since(start))
+	return deleted, nil
+}
+
+// queryEndorsements runs an endorsement query across all result pages
+func (r *DynamoDBRepository) queryEndorsements(operation string, input *dynamodb.QueryInput) ([]*models.Endorsement, error) {
+	var endorsements []*models.Endorsement
+	for {
+		result, err := r.query(operation, input)
+		if err != nil {
+			return nil, err
+		}
+		for i, item := range result.Items {
+			var endorsement models.Endorsement
+			if err := dynamodbattribute.UnmarshalMap(item, &endorsement); err != nil {
+				logger.WithComponent("database").Error("Failed to unmarshal endorsement data", "operation", operation, "error", err.Error(), "item_index", i)
+				continue
+			}
+			endorsements = append(endorsements, &endorsement)
+		}
+		if len(result.LastEvaluatedKey) == 0 {
+			break
+		}
+		input.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+	return endorsements, nil
+}
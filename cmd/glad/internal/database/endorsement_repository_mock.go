@@ -0,0 +1,189 @@
+package database
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
+	"github.com/hackmajoris/glad-stack/pkg/logger"
+)
+
+// CreateEndorsement stores an endorsement in memory
+func (m *MockRepository) CreateEndorsement(endorsement *models.Endorsement) error {
+	log := logger.WithComponent("database").With("operation", "CreateEndorsement", "username", endorsement.Username, "skill_id", endorsement.SkillID, "endorser", endorsement.Endorser, "repository", "mock")
+	start := time.Now()
+
+	log.Debug("Starting endorsement creation in mock repository")
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	endorsement.SetKeys()
+	if _, exists := m.endorsements[endorsement.EntityID]; exists {
+		log.Info("Endorsement already exists in mock repository", "duration", time.Since(start))
+		return apperrors.ErrAlreadyEndorsed
+	}
+	m.endorsements[endorsement.EntityID] = endorsement
+
+	log.Info("Endorsement created successfully in mock repository", "duration", time.Since(start))
+	return nil
+}
+
+// DeleteEndorsement removes an endorsement from memory
+func (m *MockRepository) DeleteEndorsement(username, skillID, endorser string) error {
+	log := logger.WithComponent("database").With("operation", "DeleteEndorsement", "username", username, "skill_id", skillID, "endorser", endorser, "repository", "mock")
+	start := time.Now()
+
+	log.Debug("Starting endorsement deletion in mock repository")
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	entityID := models.BuildEndorsementEntityID(username, skillID, endorser)
+	if _, exists := m.endorsements[entityID]; !exists {
+		log.Info("Endorsement not found for deletion in mock repository", "duration", time.Since(start))
+		return apperrors.ErrEndorsementNotFound
+	}
+	delete(m.endorsements, entityID)
+
+	log.Info("Endorsement deleted successfully from mock repository", "duration", time.Since(start))
+	return nil
+}
+
+// AddEndorsement stores an endorsement in memory and increments the skill's Endorsements
+func (m *MockRepository) AddEndorsement(endorsement *models.Endorsement) error {
+	log := logger.WithComponent("database").With("operation", "AddEndorsement", "username", endorsement.Username, "skill_id", endorsement.SkillID, "endorser", endorsement.Endorser, "repository", "mock")
+	start := time.Now()
+
+	log.Debug("Starting endorsement creation in mock repository")
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	endorsement.SetKeys()
+	if _, exists := m.endorsements[endorsement.EntityID]; exists {
+		log.Info("Endorsement already exists in mock repository", "duration", time.Since(start))
+		return apperrors.ErrAlreadyEndorsed
+	}
+	if !m.adjustEndorsements(endorsement.Username, endorsement.SkillID, 1) {
+		log.Info("Skill not found for endorsement in mock repository", "duration", time.Since(start))
+		return apperrors.ErrSkillNotFound
+	}
+	m.endorsements[endorsement.EntityID] = endorsement
+
+	log.Info("Endorsement created successfully in mock repository", "duration", time.Since(start))
+	return nil
+}
+
+// RemoveEndorsement removes an endorsement from memory and decrements the skill's Endorsements
+func (m *MockRepository) RemoveEndorsement(username, skillID, endorser string) error {
+	log := logger.WithComponent("database").With("operation", "RemoveEndorsement", "username", username, "skill_id", skillID, "endorser", endorser, "repository", "mock")
+	start := time.Now()
+
+	log.Debug("Starting endorsement deletion in mock repository")
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	entityID := models.BuildEndorsementEntityID(username, skillID, endorser)
+	if _, exists := m.endorsements[entityID]; !exists {
+		log.Info("Endorsement not found for deletion in mock repository", "duration", time.Since(start))
+		return apperrors.ErrEndorsementNotFound
+	}
+	if !m.adjustEndorsements(username, skillID, -1) {
+		log.Info("Skill not found for endorsement removal in mock repository", "duration", time.Since(start))
+		return apperrors.ErrSkillNotFound
+	}
+	delete(m.endorsements, entityID)
+
+	log.Info("Endorsement deleted successfully from mock repository", "duration", time.Since(start))
+	return nil
+}
+
+// adjustEndorsements adds delta to a stored skill's Endorsements, reporting whether the skill exists
+// The stored skill is replaced by an updated copy, so skills handed out earlier keep their values.
+// Callers must hold m.mutex.
+func (m *MockRepository) adjustEndorsements(username, skillID string, delta int) bool {
+	key := models.BuildUserSkillEntityID(username, skillID)
+	skill, exists := m.skills[key]
+	if !exists {
+		return false
+	}
+	updated := *skill
+	updated.Endorsements += delta
+	updated.UpdatedAt = time.Now()
+	m.skills[key] = &updated
+	return true
+}
+
+// ListEndorsements retrieves the endorsements of a user's skill from memory, ordered by endorser
+func (m *MockRepository) ListEndorsements(username, skillID string) ([]*models.Endorsement, error) {
+	log := logger.WithComponent("database").With("operation", "ListEndorsements", "username", username, "skill_id", skillID, "repository", "mock")
+	start := time.Now()
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	prefix := models.BuildSkillEndorsementPrefix(username, skillID)
+	var endorsements []*models.Endorsement
+	for entityID, endorsement := range m.endorsements {
+		if strings.HasPrefix(entityID, prefix) {
+			endorsements = append(endorsements, endorsement)
+		}
+	}
+
+	// Match the DynamoDB ascending sort on entity_id
+	sort.Slice(endorsements, func(i, j int) bool { return endorsements[i].EntityID < endorsements[j].EntityID })
+
+	log.Info("Endorsements retrieved successfully from mock repository", "count", len(endorsements), "duration", time.Since(start))
+	return endorsements, nil
+}
+
+// ListEndorsementsByEndorser retrieves every endorsement an endorser has given from memory
+func (m *MockRepository) ListEndorsementsByEndorser(endorser string) ([]*models.Endorsement, error) {
+	log := logger.WithComponent("database").With("operation", "ListEndorsementsByEndorser", "endorser", endorser, "repository", "mock")
+	start := time.Now()
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var endorsements []*models.Endorsement
+	for _, endorsement := range m.endorsements {
+		if endorsement.Endorser == endorser {
+			endorsements = append(endorsements, endorsement)
+		}
+	}
+	sort.Slice(endorsements, func(i, j int) bool { return endorsements[i].EntityID < endorsements[j].EntityID })
+
+	log.Info("Endorsements by endorser retrieved successfully from mock repository", "count", len(endorsements), "duration", time.Since(start))
+	return endorsements, nil
+}
+
+// DeleteEndorsementsForSkill removes all endorsements of a user's skill from memory
+func (m *MockRepository) DeleteEndorsementsForSkill(username, skillID string) (int, error) {
+	log := logger.WithComponent("database").With("operation", "DeleteEndorsementsForSkill", "username", username, "skill_id", skillID, "repository", "mock")
+	start := time.Now()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	deleted := m.deleteEndorsementsByPrefix(models.BuildSkillEndorsementPrefix(username, skillID))
+
+	log.Info("Skill endorsements deleted successfully from mock repository", "count", deleted, "duration", time.Since(start))
+	return deleted, nil
+}
+
+// deleteEndorsementsByPrefix removes the endorsements whose entity_id starts with prefix
+// The caller must hold the write lock.
+func (m *MockRepository) deleteEndorsementsByPrefix(prefix string) int {
+	deleted := 0
+	for entityID := range m.endorsements {
+		if strings.HasPrefix(entityID, prefix) {
+			delete(m.endorsements, entityID)
+			deleted++
+		}
+	}
+	return deleted
+}
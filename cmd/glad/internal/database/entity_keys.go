@@ -26,6 +26,18 @@ func BuildMasterSkillEntityID(skillID string) string {
 	return fmt.Sprintf("SKILL#%s", strings.ToLower(skillID))
 }
 
+// BuildSkillNameEntityID creates an entity ID for the claim on a master skill name
+// Format: SKILLNAME#<lowercased name>
+func BuildSkillNameEntityID(skillName string) string {
+	return fmt.Sprintf("SKILLNAME#%s", strings.ToLower(skillName))
+}
+
+// BuildEmailEntityID creates an entity ID for the claim on a user's email address
+// Format: EMAIL#<lowercased address>
+func BuildEmailEntityID(email string) string {
+	return fmt.Sprintf("EMAIL#%s", strings.ToLower(email))
+}
+
 // ParseUserEntityID extracts the username from a User entity ID
 // Returns the username or empty string if invalid format
 func ParseUserEntityID(entityID string) string {
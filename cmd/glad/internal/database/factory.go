@@ -13,6 +13,13 @@ type Repository interface {
 	UserRepository
 	SkillRepository
 	MasterSkillRepository
+	SkillSnapshotRepository
+	SkillHistoryRepository
+	EndorsementRepository
+	ActivityRepository
+
+	// Ping verifies connectivity to the underlying data store
+	Ping() error
 }
 
 // NewRepository creates the appropriate repository implementation based on configuration
@@ -26,7 +33,16 @@ func NewRepository(cfg *config.Config) Repository {
 	}
 
 	log.Info("Creating DynamoDB repository for production/Lambda")
-	return NewDynamoDBRepository()
+	return NewDynamoDBRepository(
+		WithRepositoryConfig(RepositoryConfig{
+			TableName:            cfg.Database.TableName,
+			BySkillIndex:         cfg.Database.BySkillIndex,
+			RecentlyUpdatedIndex: cfg.Database.RecentlyUpdatedIndex,
+		}),
+		WithSlowQueryThreshold(cfg.Database.SlowQueryThreshold),
+		WithConsumedCapacityLogging(cfg.Database.LogConsumedCapacity),
+		WithMaxWriteAttempts(cfg.Database.MaxWriteAttempts),
+	)
 }
 
 // shouldUseMockRepository determines if we should use mock repository
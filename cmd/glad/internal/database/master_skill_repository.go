@@ -1,12 +1,45 @@
 package database
 
-import "github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
+import (
+	"context"
+	"errors"
+
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
+)
+
+// ErrMasterSkillChanged reports that a master skill changed between being read and written
+var ErrMasterSkillChanged = errors.New("master skill changed concurrently")
 
 // MasterSkillRepository defines operations for master skills
+// Every active master skill holds a claim on its name, ignoring case, which no other skill can
+// take: creating a skill, or renaming or restoring one, under a name another skill holds fails
+// with ErrMasterSkillNameTaken. Soft-deleting or deleting a skill releases its claim.
 type MasterSkillRepository interface {
 	CreateMasterSkill(skill *models.Skill) error
 	GetMasterSkill(skillID string) (*models.Skill, error)
+	// GetMasterSkillWithContext is GetMasterSkill bounded by ctx, failing with ErrTimeout once ctx is done
+	GetMasterSkillWithContext(ctx context.Context, skillID string) (*models.Skill, error)
 	UpdateMasterSkill(skill *models.Skill) error
+	// SetSkillNameLower stores the lowercased skillName as the skill's SkillNameLower if its name
+	// is still skillName. Any other name, or a missing skill, fails with ErrMasterSkillChanged;
+	// the write that changed it has set SkillNameLower already.
+	SetSkillNameLower(skillID, skillName string) error
+	// ClaimSkillName stores the name claim of an active skill written before claims existed if the
+	// skill is unchanged since it was read, failing with ErrMasterSkillChanged otherwise
+	ClaimSkillName(skill *models.Skill) error
+	// SkillNameOwner returns the ID of the skill holding the claim on name, or "" if none does
+	SkillNameOwner(name string) (string, error)
 	DeleteMasterSkill(skillID string) error
 	ListMasterSkills() ([]*models.Skill, error)
+	// ListMasterSkillsFiltered returns skills in category (if non-empty) carrying all of tags
+	ListMasterSkillsFiltered(category string, tags []string) ([]*models.Skill, error)
+	// ListMasterSkillsPage returns up to limit filtered skills after nextToken, plus the token
+	// of the following page (empty on the last page); limit 0 means no limit
+	ListMasterSkillsPage(category string, tags []string, limit int, nextToken string) ([]*models.Skill, string, error)
+	// SearchMasterSkillsByNamePrefix returns skills whose name starts with prefix, ignoring case
+	SearchMasterSkillsByNamePrefix(prefix string) ([]*models.Skill, error)
+	// ListFeaturedMasterSkills returns the skills flagged as featured, including soft-deleted ones
+	ListFeaturedMasterSkills() ([]*models.Skill, error)
+	// ListMasterSkillCategories returns the distinct categories of active skills, in no particular order
+	ListMasterSkillCategories() ([]string, error)
 }
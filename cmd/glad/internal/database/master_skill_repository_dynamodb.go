@@ -1,6 +1,9 @@
 package database
 
 import (
+	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
@@ -12,7 +15,8 @@ import (
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
 )
 
-// CreateMasterSkill inserts a new master skill
+// CreateMasterSkill inserts a new master skill together with the claim on its name, unless it is
+// soft-deleted
 func (r *DynamoDBRepository) CreateMasterSkill(skill *models.Skill) error {
 	log := logger.WithComponent("database").With("operation", "CreateMasterSkill", "skill_id", skill.SkillID)
 	start := time.Now()
@@ -27,16 +31,33 @@ func (r *DynamoDBRepository) CreateMasterSkill(skill *models.Skill) error {
 		return err
 	}
 
-	input := &dynamodb.PutItemInput{
-		TableName:           aws.String(TableName),
-		Item:                item,
-		ConditionExpression: aws.String("attribute_not_exists(entity_id)"),
+	input := &dynamodb.TransactWriteItemsInput{
+		TransactItems: []*dynamodb.TransactWriteItem{
+			{
+				Put: &dynamodb.Put{
+					TableName:           aws.String(r.names.TableName),
+					Item:                item,
+					ConditionExpression: aws.String("attribute_not_exists(entity_id)"),
+				},
+			},
+		},
+	}
+	if name := claimedSkillName(skill); name != "" {
+		input.TransactItems = append(input.TransactItems, r.claimSkillNameItem(skill.SkillID, name))
 	}
 
-	_, err = r.client.PutItem(input)
+	_, err = r.transactWriteItemsWithContext(context.Background(), "CreateMasterSkill", input)
 	if err != nil {
+		if failed, ok := failedCondition(err); ok {
+			if failed == 0 {
+				log.Info("Master skill already exists", "duration", time.Since(start))
+				return apperrors.ErrSkillAlreadyExists
+			}
+			log.Info("Master skill name already claimed", "skill_name", skill.SkillName, "duration", time.Since(start))
+			return apperrors.ErrMasterSkillNameTaken
+		}
 		log.Error("Failed to create master skill in DynamoDB", "error", err.Error(), "duration", time.Since(start))
-		return apperrors.ErrSkillAlreadyExists
+		return err
 	}
 
 	log.Info("Master skill created successfully", "duration", time.Since(start))
@@ -45,6 +66,11 @@ func (r *DynamoDBRepository) CreateMasterSkill(skill *models.Skill) error {
 
 // GetMasterSkill retrieves a master skill by ID
 func (r *DynamoDBRepository) GetMasterSkill(skillID string) (*models.Skill, error) {
+	return r.GetMasterSkillWithContext(context.Background(), skillID)
+}
+
+// GetMasterSkillWithContext is GetMasterSkill bounded by ctx
+func (r *DynamoDBRepository) GetMasterSkillWithContext(ctx context.Context, skillID string) (*models.Skill, error) {
 	log := logger.WithComponent("database").With("operation", "GetMasterSkill", "skill_id", skillID)
 	start := time.Now()
 
@@ -53,14 +79,14 @@ func (r *DynamoDBRepository) GetMasterSkill(skillID string) (*models.Skill, erro
 	entityID := BuildMasterSkillEntityID(skillID)
 
 	input := &dynamodb.GetItemInput{
-		TableName: aws.String(TableName),
+		TableName: aws.String(r.names.TableName),
 		Key: map[string]*dynamodb.AttributeValue{
 			"EntityType": {S: aws.String("Skill")},
 			"entity_id":  {S: aws.String(entityID)},
 		},
 	}
 
-	result, err := r.client.GetItem(input)
+	result, err := r.getItemWithContext(ctx, "GetMasterSkill", input)
 	if err != nil {
 		log.Error("Failed to get master skill from DynamoDB", "error", err.Error(), "duration", time.Since(start))
 		return nil, err
@@ -82,7 +108,8 @@ func (r *DynamoDBRepository) GetMasterSkill(skillID string) (*models.Skill, erro
 	return &skill, nil
 }
 
-// UpdateMasterSkill updates an existing master skill
+// UpdateMasterSkill updates an existing master skill, moving its name claim if the name changes
+// or the skill is soft-deleted
 func (r *DynamoDBRepository) UpdateMasterSkill(skill *models.Skill) error {
 	log := logger.WithComponent("database").With("operation", "UpdateMasterSkill", "skill_id", skill.SkillID)
 	start := time.Now()
@@ -92,57 +119,248 @@ func (r *DynamoDBRepository) UpdateMasterSkill(skill *models.Skill) error {
 	skill.SetKeys()
 	skill.UpdatedAt = time.Now()
 
-	item, err := dynamodbattribute.MarshalMap(skill)
-	if err != nil {
-		log.Error("Failed to marshal skill data for update", "error", err.Error(), "duration", time.Since(start))
-		return err
-	}
-
-	input := &dynamodb.PutItemInput{
-		TableName:           aws.String(TableName),
-		Item:                item,
-		ConditionExpression: aws.String("attribute_exists(entity_id)"),
-	}
-
-	_, err = r.client.PutItem(input)
-	if err != nil {
+	if err := r.writeMasterSkill("UpdateMasterSkill", skill.SkillID, skill); err != nil {
 		log.Error("Failed to update master skill in DynamoDB", "error", err.Error(), "duration", time.Since(start))
-		return apperrors.ErrSkillNotFound
+		return err
 	}
 
 	log.Info("Master skill updated successfully", "duration", time.Since(start))
 	return nil
 }
 
-// DeleteMasterSkill removes a master skill
+// DeleteMasterSkill removes a master skill and releases the claim on its name
 func (r *DynamoDBRepository) DeleteMasterSkill(skillID string) error {
 	log := logger.WithComponent("database").With("operation", "DeleteMasterSkill", "skill_id", skillID)
 	start := time.Now()
 
 	log.Debug("Starting master skill deletion")
 
-	entityID := BuildMasterSkillEntityID(skillID)
+	if err := r.writeMasterSkill("DeleteMasterSkill", skillID, nil); err != nil {
+		log.Error("Failed to delete master skill from DynamoDB", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
 
-	input := &dynamodb.DeleteItemInput{
-		TableName: aws.String(TableName),
-		Key: map[string]*dynamodb.AttributeValue{
-			"EntityType": {S: aws.String("Skill")},
-			"entity_id":  {S: aws.String(entityID)},
-		},
-		ConditionExpression: aws.String("attribute_exists(entity_id)"),
+	log.Info("Master skill deleted successfully", "duration", time.Since(start))
+	return nil
+}
+
+// masterSkillWriteAttempts bounds how often a master skill that changes mid-write is re-read
+const masterSkillWriteAttempts = 3
+
+// writeMasterSkill replaces the stored master skill skillID with skill, or deletes it if skill is
+// nil, moving the name claim in the same transaction. The write is conditioned on the stored skill
+// being unchanged since it was read, so the claim released is the one it held; a skill changed
+// meanwhile is re-read. A name the skill has no claim on, as for skills written before claims
+// existed, is left to whichever skill holds it.
+func (r *DynamoDBRepository) writeMasterSkill(operation, skillID string, skill *models.Skill) error {
+	release := true
+	for attempt := 1; ; attempt++ {
+		stored, err := r.GetMasterSkill(skillID)
+		if err != nil {
+			return err
+		}
+		updatedAt, err := dynamodbattribute.Marshal(stored.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		condition := aws.String("UpdatedAt = :updatedAt")
+		values := map[string]*dynamodb.AttributeValue{":updatedAt": updatedAt}
+
+		write := &dynamodb.TransactWriteItem{}
+		if skill != nil {
+			item, err := dynamodbattribute.MarshalMap(skill)
+			if err != nil {
+				return err
+			}
+			write.Put = &dynamodb.Put{
+				TableName:                 aws.String(r.names.TableName),
+				Item:                      item,
+				ConditionExpression:       condition,
+				ExpressionAttributeValues: values,
+			}
+		} else {
+			write.Delete = &dynamodb.Delete{
+				TableName:                 aws.String(r.names.TableName),
+				Key:                       r.itemKey("Skill", BuildMasterSkillEntityID(skillID)),
+				ConditionExpression:       condition,
+				ExpressionAttributeValues: values,
+			}
+		}
+
+		items := []*dynamodb.TransactWriteItem{write}
+		claimIndex, releaseIndex := -1, -1
+		if from, to := claimedSkillName(stored), claimedSkillName(skill); from != to {
+			if to != "" {
+				claimIndex = len(items)
+				items = append(items, r.claimSkillNameItem(skillID, to))
+			}
+			if from != "" && release {
+				releaseIndex = len(items)
+				items = append(items, r.releaseSkillNameItem(skillID, from))
+			}
+		}
+
+		_, err = r.transactWriteItemsWithContext(context.Background(), operation, &dynamodb.TransactWriteItemsInput{TransactItems: items})
+		if err == nil {
+			return nil
+		}
+
+		conditions := failedConditions(err)
+		switch {
+		case conditions == nil:
+			return err
+		case conditions[claimIndex]:
+			return apperrors.ErrMasterSkillNameTaken
+		case conditions[0] && attempt == masterSkillWriteAttempts:
+			return ErrMasterSkillChanged
+		case conditions[0]:
+			logger.WithComponent("database").Debug("Master skill changed meanwhile, re-reading", "operation", operation, "skill_id", skillID, "attempt", attempt)
+		case conditions[releaseIndex]:
+			// Another skill holds the name, so there is no claim to release
+			release = false
+		default:
+			return err
+		}
 	}
+}
 
-	_, err := r.client.DeleteItem(input)
+// claimedSkillName returns the lowercased name skill holds a claim on, or "" for a soft-deleted
+// or missing skill
+func claimedSkillName(skill *models.Skill) string {
+	if skill == nil || skill.Deleted {
+		return ""
+	}
+	return strings.ToLower(skill.SkillName)
+}
+
+// claimSkillNameItem puts the claim on skillName for skillID unless another skill holds it
+func (r *DynamoDBRepository) claimSkillNameItem(skillID, skillName string) *dynamodb.TransactWriteItem {
+	item := r.itemKey("SkillName", BuildSkillNameEntityID(skillName))
+	item["skill_id"] = &dynamodb.AttributeValue{S: aws.String(skillID)}
+
+	return &dynamodb.TransactWriteItem{Put: &dynamodb.Put{
+		TableName:           aws.String(r.names.TableName),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(entity_id) OR skill_id = :skillID"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":skillID": {S: aws.String(skillID)},
+		},
+	}}
+}
+
+// releaseSkillNameItem deletes the claim on skillName, on the condition that skillID holds it
+func (r *DynamoDBRepository) releaseSkillNameItem(skillID, skillName string) *dynamodb.TransactWriteItem {
+	return &dynamodb.TransactWriteItem{Delete: &dynamodb.Delete{
+		TableName:           aws.String(r.names.TableName),
+		Key:                 r.itemKey("SkillName", BuildSkillNameEntityID(skillName)),
+		ConditionExpression: aws.String("skill_id = :skillID"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":skillID": {S: aws.String(skillID)},
+		},
+	}}
+}
+
+// ClaimSkillName puts the claim on an active skill's name, on the condition that the stored skill
+// still has the UpdatedAt it was read with
+func (r *DynamoDBRepository) ClaimSkillName(skill *models.Skill) error {
+	log := logger.WithComponent("database").With("operation", "ClaimSkillName", "skill_id", skill.SkillID)
+	start := time.Now()
+
+	updatedAt, err := dynamodbattribute.Marshal(skill.UpdatedAt)
 	if err != nil {
-		log.Error("Failed to delete master skill from DynamoDB", "error", err.Error(), "duration", time.Since(start))
-		return apperrors.ErrSkillNotFound
+		log.Error("Failed to marshal skill timestamp", "error", err.Error(), "duration", time.Since(start))
+		return err
 	}
 
-	log.Info("Master skill deleted successfully", "duration", time.Since(start))
+	input := &dynamodb.TransactWriteItemsInput{
+		TransactItems: []*dynamodb.TransactWriteItem{
+			{
+				ConditionCheck: &dynamodb.ConditionCheck{
+					TableName:                 aws.String(r.names.TableName),
+					Key:                       r.itemKey("Skill", BuildMasterSkillEntityID(skill.SkillID)),
+					ConditionExpression:       aws.String("UpdatedAt = :updatedAt"),
+					ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{":updatedAt": updatedAt},
+				},
+			},
+			r.claimSkillNameItem(skill.SkillID, skill.SkillName),
+		},
+	}
+
+	if _, err := r.transactWriteItemsWithContext(context.Background(), "ClaimSkillName", input); err != nil {
+		if failed, ok := failedCondition(err); ok {
+			if failed == 0 {
+				log.Info("Master skill changed before its name was claimed", "duration", time.Since(start))
+				return ErrMasterSkillChanged
+			}
+			log.Info("Master skill name already claimed", "skill_name", skill.SkillName, "duration", time.Since(start))
+			return apperrors.ErrMasterSkillNameTaken
+		}
+		log.Error("Failed to claim skill name", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
+
+	log.Debug("Skill name claimed", "duration", time.Since(start))
 	return nil
 }
 
-// ListMasterSkills retrieves all master skills
+// SkillNameOwner reads the claim on name and returns the skill ID it holds
+func (r *DynamoDBRepository) SkillNameOwner(name string) (string, error) {
+	log := logger.WithComponent("database").With("operation", "SkillNameOwner", "skill_name", name)
+	start := time.Now()
+
+	input := &dynamodb.GetItemInput{
+		TableName:      aws.String(r.names.TableName),
+		Key:            r.itemKey("SkillName", BuildSkillNameEntityID(name)),
+		ConsistentRead: aws.Bool(true),
+	}
+
+	result, err := r.getItem("SkillNameOwner", input)
+	if err != nil {
+		log.Error("Failed to get skill name claim from DynamoDB", "error", err.Error(), "duration", time.Since(start))
+		return "", err
+	}
+	if result.Item == nil {
+		log.Debug("Skill name unclaimed", "duration", time.Since(start))
+		return "", nil
+	}
+
+	var claim struct {
+		SkillID string `dynamodbav:"skill_id"`
+	}
+	if err := dynamodbattribute.UnmarshalMap(result.Item, &claim); err != nil {
+		log.Error("Failed to unmarshal skill name claim", "error", err.Error(), "duration", time.Since(start))
+		return "", err
+	}
+
+	log.Debug("Skill name claim retrieved", "duration", time.Since(start))
+	return claim.SkillID, nil
+}
+
+// queryMasterSkills runs input over every page and returns the master skills it matched
+// Items that fail to unmarshal are logged and skipped.
+func (r *DynamoDBRepository) queryMasterSkills(operation string, input *dynamodb.QueryInput) ([]*models.Skill, error) {
+	var skills []*models.Skill
+	for {
+		result, err := r.query(operation, input)
+		if err != nil {
+			return nil, err
+		}
+		for i, item := range result.Items {
+			var skill models.Skill
+			if err := dynamodbattribute.UnmarshalMap(item, &skill); err != nil {
+				logger.WithComponent("database").Error("Failed to unmarshal skill data", "operation", operation, "error", err.Error(), "item_index", i)
+				continue
+			}
+			skills = append(skills, &skill)
+		}
+		if len(result.LastEvaluatedKey) == 0 {
+			return skills, nil
+		}
+		input.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+}
+
+// ListMasterSkills retrieves all master skills, reading every page
 func (r *DynamoDBRepository) ListMasterSkills() ([]*models.Skill, error) {
 	log := logger.WithComponent("database").With("operation", "ListMasterSkills")
 	start := time.Now()
@@ -150,19 +368,68 @@ func (r *DynamoDBRepository) ListMasterSkills() ([]*models.Skill, error) {
 	log.Debug("Starting master skills list retrieval")
 
 	input := &dynamodb.QueryInput{
-		TableName:              aws.String(TableName),
+		TableName:              aws.String(r.names.TableName),
 		KeyConditionExpression: aws.String("EntityType = :entityType"),
 		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
 			":entityType": {S: aws.String("Skill")},
 		},
 	}
 
-	result, err := r.client.Query(input)
+	skills, err := r.queryMasterSkills("ListMasterSkills", input)
 	if err != nil {
 		log.Error("Failed to query master skills", "error", err.Error(), "duration", time.Since(start))
 		return nil, err
 	}
 
+	log.Info("Master skills retrieved successfully", "count", len(skills), "duration", time.Since(start))
+	return skills, nil
+}
+
+// SetSkillNameLower sets SkillNameLower in place, on the condition that SkillName is unchanged
+func (r *DynamoDBRepository) SetSkillNameLower(skillID, skillName string) error {
+	log := logger.WithComponent("database").With("operation", "SetSkillNameLower", "skill_id", skillID)
+	start := time.Now()
+
+	input := &dynamodb.UpdateItemInput{
+		TableName:           aws.String(r.names.TableName),
+		Key:                 r.itemKey("Skill", BuildMasterSkillEntityID(skillID)),
+		UpdateExpression:    aws.String("SET SkillNameLower = :lower"),
+		ConditionExpression: aws.String("SkillName = :name"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":lower": {S: aws.String(strings.ToLower(skillName))},
+			":name":  {S: aws.String(skillName)},
+		},
+	}
+
+	if _, err := r.updateItem("SetSkillNameLower", input); err != nil {
+		if isConditionFailed(err) {
+			log.Info("Master skill changed before its lowercased name was set", "duration", time.Since(start))
+			return ErrMasterSkillChanged
+		}
+		log.Error("Failed to set lowercased skill name", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
+
+	log.Debug("Lowercased skill name set", "duration", time.Since(start))
+	return nil
+}
+
+// ListMasterSkillsFiltered retrieves master skills matching a category and all given tags
+// Queries the EntityType="Skill" partition and narrows results with a FilterExpression
+func (r *DynamoDBRepository) ListMasterSkillsFiltered(category string, tags []string) ([]*models.Skill, error) {
+	log := logger.WithComponent("database").With("operation", "ListMasterSkillsFiltered", "category", category, "tags", tags)
+	start := time.Now()
+
+	log.Debug("Starting filtered master skills list retrieval")
+
+	input := r.masterSkillQuery(category, tags)
+
+	result, err := r.query("ListMasterSkillsFiltered", input)
+	if err != nil {
+		log.Error("Failed to query filtered master skills", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
 	var skills []*models.Skill
 	for i, item := range result.Items {
 		var skill models.Skill
@@ -173,6 +440,204 @@ func (r *DynamoDBRepository) ListMasterSkills() ([]*models.Skill, error) {
 		skills = append(skills, &skill)
 	}
 
-	log.Info("Master skills retrieved successfully", "count", len(skills), "duration", time.Since(start))
+	log.Info("Filtered master skills retrieved successfully", "count", len(skills), "duration", time.Since(start))
+	return skills, nil
+}
+
+// ListMasterSkillsPage retrieves one page of master skills matching a category and all given tags
+// DynamoDB applies limit before the category and tag filters, so a page may hold fewer
+// than limit skills even when more follow; only an empty token marks the last page.
+func (r *DynamoDBRepository) ListMasterSkillsPage(category string, tags []string, limit int, nextToken string) ([]*models.Skill, string, error) {
+	log := logger.WithComponent("database").With("operation", "ListMasterSkillsPage", "category", category, "tags", tags, "limit", limit)
+	start := time.Now()
+
+	log.Debug("Starting paginated master skills list retrieval")
+
+	startKey, err := decodePageToken(nextToken)
+	if err != nil {
+		log.Info("Invalid pagination token", "duration", time.Since(start))
+		return nil, "", err
+	}
+
+	input := r.masterSkillQuery(category, tags)
+	input.ExclusiveStartKey = startKey
+	if limit > 0 {
+		input.Limit = aws.Int64(int64(limit))
+	}
+
+	result, err := r.query("ListMasterSkillsPage", input)
+	if err != nil {
+		log.Error("Failed to query master skills page", "error", err.Error(), "duration", time.Since(start))
+		return nil, "", err
+	}
+
+	var skills []*models.Skill
+	for i, item := range result.Items {
+		var skill models.Skill
+		if err := dynamodbattribute.UnmarshalMap(item, &skill); err != nil {
+			log.Error("Failed to unmarshal skill data", "error", err.Error(), "item_index", i, "duration", time.Since(start))
+			continue
+		}
+		skills = append(skills, &skill)
+	}
+
+	token, err := encodePageToken(result.LastEvaluatedKey)
+	if err != nil {
+		log.Error("Failed to encode pagination token", "error", err.Error(), "duration", time.Since(start))
+		return nil, "", err
+	}
+
+	log.Info("Master skills page retrieved successfully", "count", len(skills), "has_more", token != "", "duration", time.Since(start))
+	return skills, token, nil
+}
+
+// masterSkillQuery builds the query for master skills in category (if non-empty) carrying all of tags
+func (r *DynamoDBRepository) masterSkillQuery(category string, tags []string) *dynamodb.QueryInput {
+	values := map[string]*dynamodb.AttributeValue{
+		":entityType": {S: aws.String("Skill")},
+	}
+
+	var filters []string
+	if category != "" {
+		filters = append(filters, "Category = :category")
+		values[":category"] = &dynamodb.AttributeValue{S: aws.String(category)}
+	}
+	for i, tag := range tags {
+		placeholder := fmt.Sprintf(":tag%d", i)
+		filters = append(filters, fmt.Sprintf("contains(Tags, %s)", placeholder))
+		values[placeholder] = &dynamodb.AttributeValue{S: aws.String(tag)}
+	}
+
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(r.names.TableName),
+		KeyConditionExpression:    aws.String("EntityType = :entityType"),
+		ExpressionAttributeValues: values,
+	}
+	if len(filters) > 0 {
+		input.FilterExpression = aws.String(strings.Join(filters, " AND "))
+	}
+	return input
+}
+
+// SearchMasterSkillsByNamePrefix retrieves master skills whose name starts with prefix, ignoring case
+// Queries the EntityType="Skill" partition with a begins_with filter on the lowercased name,
+// reading every page. No Limit is set because DynamoDB applies it before the filter; callers
+// cap the result.
+func (r *DynamoDBRepository) SearchMasterSkillsByNamePrefix(prefix string) ([]*models.Skill, error) {
+	log := logger.WithComponent("database").With("operation", "SearchMasterSkillsByNamePrefix", "prefix", prefix)
+	start := time.Now()
+
+	log.Debug("Starting master skill prefix search")
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(r.names.TableName),
+		KeyConditionExpression: aws.String("EntityType = :entityType"),
+		FilterExpression:       aws.String("begins_with(SkillNameLower, :prefix)"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":entityType": {S: aws.String("Skill")},
+			":prefix":     {S: aws.String(strings.ToLower(prefix))},
+		},
+	}
+
+	skills, err := r.queryMasterSkills("SearchMasterSkillsByNamePrefix", input)
+	if err != nil {
+		log.Error("Failed to search master skills", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	log.Info("Master skill prefix search completed", "count", len(skills), "duration", time.Since(start))
+	return skills, nil
+}
+
+// ListFeaturedMasterSkills retrieves the master skills flagged as featured
+// The catalog is small and read rarely enough that filtering the Skill partition is cheaper
+// to run than a sparse GSI on Featured; Featured is omitted when false, so only flagged
+// items match the filter.
+func (r *DynamoDBRepository) ListFeaturedMasterSkills() ([]*models.Skill, error) {
+	log := logger.WithComponent("database").With("operation", "ListFeaturedMasterSkills")
+	start := time.Now()
+
+	log.Debug("Starting featured master skills retrieval")
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(r.names.TableName),
+		KeyConditionExpression: aws.String("EntityType = :entityType"),
+		FilterExpression:       aws.String("Featured = :featured"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":entityType": {S: aws.String("Skill")},
+			":featured":   {BOOL: aws.Bool(true)},
+		},
+	}
+
+	var skills []*models.Skill
+	for {
+		result, err := r.query("ListFeaturedMasterSkills", input)
+		if err != nil {
+			log.Error("Failed to query featured master skills", "error", err.Error(), "duration", time.Since(start))
+			return nil, err
+		}
+		for i, item := range result.Items {
+			var skill models.Skill
+			if err := dynamodbattribute.UnmarshalMap(item, &skill); err != nil {
+				log.Error("Failed to unmarshal skill data", "error", err.Error(), "item_index", i, "duration", time.Since(start))
+				continue
+			}
+			skills = append(skills, &skill)
+		}
+		if len(result.LastEvaluatedKey) == 0 {
+			break
+		}
+		input.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+
+	log.Info("Featured master skills retrieved successfully", "count", len(skills), "duration", time.Since(start))
 	return skills, nil
 }
+
+// ListMasterSkillCategories retrieves the distinct categories of active master skills
+// Only Category and Deleted are projected, so whole skill items are never transferred.
+// Categories are deduplicated in memory across all result pages.
+func (r *DynamoDBRepository) ListMasterSkillCategories() ([]string, error) {
+	log := logger.WithComponent("database").With("operation", "ListMasterSkillCategories")
+	start := time.Now()
+
+	log.Debug("Starting master skill categories retrieval")
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(r.names.TableName),
+		KeyConditionExpression: aws.String("EntityType = :entityType"),
+		ProjectionExpression:   aws.String("Category, Deleted"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":entityType": {S: aws.String("Skill")},
+		},
+	}
+
+	seen := make(map[string]bool)
+	var categories []string
+	for {
+		result, err := r.query("ListMasterSkillCategories", input)
+		if err != nil {
+			log.Error("Failed to query master skill categories", "error", err.Error(), "duration", time.Since(start))
+			return nil, err
+		}
+		for i, item := range result.Items {
+			var skill models.Skill
+			if err := dynamodbattribute.UnmarshalMap(item, &skill); err != nil {
+				log.Error("Failed to unmarshal skill data", "error", err.Error(), "item_index", i, "duration", time.Since(start))
+				continue
+			}
+			if skill.Deleted || seen[skill.Category] {
+				continue
+			}
+			seen[skill.Category] = true
+			categories = append(categories, skill.Category)
+		}
+		if len(result.LastEvaluatedKey) == 0 {
+			break
+		}
+		input.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+
+	log.Info("Master skill categories retrieved successfully", "count", len(categories), "duration", time.Since(start))
+	return categories, nil
+}
@@ -1,6 +1,10 @@
 package database
 
 import (
+	"context"
+	"encoding/base64"
+	"sort"
+	"strings"
 	"time"
 
 	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
@@ -8,7 +12,7 @@ import (
 	"github.com/hackmajoris/glad-stack/pkg/logger"
 )
 
-// CreateMasterSkill creates a master skill in memory
+// CreateMasterSkill creates a master skill in memory and claims its name
 func (m *MockRepository) CreateMasterSkill(skill *models.Skill) error {
 	log := logger.WithComponent("database").With("operation", "CreateMasterSkill", "skill_id", skill.SkillID, "repository", "mock")
 	start := time.Now()
@@ -22,17 +26,35 @@ func (m *MockRepository) CreateMasterSkill(skill *models.Skill) error {
 		log.Debug("Master skill already exists", "duration", time.Since(start))
 		return apperrors.ErrSkillAlreadyExists
 	}
+	name := strings.ToLower(skill.SkillName)
+	if owner, claimed := m.skillNames[name]; !skill.Deleted && claimed && owner != skill.SkillID {
+		log.Debug("Master skill name already claimed", "skill_name", skill.SkillName, "duration", time.Since(start))
+		return apperrors.ErrMasterSkillNameTaken
+	}
 
 	m.masterSkills[skill.SkillID] = skill
+	if !skill.Deleted {
+		m.skillNames[name] = skill.SkillID
+	}
 	log.Info("Master skill created successfully in mock repository", "total_master_skills", len(m.masterSkills), "duration", time.Since(start))
 	return nil
 }
 
 // GetMasterSkill retrieves a master skill from memory
 func (m *MockRepository) GetMasterSkill(skillID string) (*models.Skill, error) {
+	return m.GetMasterSkillWithContext(context.Background(), skillID)
+}
+
+// GetMasterSkillWithContext is GetMasterSkill bounded by ctx
+func (m *MockRepository) GetMasterSkillWithContext(ctx context.Context, skillID string) (*models.Skill, error) {
 	log := logger.WithComponent("database").With("operation", "GetMasterSkill", "skill_id", skillID, "repository", "mock")
 	start := time.Now()
 
+	if err := ctx.Err(); err != nil {
+		log.Info("Context done before mock operation", "error", err.Error(), "duration", time.Since(start))
+		return nil, contextError(ctx, err)
+	}
+
 	log.Debug("Starting master skill retrieval from mock repository")
 
 	m.mutex.RLock()
@@ -48,7 +70,9 @@ func (m *MockRepository) GetMasterSkill(skillID string) (*models.Skill, error) {
 	return skill, nil
 }
 
-// UpdateMasterSkill updates a master skill in memory
+// UpdateMasterSkill updates a master skill in memory, moving its name claim like DynamoDB does
+// Stored skills are shared with callers, so the claim released is the one the skill holds in
+// skillNames rather than one derived from its previous name.
 func (m *MockRepository) UpdateMasterSkill(skill *models.Skill) error {
 	log := logger.WithComponent("database").With("operation", "UpdateMasterSkill", "skill_id", skill.SkillID, "repository", "mock")
 	start := time.Now()
@@ -63,11 +87,84 @@ func (m *MockRepository) UpdateMasterSkill(skill *models.Skill) error {
 		return apperrors.ErrSkillNotFound
 	}
 
+	var to string
+	if !skill.Deleted {
+		to = strings.ToLower(skill.SkillName)
+	}
+	if owner, claimed := m.skillNames[to]; to != "" && claimed && owner != skill.SkillID {
+		log.Debug("Master skill name already claimed", "skill_name", skill.SkillName, "duration", time.Since(start))
+		return apperrors.ErrMasterSkillNameTaken
+	}
+
+	m.releaseSkillName(skill.SkillID)
+	if to != "" {
+		m.skillNames[to] = skill.SkillID
+	}
 	m.masterSkills[skill.SkillID] = skill
 	log.Info("Master skill updated successfully in mock repository", "duration", time.Since(start))
 	return nil
 }
 
+// releaseSkillName drops the name claim skillID holds, if any; the caller must hold the write lock
+func (m *MockRepository) releaseSkillName(skillID string) {
+	for name, owner := range m.skillNames {
+		if owner == skillID {
+			delete(m.skillNames, name)
+		}
+	}
+}
+
+// ClaimSkillName claims an active master skill's name in memory if the stored skill is unchanged
+func (m *MockRepository) ClaimSkillName(skill *models.Skill) error {
+	log := logger.WithComponent("database").With("operation", "ClaimSkillName", "skill_id", skill.SkillID, "repository", "mock")
+	start := time.Now()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	stored, exists := m.masterSkills[skill.SkillID]
+	if !exists || !stored.UpdatedAt.Equal(skill.UpdatedAt) {
+		log.Debug("Master skill changed before its name was claimed", "duration", time.Since(start))
+		return ErrMasterSkillChanged
+	}
+	name := strings.ToLower(skill.SkillName)
+	if owner, claimed := m.skillNames[name]; claimed && owner != skill.SkillID {
+		log.Debug("Master skill name already claimed", "skill_name", skill.SkillName, "duration", time.Since(start))
+		return apperrors.ErrMasterSkillNameTaken
+	}
+
+	m.skillNames[name] = skill.SkillID
+	log.Debug("Skill name claimed in mock repository", "duration", time.Since(start))
+	return nil
+}
+
+// SkillNameOwner returns the ID of the master skill holding the claim on name in memory
+func (m *MockRepository) SkillNameOwner(name string) (string, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.skillNames[strings.ToLower(name)], nil
+}
+
+// SetSkillNameLower sets a master skill's SkillNameLower in memory if its name is still skillName
+func (m *MockRepository) SetSkillNameLower(skillID, skillName string) error {
+	log := logger.WithComponent("database").With("operation", "SetSkillNameLower", "skill_id", skillID, "repository", "mock")
+	start := time.Now()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	skill, exists := m.masterSkills[skillID]
+	if !exists || skill.SkillName != skillName {
+		log.Debug("Master skill changed before its lowercased name was set", "duration", time.Since(start))
+		return ErrMasterSkillChanged
+	}
+
+	skill.SkillNameLower = strings.ToLower(skillName)
+	log.Debug("Lowercased skill name set in mock repository", "duration", time.Since(start))
+	return nil
+}
+
 // DeleteMasterSkill deletes a master skill from memory
 func (m *MockRepository) DeleteMasterSkill(skillID string) error {
 	log := logger.WithComponent("database").With("operation", "DeleteMasterSkill", "skill_id", skillID, "repository", "mock")
@@ -84,6 +181,7 @@ func (m *MockRepository) DeleteMasterSkill(skillID string) error {
 	}
 
 	delete(m.masterSkills, skillID)
+	m.releaseSkillName(skillID)
 	log.Info("Master skill deleted successfully from mock repository", "duration", time.Since(start))
 	return nil
 }
@@ -106,3 +204,158 @@ func (m *MockRepository) ListMasterSkills() ([]*models.Skill, error) {
 	log.Info("Master skills retrieved successfully from mock repository", "count", len(skills), "duration", time.Since(start))
 	return skills, nil
 }
+
+// ListFeaturedMasterSkills retrieves featured master skills from memory
+func (m *MockRepository) ListFeaturedMasterSkills() ([]*models.Skill, error) {
+	log := logger.WithComponent("database").With("operation", "ListFeaturedMasterSkills", "repository", "mock")
+	start := time.Now()
+
+	log.Debug("Starting featured master skills retrieval from mock repository")
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var skills []*models.Skill
+	for _, skill := range m.masterSkills {
+		if skill.Featured {
+			skills = append(skills, skill)
+		}
+	}
+
+	log.Info("Featured master skills retrieved successfully from mock repository", "count", len(skills), "duration", time.Since(start))
+	return skills, nil
+}
+
+// ListMasterSkillsFiltered retrieves master skills matching a category and all given tags from memory
+func (m *MockRepository) ListMasterSkillsFiltered(category string, tags []string) ([]*models.Skill, error) {
+	log := logger.WithComponent("database").With("operation", "ListMasterSkillsFiltered", "category", category, "tags", tags, "repository", "mock")
+	start := time.Now()
+
+	log.Debug("Starting filtered master skills list retrieval from mock repository")
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var skills []*models.Skill
+	for _, skill := range m.masterSkills {
+		if category != "" && skill.Category != category {
+			continue
+		}
+		if !hasAllTags(skill.Tags, tags) {
+			continue
+		}
+		skills = append(skills, skill)
+	}
+
+	log.Info("Filtered master skills retrieved successfully from mock repository", "count", len(skills), "duration", time.Since(start))
+	return skills, nil
+}
+
+// ListMasterSkillsPage retrieves one page of filtered master skills from memory
+func (m *MockRepository) ListMasterSkillsPage(category string, tags []string, limit int, nextToken string) ([]*models.Skill, string, error) {
+	log := logger.WithComponent("database").With("operation", "ListMasterSkillsPage", "category", category, "tags", tags, "limit", limit, "repository", "mock")
+	start := time.Now()
+
+	log.Debug("Starting paginated master skills list retrieval from mock repository")
+
+	// Tokens encode the last skill ID returned; results are sorted by skill ID for stable pages
+	var after string
+	if nextToken != "" {
+		decoded, err := base64.RawURLEncoding.DecodeString(nextToken)
+		if err != nil || len(decoded) == 0 {
+			log.Info("Invalid pagination token", "duration", time.Since(start))
+			return nil, "", apperrors.ErrInvalidPageToken
+		}
+		after = string(decoded)
+	}
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var skills []*models.Skill
+	for _, skill := range m.masterSkills {
+		if skill.SkillID <= after {
+			continue
+		}
+		if category != "" && skill.Category != category {
+			continue
+		}
+		if !hasAllTags(skill.Tags, tags) {
+			continue
+		}
+		skills = append(skills, skill)
+	}
+	sort.Slice(skills, func(i, j int) bool { return skills[i].SkillID < skills[j].SkillID })
+
+	var token string
+	if limit > 0 && len(skills) > limit {
+		skills = skills[:limit]
+		token = base64.RawURLEncoding.EncodeToString([]byte(skills[limit-1].SkillID))
+	}
+
+	log.Info("Master skills page retrieved successfully from mock repository", "count", len(skills), "has_more", token != "", "duration", time.Since(start))
+	return skills, token, nil
+}
+
+// hasAllTags reports whether skillTags contains every tag in required (exact match, like DynamoDB contains)
+func hasAllTags(skillTags, required []string) bool {
+	for _, tag := range required {
+		found := false
+		for _, t := range skillTags {
+			if t == tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// SearchMasterSkillsByNamePrefix retrieves master skills whose name starts with prefix from memory, ignoring case
+func (m *MockRepository) SearchMasterSkillsByNamePrefix(prefix string) ([]*models.Skill, error) {
+	log := logger.WithComponent("database").With("operation", "SearchMasterSkillsByNamePrefix", "prefix", prefix, "repository", "mock")
+	start := time.Now()
+
+	log.Debug("Starting master skill prefix search in mock repository")
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	lowerPrefix := strings.ToLower(prefix)
+	var skills []*models.Skill
+	for _, skill := range m.masterSkills {
+		if strings.HasPrefix(strings.ToLower(skill.SkillName), lowerPrefix) {
+			skills = append(skills, skill)
+		}
+	}
+
+	log.Info("Master skill prefix search completed in mock repository", "count", len(skills), "duration", time.Since(start))
+	return skills, nil
+}
+
+// ListMasterSkillCategories retrieves the distinct categories of active master skills from memory
+func (m *MockRepository) ListMasterSkillCategories() ([]string, error) {
+	log := logger.WithComponent("database").With("operation", "ListMasterSkillCategories", "repository", "mock")
+	start := time.Now()
+
+	log.Debug("Starting master skill categories retrieval from mock repository")
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	seen := make(map[string]bool)
+	var categories []string
+	for _, skill := range m.masterSkills {
+		if skill.Deleted || seen[skill.Category] {
+			continue
+		}
+		seen[skill.Category] = true
+		categories = append(categories, skill.Category)
+	}
+
+	log.Info("Master skill categories retrieved successfully from mock repository", "count", len(categories), "duration", time.Since(start))
+	return categories, nil
+}
@@ -0,0 +1,94 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
+	"github.com/hackmajoris/glad-stack/pkg/logger"
+)
+
+// NewMockRepositoryWithData creates a mock repository preloaded through Seed
+func NewMockRepositoryWithData(users []*models.User, skills []*models.UserSkill, masterSkills []*models.Skill) (*MockRepository, error) {
+	repo := NewMockRepository()
+	if err := repo.Seed(users, skills, masterSkills); err != nil {
+		return nil, err
+	}
+	return repo, nil
+}
+
+// Seed bulk-loads users, user skills and master skills under a single write lock
+// A key that is already stored or repeated in the input is rejected. Every such key is
+// reported in one joined error, and nothing is loaded unless the whole seed is valid. Items are
+// stored as given, so seeded master skills and users hold no name or email claims, like those
+// written before claims existed.
+func (m *MockRepository) Seed(users []*models.User, skills []*models.UserSkill, masterSkills []*models.Skill) error {
+	log := logger.WithComponent("database").With("operation", "Seed", "users", len(users), "skills", len(skills), "master_skills", len(masterSkills), "repository", "mock")
+	start := time.Now()
+
+	log.Debug("Starting seed of mock repository")
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var errs []error
+	seenUsers := make(map[string]bool, len(users))
+	for _, user := range users {
+		if _, exists := m.users[user.Username]; exists || seenUsers[user.Username] {
+			errs = append(errs, fmt.Errorf("user %q: %w", user.Username, apperrors.ErrUserExists))
+		}
+		seenUsers[user.Username] = true
+	}
+	seenSkills := make(map[string]bool, len(skills))
+	for _, skill := range skills {
+		key := models.BuildUserSkillEntityID(skill.Username, skill.SkillID)
+		if _, exists := m.skills[key]; exists || seenSkills[key] {
+			errs = append(errs, fmt.Errorf("skill %q for user %q: %w", skill.SkillID, skill.Username, apperrors.ErrSkillAlreadyExists))
+		}
+		seenSkills[key] = true
+	}
+	seenMasterSkills := make(map[string]bool, len(masterSkills))
+	for _, skill := range masterSkills {
+		if _, exists := m.masterSkills[skill.SkillID]; exists || seenMasterSkills[skill.SkillID] {
+			errs = append(errs, fmt.Errorf("master skill %q: %w", skill.SkillID, apperrors.ErrMasterSkillExists))
+		}
+		seenMasterSkills[skill.SkillID] = true
+	}
+	if len(errs) > 0 {
+		log.Debug("Seed rejected duplicate keys", "duplicates", len(errs), "duration", time.Since(start))
+		return errors.Join(errs...)
+	}
+
+	for _, user := range users {
+		m.users[user.Username] = user
+	}
+	for _, skill := range skills {
+		m.skills[models.BuildUserSkillEntityID(skill.Username, skill.SkillID)] = skill
+	}
+	for _, skill := range masterSkills {
+		m.masterSkills[skill.SkillID] = skill
+	}
+
+	log.Info("Mock repository seeded successfully", "duration", time.Since(start))
+	return nil
+}
+
+// Reset removes everything stored so a single mock can be reused across subtests
+func (m *MockRepository) Reset() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.users = make(map[string]*models.User)
+	m.skills = make(map[string]*models.UserSkill)
+	m.masterSkills = make(map[string]*models.Skill)
+	m.skillNames = make(map[string]string)
+	m.emails = make(map[string]string)
+	m.snapshots = make(map[string]*models.SkillSnapshot)
+	m.histories = make(map[string]*models.SkillHistory)
+	m.endorsements = make(map[string]*models.Endorsement)
+	m.activities = make(map[string]*models.ActivityLog)
+
+	logger.WithComponent("database").Debug("Mock repository reset", "repository", "mock")
+}
@@ -0,0 +1,133 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
+)
+
+func TestNewMockRepositoryWithData(t *testing.T) {
+	user, _ := models.NewUser("alice", "Alice", "password123")
+	skill, _ := models.NewUserSkill("alice", "go", "Go", "Programming", models.ProficiencyExpert, 6)
+	master, _ := models.NewSkill("go", "Go", "Go language", "Programming", nil)
+
+	repo, err := NewMockRepositoryWithData([]*models.User{user}, []*models.UserSkill{skill}, []*models.Skill{master})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := repo.GetUser("alice"); err != nil {
+		t.Errorf("Expected seeded user, got %v", err)
+	}
+	if _, err := repo.GetSkill("alice", "go"); err != nil {
+		t.Errorf("Expected seeded skill, got %v", err)
+	}
+	if _, err := repo.GetMasterSkill("go"); err != nil {
+		t.Errorf("Expected seeded master skill, got %v", err)
+	}
+}
+
+func TestMockRepository_Seed_RejectsDuplicates(t *testing.T) {
+	repo := NewMockRepository()
+	existing, _ := models.NewUser("alice", "Alice", "password123")
+	if err := repo.CreateUser(existing); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	bob, _ := models.NewUser("bob", "Bob", "password123")
+	skill, _ := models.NewUserSkill("bob", "go", "Go", "Programming", models.ProficiencyBeginner, 1)
+	master, _ := models.NewSkill("go", "Go", "Go language", "Programming", nil)
+
+	err := repo.Seed(
+		[]*models.User{existing, bob},
+		[]*models.UserSkill{skill, skill},
+		[]*models.Skill{master, master},
+	)
+	if err == nil {
+		t.Fatal("Expected error for duplicate keys, got nil")
+	}
+
+	for _, target := range []error{apperrors.ErrUserExists, apperrors.ErrSkillAlreadyExists, apperrors.ErrMasterSkillExists} {
+		if !errors.Is(err, target) {
+			t.Errorf("Expected aggregated error to include %v, got %v", target, err)
+		}
+	}
+	for _, key := range []string{`user "alice"`, `skill "go" for user "bob"`, `master skill "go"`} {
+		if !strings.Contains(err.Error(), key) {
+			t.Errorf("Expected error to name %s, got %v", key, err)
+		}
+	}
+
+	// A rejected seed loads nothing
+	if _, err := repo.GetUser("bob"); !errors.Is(err, apperrors.ErrUserNotFound) {
+		t.Errorf("Expected bob not to be loaded, got %v", err)
+	}
+	if _, err := repo.GetMasterSkill("go"); err == nil {
+		t.Error("Expected master skill not to be loaded")
+	}
+}
+
+func TestMockRepository_Reset(t *testing.T) {
+	user, _ := models.NewUser("alice", "Alice", "password123")
+	skill, _ := models.NewUserSkill("alice", "go", "Go", "Programming", models.ProficiencyExpert, 6)
+	repo, err := NewMockRepositoryWithData([]*models.User{user}, []*models.UserSkill{skill}, nil)
+	if err != nil {
+		t.Fatalf("Failed to seed repository: %v", err)
+	}
+	if err := repo.CreateSkillHistory(models.NewSkillHistory("alice", "go", models.ProficiencyAdvanced, models.ProficiencyExpert)); err != nil {
+		t.Fatalf("Failed to create skill history: %v", err)
+	}
+
+	repo.Reset()
+
+	if users, _ := repo.ListUsers(); len(users) != 0 {
+		t.Errorf("Expected no users after reset, got %d", len(users))
+	}
+	if skills, _ := repo.ListSkillsForUser("alice"); len(skills) != 0 {
+		t.Errorf("Expected no skills after reset, got %d", len(skills))
+	}
+	if history, _ := repo.ListSkillHistoryForUser("alice"); len(history) != 0 {
+		t.Errorf("Expected no skill history after reset, got %d", len(history))
+	}
+
+	// The same data can be seeded again once reset
+	if err := repo.Seed([]*models.User{user}, []*models.UserSkill{skill}, nil); err != nil {
+		t.Errorf("Expected seed after reset to succeed, got %v", err)
+	}
+}
+
+func TestMockRepository_Seed_ConcurrentAccess(t *testing.T) {
+	repo := NewMockRepository()
+	concurrency := 10
+
+	users := make([][]*models.User, concurrency)
+	for i := range users {
+		user, _ := models.NewUser(fmt.Sprintf("user%d", i), fmt.Sprintf("User %d", i), "password123")
+		users[i] = []*models.User{user}
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(2)
+		go func(id int) {
+			defer wg.Done()
+			if err := repo.Seed(users[id], nil, nil); err != nil {
+				t.Errorf("Seed %d failed: %v", id, err)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			_, _ = repo.ListUsers()
+		}()
+	}
+	wg.Wait()
+
+	if listed, _ := repo.ListUsers(); len(listed) != concurrency {
+		t.Errorf("Expected %d users, got %d", concurrency, len(listed))
+	}
+}
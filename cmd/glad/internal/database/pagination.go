@@ -0,0 +1,39 @@
+package database
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
+
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+// encodePageToken turns a DynamoDB LastEvaluatedKey into an opaque, URL-safe token
+// An empty key (no further pages) yields an empty token.
+func encodePageToken(key map[string]*dynamodb.AttributeValue) (string, error) {
+	if len(key) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodePageToken restores the ExclusiveStartKey from a token produced by encodePageToken
+func decodePageToken(token string) (map[string]*dynamodb.AttributeValue, error) {
+	if token == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, apperrors.ErrInvalidPageToken
+	}
+	var key map[string]*dynamodb.AttributeValue
+	if err := json.Unmarshal(data, &key); err != nil || len(key) == 0 {
+		return nil, apperrors.ErrInvalidPageToken
+	}
+	return key, nil
+}
@@ -0,0 +1,211 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
+	"github.com/hackmajoris/glad-stack/pkg/logger"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+// defaultSlowQueryThreshold is how long a query may take before it is logged as slow
+const defaultSlowQueryThreshold = 500 * time.Millisecond
+
+// query runs a DynamoDB query and logs it at Warn when it takes longer than the slow-query threshold
+// Only the shape of the query is logged: expressions reference values through placeholders,
+// and ExpressionAttributeValues is never logged, so no user data ends up in the warning.
+func (r *DynamoDBRepository) query(operation string, input *dynamodb.QueryInput) (*dynamodb.QueryOutput, error) {
+	return r.queryWithContext(context.Background(), operation, input)
+}
+
+// queryWithContext is query bounded by ctx; a query cut short by ctx fails with ErrTimeout
+func (r *DynamoDBRepository) queryWithContext(ctx context.Context, operation string, input *dynamodb.QueryInput) (*dynamodb.QueryOutput, error) {
+	if r.logConsumedCapacity {
+		input.ReturnConsumedCapacity = aws.String(dynamodb.ReturnConsumedCapacityTotal)
+	}
+
+	start := time.Now()
+	result, err := r.client.QueryWithContext(ctx, input)
+	duration := time.Since(start)
+
+	if result != nil {
+		r.logCapacity(operation, result.ConsumedCapacity)
+	}
+
+	if r.slowQueryThreshold > 0 && duration > r.slowQueryThreshold {
+		args := []any{
+			"operation", operation,
+			"index", aws.StringValue(input.IndexName),
+			"key_condition", aws.StringValue(input.KeyConditionExpression),
+			"duration", duration,
+			"threshold", r.slowQueryThreshold,
+		}
+		if input.FilterExpression != nil {
+			args = append(args, "filter", aws.StringValue(input.FilterExpression))
+		}
+		if result != nil && result.ConsumedCapacity != nil {
+			args = append(args, "consumed_capacity", aws.Float64Value(result.ConsumedCapacity.CapacityUnits))
+		}
+		logger.WithComponent("database").Warn("Slow DynamoDB query", args...)
+	}
+
+	return result, contextError(ctx, err)
+}
+
+// putItem writes an item, retrying throttled writes and logging its consumed capacity when enabled
+func (r *DynamoDBRepository) putItem(operation string, input *dynamodb.PutItemInput) (*dynamodb.PutItemOutput, error) {
+	return r.putItemWithContext(context.Background(), operation, input)
+}
+
+// putItemWithContext is putItem bounded by ctx; a write cut short by ctx fails with ErrTimeout
+func (r *DynamoDBRepository) putItemWithContext(ctx context.Context, operation string, input *dynamodb.PutItemInput) (*dynamodb.PutItemOutput, error) {
+	if r.logConsumedCapacity {
+		input.ReturnConsumedCapacity = aws.String(dynamodb.ReturnConsumedCapacityTotal)
+	}
+
+	var result *dynamodb.PutItemOutput
+	err := r.withRetry(ctx, operation, func() (err error) {
+		result, err = r.client.PutItemWithContext(ctx, input)
+		return err
+	})
+	if result != nil {
+		r.logCapacity(operation, result.ConsumedCapacity)
+	}
+	return result, contextError(ctx, err)
+}
+
+// updateItem updates an item, retrying throttled writes and logging its consumed capacity when enabled
+func (r *DynamoDBRepository) updateItem(operation string, input *dynamodb.UpdateItemInput) (*dynamodb.UpdateItemOutput, error) {
+	if r.logConsumedCapacity {
+		input.ReturnConsumedCapacity = aws.String(dynamodb.ReturnConsumedCapacityTotal)
+	}
+
+	var result *dynamodb.UpdateItemOutput
+	err := r.withRetry(context.Background(), operation, func() (err error) {
+		result, err = r.client.UpdateItem(input)
+		return err
+	})
+	if result != nil {
+		r.logCapacity(operation, result.ConsumedCapacity)
+	}
+	return result, err
+}
+
+// deleteItem deletes an item, retrying throttled writes
+func (r *DynamoDBRepository) deleteItem(operation string, input *dynamodb.DeleteItemInput) (*dynamodb.DeleteItemOutput, error) {
+	return r.deleteItemWithContext(context.Background(), operation, input)
+}
+
+// deleteItemWithContext is deleteItem bounded by ctx; a delete cut short by ctx fails with ErrTimeout
+func (r *DynamoDBRepository) deleteItemWithContext(ctx context.Context, operation string, input *dynamodb.DeleteItemInput) (*dynamodb.DeleteItemOutput, error) {
+	var result *dynamodb.DeleteItemOutput
+	err := r.withRetry(ctx, operation, func() (err error) {
+		result, err = r.client.DeleteItemWithContext(ctx, input)
+		return err
+	})
+	return result, contextError(ctx, err)
+}
+
+// transactWriteItemsWithContext runs a write transaction bounded by ctx, retrying throttled attempts
+// A transaction cut short by ctx fails with ErrTimeout.
+func (r *DynamoDBRepository) transactWriteItemsWithContext(ctx context.Context, operation string, input *dynamodb.TransactWriteItemsInput) (*dynamodb.TransactWriteItemsOutput, error) {
+	if r.logConsumedCapacity {
+		input.ReturnConsumedCapacity = aws.String(dynamodb.ReturnConsumedCapacityTotal)
+	}
+
+	var result *dynamodb.TransactWriteItemsOutput
+	err := r.withRetry(ctx, operation, func() (err error) {
+		result, err = r.client.TransactWriteItemsWithContext(ctx, input)
+		return err
+	})
+	if result != nil {
+		for _, capacity := range result.ConsumedCapacity {
+			r.logCapacity(operation, capacity)
+		}
+	}
+	return result, contextError(ctx, err)
+}
+
+// isConditionFailed reports whether a single-item write was rejected by its condition expression
+// Callers map it to a domain error such as not-found or already-exists; any other error, like
+// throttling or missing permissions, is returned as is so it surfaces as a server error.
+func isConditionFailed(err error) bool {
+	aerr, ok := err.(awserr.Error)
+	return ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException
+}
+
+// failedCondition returns the index of the first transaction item whose condition check failed
+// It reports false for any error other than a transaction cancelled by a condition check.
+func failedCondition(err error) (int, bool) {
+	canceled, ok := err.(*dynamodb.TransactionCanceledException)
+	if !ok {
+		return 0, false
+	}
+	for i, reason := range canceled.CancellationReasons {
+		if aws.StringValue(reason.Code) == "ConditionalCheckFailed" {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// failedConditions returns the indexes of every transaction item whose condition check failed
+// It returns nil for any error other than a transaction cancelled by condition checks.
+func failedConditions(err error) map[int]bool {
+	canceled, ok := err.(*dynamodb.TransactionCanceledException)
+	if !ok {
+		return nil
+	}
+	var failed map[int]bool
+	for i, reason := range canceled.CancellationReasons {
+		if aws.StringValue(reason.Code) == "ConditionalCheckFailed" {
+			if failed == nil {
+				failed = make(map[int]bool)
+			}
+			failed[i] = true
+		}
+	}
+	return failed
+}
+
+// getItem reads an item, logging its consumed capacity when enabled
+func (r *DynamoDBRepository) getItem(operation string, input *dynamodb.GetItemInput) (*dynamodb.GetItemOutput, error) {
+	return r.getItemWithContext(context.Background(), operation, input)
+}
+
+// getItemWithContext is getItem bounded by ctx; a read cut short by ctx fails with ErrTimeout
+func (r *DynamoDBRepository) getItemWithContext(ctx context.Context, operation string, input *dynamodb.GetItemInput) (*dynamodb.GetItemOutput, error) {
+	if r.logConsumedCapacity {
+		input.ReturnConsumedCapacity = aws.String(dynamodb.ReturnConsumedCapacityTotal)
+	}
+
+	result, err := r.client.GetItemWithContext(ctx, input)
+	if result != nil {
+		r.logCapacity(operation, result.ConsumedCapacity)
+	}
+	return result, contextError(ctx, err)
+}
+
+// logCapacity logs the capacity units a request consumed
+// DynamoDB only reports capacity when it was requested, so this is a no-op unless logging is enabled.
+func (r *DynamoDBRepository) logCapacity(operation string, capacity *dynamodb.ConsumedCapacity) {
+	if !r.logConsumedCapacity || capacity == nil {
+		return
+	}
+	logger.WithComponent("database").Info("DynamoDB consumed capacity", "operation", operation, "capacity_units", aws.Float64Value(capacity.CapacityUnits))
+}
+
+// contextError replaces err with ErrTimeout when ctx ended before the request completed
+// The SDK reports a cancelled request as a generic RequestCanceled error; callers only need to
+// know the deadline was hit.
+func contextError(ctx context.Context, err error) error {
+	if err != nil && ctx.Err() != nil {
+		return fmt.Errorf("%w: %v", apperrors.ErrTimeout, ctx.Err())
+	}
+	return err
+}
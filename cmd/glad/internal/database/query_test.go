@@ -0,0 +1,998 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
+	"github.com/hackmajoris/glad-stack/pkg/logger"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+)
+
+// delayedQueryClient answers every query with no items after a fixed delay
+type delayedQueryClient struct {
+	dynamodbiface.DynamoDBAPI
+	delay time.Duration
+}
+
+func (c *delayedQueryClient) QueryWithContext(_ aws.Context, input *dynamodb.QueryInput, _ ...request.Option) (*dynamodb.QueryOutput, error) {
+	time.Sleep(c.delay)
+	return &dynamodb.QueryOutput{ConsumedCapacity: &dynamodb.ConsumedCapacity{CapacityUnits: aws.Float64(2.5)}}, nil
+}
+
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+
+	var buf bytes.Buffer
+	original := logger.Log
+	logger.Log = &logger.Logger{Logger: slog.New(slog.NewTextHandler(&buf, nil))}
+	t.Cleanup(func() { logger.Log = original })
+	return &buf
+}
+
+func TestDynamoDBRepository_Query_LogsSlowQueries(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold time.Duration
+		delay     time.Duration
+		wantWarn  bool
+	}{
+		{name: "slow query logged", threshold: 5 * time.Millisecond, delay: 20 * time.Millisecond, wantWarn: true},
+		{name: "fast query not logged", threshold: time.Second, delay: 0},
+		{name: "threshold zero disables logging", threshold: 0, delay: 20 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs := captureLogs(t)
+			repo := &DynamoDBRepository{
+				client:              &delayedQueryClient{delay: tt.delay},
+				slowQueryThreshold:  tt.threshold,
+				logConsumedCapacity: true,
+			}
+
+			input := &dynamodb.QueryInput{
+				TableName:              aws.String(DefaultTableName),
+				IndexName:              aws.String("SkillNameIndex"),
+				KeyConditionExpression: aws.String("EntityType = :entityType AND begins_with(entity_id, :prefix)"),
+				FilterExpression:       aws.String("Category = :category"),
+				ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+					":entityType": {S: aws.String("UserSkill")},
+					":prefix":     {S: aws.String("USER#alice#")},
+					":category":   {S: aws.String("Programming")},
+				},
+			}
+			if _, err := repo.query("ListSkillsForUser", input); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			output := logs.String()
+			if !tt.wantWarn {
+				if strings.Contains(output, "Slow DynamoDB query") {
+					t.Errorf("Expected no slow-query warning, got %s", output)
+				}
+				return
+			}
+
+			for _, want := range []string{
+				"level=WARN",
+				"Slow DynamoDB query",
+				"operation=ListSkillsForUser",
+				"index=SkillNameIndex",
+				`key_condition="EntityType = :entityType AND begins_with(entity_id, :prefix)"`,
+				`filter="Category = :category"`,
+				"consumed_capacity=2.5",
+			} {
+				if !strings.Contains(output, want) {
+					t.Errorf("Expected log to contain %s, got %s", want, output)
+				}
+			}
+			// Attribute values stay out of the log
+			for _, value := range []string{"alice", "Programming"} {
+				if strings.Contains(output, value) {
+					t.Errorf("Expected value %q to be redacted, got %s", value, output)
+				}
+			}
+			if aws.StringValue(input.ReturnConsumedCapacity) != dynamodb.ReturnConsumedCapacityTotal {
+				t.Errorf("Expected consumed capacity to be requested, got %v", input.ReturnConsumedCapacity)
+			}
+		})
+	}
+}
+
+// capacityClient reports one capacity unit for every query, put and get
+type capacityClient struct {
+	dynamodbiface.DynamoDBAPI
+}
+
+func (c *capacityClient) QueryWithContext(_ aws.Context, input *dynamodb.QueryInput, _ ...request.Option) (*dynamodb.QueryOutput, error) {
+	return &dynamodb.QueryOutput{ConsumedCapacity: consumedIfRequested(input.ReturnConsumedCapacity)}, nil
+}
+
+func (c *capacityClient) PutItemWithContext(_ aws.Context, input *dynamodb.PutItemInput, _ ...request.Option) (*dynamodb.PutItemOutput, error) {
+	return &dynamodb.PutItemOutput{ConsumedCapacity: consumedIfRequested(input.ReturnConsumedCapacity)}, nil
+}
+
+func (c *capacityClient) GetItemWithContext(_ aws.Context, input *dynamodb.GetItemInput, _ ...request.Option) (*dynamodb.GetItemOutput, error) {
+	return &dynamodb.GetItemOutput{ConsumedCapacity: consumedIfRequested(input.ReturnConsumedCapacity)}, nil
+}
+
+// consumedIfRequested mirrors DynamoDB, which only reports capacity when asked to
+func consumedIfRequested(returnConsumedCapacity *string) *dynamodb.ConsumedCapacity {
+	if aws.StringValue(returnConsumedCapacity) == "" {
+		return nil
+	}
+	return &dynamodb.ConsumedCapacity{CapacityUnits: aws.Float64(1)}
+}
+
+func TestDynamoDBRepository_ConsumedCapacityLogging(t *testing.T) {
+	for _, enabled := range []bool{true, false} {
+		logs := captureLogs(t)
+		repo := &DynamoDBRepository{client: &capacityClient{}}
+		WithConsumedCapacityLogging(enabled)(repo)
+
+		queryInput := &dynamodb.QueryInput{KeyConditionExpression: aws.String("EntityType = :entityType")}
+		putInput := &dynamodb.PutItemInput{}
+		getInput := &dynamodb.GetItemInput{}
+		if _, err := repo.query("ListUsers", queryInput); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, err := repo.putItem("CreateUser", putInput); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, err := repo.getItem("GetUser", getInput); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		want := ""
+		if enabled {
+			want = dynamodb.ReturnConsumedCapacityTotal
+		}
+		for name, got := range map[string]*string{
+			"query": queryInput.ReturnConsumedCapacity,
+			"put":   putInput.ReturnConsumedCapacity,
+			"get":   getInput.ReturnConsumedCapacity,
+		} {
+			if aws.StringValue(got) != want {
+				t.Errorf("Expected %s ReturnConsumedCapacity %q with logging %v, got %q", name, want, enabled, aws.StringValue(got))
+			}
+		}
+
+		for _, operation := range []string{"ListUsers", "CreateUser", "GetUser"} {
+			logged := strings.Contains(logs.String(), "operation="+operation+" capacity_units=1")
+			if logged != enabled {
+				t.Errorf("Expected capacity logged for %s to be %v, got logs %s", operation, enabled, logs.String())
+			}
+		}
+	}
+}
+
+// recordingClient keeps the last query and put inputs and answers with empty results
+type recordingClient struct {
+	dynamodbiface.DynamoDBAPI
+	queries []*dynamodb.QueryInput
+	puts    []*dynamodb.PutItemInput
+}
+
+func (c *recordingClient) QueryWithContext(_ aws.Context, input *dynamodb.QueryInput, _ ...request.Option) (*dynamodb.QueryOutput, error) {
+	c.queries = append(c.queries, input)
+	return &dynamodb.QueryOutput{}, nil
+}
+
+func (c *recordingClient) PutItemWithContext(_ aws.Context, input *dynamodb.PutItemInput, _ ...request.Option) (*dynamodb.PutItemOutput, error) {
+	c.puts = append(c.puts, input)
+	return &dynamodb.PutItemOutput{}, nil
+}
+
+func TestDynamoDBRepository_UsesConfiguredNames(t *testing.T) {
+	client := &recordingClient{}
+	repo := &DynamoDBRepository{client: client}
+	WithRepositoryConfig(RepositoryConfig{TableName: "glad-entities-staging", BySkillIndex: "BySkillV2"})(repo)
+
+	user, _ := models.NewUser("alice", "Alice", "password123")
+	if err := repo.CreateUser(user); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, _, err := repo.ListUsersBySkill("Programming", "Go", 0, ""); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := repo.ListRecentSkills(10); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(client.puts) != 1 || aws.StringValue(client.puts[0].TableName) != "glad-entities-staging" {
+		t.Fatalf("Expected put into glad-entities-staging, got %v", client.puts)
+	}
+	if len(client.queries) != 2 {
+		t.Fatalf("Expected 2 queries, got %d", len(client.queries))
+	}
+	for i, index := range []string{"BySkillV2", DefaultRecentlyUpdatedIndex} {
+		query := client.queries[i]
+		if aws.StringValue(query.TableName) != "glad-entities-staging" {
+			t.Errorf("Expected query %d on glad-entities-staging, got %s", i, aws.StringValue(query.TableName))
+		}
+		if aws.StringValue(query.IndexName) != index {
+			t.Errorf("Expected query %d on index %s, got %s", i, index, aws.StringValue(query.IndexName))
+		}
+	}
+}
+
+// transactClient records write transactions and cancels them with the given reason codes
+type transactClient struct {
+	dynamodbiface.DynamoDBAPI
+	inputs  []*dynamodb.TransactWriteItemsInput
+	reasons []string
+}
+
+func (c *transactClient) TransactWriteItemsWithContext(_ aws.Context, input *dynamodb.TransactWriteItemsInput, _ ...request.Option) (*dynamodb.TransactWriteItemsOutput, error) {
+	c.inputs = append(c.inputs, input)
+	if c.reasons == nil {
+		return &dynamodb.TransactWriteItemsOutput{}, nil
+	}
+	canceled := &dynamodb.TransactionCanceledException{Message_: aws.String("transaction cancelled")}
+	for _, code := range c.reasons {
+		canceled.CancellationReasons = append(canceled.CancellationReasons, &dynamodb.CancellationReason{Code: aws.String(code)})
+	}
+	return nil, canceled
+}
+
+func TestDynamoDBRepository_SkillWritesUpdateSkillCount(t *testing.T) {
+	client := &transactClient{}
+	repo := &DynamoDBRepository{client: client, names: DefaultRepositoryConfig()}
+
+	skill, _ := models.NewUserSkill("alice", "go", "Go", "Programming", models.ProficiencyBeginner, 1)
+	if err := repo.CreateSkill(skill); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := repo.DeleteSkill("alice", "go"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(client.inputs) != 2 {
+		t.Fatalf("Expected 2 transactions, got %d", len(client.inputs))
+	}
+	for i, delta := range []string{"1", "-1"} {
+		items := client.inputs[i].TransactItems
+		if len(items) != 2 || items[1].Update == nil {
+			t.Fatalf("Expected skill write plus user update in transaction %d, got %v", i, items)
+		}
+		update := items[1].Update
+		if aws.StringValue(update.Key["entity_id"].S) != BuildUserEntityID("alice") {
+			t.Errorf("Expected update of alice's user item, got %s", aws.StringValue(update.Key["entity_id"].S))
+		}
+		if got := aws.StringValue(update.ExpressionAttributeValues[":delta"].N); got != delta {
+			t.Errorf("Expected SkillCount delta %s in transaction %d, got %s", delta, i, got)
+		}
+	}
+	if client.inputs[0].TransactItems[0].Put == nil || client.inputs[1].TransactItems[0].Delete == nil {
+		t.Error("Expected the create to put and the delete to delete the skill")
+	}
+
+	// Cancellation reasons identify which condition failed
+	tests := []struct {
+		name    string
+		reasons []string
+		create  bool
+		want    error
+	}{
+		{name: "create existing skill", reasons: []string{"ConditionalCheckFailed", "None"}, create: true, want: apperrors.ErrSkillAlreadyExists},
+		{name: "create for missing user", reasons: []string{"None", "ConditionalCheckFailed"}, create: true, want: apperrors.ErrUserNotFound},
+		{name: "delete missing skill", reasons: []string{"ConditionalCheckFailed", "None"}, want: apperrors.ErrSkillNotFound},
+		{name: "delete for missing user", reasons: []string{"None", "ConditionalCheckFailed"}, want: apperrors.ErrUserNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client.reasons = tt.reasons
+			var err error
+			if tt.create {
+				err = repo.CreateSkill(skill)
+			} else {
+				err = repo.DeleteSkill("alice", "go")
+			}
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestDynamoDBRepository_EndorsementWritesUpdateEndorsements(t *testing.T) {
+	client := &transactClient{}
+	repo := &DynamoDBRepository{client: client, names: DefaultRepositoryConfig()}
+
+	if err := repo.AddEndorsement(models.NewEndorsement("alice", "go", "bob")); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := repo.RemoveEndorsement("alice", "go", "bob"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(client.inputs) != 2 {
+		t.Fatalf("Expected 2 transactions, got %d", len(client.inputs))
+	}
+	for i, delta := range []string{"1", "-1"} {
+		items := client.inputs[i].TransactItems
+		if len(items) != 2 || items[1].Update == nil {
+			t.Fatalf("Expected endorsement write plus skill update in transaction %d, got %v", i, items)
+		}
+		update := items[1].Update
+		if aws.StringValue(update.Key["entity_id"].S) != BuildUserSkillEntityID("alice", "go") {
+			t.Errorf("Expected update of alice's go skill, got %s", aws.StringValue(update.Key["entity_id"].S))
+		}
+		if got := aws.StringValue(update.UpdateExpression); !strings.HasPrefix(got, "ADD Endorsements :delta") {
+			t.Errorf("Expected Endorsements to be added to in place, got %q", got)
+		}
+		if got := aws.StringValue(update.ExpressionAttributeValues[":delta"].N); got != delta {
+			t.Errorf("Expected Endorsements delta %s in transaction %d, got %s", delta, i, got)
+		}
+	}
+	if client.inputs[0].TransactItems[0].Put == nil || client.inputs[1].TransactItems[0].Delete == nil {
+		t.Error("Expected the add to put and the removal to delete the endorsement")
+	}
+
+	// Cancellation reasons identify which condition failed
+	tests := []struct {
+		name    string
+		reasons []string
+		add     bool
+		want    error
+	}{
+		{name: "add existing endorsement", reasons: []string{"ConditionalCheckFailed", "None"}, add: true, want: apperrors.ErrAlreadyEndorsed},
+		{name: "add to missing skill", reasons: []string{"None", "ConditionalCheckFailed"}, add: true, want: apperrors.ErrSkillNotFound},
+		{name: "remove missing endorsement", reasons: []string{"ConditionalCheckFailed", "None"}, want: apperrors.ErrEndorsementNotFound},
+		{name: "remove from missing skill", reasons: []string{"None", "ConditionalCheckFailed"}, want: apperrors.ErrSkillNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client.reasons = tt.reasons
+			var err error
+			if tt.add {
+				err = repo.AddEndorsement(models.NewEndorsement("alice", "go", "bob"))
+			} else {
+				err = repo.RemoveEndorsement("alice", "go", "bob")
+			}
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+// contextAwareClient fails requests whose context is done, as the SDK does
+type contextAwareClient struct {
+	dynamodbiface.DynamoDBAPI
+}
+
+func (c *contextAwareClient) GetItemWithContext(ctx aws.Context, _ *dynamodb.GetItemInput, _ ...request.Option) (*dynamodb.GetItemOutput, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, awserr.New(request.CanceledErrorCode, "request context canceled", err)
+	}
+	return &dynamodb.GetItemOutput{}, nil
+}
+
+func TestDynamoDBRepository_GetSkillWithContext_Cancelled(t *testing.T) {
+	repo := &DynamoDBRepository{client: &contextAwareClient{}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := repo.GetSkillWithContext(ctx, "alice", "go"); !errors.Is(err, apperrors.ErrTimeout) {
+		t.Errorf("Expected ErrTimeout for a cancelled context, got %v", err)
+	}
+
+	// A live context reaches DynamoDB, which has no such skill
+	if _, err := repo.GetSkillWithContext(context.Background(), "alice", "go"); !errors.Is(err, apperrors.ErrSkillNotFound) {
+		t.Errorf("Expected ErrSkillNotFound, got %v", err)
+	}
+}
+
+// failingWriteClient rejects every PutItem and DeleteItem call with the given error code
+type failingWriteClient struct {
+	dynamodbiface.DynamoDBAPI
+	code string
+}
+
+func (c *failingWriteClient) PutItemWithContext(_ aws.Context, _ *dynamodb.PutItemInput, _ ...request.Option) (*dynamodb.PutItemOutput, error) {
+	return nil, awserr.New(c.code, "injected failure", nil)
+}
+
+func (c *failingWriteClient) UpdateItem(_ *dynamodb.UpdateItemInput) (*dynamodb.UpdateItemOutput, error) {
+	return nil, awserr.New(c.code, "injected failure", nil)
+}
+
+func (c *failingWriteClient) DeleteItemWithContext(_ aws.Context, _ *dynamodb.DeleteItemInput, _ ...request.Option) (*dynamodb.DeleteItemOutput, error) {
+	return nil, awserr.New(c.code, "injected failure", nil)
+}
+
+func TestDynamoDBRepository_ConditionalWrites_MapOnlyFailedConditions(t *testing.T) {
+	writes := []struct {
+		name        string
+		write       func(r *DynamoDBRepository) error
+		conditionTo error
+	}{
+		{name: "UpdateSkill", write: func(r *DynamoDBRepository) error {
+			return r.UpdateSkill(&models.UserSkill{Username: "alice", SkillID: "go"})
+		}, conditionTo: apperrors.ErrSkillNotFound},
+		{name: "CreateUser", write: func(r *DynamoDBRepository) error {
+			return r.CreateUser(&models.User{Username: "alice"})
+		}, conditionTo: apperrors.ErrUserExists},
+		{name: "UpdateUser", write: func(r *DynamoDBRepository) error {
+			return r.UpdateUser(&models.User{Username: "alice"})
+		}, conditionTo: apperrors.ErrUserNotFound},
+	}
+
+	throttled := dynamodb.ErrCodeProvisionedThroughputExceededException
+
+	for _, tt := range writes {
+		t.Run(tt.name+" condition failure", func(t *testing.T) {
+			repo := &DynamoDBRepository{client: &failingWriteClient{code: dynamodb.ErrCodeConditionalCheckFailedException}, names: DefaultRepositoryConfig()}
+
+			if err := tt.write(repo); !errors.Is(err, tt.conditionTo) {
+				t.Errorf("Expected %v, got %v", tt.conditionTo, err)
+			}
+		})
+
+		t.Run(tt.name+" throttled", func(t *testing.T) {
+			repo := &DynamoDBRepository{client: &failingWriteClient{code: throttled}, names: DefaultRepositoryConfig()}
+
+			err := tt.write(repo)
+			if errors.Is(err, tt.conditionTo) {
+				t.Fatalf("Expected throttling not to be reported as %v", tt.conditionTo)
+			}
+			if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != throttled {
+				t.Errorf("Expected %s error, got %v", throttled, err)
+			}
+		})
+	}
+}
+
+// updateRecordingClient keeps every UpdateItem input and answers with an empty result
+type updateRecordingClient struct {
+	dynamodbiface.DynamoDBAPI
+	updates []*dynamodb.UpdateItemInput
+}
+
+func (c *updateRecordingClient) UpdateItem(input *dynamodb.UpdateItemInput) (*dynamodb.UpdateItemOutput, error) {
+	c.updates = append(c.updates, input)
+	return &dynamodb.UpdateItemOutput{}, nil
+}
+
+func TestDynamoDBRepository_UpdateUser_LeavesSkillCount(t *testing.T) {
+	client := &updateRecordingClient{}
+	repo := &DynamoDBRepository{client: client, names: DefaultRepositoryConfig()}
+
+	user, _ := models.NewUser("alice", "Alice", "password123")
+	user.SkillCount = 7
+	if err := repo.UpdateUser(user); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(client.updates) != 1 {
+		t.Fatalf("Expected 1 update, got %d", len(client.updates))
+	}
+	update := client.updates[0]
+	written := make(map[string]bool)
+	for _, name := range update.ExpressionAttributeNames {
+		written[aws.StringValue(name)] = true
+	}
+	if written["SkillCount"] || written["entity_id"] || written["EntityType"] {
+		t.Errorf("Expected neither SkillCount nor the keys to be written, got %s", aws.StringValue(update.UpdateExpression))
+	}
+	for _, name := range []string{"Name", "NameLower", "UpdatedAt", "PasswordHash"} {
+		if !written[name] {
+			t.Errorf("Expected %s to be written, got %s", name, aws.StringValue(update.UpdateExpression))
+		}
+	}
+
+	// An empty email is removed rather than left behind
+	if !strings.Contains(aws.StringValue(update.UpdateExpression), " REMOVE ") || !written["Email"] {
+		t.Errorf("Expected the empty Email to be removed, got %s", aws.StringValue(update.UpdateExpression))
+	}
+	if aws.StringValue(update.ConditionExpression) != "attribute_exists(entity_id)" {
+		t.Errorf("Expected the update to require an existing user, got %s", aws.StringValue(update.ConditionExpression))
+	}
+}
+
+// scriptedTransactClient cancels successive write transactions with the next list of reason codes
+// and lets them through once the script runs out
+type scriptedTransactClient struct {
+	dynamodbiface.DynamoDBAPI
+	inputs []*dynamodb.TransactWriteItemsInput
+	script [][]string
+}
+
+func (c *scriptedTransactClient) TransactWriteItemsWithContext(_ aws.Context, input *dynamodb.TransactWriteItemsInput, _ ...request.Option) (*dynamodb.TransactWriteItemsOutput, error) {
+	c.inputs = append(c.inputs, input)
+	if len(c.script) == 0 {
+		return &dynamodb.TransactWriteItemsOutput{}, nil
+	}
+	reasons := c.script[0]
+	c.script = c.script[1:]
+
+	canceled := &dynamodb.TransactionCanceledException{Message_: aws.String("transaction cancelled")}
+	for _, code := range reasons {
+		canceled.CancellationReasons = append(canceled.CancellationReasons, &dynamodb.CancellationReason{Code: aws.String(code)})
+	}
+	return nil, canceled
+}
+
+func TestDynamoDBRepository_BatchDeleteSkills_CountsOnlyDeletedSkills(t *testing.T) {
+	// rust was deleted concurrently, so the first transaction is cancelled on its condition
+	client := &scriptedTransactClient{script: [][]string{{"None", "ConditionalCheckFailed", "None", "None"}}}
+	repo := &DynamoDBRepository{client: client, names: DefaultRepositoryConfig()}
+
+	err := repo.BatchDeleteSkills("alice", []string{"go", "rust", "python"})
+	var batchErr *BatchWriteError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("Expected a BatchWriteError, got %v", err)
+	}
+	if len(batchErr.Failed) != 1 || !errors.Is(batchErr.Failed[1], apperrors.ErrSkillNotFound) {
+		t.Errorf("Expected only rust to fail with ErrSkillNotFound, got %v", batchErr.Failed)
+	}
+
+	if len(client.inputs) != 2 {
+		t.Fatalf("Expected a retry without rust, got %d transactions", len(client.inputs))
+	}
+	retry := client.inputs[1].TransactItems
+	if len(retry) != 3 {
+		t.Fatalf("Expected 2 deletes and the count update, got %d items", len(retry))
+	}
+	for i, skillID := range []string{"go", "python"} {
+		if got := aws.StringValue(retry[i].Delete.Key["entity_id"].S); got != BuildUserSkillEntityID("alice", skillID) {
+			t.Errorf("Expected delete %d of %s, got %s", i, skillID, got)
+		}
+	}
+	if got := aws.StringValue(retry[2].Update.ExpressionAttributeValues[":delta"].N); got != "-2" {
+		t.Errorf("Expected the count to drop by 2, got %s", got)
+	}
+
+	// A missing owner fails every skill in the transaction
+	client = &scriptedTransactClient{script: [][]string{{"None", "ConditionalCheckFailed"}}}
+	repo.client = client
+	err = repo.BatchDeleteSkills("ghost", []string{"go"})
+	if !errors.As(err, &batchErr) || !errors.Is(batchErr.Failed[0], apperrors.ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound for the missing owner, got %v", err)
+	}
+}
+
+func TestDynamoDBRepository_BatchCreateSkills_CountsOnlyCreatedSkills(t *testing.T) {
+	// rust was added concurrently, so the first transaction is cancelled on its condition
+	client := &scriptedTransactClient{script: [][]string{{"None", "ConditionalCheckFailed", "None", "None"}}}
+	repo := &DynamoDBRepository{client: client, names: DefaultRepositoryConfig()}
+
+	var skills []*models.UserSkill
+	for _, skillID := range []string{"go", "rust", "python"} {
+		skill, _ := models.NewUserSkill("alice", skillID, skillID, "Programming", models.ProficiencyBeginner, 1)
+		skills = append(skills, skill)
+	}
+	err := repo.BatchCreateSkills("alice", skills)
+	var batchErr *BatchWriteError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("Expected a BatchWriteError, got %v", err)
+	}
+	if len(batchErr.Failed) != 1 || !errors.Is(batchErr.Failed[1], apperrors.ErrSkillAlreadyExists) {
+		t.Errorf("Expected only rust to fail with ErrSkillAlreadyExists, got %v", batchErr.Failed)
+	}
+
+	if len(client.inputs) != 2 {
+		t.Fatalf("Expected a retry without rust, got %d transactions", len(client.inputs))
+	}
+	retry := client.inputs[1].TransactItems
+	if len(retry) != 3 {
+		t.Fatalf("Expected 2 puts and the count update, got %d items", len(retry))
+	}
+	for i, skillID := range []string{"go", "python"} {
+		put := retry[i].Put
+		if got := aws.StringValue(put.Item["entity_id"].S); got != BuildUserSkillEntityID("alice", skillID) {
+			t.Errorf("Expected put %d of %s, got %s", i, skillID, got)
+		}
+		if aws.StringValue(put.ConditionExpression) != "attribute_not_exists(entity_id)" {
+			t.Errorf("Expected put %d to require a new skill, got %s", i, aws.StringValue(put.ConditionExpression))
+		}
+	}
+	if got := aws.StringValue(retry[2].Update.ExpressionAttributeValues[":delta"].N); got != "2" {
+		t.Errorf("Expected the count to rise by 2, got %s", got)
+	}
+
+	// A missing owner fails every skill in the transaction
+	client = &scriptedTransactClient{script: [][]string{{"None", "ConditionalCheckFailed"}}}
+	repo.client = client
+	err = repo.BatchCreateSkills("ghost", skills[:1])
+	if !errors.As(err, &batchErr) || !errors.Is(batchErr.Failed[0], apperrors.ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound for the missing owner, got %v", err)
+	}
+}
+
+func TestDynamoDBRepository_MergeUserSkill_SingleTransaction(t *testing.T) {
+	client := &transactClient{}
+	repo := &DynamoDBRepository{client: client, names: DefaultRepositoryConfig()}
+
+	source, _ := models.NewUserSkill("bob", "golang", "Golang", "Backend", models.ProficiencyExpert, 3)
+	target := *source
+	target.SkillID = "go"
+	merge := &UserSkillMerge{
+		Source:           source,
+		Target:           &target,
+		Replaces:         true,
+		MoveEndorsements: []*models.Endorsement{models.NewEndorsement("bob", "golang", "erin")},
+		DropEndorsements: []*models.Endorsement{models.NewEndorsement("bob", "golang", "dave")},
+		MoveHistory:      []*models.SkillHistory{models.NewSkillHistory("bob", "golang", models.ProficiencyBeginner, models.ProficiencyExpert)},
+	}
+	if err := repo.MergeUserSkill(merge); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(client.inputs) != 1 {
+		t.Fatalf("Expected a single transaction, got %d", len(client.inputs))
+	}
+	items := client.inputs[0].TransactItems
+	if len(items) != 8 {
+		t.Fatalf("Expected target, source, count, drop and 2 moves of 2 items, got %d items", len(items))
+	}
+	if got := aws.StringValue(items[0].Put.Item["entity_id"].S); got != BuildUserSkillEntityID("bob", "go") {
+		t.Errorf("Expected the target to be written first, got %s", got)
+	}
+	if got := aws.StringValue(items[2].Update.ExpressionAttributeValues[":delta"].N); got != "-1" {
+		t.Errorf("Expected the count to drop by 1, got %s", got)
+	}
+	if got := aws.StringValue(items[3].Delete.Key["entity_id"].S); got != models.BuildEndorsementEntityID("bob", "golang", "dave") {
+		t.Errorf("Expected dave's duplicate endorsement to be deleted, got %s", got)
+	}
+	if got := aws.StringValue(items[4].Put.Item["entity_id"].S); got != models.BuildEndorsementEntityID("bob", "go", "erin") {
+		t.Errorf("Expected erin's endorsement to move to the target, got %s", got)
+	}
+
+	// The target vanished since the merge was planned
+	repo.client = &transactClient{reasons: []string{"ConditionalCheckFailed", "None", "None"}}
+	if err := repo.MergeUserSkill(merge); !errors.Is(err, apperrors.ErrSkillNotFound) {
+		t.Errorf("Expected ErrSkillNotFound, got %v", err)
+	}
+}
+
+func TestDynamoDBRepository_RenameSkill(t *testing.T) {
+	client := &updateRecordingClient{}
+	repo := &DynamoDBRepository{client: client, names: DefaultRepositoryConfig()}
+
+	if err := repo.RenameSkill("alice", "golang", "Go", "Programming"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(client.updates) != 1 {
+		t.Fatalf("Expected one update, got %d", len(client.updates))
+	}
+	update := client.updates[0]
+	if got := aws.StringValue(update.UpdateExpression); got != "SET SkillName = :skillName, Category = :category" {
+		t.Errorf("Expected only the name and category to be set, got %q", got)
+	}
+	if got := aws.StringValue(update.ConditionExpression); got != "attribute_exists(entity_id)" {
+		t.Errorf("Expected the skill to have to exist, got %q", got)
+	}
+
+	repo.client = &failingWriteClient{code: dynamodb.ErrCodeConditionalCheckFailedException}
+	if err := repo.RenameSkill("alice", "golang", "Go", "Programming"); !errors.Is(err, apperrors.ErrSkillNotFound) {
+		t.Errorf("Expected ErrSkillNotFound for a deleted skill, got %v", err)
+	}
+}
+
+func TestDynamoDBRepository_SetSkillLevel(t *testing.T) {
+	client := &updateRecordingClient{}
+	repo := &DynamoDBRepository{client: client, names: DefaultRepositoryConfig()}
+
+	if err := repo.SetSkillLevel("alice", "go", models.ProficiencyIntermediate, models.ProficiencyAdvanced); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(client.updates) != 1 {
+		t.Fatalf("Expected one update, got %d", len(client.updates))
+	}
+	update := client.updates[0]
+	if got := aws.StringValue(update.UpdateExpression); got != "SET ProficiencyLevel = :level, UpdatedAt = :updatedAt" {
+		t.Errorf("Expected only the level and UpdatedAt to be set, got %q", got)
+	}
+	if got := aws.StringValue(update.ConditionExpression); got != "ProficiencyLevel = :from" {
+		t.Errorf("Expected the skill to have to hold the old level, got %q", got)
+	}
+	if got := aws.StringValue(update.ExpressionAttributeValues[":from"].S); got != string(models.ProficiencyIntermediate) {
+		t.Errorf("Expected the old level to be Intermediate, got %q", got)
+	}
+
+	repo.client = &failingWriteClient{code: dynamodb.ErrCodeConditionalCheckFailedException}
+	if err := repo.SetSkillLevel("alice", "go", models.ProficiencyIntermediate, models.ProficiencyAdvanced); !errors.Is(err, ErrSkillLevelChanged) {
+		t.Errorf("Expected ErrSkillLevelChanged for a skill promoted meanwhile, got %v", err)
+	}
+}
+
+// pagedQueryClient answers queries one item per page, like DynamoDB does once a page reaches its size cap
+type pagedQueryClient struct {
+	dynamodbiface.DynamoDBAPI
+	items   []map[string]*dynamodb.AttributeValue
+	queries int
+}
+
+func (c *pagedQueryClient) QueryWithContext(_ aws.Context, input *dynamodb.QueryInput, _ ...request.Option) (*dynamodb.QueryOutput, error) {
+	c.queries++
+	page := 0
+	if input.ExclusiveStartKey != nil {
+		page, _ = strconv.Atoi(aws.StringValue(input.ExclusiveStartKey["entity_id"].S))
+	}
+	output := &dynamodb.QueryOutput{Items: c.items[page : page+1]}
+	if page+1 < len(c.items) {
+		output.LastEvaluatedKey = map[string]*dynamodb.AttributeValue{"entity_id": {S: aws.String(strconv.Itoa(page + 1))}}
+	}
+	return output, nil
+}
+
+func TestDynamoDBRepository_SearchMasterSkillsByNamePrefix_ReadsEveryPage(t *testing.T) {
+	client := &pagedQueryClient{}
+	for _, name := range []string{"Go", "Golang", "Gopher"} {
+		skill, _ := models.NewSkill(strings.ToLower(name), name, "", "Programming", nil)
+		item, err := dynamodbattribute.MarshalMap(skill)
+		if err != nil {
+			t.Fatalf("Failed to marshal skill: %v", err)
+		}
+		client.items = append(client.items, item)
+	}
+	repo := &DynamoDBRepository{client: client, names: DefaultRepositoryConfig()}
+
+	skills, err := repo.SearchMasterSkillsByNamePrefix("go")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(skills) != 3 || client.queries != 3 {
+		t.Errorf("Expected 3 skills from 3 pages, got %d from %d queries", len(skills), client.queries)
+	}
+}
+
+func TestDynamoDBRepository_SearchUsers_ReadsEveryPage(t *testing.T) {
+	client := &pagedQueryClient{}
+	for _, username := range []string{"alice", "alicia", "alina"} {
+		user, _ := models.NewUser(username, "Test User", "password123")
+		item, err := dynamodbattribute.MarshalMap(user)
+		if err != nil {
+			t.Fatalf("Failed to marshal user: %v", err)
+		}
+		client.items = append(client.items, item)
+	}
+	repo := &DynamoDBRepository{client: client, names: DefaultRepositoryConfig()}
+
+	users, err := repo.SearchUsers("ali")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(users) != 3 || client.queries != 3 {
+		t.Errorf("Expected 3 users from 3 pages, got %d from %d queries", len(users), client.queries)
+	}
+}
+
+// storedSkillClient answers GetItem with a stored master skill, or none if skill is nil, and
+// scripts transactions like scriptedTransactClient
+type storedSkillClient struct {
+	scriptedTransactClient
+	skill *models.Skill
+}
+
+func (c *storedSkillClient) GetItemWithContext(_ aws.Context, _ *dynamodb.GetItemInput, _ ...request.Option) (*dynamodb.GetItemOutput, error) {
+	if c.skill == nil {
+		return &dynamodb.GetItemOutput{}, nil
+	}
+	item, err := dynamodbattribute.MarshalMap(c.skill)
+	return &dynamodb.GetItemOutput{Item: item}, err
+}
+
+func TestDynamoDBRepository_CreateMasterSkill_ClaimsName(t *testing.T) {
+	client := &transactClient{}
+	repo := &DynamoDBRepository{client: client, names: DefaultRepositoryConfig()}
+
+	skill, _ := models.NewSkill("go", "Go", "", "Programming", nil)
+	if err := repo.CreateMasterSkill(skill); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	items := client.inputs[0].TransactItems
+	if len(items) != 2 {
+		t.Fatalf("Expected the skill and its name claim, got %d items", len(items))
+	}
+	claim := items[1].Put
+	if got := aws.StringValue(claim.Item["entity_id"].S); got != BuildSkillNameEntityID("go") {
+		t.Errorf("Expected the claim on go, got %s", got)
+	}
+	if got := aws.StringValue(claim.Item["skill_id"].S); got != "go" {
+		t.Errorf("Expected the claim to hold go, got %s", got)
+	}
+
+	tests := []struct {
+		reasons  []string
+		expected error
+	}{
+		{reasons: []string{"ConditionalCheckFailed", "None"}, expected: apperrors.ErrSkillAlreadyExists},
+		{reasons: []string{"None", "ConditionalCheckFailed"}, expected: apperrors.ErrMasterSkillNameTaken},
+	}
+	for _, tt := range tests {
+		repo.client = &transactClient{reasons: tt.reasons}
+		if err := repo.CreateMasterSkill(skill); !errors.Is(err, tt.expected) {
+			t.Errorf("Expected %v for %v, got %v", tt.expected, tt.reasons, err)
+		}
+	}
+}
+
+func TestDynamoDBRepository_UpdateMasterSkill_MovesNameClaim(t *testing.T) {
+	stored, _ := models.NewSkill("go", "Go", "", "Programming", nil)
+
+	t.Run("rename", func(t *testing.T) {
+		client := &storedSkillClient{skill: stored}
+		repo := &DynamoDBRepository{client: client, names: DefaultRepositoryConfig()}
+
+		renamed := *stored
+		renamed.SkillName = "Golang"
+		if err := repo.UpdateMasterSkill(&renamed); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		items := client.inputs[0].TransactItems
+		if len(items) != 3 {
+			t.Fatalf("Expected the skill, the new claim and the released one, got %d items", len(items))
+		}
+		if got := aws.StringValue(items[0].Put.ConditionExpression); got != "UpdatedAt = :updatedAt" {
+			t.Errorf("Expected the write to require the skill as read, got %s", got)
+		}
+		if got := aws.StringValue(items[1].Put.Item["entity_id"].S); got != BuildSkillNameEntityID("golang") {
+			t.Errorf("Expected the claim on golang, got %s", got)
+		}
+		if got := aws.StringValue(items[2].Delete.Key["entity_id"].S); got != BuildSkillNameEntityID("go") {
+			t.Errorf("Expected the claim on go to be released, got %s", got)
+		}
+	})
+
+	t.Run("unchanged name", func(t *testing.T) {
+		client := &storedSkillClient{skill: stored}
+		repo := &DynamoDBRepository{client: client, names: DefaultRepositoryConfig()}
+
+		updated := *stored
+		updated.SkillName = "GO"
+		updated.Description = "The Go language"
+		if err := repo.UpdateMasterSkill(&updated); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if items := client.inputs[0].TransactItems; len(items) != 1 {
+			t.Errorf("Expected only the skill to be written, got %d items", len(items))
+		}
+	})
+
+	t.Run("taken name", func(t *testing.T) {
+		client := &storedSkillClient{skill: stored}
+		client.script = [][]string{{"None", "ConditionalCheckFailed", "None"}}
+		repo := &DynamoDBRepository{client: client, names: DefaultRepositoryConfig()}
+
+		renamed := *stored
+		renamed.SkillName = "Rust"
+		if err := repo.UpdateMasterSkill(&renamed); !errors.Is(err, apperrors.ErrMasterSkillNameTaken) {
+			t.Errorf("Expected ErrMasterSkillNameTaken, got %v", err)
+		}
+	})
+
+	t.Run("unclaimed previous name", func(t *testing.T) {
+		// go predates name claims, so there is nothing to release
+		client := &storedSkillClient{skill: stored}
+		client.script = [][]string{{"None", "None", "ConditionalCheckFailed"}}
+		repo := &DynamoDBRepository{client: client, names: DefaultRepositoryConfig()}
+
+		renamed := *stored
+		renamed.SkillName = "Golang"
+		if err := repo.UpdateMasterSkill(&renamed); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(client.inputs) != 2 || len(client.inputs[1].TransactItems) != 2 {
+			t.Errorf("Expected a retry without the release, got %d transactions", len(client.inputs))
+		}
+	})
+
+	t.Run("soft delete", func(t *testing.T) {
+		client := &storedSkillClient{skill: stored}
+		repo := &DynamoDBRepository{client: client, names: DefaultRepositoryConfig()}
+
+		deleted := *stored
+		deleted.MarkDeleted()
+		if err := repo.UpdateMasterSkill(&deleted); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		items := client.inputs[0].TransactItems
+		if len(items) != 2 || items[1].Delete == nil {
+			t.Fatalf("Expected the skill and the released claim, got %d items", len(items))
+		}
+	})
+
+	t.Run("changed meanwhile", func(t *testing.T) {
+		client := &storedSkillClient{skill: stored}
+		client.script = [][]string{{"ConditionalCheckFailed"}, {"ConditionalCheckFailed"}, {"ConditionalCheckFailed"}}
+		repo := &DynamoDBRepository{client: client, names: DefaultRepositoryConfig()}
+
+		if err := repo.UpdateMasterSkill(stored); !errors.Is(err, ErrMasterSkillChanged) {
+			t.Errorf("Expected ErrMasterSkillChanged, got %v", err)
+		}
+		if len(client.inputs) != masterSkillWriteAttempts {
+			t.Errorf("Expected %d attempts, got %d", masterSkillWriteAttempts, len(client.inputs))
+		}
+	})
+
+	t.Run("missing skill", func(t *testing.T) {
+		repo := &DynamoDBRepository{client: &storedSkillClient{}, names: DefaultRepositoryConfig()}
+
+		if err := repo.UpdateMasterSkill(stored); !errors.Is(err, apperrors.ErrSkillNotFound) {
+			t.Errorf("Expected ErrSkillNotFound, got %v", err)
+		}
+		if err := repo.DeleteMasterSkill("go"); !errors.Is(err, apperrors.ErrSkillNotFound) {
+			t.Errorf("Expected ErrSkillNotFound on delete, got %v", err)
+		}
+	})
+}
+
+func TestDynamoDBRepository_DeleteMasterSkill_ReleasesName(t *testing.T) {
+	stored, _ := models.NewSkill("go", "Go", "", "Programming", nil)
+	client := &storedSkillClient{skill: stored}
+	repo := &DynamoDBRepository{client: client, names: DefaultRepositoryConfig()}
+
+	if err := repo.DeleteMasterSkill("go"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	items := client.inputs[0].TransactItems
+	if len(items) != 2 {
+		t.Fatalf("Expected the skill and its claim to be deleted, got %d items", len(items))
+	}
+	if got := aws.StringValue(items[1].Delete.Key["entity_id"].S); got != BuildSkillNameEntityID("go") {
+		t.Errorf("Expected the claim on go to be released, got %s", got)
+	}
+}
+
+func TestDynamoDBRepository_UpdateUserEmail_MovesClaim(t *testing.T) {
+	user, _ := models.NewUser("alice", "Alice", "password123")
+	user.Email = "alice@work.example.com"
+
+	client := &scriptedTransactClient{}
+	repo := &DynamoDBRepository{client: client, names: DefaultRepositoryConfig()}
+	if err := repo.UpdateUserEmail(user, "Alice@Example.com"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	items := client.inputs[0].TransactItems
+	if len(items) != 3 {
+		t.Fatalf("Expected the user, the new claim and the released one, got %d items", len(items))
+	}
+	if got := aws.StringValue(items[1].Put.Item["entity_id"].S); got != BuildEmailEntityID("alice@work.example.com") {
+		t.Errorf("Expected the claim on the new address, got %s", got)
+	}
+	if got := aws.StringValue(items[2].Delete.Key["entity_id"].S); got != "EMAIL#alice@example.com" {
+		t.Errorf("Expected the previous address to be released, got %s", got)
+	}
+
+	tests := []struct {
+		name     string
+		script   [][]string
+		expected error
+		attempts int
+	}{
+		{name: "missing user", script: [][]string{{"ConditionalCheckFailed", "None", "None"}}, expected: apperrors.ErrUserNotFound, attempts: 1},
+		{name: "address taken", script: [][]string{{"None", "ConditionalCheckFailed", "None"}}, expected: apperrors.ErrEmailInUse, attempts: 1},
+		{name: "previous address unclaimed", script: [][]string{{"None", "None", "ConditionalCheckFailed"}}, attempts: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &scriptedTransactClient{script: tt.script}
+			repo := &DynamoDBRepository{client: client, names: DefaultRepositoryConfig()}
+
+			if err := repo.UpdateUserEmail(user, "alice@example.com"); !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+			if len(client.inputs) != tt.attempts {
+				t.Errorf("Expected %d transactions, got %d", tt.attempts, len(client.inputs))
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package database
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"github.com/hackmajoris/glad-stack/pkg/logger"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+const (
+	// defaultMaxWriteAttempts is how many times a throttled write is tried before giving up
+	defaultMaxWriteAttempts = 3
+
+	// writeRetryBaseDelay is the backoff before the first retry; it doubles on each further attempt
+	writeRetryBaseDelay = 50 * time.Millisecond
+)
+
+// retrySleep waits d between write attempts, returning ctx.Err() early once ctx is done;
+// tests replace it to avoid real delays
+var retrySleep = func(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// retryableWriteErrors are the error codes worth retrying: throttling and transient server failures
+// Conditional check failures and validation errors are deterministic and never retried.
+var retryableWriteErrors = map[string]bool{
+	dynamodb.ErrCodeProvisionedThroughputExceededException: true,
+	dynamodb.ErrCodeRequestLimitExceeded:                   true,
+	dynamodb.ErrCodeInternalServerError:                    true,
+	"ThrottlingException":                                  true,
+}
+
+// isRetryableWriteError reports whether err is a throttling or transient server error
+func isRetryableWriteError(err error) bool {
+	aerr, ok := err.(awserr.Error)
+	return ok && retryableWriteErrors[aerr.Code()]
+}
+
+// withRetry runs a write, retrying throttling and transient server errors up to maxWriteAttempts
+// times in total with jittered exponential backoff. Any other error is returned immediately.
+// These retries sit on top of the SDK's own, so they only kick in once the SDK has given up.
+// A ctx done during a backoff ends the retries with ctx.Err().
+func (r *DynamoDBRepository) withRetry(ctx context.Context, operation string, op func() error) error {
+	attempts := max(r.maxWriteAttempts, 1)
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = op(); err == nil || !isRetryableWriteError(err) {
+			return err
+		}
+		if attempt == attempts {
+			break
+		}
+
+		delay := backoffDelay(attempt)
+		logger.WithComponent("database").Warn("Retrying throttled DynamoDB write",
+			"operation", operation, "attempt", attempt, "max_attempts", attempts, "delay", delay, "error", err.Error())
+		if err := retrySleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// backoffDelay returns the wait after a failed attempt: half the exponential delay plus up to half again at random
+func backoffDelay(attempt int) time.Duration {
+	delay := writeRetryBaseDelay << (attempt - 1)
+	return delay/2 + rand.N(delay/2+1)
+}
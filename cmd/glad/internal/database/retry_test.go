@@ -0,0 +1,108 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+)
+
+// flakyPutClient fails the first len(failures) PutItem calls with the given error codes, then succeeds
+type flakyPutClient struct {
+	dynamodbiface.DynamoDBAPI
+	failures []string
+	calls    int
+}
+
+func (c *flakyPutClient) PutItemWithContext(_ aws.Context, input *dynamodb.PutItemInput, _ ...request.Option) (*dynamodb.PutItemOutput, error) {
+	c.calls++
+	if c.calls <= len(c.failures) {
+		return nil, awserr.New(c.failures[c.calls-1], "injected failure", nil)
+	}
+	return &dynamodb.PutItemOutput{}, nil
+}
+
+func TestDynamoDBRepository_PutItem_RetriesThrottledWrites(t *testing.T) {
+	var delays []time.Duration
+	original := retrySleep
+	retrySleep = func(_ context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+	t.Cleanup(func() { retrySleep = original })
+
+	throttled := dynamodb.ErrCodeProvisionedThroughputExceededException
+
+	tests := []struct {
+		name          string
+		maxAttempts   int
+		failures      []string
+		expectedCalls int
+		expectedCode  string // empty when the write succeeds
+	}{
+		{name: "fails twice then succeeds", maxAttempts: 3, failures: []string{throttled, dynamodb.ErrCodeInternalServerError}, expectedCalls: 3},
+		{name: "gives up after max attempts", maxAttempts: 3, failures: []string{throttled, throttled, throttled, throttled}, expectedCalls: 3, expectedCode: throttled},
+		{name: "conditional check failure not retried", maxAttempts: 3, failures: []string{dynamodb.ErrCodeConditionalCheckFailedException}, expectedCalls: 1, expectedCode: dynamodb.ErrCodeConditionalCheckFailedException},
+		{name: "zero attempts disables retrying", maxAttempts: 0, failures: []string{throttled}, expectedCalls: 1, expectedCode: throttled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delays = nil
+			client := &flakyPutClient{failures: tt.failures}
+			repo := &DynamoDBRepository{client: client, maxWriteAttempts: tt.maxAttempts}
+
+			_, err := repo.putItem("TestPut", &dynamodb.PutItemInput{TableName: aws.String(DefaultTableName)})
+
+			if client.calls != tt.expectedCalls {
+				t.Errorf("Expected %d PutItem calls, got %d", tt.expectedCalls, client.calls)
+			}
+			if tt.expectedCode == "" {
+				if err != nil {
+					t.Errorf("Expected write to succeed, got %v", err)
+				}
+			} else if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != tt.expectedCode {
+				t.Errorf("Expected %s error, got %v", tt.expectedCode, err)
+			}
+
+			if len(delays) != tt.expectedCalls-1 {
+				t.Fatalf("Expected %d backoff delays, got %v", tt.expectedCalls-1, delays)
+			}
+			for i, delay := range delays {
+				full := writeRetryBaseDelay << i
+				if delay < full/2 || delay > full {
+					t.Errorf("Expected delay %d within [%s, %s], got %s", i+1, full/2, full, delay)
+				}
+			}
+		})
+	}
+}
+
+func TestDynamoDBRepository_PutItem_StopsRetryingWhenContextDone(t *testing.T) {
+	throttled := dynamodb.ErrCodeProvisionedThroughputExceededException
+	client := &flakyPutClient{failures: []string{throttled, throttled, throttled}}
+	repo := &DynamoDBRepository{client: client, maxWriteAttempts: 3}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	begin := time.Now()
+	_, err := repo.putItemWithContext(ctx, "TestPut", &dynamodb.PutItemInput{TableName: aws.String(DefaultTableName)})
+
+	if client.calls != 1 {
+		t.Errorf("Expected no retry once the context is done, got %d PutItem calls", client.calls)
+	}
+	if !errors.Is(err, apperrors.ErrTimeout) {
+		t.Errorf("Expected ErrTimeout, got %v", err)
+	}
+	if elapsed := time.Since(begin); elapsed >= writeRetryBaseDelay/2 {
+		t.Errorf("Expected the backoff to be cut short, waited %s", elapsed)
+	}
+}
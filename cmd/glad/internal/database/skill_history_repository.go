@@ -0,0 +1,14 @@
+package database
+
+import "github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
+
+// SkillHistoryRepository defines operations for user skill proficiency history
+type SkillHistoryRepository interface {
+	CreateSkillHistory(history *models.SkillHistory) error
+	// ListSkillHistory returns the history entries for a user's skill, newest first
+	ListSkillHistory(username, skillID string) ([]*models.SkillHistory, error)
+	// ListSkillHistoryForUser returns the history entries for all of a user's skills
+	ListSkillHistoryForUser(username string) ([]*models.SkillHistory, error)
+	// DeleteSkillHistoryForUser removes the history entries for all of a user's skills and returns how many were removed
+	DeleteSkillHistoryForUser(username string) (int, error)
+}
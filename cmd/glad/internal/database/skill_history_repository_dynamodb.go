@@ -0,0 +1,140 @@
+package database
+
+import (
+	"time"
+
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
+	"github.com/hackmajoris/glad-stack/pkg/logger"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+)
+
+// CreateSkillHistory inserts a new skill history entry into DynamoDB
+func (r *DynamoDBRepository) CreateSkillHistory(history *models.SkillHistory) error {
+	log := logger.WithComponent("database").With("operation", "CreateSkillHistory", "username", history.Username, "skill_id", history.SkillID)
+	start := time.Now()
+
+	log.Debug("Starting skill history creation")
+
+	// Ensure keys are set
+	history.SetKeys()
+
+	item, err := dynamodbattribute.MarshalMap(history)
+	if err != nil {
+		log.Error("Failed to marshal skill history data", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
+
+	input := &dynamodb.PutItemInput{
+		TableName:           aws.String(r.names.TableName),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(entity_id)"),
+	}
+
+	if _, err := r.putItem("CreateSkillHistory", input); err != nil {
+		log.Error("Failed to create skill history in DynamoDB", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
+
+	log.Info("Skill history created successfully", "old_level", history.OldLevel, "new_level", history.NewLevel, "duration", time.Since(start))
+	return nil
+}
+
+// ListSkillHistory retrieves the history entries for a user's skill, newest first
+// Queries the base table on the EntityType partition with a begins_with condition on entity_id;
+// timestamps in the entity_id sort chronologically, so a descending scan yields newest first.
+func (r *DynamoDBRepository) ListSkillHistory(username, skillID string) ([]*models.SkillHistory, error) {
+	log := logger.WithComponent("database").With("operation", "ListSkillHistory", "username", username, "skill_id", skillID)
+	start := time.Now()
+
+	log.Debug("Starting skill history retrieval")
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(r.names.TableName),
+		KeyConditionExpression: aws.String("EntityType = :entityType AND begins_with(entity_id, :prefix)"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":entityType": {S: aws.String("SkillHistory")},
+			":prefix":     {S: aws.String(models.BuildSkillHistoryPrefix(username, skillID))},
+		},
+		ScanIndexForward: aws.Bool(false),
+	}
+
+	result, err := r.query("ListSkillHistory", input)
+	if err != nil {
+		log.Error("Failed to query skill history", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	var entries []*models.SkillHistory
+	for i, item := range result.Items {
+		var entry models.SkillHistory
+		if err := dynamodbattribute.UnmarshalMap(item, &entry); err != nil {
+			log.Error("Failed to unmarshal skill history data", "error", err.Error(), "item_index", i, "duration", time.Since(start))
+			continue
+		}
+		entries = append(entries, &entry)
+	}
+
+	log.Info("Skill history retrieved successfully", "count", len(entries), "duration", time.Since(start))
+	return entries, nil
+}
+
+// ListSkillHistoryForUser retrieves the history entries for all of a user's skills
+func (r *DynamoDBRepository) ListSkillHistoryForUser(username string) ([]*models.SkillHistory, error) {
+	log := logger.WithComponent("database").With("operation", "ListSkillHistoryForUser", "username", username)
+	start := time.Now()
+
+	log.Debug("Starting user skill history retrieval")
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(r.names.TableName),
+		KeyConditionExpression: aws.String("EntityType = :entityType AND begins_with(entity_id, :prefix)"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":entityType": {S: aws.String("SkillHistory")},
+			":prefix":     {S: aws.String(models.BuildUserSkillHistoryPrefix(username))},
+		},
+	}
+
+	var entries []*models.SkillHistory
+	for {
+		result, err := r.query("ListSkillHistoryForUser", input)
+		if err != nil {
+			log.Error("Failed to query skill history", "error", err.Error(), "duration", time.Since(start))
+			return nil, err
+		}
+		for i, item := range result.Items {
+			var entry models.SkillHistory
+			if err := dynamodbattribute.UnmarshalMap(item, &entry); err != nil {
+				log.Error("Failed to unmarshal skill history data", "error", err.Error(), "item_index", i, "duration", time.Since(start))
+				continue
+			}
+			entries = append(entries, &entry)
+		}
+		if len(result.LastEvaluatedKey) == 0 {
+			break
+		}
+		input.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+
+	log.Info("User skill history retrieved successfully", "count", len(entries), "duration", time.Since(start))
+	return entries, nil
+}
+
+// DeleteSkillHistoryForUser removes the history entries for all of a user's skills
+func (r *DynamoDBRepository) DeleteSkillHistoryForUser(username string) (int, error) {
+	log := logger.WithComponent("database").With("operation", "DeleteSkillHistoryForUser", "username", username)
+	start := time.Now()
+
+	log.Debug("Starting user skill history deletion")
+
+	deleted, err := r.deleteByPrefix("DeleteSkillHistory", "SkillHistory", models.BuildUserSkillHistoryPrefix(username))
+	if err != nil {
+		log.Error("Failed to delete skill history", "error", err.Error(), "duration", time.Since(start))
+		return 0, err
+	}
+
+	log.Info("User skill history deleted successfully", "count", deleted, "duration", time.Since(start))
+	return deleted, nil
+}
@@ -0,0 +1,94 @@
+package database
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
+	"github.com/hackmajoris/glad-stack/pkg/logger"
+)
+
+// CreateSkillHistory stores a skill history entry in memory
+func (m *MockRepository) CreateSkillHistory(history *models.SkillHistory) error {
+	log := logger.WithComponent("database").With("operation", "CreateSkillHistory", "username", history.Username, "skill_id", history.SkillID, "repository", "mock")
+	start := time.Now()
+
+	log.Debug("Starting skill history creation in mock repository")
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	history.SetKeys()
+	m.histories[history.EntityID] = history
+
+	log.Info("Skill history created successfully in mock repository", "old_level", history.OldLevel, "new_level", history.NewLevel, "duration", time.Since(start))
+	return nil
+}
+
+// ListSkillHistory retrieves the history entries for a user's skill from memory, newest first
+func (m *MockRepository) ListSkillHistory(username, skillID string) ([]*models.SkillHistory, error) {
+	log := logger.WithComponent("database").With("operation", "ListSkillHistory", "username", username, "skill_id", skillID, "repository", "mock")
+	start := time.Now()
+
+	log.Debug("Starting skill history retrieval from mock repository")
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	prefix := models.BuildSkillHistoryPrefix(username, skillID)
+	var entries []*models.SkillHistory
+	for entityID, entry := range m.histories {
+		if strings.HasPrefix(entityID, prefix) {
+			entries = append(entries, entry)
+		}
+	}
+
+	// Match the DynamoDB descending sort on entity_id
+	sort.Slice(entries, func(i, j int) bool { return entries[i].EntityID > entries[j].EntityID })
+
+	log.Info("Skill history retrieved successfully from mock repository", "count", len(entries), "duration", time.Since(start))
+	return entries, nil
+}
+
+// ListSkillHistoryForUser retrieves the history entries for all of a user's skills from memory
+func (m *MockRepository) ListSkillHistoryForUser(username string) ([]*models.SkillHistory, error) {
+	log := logger.WithComponent("database").With("operation", "ListSkillHistoryForUser", "username", username, "repository", "mock")
+	start := time.Now()
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	prefix := models.BuildUserSkillHistoryPrefix(username)
+	var entries []*models.SkillHistory
+	for entityID, entry := range m.histories {
+		if strings.HasPrefix(entityID, prefix) {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].EntityID < entries[j].EntityID })
+
+	log.Info("User skill history retrieved successfully from mock repository", "count", len(entries), "duration", time.Since(start))
+	return entries, nil
+}
+
+// DeleteSkillHistoryForUser removes the history entries for all of a user's skills from memory
+func (m *MockRepository) DeleteSkillHistoryForUser(username string) (int, error) {
+	log := logger.WithComponent("database").With("operation", "DeleteSkillHistoryForUser", "username", username, "repository", "mock")
+	start := time.Now()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	prefix := models.BuildUserSkillHistoryPrefix(username)
+	deleted := 0
+	for entityID := range m.histories {
+		if strings.HasPrefix(entityID, prefix) {
+			delete(m.histories, entityID)
+			deleted++
+		}
+	}
+
+	log.Info("User skill history deleted successfully from mock repository", "count", deleted, "duration", time.Since(start))
+	return deleted, nil
+}
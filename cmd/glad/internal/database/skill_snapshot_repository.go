@@ -0,0 +1,12 @@
+package database
+
+import "github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
+
+// SkillSnapshotRepository defines operations for point-in-time skill snapshots
+type SkillSnapshotRepository interface {
+	CreateSkillSnapshot(snapshot *models.SkillSnapshot) error
+	GetSkillSnapshot(username, snapshotID string) (*models.SkillSnapshot, error)
+	ListSkillSnapshots(username string) ([]*models.SkillSnapshot, error)
+	// DeleteSkillSnapshotsForUser removes all of a user's snapshots and returns how many were removed
+	DeleteSkillSnapshotsForUser(username string) (int, error)
+}
@@ -0,0 +1,138 @@
+package database
+
+import (
+	"time"
+
+	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
+	"github.com/hackmajoris/glad-stack/pkg/logger"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+)
+
+// CreateSkillSnapshot inserts a new skill snapshot into DynamoDB
+func (r *DynamoDBRepository) CreateSkillSnapshot(snapshot *models.SkillSnapshot) error {
+	log := logger.WithComponent("database").With("operation", "CreateSkillSnapshot", "username", snapshot.Username, "snapshot_id", snapshot.SnapshotID)
+	start := time.Now()
+
+	log.Debug("Starting skill snapshot creation")
+
+	// Ensure keys are set
+	snapshot.SetKeys()
+
+	item, err := dynamodbattribute.MarshalMap(snapshot)
+	if err != nil {
+		log.Error("Failed to marshal snapshot data", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
+
+	input := &dynamodb.PutItemInput{
+		TableName:           aws.String(r.names.TableName),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(entity_id)"),
+	}
+
+	if _, err := r.putItem("CreateSkillSnapshot", input); err != nil {
+		log.Error("Failed to create skill snapshot in DynamoDB", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
+
+	log.Info("Skill snapshot created successfully", "skills", len(snapshot.Skills), "duration", time.Since(start))
+	return nil
+}
+
+// GetSkillSnapshot retrieves a skill snapshot for a user
+func (r *DynamoDBRepository) GetSkillSnapshot(username, snapshotID string) (*models.SkillSnapshot, error) {
+	log := logger.WithComponent("database").With("operation", "GetSkillSnapshot", "username", username, "snapshot_id", snapshotID)
+	start := time.Now()
+
+	log.Debug("Starting skill snapshot retrieval")
+
+	input := &dynamodb.GetItemInput{
+		TableName: aws.String(r.names.TableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"EntityType": {S: aws.String("SkillSnapshot")},
+			"entity_id":  {S: aws.String(models.BuildSkillSnapshotEntityID(username, snapshotID))},
+		},
+	}
+
+	result, err := r.getItem("GetSkillSnapshot", input)
+	if err != nil {
+		log.Error("Failed to get skill snapshot from DynamoDB", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	if result.Item == nil {
+		log.Debug("Skill snapshot not found", "duration", time.Since(start))
+		return nil, apperrors.ErrSnapshotNotFound
+	}
+
+	var snapshot models.SkillSnapshot
+	if err := dynamodbattribute.UnmarshalMap(result.Item, &snapshot); err != nil {
+		log.Error("Failed to unmarshal snapshot data", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	log.Debug("Skill snapshot retrieved successfully", "duration", time.Since(start))
+	return &snapshot, nil
+}
+
+// ListSkillSnapshots retrieves all snapshots for a user
+func (r *DynamoDBRepository) ListSkillSnapshots(username string) ([]*models.SkillSnapshot, error) {
+	log := logger.WithComponent("database").With("operation", "ListSkillSnapshots", "username", username)
+	start := time.Now()
+
+	log.Debug("Starting skill snapshot list retrieval")
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(r.names.TableName),
+		KeyConditionExpression: aws.String("EntityType = :entityType AND begins_with(entity_id, :prefix)"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":entityType": {S: aws.String("SkillSnapshot")},
+			":prefix":     {S: aws.String(models.BuildUserSnapshotPrefix(username))},
+		},
+	}
+
+	var snapshots []*models.SkillSnapshot
+	for {
+		result, err := r.query("ListSkillSnapshots", input)
+		if err != nil {
+			log.Error("Failed to query skill snapshots", "error", err.Error(), "duration", time.Since(start))
+			return nil, err
+		}
+		for i, item := range result.Items {
+			var snapshot models.SkillSnapshot
+			if err := dynamodbattribute.UnmarshalMap(item, &snapshot); err != nil {
+				log.Error("Failed to unmarshal snapshot data", "error", err.Error(), "item_index", i, "duration", time.Since(start))
+				continue
+			}
+			snapshots = append(snapshots, &snapshot)
+		}
+		if len(result.LastEvaluatedKey) == 0 {
+			break
+		}
+		input.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+
+	log.Debug("Skill snapshots retrieved successfully", "count", len(snapshots), "duration", time.Since(start))
+	return snapshots, nil
+}
+
+// DeleteSkillSnapshotsForUser removes all snapshots for a user
+func (r *DynamoDBRepository) DeleteSkillSnapshotsForUser(username string) (int, error) {
+	log := logger.WithComponent("database").With("operation", "DeleteSkillSnapshotsForUser", "username", username)
+	start := time.Now()
+
+	log.Debug("Starting skill snapshot deletion")
+
+	deleted, err := r.deleteByPrefix("DeleteSkillSnapshots", "SkillSnapshot", models.BuildUserSnapshotPrefix(username))
+	if err != nil {
+		log.Error("Failed to delete skill snapshots", "error", err.Error(), "duration", time.Since(start))
+		return 0, err
+	}
+
+	log.Info("Skill snapshots deleted successfully", "count", deleted, "duration", time.Since(start))
+	return deleted, nil
+}
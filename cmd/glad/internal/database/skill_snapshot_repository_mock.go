@@ -0,0 +1,90 @@
+package database
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
+	"github.com/hackmajoris/glad-stack/pkg/logger"
+)
+
+// CreateSkillSnapshot stores a skill snapshot in memory
+func (m *MockRepository) CreateSkillSnapshot(snapshot *models.SkillSnapshot) error {
+	log := logger.WithComponent("database").With("operation", "CreateSkillSnapshot", "username", snapshot.Username, "snapshot_id", snapshot.SnapshotID, "repository", "mock")
+	start := time.Now()
+
+	log.Debug("Starting skill snapshot creation in mock repository")
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	key := models.BuildSkillSnapshotEntityID(snapshot.Username, snapshot.SnapshotID)
+	m.snapshots[key] = snapshot
+
+	log.Info("Skill snapshot created successfully in mock repository", "skills", len(snapshot.Skills), "duration", time.Since(start))
+	return nil
+}
+
+// GetSkillSnapshot retrieves a skill snapshot from memory
+func (m *MockRepository) GetSkillSnapshot(username, snapshotID string) (*models.SkillSnapshot, error) {
+	log := logger.WithComponent("database").With("operation", "GetSkillSnapshot", "username", username, "snapshot_id", snapshotID, "repository", "mock")
+	start := time.Now()
+
+	log.Debug("Starting skill snapshot retrieval from mock repository")
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	snapshot, exists := m.snapshots[models.BuildSkillSnapshotEntityID(username, snapshotID)]
+	if !exists {
+		log.Debug("Skill snapshot not found in mock repository", "duration", time.Since(start))
+		return nil, apperrors.ErrSnapshotNotFound
+	}
+
+	log.Debug("Skill snapshot retrieved successfully from mock repository", "duration", time.Since(start))
+	return snapshot, nil
+}
+
+// ListSkillSnapshots retrieves all snapshots for a user from memory
+func (m *MockRepository) ListSkillSnapshots(username string) ([]*models.SkillSnapshot, error) {
+	log := logger.WithComponent("database").With("operation", "ListSkillSnapshots", "username", username, "repository", "mock")
+	start := time.Now()
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	prefix := models.BuildUserSnapshotPrefix(username)
+	var snapshots []*models.SkillSnapshot
+	for key, snapshot := range m.snapshots {
+		if strings.HasPrefix(key, prefix) {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].SnapshotID < snapshots[j].SnapshotID })
+
+	log.Debug("Skill snapshots retrieved successfully from mock repository", "count", len(snapshots), "duration", time.Since(start))
+	return snapshots, nil
+}
+
+// DeleteSkillSnapshotsForUser removes all snapshots for a user from memory
+func (m *MockRepository) DeleteSkillSnapshotsForUser(username string) (int, error) {
+	log := logger.WithComponent("database").With("operation", "DeleteSkillSnapshotsForUser", "username", username, "repository", "mock")
+	start := time.Now()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	prefix := models.BuildUserSnapshotPrefix(username)
+	deleted := 0
+	for key := range m.snapshots {
+		if strings.HasPrefix(key, prefix) {
+			delete(m.snapshots, key)
+			deleted++
+		}
+	}
+
+	log.Info("Skill snapshots deleted successfully from mock repository", "count", deleted, "duration", time.Since(start))
+	return deleted, nil
+}
@@ -1,12 +1,41 @@
 package database
 
-import "github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
+import (
+	"context"
+	"errors"
+
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
+)
+
+// ErrUserChanged reports that a user no longer held the values a backfill read
+var ErrUserChanged = errors.New("user changed concurrently")
 
 // UserRepository defines the interface for user data operations
+// Every user with an email holds a claim on the address, ignoring case, which no other user can
+// take; deleting the user releases it.
 type UserRepository interface {
 	CreateUser(user *models.User) error
 	GetUser(username string) (*models.User, error)
+	// GetUserWithContext is GetUser bounded by ctx, failing with ErrTimeout once ctx is done
+	GetUserWithContext(ctx context.Context, username string) (*models.User, error)
 	UpdateUser(user *models.User) error
+	// UpdateUserEmail is UpdateUser for a user whose email changed from previousEmail, moving the
+	// claim on the address in the same write; an address another user holds fails with ErrEmailInUse
+	UpdateUserEmail(user *models.User, previousEmail string) error
+	// ClaimEmail stores the claim on the email of a user written before claims existed if the
+	// user's email is unchanged since it was read, failing with ErrUserChanged otherwise
+	ClaimEmail(user *models.User) error
+	// EmailOwner returns the username holding the claim on email, or "" if nobody does
+	EmailOwner(email string) (string, error)
 	UserExists(username string) (bool, error)
 	ListUsers() ([]*models.User, error)
+	// SearchUsers returns users whose username or name contains query, ignoring case
+	SearchUsers(query string) ([]*models.User, error)
+	// SetSearchNames stores the lowercased username and name as the user's UsernameLower and
+	// NameLower if the user's name is still name. Any other name, or a missing user, fails with
+	// ErrUserChanged; the write that changed it has set both attributes already.
+	SetSearchNames(username, name string) error
+	// DeleteUserCascade removes the user and all of their skills, releasing the claim on their email
+	// Skills are removed before the user, so re-running after a partial failure completes the deletion.
+	DeleteUserCascade(username string) error
 }
@@ -1,6 +1,11 @@
 package database
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
@@ -29,13 +34,17 @@ func (r *DynamoDBRepository) CreateUser(user *models.User) error {
 	}
 
 	input := &dynamodb.PutItemInput{
-		TableName:           aws.String(TableName),
+		TableName:           aws.String(r.names.TableName),
 		Item:                item,
 		ConditionExpression: aws.String("attribute_not_exists(entity_id)"),
 	}
 
-	_, err = r.client.PutItem(input)
+	_, err = r.putItem("CreateUser", input)
 	if err != nil {
+		if isConditionFailed(err) {
+			log.Info("User already exists", "duration", time.Since(start))
+			return apperrors.ErrUserExists
+		}
 		log.Error("Failed to create user in DynamoDB", "error", err.Error(), "duration", time.Since(start))
 		return err
 	}
@@ -46,23 +55,28 @@ func (r *DynamoDBRepository) CreateUser(user *models.User) error {
 
 // GetUser retrieves a user by username from DynamoDB
 func (r *DynamoDBRepository) GetUser(username string) (*models.User, error) {
+	return r.GetUserWithContext(context.Background(), username)
+}
+
+// GetUserWithContext is GetUser bounded by ctx
+func (r *DynamoDBRepository) GetUserWithContext(ctx context.Context, username string) (*models.User, error) {
 	log := logger.WithComponent("database").With("operation", "GetUser", "username", username)
 	start := time.Now()
 
 	log.Debug("Starting user retrieval")
 
 	entityID := models.BuildUserEntityID(username)
-	log.Info("Attempting to retrieve user", "entity_id", entityID, "table", TableName)
+	log.Info("Attempting to retrieve user", "entity_id", entityID, "table", r.names.TableName)
 
 	input := &dynamodb.GetItemInput{
-		TableName: aws.String(TableName),
+		TableName: aws.String(r.names.TableName),
 		Key: map[string]*dynamodb.AttributeValue{
 			"EntityType": {S: aws.String("User")},
 			"entity_id":  {S: aws.String(entityID)},
 		},
 	}
 
-	result, err := r.client.GetItem(input)
+	result, err := r.getItemWithContext(ctx, "GetUser", input)
 	if err != nil {
 		log.Error("Failed to get user from DynamoDB", "error", err.Error(), "entity_id", entityID, "duration", time.Since(start))
 		return nil, err
@@ -94,7 +108,7 @@ func (r *DynamoDBRepository) UserExists(username string) (bool, error) {
 	entityID := models.BuildUserEntityID(username)
 
 	input := &dynamodb.GetItemInput{
-		TableName: aws.String(TableName),
+		TableName: aws.String(r.names.TableName),
 		Key: map[string]*dynamodb.AttributeValue{
 			"EntityType": {S: aws.String("User")},
 			"entity_id":  {S: aws.String(entityID)},
@@ -102,7 +116,7 @@ func (r *DynamoDBRepository) UserExists(username string) (bool, error) {
 		ProjectionExpression: aws.String("entity_id"),
 	}
 
-	result, err := r.client.GetItem(input)
+	result, err := r.getItem("UserExists", input)
 	if err != nil {
 		log.Error("Failed to check user existence", "error", err.Error(), "duration", time.Since(start))
 		return false, err
@@ -114,6 +128,8 @@ func (r *DynamoDBRepository) UserExists(username string) (bool, error) {
 }
 
 // UpdateUser updates an existing user in DynamoDB
+// It sets the user's attributes in place instead of replacing the item, so the SkillCount kept by
+// concurrent skill writes is never overwritten with the caller's possibly stale copy.
 func (r *DynamoDBRepository) UpdateUser(user *models.User) error {
 	log := logger.WithComponent("database").With("operation", "UpdateUser", "username", user.Username)
 	start := time.Now()
@@ -130,14 +146,25 @@ func (r *DynamoDBRepository) UpdateUser(user *models.User) error {
 		return err
 	}
 
-	input := &dynamodb.PutItemInput{
-		TableName:           aws.String(TableName),
-		Item:                item,
-		ConditionExpression: aws.String("attribute_exists(entity_id)"),
+	expression, names, values := userUpdateExpression(item)
+	input := &dynamodb.UpdateItemInput{
+		TableName: aws.String(r.names.TableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"EntityType": {S: aws.String("User")},
+			"entity_id":  {S: aws.String(user.EntityID)},
+		},
+		UpdateExpression:          aws.String(expression),
+		ConditionExpression:       aws.String("attribute_exists(entity_id)"),
+		ExpressionAttributeNames:  names,
+		ExpressionAttributeValues: values,
 	}
 
-	_, err = r.client.PutItem(input)
+	_, err = r.updateItem("UpdateUser", input)
 	if err != nil {
+		if isConditionFailed(err) {
+			log.Info("User not found for update", "duration", time.Since(start))
+			return apperrors.ErrUserNotFound
+		}
 		log.Error("Failed to update user in DynamoDB", "error", err.Error(), "duration", time.Since(start))
 		return err
 	}
@@ -146,6 +173,217 @@ func (r *DynamoDBRepository) UpdateUser(user *models.User) error {
 	return nil
 }
 
+// UpdateUserEmail updates a user like UpdateUser, claiming their new email and releasing
+// previousEmail in the same transaction. A previous address the user holds no claim on, as for
+// emails set before claims existed, is left to whichever user holds it.
+func (r *DynamoDBRepository) UpdateUserEmail(user *models.User, previousEmail string) error {
+	log := logger.WithComponent("database").With("operation", "UpdateUserEmail", "username", user.Username)
+	start := time.Now()
+
+	log.Debug("Starting user email update")
+
+	user.SetKeys()
+	user.UpdatedAt = time.Now()
+
+	item, err := dynamodbattribute.MarshalMap(user)
+	if err != nil {
+		log.Error("Failed to marshal user data for update", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
+
+	expression, names, values := userUpdateExpression(item)
+	release := previousEmail != "" && !strings.EqualFold(previousEmail, user.Email)
+	for {
+		items := []*dynamodb.TransactWriteItem{
+			{
+				Update: &dynamodb.Update{
+					TableName:                 aws.String(r.names.TableName),
+					Key:                       r.itemKey("User", user.EntityID),
+					UpdateExpression:          aws.String(expression),
+					ConditionExpression:       aws.String("attribute_exists(entity_id)"),
+					ExpressionAttributeNames:  names,
+					ExpressionAttributeValues: values,
+				},
+			},
+		}
+		claimIndex, releaseIndex := -1, -1
+		if user.Email != "" {
+			claimIndex = len(items)
+			items = append(items, r.claimEmailItem(user.Username, user.Email))
+		}
+		if release {
+			releaseIndex = len(items)
+			items = append(items, r.releaseEmailItem(user.Username, previousEmail))
+		}
+
+		_, err = r.transactWriteItemsWithContext(context.Background(), "UpdateUserEmail", &dynamodb.TransactWriteItemsInput{TransactItems: items})
+		if err == nil {
+			break
+		}
+
+		conditions := failedConditions(err)
+		switch {
+		case conditions[0]:
+			log.Info("User not found for update", "duration", time.Since(start))
+			return apperrors.ErrUserNotFound
+		case conditions[claimIndex]:
+			log.Info("Email already claimed by another user", "duration", time.Since(start))
+			return apperrors.ErrEmailInUse
+		case conditions[releaseIndex]:
+			// Another user holds the previous address, so there is no claim to release
+			release = false
+			continue
+		}
+		log.Error("Failed to update user email in DynamoDB", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
+
+	log.Info("User email updated successfully", "duration", time.Since(start))
+	return nil
+}
+
+// claimEmailItem puts the claim on email for username unless another user holds it
+func (r *DynamoDBRepository) claimEmailItem(username, email string) *dynamodb.TransactWriteItem {
+	item := r.itemKey("Email", BuildEmailEntityID(email))
+	item["Username"] = &dynamodb.AttributeValue{S: aws.String(username)}
+
+	return &dynamodb.TransactWriteItem{Put: &dynamodb.Put{
+		TableName:           aws.String(r.names.TableName),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(entity_id) OR Username = :username"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":username": {S: aws.String(username)},
+		},
+	}}
+}
+
+// releaseEmailItem deletes the claim on email, on the condition that username holds it
+func (r *DynamoDBRepository) releaseEmailItem(username, email string) *dynamodb.TransactWriteItem {
+	return &dynamodb.TransactWriteItem{Delete: &dynamodb.Delete{
+		TableName:           aws.String(r.names.TableName),
+		Key:                 r.itemKey("Email", BuildEmailEntityID(email)),
+		ConditionExpression: aws.String("Username = :username"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":username": {S: aws.String(username)},
+		},
+	}}
+}
+
+// ClaimEmail puts the claim on a user's email, on the condition that the stored user still has it
+func (r *DynamoDBRepository) ClaimEmail(user *models.User) error {
+	log := logger.WithComponent("database").With("operation", "ClaimEmail", "username", user.Username)
+	start := time.Now()
+
+	input := &dynamodb.TransactWriteItemsInput{
+		TransactItems: []*dynamodb.TransactWriteItem{
+			{
+				ConditionCheck: &dynamodb.ConditionCheck{
+					TableName:           aws.String(r.names.TableName),
+					Key:                 r.itemKey("User", BuildUserEntityID(user.Username)),
+					ConditionExpression: aws.String("Email = :email"),
+					ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+						":email": {S: aws.String(user.Email)},
+					},
+				},
+			},
+			r.claimEmailItem(user.Username, user.Email),
+		},
+	}
+
+	if _, err := r.transactWriteItemsWithContext(context.Background(), "ClaimEmail", input); err != nil {
+		if failed, ok := failedCondition(err); ok {
+			if failed == 0 {
+				log.Info("User changed before their email was claimed", "duration", time.Since(start))
+				return ErrUserChanged
+			}
+			log.Info("Email already claimed by another user", "duration", time.Since(start))
+			return apperrors.ErrEmailInUse
+		}
+		log.Error("Failed to claim email", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
+
+	log.Debug("Email claimed", "duration", time.Since(start))
+	return nil
+}
+
+// EmailOwner reads the claim on email and returns the username it holds
+func (r *DynamoDBRepository) EmailOwner(email string) (string, error) {
+	log := logger.WithComponent("database").With("operation", "EmailOwner")
+	start := time.Now()
+
+	input := &dynamodb.GetItemInput{
+		TableName:      aws.String(r.names.TableName),
+		Key:            r.itemKey("Email", BuildEmailEntityID(email)),
+		ConsistentRead: aws.Bool(true),
+	}
+
+	result, err := r.getItem("EmailOwner", input)
+	if err != nil {
+		log.Error("Failed to get email claim from DynamoDB", "error", err.Error(), "duration", time.Since(start))
+		return "", err
+	}
+	if result.Item == nil {
+		log.Debug("Email unclaimed", "duration", time.Since(start))
+		return "", nil
+	}
+
+	var claim struct {
+		Username string `dynamodbav:"Username"`
+	}
+	if err := dynamodbattribute.UnmarshalMap(result.Item, &claim); err != nil {
+		log.Error("Failed to unmarshal email claim", "error", err.Error(), "duration", time.Since(start))
+		return "", err
+	}
+
+	log.Debug("Email claim retrieved", "duration", time.Since(start))
+	return claim.Username, nil
+}
+
+// userUpdateSkipped lists the attributes UpdateUser never writes: the keys, which cannot change,
+// and SkillCount, which only the atomic ADD alongside each skill write maintains
+var userUpdateSkipped = map[string]bool{"EntityType": true, "entity_id": true, "SkillCount": true}
+
+// userOptionalAttributes are left out of a marshalled user when empty, so UpdateUser removes them
+var userOptionalAttributes = []string{"Email", "Status"}
+
+// userUpdateExpression builds an update that sets every attribute of a marshalled user except
+// the skipped ones and removes the optional attributes it leaves out
+func userUpdateExpression(item map[string]*dynamodb.AttributeValue) (string, map[string]*string, map[string]*dynamodb.AttributeValue) {
+	attributes := make([]string, 0, len(item))
+	for name := range item {
+		if !userUpdateSkipped[name] {
+			attributes = append(attributes, name)
+		}
+	}
+	sort.Strings(attributes)
+
+	names := make(map[string]*string, len(attributes))
+	values := make(map[string]*dynamodb.AttributeValue, len(attributes))
+	sets := make([]string, len(attributes))
+	for i, name := range attributes {
+		placeholder := fmt.Sprintf("a%d", i)
+		names["#"+placeholder] = aws.String(name)
+		values[":"+placeholder] = item[name]
+		sets[i] = fmt.Sprintf("#%s = :%s", placeholder, placeholder)
+	}
+	expression := "SET " + strings.Join(sets, ", ")
+
+	var removes []string
+	for i, name := range userOptionalAttributes {
+		if _, ok := item[name]; !ok {
+			placeholder := fmt.Sprintf("#r%d", i)
+			names[placeholder] = aws.String(name)
+			removes = append(removes, placeholder)
+		}
+	}
+	if len(removes) > 0 {
+		expression += " REMOVE " + strings.Join(removes, ", ")
+	}
+
+	return expression, names, values
+}
+
 // ListUsers retrieves all users from DynamoDB using Query on ByEntityType GSI
 func (r *DynamoDBRepository) ListUsers() ([]*models.User, error) {
 	log := logger.WithComponent("database").With("operation", "ListUsers")
@@ -154,29 +392,188 @@ func (r *DynamoDBRepository) ListUsers() ([]*models.User, error) {
 	log.Debug("Starting users list retrieval")
 
 	input := &dynamodb.QueryInput{
-		TableName:              aws.String(TableName),
+		TableName:              aws.String(r.names.TableName),
 		KeyConditionExpression: aws.String("EntityType = :entityType"),
 		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
 			":entityType": {S: aws.String("User")},
 		},
 	}
 
-	result, err := r.client.Query(input)
-	if err != nil {
-		log.Error("Failed to query users table", "error", err.Error(), "duration", time.Since(start))
-		return nil, err
+	var users []*models.User
+	for {
+		result, err := r.query("ListUsers", input)
+		if err != nil {
+			log.Error("Failed to query users table", "error", err.Error(), "duration", time.Since(start))
+			return nil, err
+		}
+
+		for i, item := range result.Items {
+			var user models.User
+			if err := dynamodbattribute.UnmarshalMap(item, &user); err != nil {
+				log.Error("Failed to unmarshal user data", "error", err.Error(), "item_index", i, "duration", time.Since(start))
+				return nil, err
+			}
+			users = append(users, &user)
+		}
+
+		if len(result.LastEvaluatedKey) == 0 {
+			break
+		}
+		input.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+
+	log.Info("Users retrieved successfully", "count", len(users), "duration", time.Since(start))
+	return users, nil
+}
+
+// SearchUsers retrieves users whose username or name contains query, ignoring case
+// The User partition is queried with a contains filter on the lowercased copies SetKeys
+// maintains, reading every page since DynamoDB filters each page after reading it. Users written
+// before those attributes existed match once the user-search-names backfill has run.
+func (r *DynamoDBRepository) SearchUsers(query string) ([]*models.User, error) {
+	log := logger.WithComponent("database").With("operation", "SearchUsers", "query", query)
+	start := time.Now()
+
+	log.Debug("Starting user search")
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(r.names.TableName),
+		KeyConditionExpression: aws.String("EntityType = :entityType"),
+		FilterExpression:       aws.String("contains(UsernameLower, :query) OR contains(NameLower, :query)"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":entityType": {S: aws.String("User")},
+			":query":      {S: aws.String(strings.ToLower(query))},
+		},
 	}
 
 	var users []*models.User
-	for i, item := range result.Items {
-		var user models.User
-		if err := dynamodbattribute.UnmarshalMap(item, &user); err != nil {
-			log.Error("Failed to unmarshal user data", "error", err.Error(), "item_index", i, "duration", time.Since(start))
+	for {
+		result, err := r.query("SearchUsers", input)
+		if err != nil {
+			log.Error("Failed to search users", "error", err.Error(), "duration", time.Since(start))
 			return nil, err
 		}
-		users = append(users, &user)
+
+		for i, item := range result.Items {
+			var user models.User
+			if err := dynamodbattribute.UnmarshalMap(item, &user); err != nil {
+				log.Error("Failed to unmarshal user data", "error", err.Error(), "item_index", i, "duration", time.Since(start))
+				return nil, err
+			}
+			users = append(users, &user)
+		}
+
+		if len(result.LastEvaluatedKey) == 0 {
+			break
+		}
+		input.ExclusiveStartKey = result.LastEvaluatedKey
 	}
 
-	log.Info("Users retrieved successfully", "count", len(users), "duration", time.Since(start))
+	log.Info("User search completed", "count", len(users), "duration", time.Since(start))
 	return users, nil
 }
+
+// SetSearchNames sets UsernameLower and NameLower in place, on the condition that Name is unchanged
+func (r *DynamoDBRepository) SetSearchNames(username, name string) error {
+	log := logger.WithComponent("database").With("operation", "SetSearchNames", "username", username)
+	start := time.Now()
+
+	input := &dynamodb.UpdateItemInput{
+		TableName:                aws.String(r.names.TableName),
+		Key:                      r.itemKey("User", BuildUserEntityID(username)),
+		UpdateExpression:         aws.String("SET UsernameLower = :usernameLower, NameLower = :nameLower"),
+		ConditionExpression:      aws.String("#name = :name"),
+		ExpressionAttributeNames: map[string]*string{"#name": aws.String("Name")},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":usernameLower": {S: aws.String(strings.ToLower(username))},
+			":nameLower":     {S: aws.String(strings.ToLower(name))},
+			":name":          {S: aws.String(name)},
+		},
+	}
+
+	if _, err := r.updateItem("SetSearchNames", input); err != nil {
+		if isConditionFailed(err) {
+			log.Info("User changed before its search names were set", "duration", time.Since(start))
+			return ErrUserChanged
+		}
+		log.Error("Failed to set search names", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
+
+	log.Debug("Search names set", "duration", time.Since(start))
+	return nil
+}
+
+// DeleteUserCascade removes a user and all of their skills from DynamoDB
+// Skills are batch-deleted first and the user row last, so a failed run leaves the user in place
+// and can simply be retried; deleting an already-deleted skill is a no-op.
+func (r *DynamoDBRepository) DeleteUserCascade(username string) error {
+	log := logger.WithComponent("database").With("operation", "DeleteUserCascade", "username", username)
+	start := time.Now()
+
+	log.Debug("Starting cascading user deletion")
+
+	// Remove every skill, following pagination so large profiles are fully removed
+	deleted, err := r.deleteByPrefix("DeleteUserSkills", "UserSkill", "USERSKILL#"+username+"#")
+	if err != nil {
+		log.Error("Failed to delete user skills", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
+
+	// Endorsements received go with the skills they were given for
+	endorsements, err := r.deleteByPrefix("DeleteUserEndorsements", "Endorsement", models.BuildUserEndorsementPrefix(username))
+	if err != nil {
+		log.Error("Failed to delete user endorsements", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
+
+	if err := r.deleteUserRow(username); err != nil {
+		if errors.Is(err, apperrors.ErrUserNotFound) {
+			log.Info("User not found for deletion", "duration", time.Since(start))
+			return err
+		}
+		log.Error("Failed to delete user from DynamoDB", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
+
+	log.Info("User and skills deleted successfully", "skills", deleted, "endorsements", endorsements, "duration", time.Since(start))
+	return nil
+}
+
+// deleteUserRow deletes the user item together with the claim on their email, if they hold one
+func (r *DynamoDBRepository) deleteUserRow(username string) error {
+	user, err := r.GetUser(username)
+	if err != nil {
+		return err
+	}
+
+	release := user.Email != ""
+	for {
+		items := []*dynamodb.TransactWriteItem{
+			{
+				Delete: &dynamodb.Delete{
+					TableName:           aws.String(r.names.TableName),
+					Key:                 r.itemKey("User", models.BuildUserEntityID(username)),
+					ConditionExpression: aws.String("attribute_exists(entity_id)"),
+				},
+			},
+		}
+		if release {
+			items = append(items, r.releaseEmailItem(user.Username, user.Email))
+		}
+
+		_, err := r.transactWriteItemsWithContext(context.Background(), "DeleteUserCascade", &dynamodb.TransactWriteItemsInput{TransactItems: items})
+		conditions := failedConditions(err)
+		switch {
+		case err == nil:
+			return nil
+		case conditions[0]:
+			return apperrors.ErrUserNotFound
+		case conditions[1]:
+			// Another user holds the address, so there is no claim to release
+			release = false
+		default:
+			return err
+		}
+	}
+}
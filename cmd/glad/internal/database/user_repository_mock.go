@@ -1,6 +1,8 @@
 package database
 
 import (
+	"context"
+	"strings"
 	"time"
 
 	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
@@ -30,9 +32,19 @@ func (m *MockRepository) CreateUser(user *models.User) error {
 
 // GetUser retrieves a user from memory
 func (m *MockRepository) GetUser(username string) (*models.User, error) {
+	return m.GetUserWithContext(context.Background(), username)
+}
+
+// GetUserWithContext is GetUser bounded by ctx
+func (m *MockRepository) GetUserWithContext(ctx context.Context, username string) (*models.User, error) {
 	log := logger.WithComponent("database").With("operation", "GetUser", "username", username, "repository", "mock")
 	start := time.Now()
 
+	if err := ctx.Err(); err != nil {
+		log.Info("Context done before mock operation", "error", err.Error(), "duration", time.Since(start))
+		return nil, contextError(ctx, err)
+	}
+
 	log.Debug("Starting user retrieval from mock repository")
 
 	m.mutex.RLock()
@@ -58,16 +70,87 @@ func (m *MockRepository) UpdateUser(user *models.User) error {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
-	if _, exists := m.users[user.Username]; !exists {
+	existing, exists := m.users[user.Username]
+	if !exists {
 		log.Debug("User not found for update", "duration", time.Since(start))
 		return apperrors.ErrUserNotFound
 	}
 
+	// SkillCount is only maintained by skill writes, as in DynamoDB
+	user.SkillCount = existing.SkillCount
 	m.users[user.Username] = user
 	log.Info("User updated successfully in mock repository", "duration", time.Since(start))
 	return nil
 }
 
+// UpdateUserEmail updates a user in memory, moving the claim on their email like DynamoDB does
+func (m *MockRepository) UpdateUserEmail(user *models.User, previousEmail string) error {
+	log := logger.WithComponent("database").With("operation", "UpdateUserEmail", "username", user.Username, "repository", "mock")
+	start := time.Now()
+
+	log.Debug("Starting user email update in mock repository")
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	existing, exists := m.users[user.Username]
+	if !exists {
+		log.Debug("User not found for update", "duration", time.Since(start))
+		return apperrors.ErrUserNotFound
+	}
+	email := strings.ToLower(user.Email)
+	if owner, claimed := m.emails[email]; email != "" && claimed && owner != user.Username {
+		log.Debug("Email already claimed by another user", "duration", time.Since(start))
+		return apperrors.ErrEmailInUse
+	}
+
+	previous := strings.ToLower(previousEmail)
+	if m.emails[previous] == user.Username && previous != email {
+		delete(m.emails, previous)
+	}
+	if email != "" {
+		m.emails[email] = user.Username
+	}
+
+	// SkillCount is only maintained by skill writes, as in DynamoDB
+	user.SkillCount = existing.SkillCount
+	m.users[user.Username] = user
+	log.Info("User email updated successfully in mock repository", "duration", time.Since(start))
+	return nil
+}
+
+// ClaimEmail claims a user's email in memory if the stored user still has it
+func (m *MockRepository) ClaimEmail(user *models.User) error {
+	log := logger.WithComponent("database").With("operation", "ClaimEmail", "username", user.Username, "repository", "mock")
+	start := time.Now()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	stored, exists := m.users[user.Username]
+	if !exists || stored.Email != user.Email {
+		log.Debug("User changed before their email was claimed", "duration", time.Since(start))
+		return ErrUserChanged
+	}
+	email := strings.ToLower(user.Email)
+	if owner, claimed := m.emails[email]; claimed && owner != user.Username {
+		log.Debug("Email already claimed by another user", "duration", time.Since(start))
+		return apperrors.ErrEmailInUse
+	}
+
+	m.emails[email] = user.Username
+	log.Debug("Email claimed in mock repository", "duration", time.Since(start))
+	return nil
+}
+
+// EmailOwner returns the username holding the claim on email in memory
+func (m *MockRepository) EmailOwner(email string) (string, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.emails[strings.ToLower(email)], nil
+}
+
 // UserExists checks if a user exists in memory
 func (m *MockRepository) UserExists(username string) (bool, error) {
 	log := logger.WithComponent("database").With("operation", "UserExists", "username", username, "repository", "mock")
@@ -101,3 +184,78 @@ func (m *MockRepository) ListUsers() ([]*models.User, error) {
 	log.Info("Users retrieved successfully from mock repository", "count", len(users), "duration", time.Since(start))
 	return users, nil
 }
+
+// SearchUsers retrieves users whose username or name contains query from memory, ignoring case
+func (m *MockRepository) SearchUsers(query string) ([]*models.User, error) {
+	log := logger.WithComponent("database").With("operation", "SearchUsers", "query", query, "repository", "mock")
+	start := time.Now()
+
+	log.Debug("Starting user search in mock repository")
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	query = strings.ToLower(query)
+	var users []*models.User
+	for _, user := range m.users {
+		if strings.Contains(strings.ToLower(user.Username), query) || strings.Contains(strings.ToLower(user.Name), query) {
+			users = append(users, user)
+		}
+	}
+
+	log.Info("User search completed in mock repository", "count", len(users), "duration", time.Since(start))
+	return users, nil
+}
+
+// SetSearchNames sets a user's UsernameLower and NameLower in memory if its name is still name
+func (m *MockRepository) SetSearchNames(username, name string) error {
+	log := logger.WithComponent("database").With("operation", "SetSearchNames", "username", username, "repository", "mock")
+	start := time.Now()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	user, exists := m.users[username]
+	if !exists || user.Name != name {
+		log.Debug("User changed before its search names were set", "duration", time.Since(start))
+		return ErrUserChanged
+	}
+
+	user.UsernameLower = strings.ToLower(username)
+	user.NameLower = strings.ToLower(name)
+	log.Debug("Search names set in mock repository", "duration", time.Since(start))
+	return nil
+}
+
+// DeleteUserCascade removes a user and all of their skills from memory
+func (m *MockRepository) DeleteUserCascade(username string) error {
+	log := logger.WithComponent("database").With("operation", "DeleteUserCascade", "username", username, "repository", "mock")
+	start := time.Now()
+
+	log.Debug("Starting cascading user deletion in mock repository")
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	user, exists := m.users[username]
+	if !exists {
+		log.Info("User not found for deletion in mock repository", "duration", time.Since(start))
+		return apperrors.ErrUserNotFound
+	}
+
+	deleted := 0
+	for key, skill := range m.skills {
+		if skill.Username == username {
+			delete(m.skills, key)
+			deleted++
+		}
+	}
+	endorsements := m.deleteEndorsementsByPrefix(models.BuildUserEndorsementPrefix(username))
+	if email := strings.ToLower(user.Email); m.emails[email] == username {
+		delete(m.emails, email)
+	}
+	delete(m.users, username)
+
+	log.Info("User and skills deleted successfully from mock repository", "skills", deleted, "endorsements", endorsements, "duration", time.Since(start))
+	return nil
+}
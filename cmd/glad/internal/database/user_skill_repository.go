@@ -1,16 +1,82 @@
 package database
 
-import "github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
+import (
+	"context"
+	"errors"
+
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
+)
+
+// ErrSkillCountChanged reports that a user's SkillCount no longer held the value a replacement expected
+var ErrSkillCountChanged = errors.New("skill count changed concurrently")
+
+// ErrSkillLevelChanged reports that a skill no longer held the proficiency level a level change expected
+var ErrSkillLevelChanged = errors.New("skill level changed concurrently")
+
+// UserSkillMerge describes how one user's skill moves onto another master skill
+// Target replaces Source under the target skill ID; when Replaces is set the user already held
+// the target skill, and Target overwrites it. Endorsement and history records of Source are
+// moved under the target skill ID, except DropEndorsements, whose endorsers had already
+// endorsed the target skill and are deleted instead.
+type UserSkillMerge struct {
+	Source           *models.UserSkill
+	Target           *models.UserSkill
+	Replaces         bool
+	MoveEndorsements []*models.Endorsement
+	DropEndorsements []*models.Endorsement
+	MoveHistory      []*models.SkillHistory
+}
 
 // SkillRepository defines operations for user skills
+// The single-skill operations and ListSkillsForUser serve the request hot path, so each has a
+// WithContext variant that fails with ErrTimeout once ctx is done.
+// CreateSkill and DeleteSkill keep the owner's SkillCount in step; in DynamoDB they fail with
+// ErrUserNotFound when the owner does not exist.
 type SkillRepository interface {
 	CreateSkill(skill *models.UserSkill) error
+	CreateSkillWithContext(ctx context.Context, skill *models.UserSkill) error
+	// BatchCreateSkills adds many of a user's skills at once; partial failures are reported as *BatchWriteError
+	// SkillCount rises by exactly the number of skills created. Skills that exist already fail
+	// with ErrSkillAlreadyExists, and every skill fails with ErrUserNotFound if the owner does not exist.
+	BatchCreateSkills(username string, skills []*models.UserSkill) error
+	// BatchDeleteSkills removes many of a user's skills at once; partial failures are reported as *BatchWriteError
+	// SkillCount drops by exactly the number of skills removed. Skills that do not exist at delete
+	// time fail with ErrSkillNotFound, so a concurrent delete is never counted twice.
+	BatchDeleteSkills(username string, skillIDs []string) error
+	// AdjustSkillCount adds delta to a user's SkillCount
+	AdjustSkillCount(username string, delta int) error
+	// ReplaceSkillCount sets a user's SkillCount to count if it still holds old
+	// A missing SkillCount counts as 0. Any other value fails with ErrSkillCountChanged, so a
+	// skill written since old was read is never lost.
+	ReplaceSkillCount(username string, old, count int) error
+	// MergeUserSkill applies merge; the skill rows and SkillCount change in a single write
+	// It fails with ErrSkillNotFound when Source is gone, with ErrSkillAlreadyExists when Target
+	// appeared although Replaces is unset, and with ErrSkillNotFound when it vanished although set.
+	MergeUserSkill(merge *UserSkillMerge) error
+	// CountSkillsForUser returns a user's SkillCount without listing their skills
+	CountSkillsForUser(username string) (int, error)
 	GetSkill(username, skillID string) (*models.UserSkill, error)
+	GetSkillWithContext(ctx context.Context, username, skillID string) (*models.UserSkill, error)
 	UpdateSkill(skill *models.UserSkill) error
+	// RenameSkill sets a skill's denormalized SkillName and Category, leaving its other attributes,
+	// UpdatedAt included, as stored; a skill that no longer exists fails with ErrSkillNotFound
+	RenameSkill(username, skillID, skillName, category string) error
+	UpdateSkillWithContext(ctx context.Context, skill *models.UserSkill) error
+	// SetSkillLevel sets a skill's ProficiencyLevel to level if it still holds from, leaving its other
+	// attributes, Endorsements included, as stored. Any other level, or a skill that no longer
+	// exists, fails with ErrSkillLevelChanged.
+	SetSkillLevel(username, skillID string, from, level models.ProficiencyLevel) error
 	DeleteSkill(username, skillID string) error
+	DeleteSkillWithContext(ctx context.Context, username, skillID string) error
 	ListSkillsForUser(username string) ([]*models.UserSkill, error)
-	// ListUsersBySkill queries the BySkill GSI with Category + SkillName
-	ListUsersBySkill(category, skillName string) ([]*models.UserSkill, error)
+	ListSkillsForUserWithContext(ctx context.Context, username string) ([]*models.UserSkill, error)
+	// ListSkillsBySkillID returns every user's skill referencing the master skill skillID
+	ListSkillsBySkillID(skillID string) ([]*models.UserSkill, error)
+	// ListUsersBySkill queries the BySkill GSI with Category + SkillName, one page at a time
+	// A limit of 0 leaves the page size to DynamoDB. The returned token is empty on the last page.
+	ListUsersBySkill(category, skillName string, limit int, nextToken string) ([]*models.UserSkill, string, error)
 	// ListUsersBySkillAndLevel queries the BySkill GSI with Category + SkillName + ProficiencyLevel
 	ListUsersBySkillAndLevel(category, skillName string, proficiencyLevel models.ProficiencyLevel) ([]*models.UserSkill, error)
+	// ListRecentSkills queries the RecentlyUpdated GSI for the limit most recently updated skills, newest first
+	ListRecentSkills(limit int) ([]*models.UserSkill, error)
 }
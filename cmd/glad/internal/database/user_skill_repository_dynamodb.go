@@ -1,6 +1,9 @@
 package database
 
 import (
+	"context"
+	"fmt"
+	"strconv"
 	"time"
 
 	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
@@ -13,7 +16,14 @@ import (
 )
 
 // CreateSkill inserts a new user skill into DynamoDB
+// The skill is written in one transaction with an increment of the owner's SkillCount, so the
+// count never drifts from the stored skills. The owner must exist.
 func (r *DynamoDBRepository) CreateSkill(skill *models.UserSkill) error {
+	return r.CreateSkillWithContext(context.Background(), skill)
+}
+
+// CreateSkillWithContext is CreateSkill bounded by ctx
+func (r *DynamoDBRepository) CreateSkillWithContext(ctx context.Context, skill *models.UserSkill) error {
 	log := logger.WithComponent("database").With("operation", "CreateSkill", "username", skill.Username, "skill_id", skill.SkillID)
 	start := time.Now()
 
@@ -28,15 +38,28 @@ func (r *DynamoDBRepository) CreateSkill(skill *models.UserSkill) error {
 		return err
 	}
 
-	input := &dynamodb.PutItemInput{
-		TableName:           aws.String(TableName),
-		Item:                item,
-		ConditionExpression: aws.String("attribute_not_exists(entity_id)"),
+	input := &dynamodb.TransactWriteItemsInput{
+		TransactItems: []*dynamodb.TransactWriteItem{
+			{
+				Put: &dynamodb.Put{
+					TableName:           aws.String(r.names.TableName),
+					Item:                item,
+					ConditionExpression: aws.String("attribute_not_exists(entity_id)"),
+				},
+			},
+			{Update: r.skillCountUpdate(skill.Username, 1)},
+		},
 	}
-	_, err = r.client.PutItem(input)
+	_, err = r.transactWriteItemsWithContext(ctx, "CreateSkill", input)
 
 	if err != nil {
 		log.Error("Failed to create skill in DynamoDB", "error", err.Error(), "duration", time.Since(start))
+		if failed, ok := failedCondition(err); ok {
+			if failed == 0 {
+				return apperrors.ErrSkillAlreadyExists
+			}
+			return apperrors.ErrUserNotFound
+		}
 		return err
 	}
 
@@ -45,8 +68,260 @@ func (r *DynamoDBRepository) CreateSkill(skill *models.UserSkill) error {
 	return nil
 }
 
+// BatchCreateSkills creates a user's skills in write transactions of up to transactSkillLimit
+// Each transaction puts its skills on the condition that they do not exist yet and raises
+// SkillCount by their number in the same write. When some skills exist already the transaction
+// is cancelled; those fail with ErrSkillAlreadyExists and the rest are retried without them.
+func (r *DynamoDBRepository) BatchCreateSkills(username string, skills []*models.UserSkill) error {
+	log := logger.WithComponent("database").With("operation", "BatchCreateSkills", "username", username, "count", len(skills))
+	start := time.Now()
+
+	log.Debug("Starting batch skill creation")
+
+	writes := make([]*dynamodb.TransactWriteItem, len(skills))
+	for i, skill := range skills {
+		// Ensure keys are set
+		skill.SetKeys()
+
+		item, err := dynamodbattribute.MarshalMap(skill)
+		if err != nil {
+			log.Error("Failed to marshal skill data", "error", err.Error(), "index", i, "duration", time.Since(start))
+			return err
+		}
+		writes[i] = &dynamodb.TransactWriteItem{Put: &dynamodb.Put{
+			TableName:           aws.String(r.names.TableName),
+			Item:                item,
+			ConditionExpression: aws.String("attribute_not_exists(entity_id)"),
+		}}
+	}
+
+	if failed := r.transactSkillWrites("BatchCreateSkills", username, writes, 1, apperrors.ErrSkillAlreadyExists); len(failed) > 0 {
+		log.Error("Batch skill creation completed with failures", "failed", len(failed), "duration", time.Since(start))
+		return &BatchWriteError{Failed: failed}
+	}
+
+	log.Info("Batch skill creation completed successfully", "duration", time.Since(start))
+	return nil
+}
+
+// BatchDeleteSkills deletes a user's skills in write transactions of up to transactSkillLimit
+// Each transaction deletes its skills on the condition that they exist and lowers SkillCount by
+// their number in the same write. When some skills are already gone the transaction is cancelled;
+// those fail with ErrSkillNotFound and the rest are retried without them.
+func (r *DynamoDBRepository) BatchDeleteSkills(username string, skillIDs []string) error {
+	log := logger.WithComponent("database").With("operation", "BatchDeleteSkills", "username", username, "count", len(skillIDs))
+	start := time.Now()
+
+	log.Debug("Starting batch skill deletion")
+
+	writes := make([]*dynamodb.TransactWriteItem, len(skillIDs))
+	for i, skillID := range skillIDs {
+		writes[i] = &dynamodb.TransactWriteItem{Delete: &dynamodb.Delete{
+			TableName:           aws.String(r.names.TableName),
+			Key:                 r.itemKey("UserSkill", BuildUserSkillEntityID(username, skillID)),
+			ConditionExpression: aws.String("attribute_exists(entity_id)"),
+		}}
+	}
+
+	if failed := r.transactSkillWrites("BatchDeleteSkills", username, writes, -1, apperrors.ErrSkillNotFound); len(failed) > 0 {
+		log.Error("Batch skill deletion completed with failures", "failed", len(failed), "duration", time.Since(start))
+		return &BatchWriteError{Failed: failed}
+	}
+
+	log.Info("Batch skill deletion completed successfully", "duration", time.Since(start))
+	return nil
+}
+
+// transactSkillWrites runs conditional writes of username's skills in transactions of up to
+// transactSkillLimit, each adjusting SkillCount by step per write it holds. Writes whose condition
+// fails are reported as conflict and the rest of their transaction is retried without them; a
+// missing owner fails every write of the transaction with ErrUserNotFound. It returns the failed
+// writes by index.
+func (r *DynamoDBRepository) transactSkillWrites(operation, username string, writes []*dynamodb.TransactWriteItem, step int, conflict error) map[int]error {
+	log := logger.WithComponent("database").With("operation", operation, "username", username)
+
+	failed := make(map[int]error)
+	for chunkStart := 0; chunkStart < len(writes); chunkStart += transactSkillLimit {
+		var pending []int
+		for i := chunkStart; i < min(chunkStart+transactSkillLimit, len(writes)); i++ {
+			pending = append(pending, i)
+		}
+
+		for len(pending) > 0 {
+			items := make([]*dynamodb.TransactWriteItem, 0, len(pending)+1)
+			for _, i := range pending {
+				items = append(items, writes[i])
+			}
+			items = append(items, &dynamodb.TransactWriteItem{Update: r.skillCountUpdate(username, step*len(pending))})
+
+			_, err := r.transactWriteItemsWithContext(context.Background(), operation, &dynamodb.TransactWriteItemsInput{TransactItems: items})
+			if err == nil {
+				break
+			}
+
+			conditions := failedConditions(err)
+			if conditions == nil || conditions[len(pending)] {
+				if conditions != nil {
+					err = apperrors.ErrUserNotFound
+				}
+				log.Error("Skill transaction failed", "error", err.Error(), "chunk_start", chunkStart)
+				for _, i := range pending {
+					failed[i] = err
+				}
+				break
+			}
+
+			var remaining []int
+			for j, i := range pending {
+				if conditions[j] {
+					failed[i] = conflict
+				} else {
+					remaining = append(remaining, i)
+				}
+			}
+			log.Debug("Retrying skill transaction without conflicting skills", "conflicts", len(pending)-len(remaining))
+			pending = remaining
+		}
+	}
+	return failed
+}
+
+// MergeUserSkill applies merge in one write transaction when it fits in transactItemLimit items
+// The last transaction writes Target, deletes Source and the dropped endorsements and adjusts
+// SkillCount. Moves that do not fit alongside it go first, each record's put and delete in the
+// same transaction, so an interrupted merge leaves every record under one of the two skill IDs.
+func (r *DynamoDBRepository) MergeUserSkill(merge *UserSkillMerge) error {
+	log := logger.WithComponent("database").With("operation", "MergeUserSkill", "username", merge.Source.Username,
+		"source", merge.Source.SkillID, "target", merge.Target.SkillID)
+	start := time.Now()
+
+	log.Debug("Starting user skill merge")
+
+	var moves []*dynamodb.TransactWriteItem
+	for _, endorsement := range merge.MoveEndorsements {
+		moved := *endorsement
+		moved.SkillID = merge.Target.SkillID
+		moved.SetKeys()
+		pair, err := r.moveItems(&moved, endorsement.EntityType, endorsement.EntityID)
+		if err != nil {
+			log.Error("Failed to marshal endorsement", "error", err.Error(), "duration", time.Since(start))
+			return err
+		}
+		moves = append(moves, pair...)
+	}
+	for _, history := range merge.MoveHistory {
+		moved := *history
+		moved.SkillID = merge.Target.SkillID
+		moved.SetKeys()
+		pair, err := r.moveItems(&moved, history.EntityType, history.EntityID)
+		if err != nil {
+			log.Error("Failed to marshal skill history", "error", err.Error(), "duration", time.Since(start))
+			return err
+		}
+		moves = append(moves, pair...)
+	}
+
+	merge.Target.SetKeys()
+	item, err := dynamodbattribute.MarshalMap(merge.Target)
+	if err != nil {
+		log.Error("Failed to marshal skill data", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
+	targetCondition := "attribute_not_exists(entity_id)"
+	if merge.Replaces {
+		targetCondition = "attribute_exists(entity_id)"
+	}
+	final := []*dynamodb.TransactWriteItem{
+		{Put: &dynamodb.Put{
+			TableName:           aws.String(r.names.TableName),
+			Item:                item,
+			ConditionExpression: aws.String(targetCondition),
+		}},
+		{Delete: &dynamodb.Delete{
+			TableName:           aws.String(r.names.TableName),
+			Key:                 r.itemKey("UserSkill", BuildUserSkillEntityID(merge.Source.Username, merge.Source.SkillID)),
+			ConditionExpression: aws.String("attribute_exists(entity_id)"),
+		}},
+	}
+	if merge.Replaces {
+		final = append(final, &dynamodb.TransactWriteItem{Update: r.skillCountUpdate(merge.Source.Username, -1)})
+	}
+	for _, endorsement := range merge.DropEndorsements {
+		final = append(final, &dynamodb.TransactWriteItem{Delete: &dynamodb.Delete{
+			TableName: aws.String(r.names.TableName),
+			Key:       r.itemKey(endorsement.EntityType, endorsement.EntityID),
+		}})
+	}
+	if len(final) > transactItemLimit {
+		err := fmt.Errorf("merge drops %d endorsements, more than one transaction holds", len(merge.DropEndorsements))
+		log.Error("User skill merge too large", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
+
+	if len(final)+len(moves) > transactItemLimit {
+		// Moves are pairs, so a chunk of an even size never splits one
+		for chunkStart := 0; chunkStart < len(moves); chunkStart += transactItemLimit {
+			chunk := moves[chunkStart:min(chunkStart+transactItemLimit, len(moves))]
+			if _, err := r.transactWriteItemsWithContext(context.Background(), "MergeUserSkill", &dynamodb.TransactWriteItemsInput{TransactItems: chunk}); err != nil {
+				log.Error("Failed to move skill records", "error", err.Error(), "chunk_start", chunkStart, "duration", time.Since(start))
+				return err
+			}
+		}
+		moves = nil
+	}
+
+	_, err = r.transactWriteItemsWithContext(context.Background(), "MergeUserSkill", &dynamodb.TransactWriteItemsInput{
+		TransactItems: append(final, moves...),
+	})
+	if err != nil {
+		if conditions := failedConditions(err); conditions != nil {
+			switch {
+			case conditions[1]:
+				err = apperrors.ErrSkillNotFound
+			case conditions[0] && merge.Replaces:
+				err = apperrors.ErrSkillNotFound
+			case conditions[0]:
+				err = apperrors.ErrSkillAlreadyExists
+			case conditions[2] && merge.Replaces:
+				err = apperrors.ErrUserNotFound
+			}
+		}
+		log.Error("Failed to merge user skill", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
+
+	log.Info("User skill merged successfully", "moved", len(merge.MoveEndorsements)+len(merge.MoveHistory),
+		"dropped", len(merge.DropEndorsements), "duration", time.Since(start))
+	return nil
+}
+
+// moveItems returns the transaction items writing record and deleting the item it was copied from
+func (r *DynamoDBRepository) moveItems(record any, entityType, entityID string) ([]*dynamodb.TransactWriteItem, error) {
+	item, err := dynamodbattribute.MarshalMap(record)
+	if err != nil {
+		return nil, err
+	}
+	return []*dynamodb.TransactWriteItem{
+		{Put: &dynamodb.Put{TableName: aws.String(r.names.TableName), Item: item}},
+		{Delete: &dynamodb.Delete{TableName: aws.String(r.names.TableName), Key: r.itemKey(entityType, entityID)}},
+	}, nil
+}
+
+// itemKey returns the table key of an item
+func (r *DynamoDBRepository) itemKey(entityType, entityID string) map[string]*dynamodb.AttributeValue {
+	return map[string]*dynamodb.AttributeValue{
+		"EntityType": {S: aws.String(entityType)},
+		"entity_id":  {S: aws.String(entityID)},
+	}
+}
+
 // GetSkill retrieves a specific skill for a user by skill_id
 func (r *DynamoDBRepository) GetSkill(username, skillID string) (*models.UserSkill, error) {
+	return r.GetSkillWithContext(context.Background(), username, skillID)
+}
+
+// GetSkillWithContext is GetSkill bounded by ctx
+func (r *DynamoDBRepository) GetSkillWithContext(ctx context.Context, username, skillID string) (*models.UserSkill, error) {
 	log := logger.WithComponent("database").With("operation", "GetSkill", "username", username, "skill_id", skillID)
 	start := time.Now()
 
@@ -55,14 +330,16 @@ func (r *DynamoDBRepository) GetSkill(username, skillID string) (*models.UserSki
 	entityID := BuildUserSkillEntityID(username, skillID)
 
 	input := &dynamodb.GetItemInput{
-		TableName: aws.String(TableName),
+		TableName: aws.String(r.names.TableName),
 		Key: map[string]*dynamodb.AttributeValue{
 			"EntityType": {S: aws.String("UserSkill")},
 			"entity_id":  {S: aws.String(entityID)},
 		},
+		// Consistent, so a skill read right after an endorsement reflects its count
+		ConsistentRead: aws.Bool(true),
 	}
 
-	result, err := r.client.GetItem(input)
+	result, err := r.getItemWithContext(ctx, "GetSkill", input)
 	if err != nil {
 		log.Error("Failed to get skill from DynamoDB", "error", err.Error(), "duration", time.Since(start))
 		return nil, err
@@ -86,6 +363,11 @@ func (r *DynamoDBRepository) GetSkill(username, skillID string) (*models.UserSki
 
 // UpdateSkill updates an existing skill
 func (r *DynamoDBRepository) UpdateSkill(skill *models.UserSkill) error {
+	return r.UpdateSkillWithContext(context.Background(), skill)
+}
+
+// UpdateSkillWithContext is UpdateSkill bounded by ctx
+func (r *DynamoDBRepository) UpdateSkillWithContext(ctx context.Context, skill *models.UserSkill) error {
 	log := logger.WithComponent("database").With("operation", "UpdateSkill", "username", skill.Username, "skill_id", skill.SkillID)
 	start := time.Now()
 
@@ -102,13 +384,17 @@ func (r *DynamoDBRepository) UpdateSkill(skill *models.UserSkill) error {
 	}
 
 	input := &dynamodb.PutItemInput{
-		TableName:           aws.String(TableName),
+		TableName:           aws.String(r.names.TableName),
 		Item:                item,
 		ConditionExpression: aws.String("attribute_exists(entity_id)"),
 	}
 
-	_, err = r.client.PutItem(input)
+	_, err = r.putItemWithContext(ctx, "UpdateSkill", input)
 	if err != nil {
+		if isConditionFailed(err) {
+			log.Info("Skill not found for update", "duration", time.Since(start))
+			return apperrors.ErrSkillNotFound
+		}
 		log.Error("Failed to update skill in DynamoDB", "error", err.Error(), "duration", time.Since(start))
 		return err
 	}
@@ -117,8 +403,75 @@ func (r *DynamoDBRepository) UpdateSkill(skill *models.UserSkill) error {
 	return nil
 }
 
+// RenameSkill updates a skill's SkillName and Category in place, on the condition that it exists
+func (r *DynamoDBRepository) RenameSkill(username, skillID, skillName, category string) error {
+	log := logger.WithComponent("database").With("operation", "RenameSkill", "username", username, "skill_id", skillID)
+	start := time.Now()
+
+	input := &dynamodb.UpdateItemInput{
+		TableName:           aws.String(r.names.TableName),
+		Key:                 r.itemKey("UserSkill", BuildUserSkillEntityID(username, skillID)),
+		UpdateExpression:    aws.String("SET SkillName = :skillName, Category = :category"),
+		ConditionExpression: aws.String("attribute_exists(entity_id)"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":skillName": {S: aws.String(skillName)},
+			":category":  {S: aws.String(category)},
+		},
+	}
+
+	if _, err := r.updateItem("RenameSkill", input); err != nil {
+		if isConditionFailed(err) {
+			log.Info("Skill not found for rename", "duration", time.Since(start))
+			return apperrors.ErrSkillNotFound
+		}
+		log.Error("Failed to rename skill in DynamoDB", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
+
+	log.Debug("Skill renamed successfully", "duration", time.Since(start))
+	return nil
+}
+
+// SetSkillLevel sets a skill's ProficiencyLevel and UpdatedAt in place, on the condition that it
+// still holds from. Unlike UpdateSkill it leaves Endorsements as stored, so an endorsement
+// counted meanwhile is kept.
+func (r *DynamoDBRepository) SetSkillLevel(username, skillID string, from, level models.ProficiencyLevel) error {
+	log := logger.WithComponent("database").With("operation", "SetSkillLevel", "username", username, "skill_id", skillID, "from", from, "level", level)
+	start := time.Now()
+
+	input := &dynamodb.UpdateItemInput{
+		TableName:           aws.String(r.names.TableName),
+		Key:                 r.itemKey("UserSkill", BuildUserSkillEntityID(username, skillID)),
+		UpdateExpression:    aws.String("SET ProficiencyLevel = :level, UpdatedAt = :updatedAt"),
+		ConditionExpression: aws.String("ProficiencyLevel = :from"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":level":     {S: aws.String(string(level))},
+			":from":      {S: aws.String(string(from))},
+			":updatedAt": {S: aws.String(time.Now().Format(time.RFC3339Nano))},
+		},
+	}
+
+	if _, err := r.updateItem("SetSkillLevel", input); err != nil {
+		if isConditionFailed(err) {
+			log.Info("Skill level changed before update", "duration", time.Since(start))
+			return ErrSkillLevelChanged
+		}
+		log.Error("Failed to set skill level in DynamoDB", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
+
+	log.Info("Skill level set successfully", "duration", time.Since(start))
+	return nil
+}
+
 // DeleteSkill removes a skill from a user
+// Like CreateSkill, the delete and the decrement of the owner's SkillCount form one transaction.
 func (r *DynamoDBRepository) DeleteSkill(username, skillID string) error {
+	return r.DeleteSkillWithContext(context.Background(), username, skillID)
+}
+
+// DeleteSkillWithContext is DeleteSkill bounded by ctx
+func (r *DynamoDBRepository) DeleteSkillWithContext(ctx context.Context, username, skillID string) error {
 	log := logger.WithComponent("database").With("operation", "DeleteSkill", "username", username, "skill_id", skillID)
 	start := time.Now()
 
@@ -126,18 +479,31 @@ func (r *DynamoDBRepository) DeleteSkill(username, skillID string) error {
 
 	entityID := BuildUserSkillEntityID(username, skillID)
 
-	input := &dynamodb.DeleteItemInput{
-		TableName: aws.String(TableName),
-		Key: map[string]*dynamodb.AttributeValue{
-			"EntityType": {S: aws.String("UserSkill")},
-			"entity_id":  {S: aws.String(entityID)},
+	input := &dynamodb.TransactWriteItemsInput{
+		TransactItems: []*dynamodb.TransactWriteItem{
+			{
+				Delete: &dynamodb.Delete{
+					TableName: aws.String(r.names.TableName),
+					Key: map[string]*dynamodb.AttributeValue{
+						"EntityType": {S: aws.String("UserSkill")},
+						"entity_id":  {S: aws.String(entityID)},
+					},
+					ConditionExpression: aws.String("attribute_exists(entity_id)"),
+				},
+			},
+			{Update: r.skillCountUpdate(username, -1)},
 		},
-		ConditionExpression: aws.String("attribute_exists(entity_id)"),
 	}
 
-	_, err := r.client.DeleteItem(input)
+	_, err := r.transactWriteItemsWithContext(ctx, "DeleteSkill", input)
 	if err != nil {
 		log.Error("Failed to delete skill from DynamoDB", "error", err.Error(), "duration", time.Since(start))
+		if failed, ok := failedCondition(err); ok {
+			if failed == 0 {
+				return apperrors.ErrSkillNotFound
+			}
+			return apperrors.ErrUserNotFound
+		}
 		return err
 	}
 
@@ -145,15 +511,138 @@ func (r *DynamoDBRepository) DeleteSkill(username, skillID string) error {
 	return nil
 }
 
+// AdjustSkillCount adds delta to a user's SkillCount
+func (r *DynamoDBRepository) AdjustSkillCount(username string, delta int) error {
+	log := logger.WithComponent("database").With("operation", "AdjustSkillCount", "username", username, "delta", delta)
+	start := time.Now()
+
+	update := r.skillCountUpdate(username, delta)
+	input := &dynamodb.UpdateItemInput{
+		TableName:                 update.TableName,
+		Key:                       update.Key,
+		UpdateExpression:          update.UpdateExpression,
+		ConditionExpression:       update.ConditionExpression,
+		ExpressionAttributeValues: update.ExpressionAttributeValues,
+	}
+
+	_, err := r.updateItem("AdjustSkillCount", input)
+	if err != nil {
+		log.Error("Failed to adjust skill count", "error", err.Error(), "duration", time.Since(start))
+		if isConditionFailed(err) {
+			return apperrors.ErrUserNotFound
+		}
+		return err
+	}
+
+	log.Debug("Skill count adjusted", "duration", time.Since(start))
+	return nil
+}
+
+// ReplaceSkillCount sets a user's SkillCount to count on the condition that it still holds old
+func (r *DynamoDBRepository) ReplaceSkillCount(username string, old, count int) error {
+	log := logger.WithComponent("database").With("operation", "ReplaceSkillCount", "username", username, "old", old, "count", count)
+	start := time.Now()
+
+	condition := "attribute_exists(entity_id) AND SkillCount = :old"
+	if old == 0 {
+		condition = "attribute_exists(entity_id) AND (attribute_not_exists(SkillCount) OR SkillCount = :old)"
+	}
+
+	input := &dynamodb.UpdateItemInput{
+		TableName: aws.String(r.names.TableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"EntityType": {S: aws.String("User")},
+			"entity_id":  {S: aws.String(BuildUserEntityID(username))},
+		},
+		UpdateExpression:    aws.String("SET SkillCount = :count"),
+		ConditionExpression: aws.String(condition),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":old":   {N: aws.String(strconv.Itoa(old))},
+			":count": {N: aws.String(strconv.Itoa(count))},
+		},
+	}
+
+	if _, err := r.updateItem("ReplaceSkillCount", input); err != nil {
+		if isConditionFailed(err) {
+			log.Info("Skill count changed or user removed before replacement", "duration", time.Since(start))
+			return ErrSkillCountChanged
+		}
+		log.Error("Failed to replace skill count", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
+
+	log.Debug("Skill count replaced", "duration", time.Since(start))
+	return nil
+}
+
+// CountSkillsForUser returns the number of skills a user has from the SkillCount on the user item
+// It reads a single attribute instead of querying the user's skills.
+func (r *DynamoDBRepository) CountSkillsForUser(username string) (int, error) {
+	log := logger.WithComponent("database").With("operation", "CountSkillsForUser", "username", username)
+	start := time.Now()
+
+	input := &dynamodb.GetItemInput{
+		TableName: aws.String(r.names.TableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"EntityType": {S: aws.String("User")},
+			"entity_id":  {S: aws.String(BuildUserEntityID(username))},
+		},
+		ProjectionExpression: aws.String("SkillCount, entity_id"),
+	}
+
+	result, err := r.getItem("CountSkillsForUser", input)
+	if err != nil {
+		log.Error("Failed to get user skill count", "error", err.Error(), "duration", time.Since(start))
+		return 0, err
+	}
+	if result.Item == nil {
+		log.Info("User not found", "duration", time.Since(start))
+		return 0, apperrors.ErrUserNotFound
+	}
+
+	var counted struct {
+		SkillCount int `dynamodbav:"SkillCount"`
+	}
+	if err := dynamodbattribute.UnmarshalMap(result.Item, &counted); err != nil {
+		log.Error("Failed to unmarshal skill count", "error", err.Error(), "duration", time.Since(start))
+		return 0, err
+	}
+
+	log.Debug("User skill count retrieved", "count", counted.SkillCount, "duration", time.Since(start))
+	return counted.SkillCount, nil
+}
+
+// skillCountUpdate builds the update adding delta to a user's SkillCount
+// The user must exist, so a skill can never create a stub user item.
+func (r *DynamoDBRepository) skillCountUpdate(username string, delta int) *dynamodb.Update {
+	return &dynamodb.Update{
+		TableName: aws.String(r.names.TableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"EntityType": {S: aws.String("User")},
+			"entity_id":  {S: aws.String(BuildUserEntityID(username))},
+		},
+		UpdateExpression:    aws.String("ADD SkillCount :delta"),
+		ConditionExpression: aws.String("attribute_exists(entity_id)"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":delta": {N: aws.String(strconv.Itoa(delta))},
+		},
+	}
+}
+
 // ListSkillsForUser retrieves all skills for a specific user using GSI ByUser
 func (r *DynamoDBRepository) ListSkillsForUser(username string) ([]*models.UserSkill, error) {
+	return r.ListSkillsForUserWithContext(context.Background(), username)
+}
+
+// ListSkillsForUserWithContext is ListSkillsForUser bounded by ctx
+func (r *DynamoDBRepository) ListSkillsForUserWithContext(ctx context.Context, username string) ([]*models.UserSkill, error) {
 	log := logger.WithComponent("database").With("operation", "ListSkillsForUser", "username", username)
 	start := time.Now()
 
 	log.Debug("Starting skills list retrieval for user")
 
 	input := &dynamodb.QueryInput{
-		TableName:              aws.String(TableName),
+		TableName:              aws.String(r.names.TableName),
 		KeyConditionExpression: aws.String("EntityType = :entityType AND begins_with(entity_id, :userPrefix)"),
 		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
 			":entityType": {S: aws.String("UserSkill")},
@@ -161,7 +650,7 @@ func (r *DynamoDBRepository) ListSkillsForUser(username string) ([]*models.UserS
 		},
 	}
 
-	result, err := r.client.Query(input)
+	result, err := r.queryWithContext(ctx, "ListSkillsForUser", input)
 	if err != nil {
 		log.Error("Failed to query skills for user", "error", err.Error(), "duration", time.Since(start))
 		return nil, err
@@ -181,28 +670,82 @@ func (r *DynamoDBRepository) ListSkillsForUser(username string) ([]*models.UserS
 	return skills, nil
 }
 
+// ListSkillsBySkillID retrieves all user skills referencing a master skill
+// The denormalized name may be stale, so this filters the UserSkill partition on the immutable
+// skill_id instead of using the BySkill GSI; it reads every user skill and is meant for admin use.
+func (r *DynamoDBRepository) ListSkillsBySkillID(skillID string) ([]*models.UserSkill, error) {
+	log := logger.WithComponent("database").With("operation", "ListSkillsBySkillID", "skill_id", skillID)
+	start := time.Now()
+
+	log.Debug("Starting skills list retrieval by skill ID")
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(r.names.TableName),
+		KeyConditionExpression: aws.String("EntityType = :entityType"),
+		FilterExpression:       aws.String("skill_id = :skillID"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":entityType": {S: aws.String("UserSkill")},
+			":skillID":    {S: aws.String(skillID)},
+		},
+	}
+
+	var skills []*models.UserSkill
+	for {
+		result, err := r.query("ListSkillsBySkillID", input)
+		if err != nil {
+			log.Error("Failed to query skills by skill ID", "error", err.Error(), "duration", time.Since(start))
+			return nil, err
+		}
+		for i, item := range result.Items {
+			var skill models.UserSkill
+			if err := dynamodbattribute.UnmarshalMap(item, &skill); err != nil {
+				log.Error("Failed to unmarshal skill data", "error", err.Error(), "item_index", i, "duration", time.Since(start))
+				continue
+			}
+			skills = append(skills, &skill)
+		}
+		if len(result.LastEvaluatedKey) == 0 {
+			break
+		}
+		input.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+
+	log.Info("Skills retrieved successfully by skill ID", "count", len(skills), "duration", time.Since(start))
+	return skills, nil
+}
+
 // ListUsersBySkill retrieves all users who have a specific skill using GSI BySkill
 // GSI BySkill structure: PK=Category, SK=SkillName+ProficiencyLevel+YearsOfExperience+Username
-func (r *DynamoDBRepository) ListUsersBySkill(category, skillName string) ([]*models.UserSkill, error) {
-	log := logger.WithComponent("database").With("operation", "ListUsersBySkill", "category", category, "skill", skillName)
+func (r *DynamoDBRepository) ListUsersBySkill(category, skillName string, limit int, nextToken string) ([]*models.UserSkill, string, error) {
+	log := logger.WithComponent("database").With("operation", "ListUsersBySkill", "category", category, "skill", skillName, "limit", limit)
 	start := time.Now()
 
 	log.Debug("Starting users list retrieval by skill")
 
+	startKey, err := decodePageToken(nextToken)
+	if err != nil {
+		log.Info("Invalid pagination token", "duration", time.Since(start))
+		return nil, "", err
+	}
+
 	input := &dynamodb.QueryInput{
-		TableName:              aws.String(TableName),
-		IndexName:              aws.String(GSIBySkill),
+		TableName:              aws.String(r.names.TableName),
+		IndexName:              aws.String(r.names.BySkillIndex),
 		KeyConditionExpression: aws.String("Category = :category AND SkillName = :skillName"),
 		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
 			":category":  {S: aws.String(category)},
 			":skillName": {S: aws.String(skillName)},
 		},
+		ExclusiveStartKey: startKey,
+	}
+	if limit > 0 {
+		input.Limit = aws.Int64(int64(limit))
 	}
 
-	result, err := r.client.Query(input)
+	result, err := r.query("ListUsersBySkill", input)
 	if err != nil {
 		log.Error("Failed to query users by skill", "error", err.Error(), "duration", time.Since(start))
-		return nil, err
+		return nil, "", err
 	}
 
 	var skills []*models.UserSkill
@@ -215,8 +758,14 @@ func (r *DynamoDBRepository) ListUsersBySkill(category, skillName string) ([]*mo
 		skills = append(skills, &skill)
 	}
 
-	log.Info("Users with skill retrieved successfully", "category", category, "skill", skillName, "count", len(skills), "duration", time.Since(start))
-	return skills, nil
+	token, err := encodePageToken(result.LastEvaluatedKey)
+	if err != nil {
+		log.Error("Failed to encode pagination token", "error", err.Error(), "duration", time.Since(start))
+		return nil, "", err
+	}
+
+	log.Info("Users with skill retrieved successfully", "category", category, "skill", skillName, "count", len(skills), "has_more", token != "", "duration", time.Since(start))
+	return skills, token, nil
 }
 
 // ListUsersBySkillAndLevel retrieves users with a specific skill at a specific proficiency level
@@ -229,8 +778,8 @@ func (r *DynamoDBRepository) ListUsersBySkillAndLevel(category, skillName string
 	log.Debug("Starting users list retrieval by skill and level")
 
 	input := &dynamodb.QueryInput{
-		TableName:              aws.String(TableName),
-		IndexName:              aws.String(GSIBySkill),
+		TableName:              aws.String(r.names.TableName),
+		IndexName:              aws.String(r.names.BySkillIndex),
 		KeyConditionExpression: aws.String("Category = :category AND SkillName = :skillName AND ProficiencyLevel = :level"),
 		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
 			":category":  {S: aws.String(category)},
@@ -239,7 +788,7 @@ func (r *DynamoDBRepository) ListUsersBySkillAndLevel(category, skillName string
 		},
 	}
 
-	result, err := r.client.Query(input)
+	result, err := r.query("ListUsersBySkillAndLevel", input)
 	if err != nil {
 		log.Error("Failed to query users by skill and level", "error", err.Error(), "duration", time.Since(start))
 		return nil, err
@@ -258,3 +807,43 @@ func (r *DynamoDBRepository) ListUsersBySkillAndLevel(category, skillName string
 	log.Info("Users with skill and level retrieved successfully", "category", category, "skill", skillName, "level", proficiencyLevel, "count", len(skills), "duration", time.Since(start))
 	return skills, nil
 }
+
+// ListRecentSkills retrieves the most recently updated user skills across all users
+// Queries the RecentlyUpdated GSI, whose single RecentFeed partition is read newest first.
+// Skills written before the index existed appear once they are next updated.
+func (r *DynamoDBRepository) ListRecentSkills(limit int) ([]*models.UserSkill, error) {
+	log := logger.WithComponent("database").With("operation", "ListRecentSkills", "limit", limit)
+	start := time.Now()
+
+	log.Debug("Starting recent skills retrieval")
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(r.names.TableName),
+		IndexName:              aws.String(r.names.RecentlyUpdatedIndex),
+		KeyConditionExpression: aws.String("RecentFeed = :feed"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":feed": {S: aws.String(models.RecentFeedUserSkills)},
+		},
+		ScanIndexForward: aws.Bool(false),
+		Limit:            aws.Int64(int64(limit)),
+	}
+
+	result, err := r.query("ListRecentSkills", input)
+	if err != nil {
+		log.Error("Failed to query recent skills", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	var skills []*models.UserSkill
+	for i, item := range result.Items {
+		var skill models.UserSkill
+		if err := dynamodbattribute.UnmarshalMap(item, &skill); err != nil {
+			log.Error("Failed to unmarshal skill data", "error", err.Error(), "item_index", i, "duration", time.Since(start))
+			continue
+		}
+		skills = append(skills, &skill)
+	}
+
+	log.Info("Recent skills retrieved successfully", "count", len(skills), "duration", time.Since(start))
+	return skills, nil
+}
@@ -1,6 +1,9 @@
 package database
 
 import (
+	"context"
+	"encoding/base64"
+	"sort"
 	"time"
 
 	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
@@ -10,9 +13,19 @@ import (
 
 // CreateSkill creates a user skill in memory
 func (m *MockRepository) CreateSkill(skill *models.UserSkill) error {
+	return m.CreateSkillWithContext(context.Background(), skill)
+}
+
+// CreateSkillWithContext is CreateSkill bounded by ctx
+func (m *MockRepository) CreateSkillWithContext(ctx context.Context, skill *models.UserSkill) error {
 	log := logger.WithComponent("database").With("operation", "CreateSkill", "username", skill.Username, "skill_id", skill.SkillID, "repository", "mock")
 	start := time.Now()
 
+	if err := ctx.Err(); err != nil {
+		log.Info("Context done before mock operation", "error", err.Error(), "duration", time.Since(start))
+		return contextError(ctx, err)
+	}
+
 	log.Debug("Starting skill creation in mock repository")
 
 	m.mutex.Lock()
@@ -25,15 +38,86 @@ func (m *MockRepository) CreateSkill(skill *models.UserSkill) error {
 	}
 
 	m.skills[key] = skill
+	m.adjustSkillCount(skill.Username, 1)
 	log.Info("Skill created successfully in mock repository", "total_skills", len(m.skills), "duration", time.Since(start))
 	return nil
 }
 
+// BatchCreateSkills adds user skills to memory, failing existing ones with ErrSkillAlreadyExists
+func (m *MockRepository) BatchCreateSkills(username string, skills []*models.UserSkill) error {
+	log := logger.WithComponent("database").With("operation", "BatchCreateSkills", "username", username, "count", len(skills), "repository", "mock")
+	start := time.Now()
+
+	log.Debug("Starting batch skill creation in mock repository")
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	failed := make(map[int]error)
+	for i, skill := range skills {
+		key := models.BuildUserSkillEntityID(username, skill.SkillID)
+		if _, exists := m.skills[key]; exists {
+			failed[i] = apperrors.ErrSkillAlreadyExists
+			continue
+		}
+		m.skills[key] = skill
+		m.adjustSkillCount(username, 1)
+	}
+
+	if len(failed) > 0 {
+		log.Info("Batch skill creation completed with existing skills in mock repository", "failed", len(failed), "duration", time.Since(start))
+		return &BatchWriteError{Failed: failed}
+	}
+
+	log.Info("Batch skill creation completed in mock repository", "total_skills", len(m.skills), "duration", time.Since(start))
+	return nil
+}
+
+// BatchDeleteSkills removes user skills from memory, failing missing ones with ErrSkillNotFound
+func (m *MockRepository) BatchDeleteSkills(username string, skillIDs []string) error {
+	log := logger.WithComponent("database").With("operation", "BatchDeleteSkills", "username", username, "count", len(skillIDs), "repository", "mock")
+	start := time.Now()
+
+	log.Debug("Starting batch skill deletion in mock repository")
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	failed := make(map[int]error)
+	for i, skillID := range skillIDs {
+		key := models.BuildUserSkillEntityID(username, skillID)
+		if _, exists := m.skills[key]; !exists {
+			failed[i] = apperrors.ErrSkillNotFound
+			continue
+		}
+		delete(m.skills, key)
+		m.adjustSkillCount(username, -1)
+	}
+
+	if len(failed) > 0 {
+		log.Info("Batch skill deletion completed with missing skills in mock repository", "failed", len(failed), "duration", time.Since(start))
+		return &BatchWriteError{Failed: failed}
+	}
+
+	log.Info("Batch skill deletion completed in mock repository", "total_skills", len(m.skills), "duration", time.Since(start))
+	return nil
+}
+
 // GetSkill retrieves a user skill from memory
 func (m *MockRepository) GetSkill(username, skillID string) (*models.UserSkill, error) {
+	return m.GetSkillWithContext(context.Background(), username, skillID)
+}
+
+// GetSkillWithContext is GetSkill bounded by ctx
+func (m *MockRepository) GetSkillWithContext(ctx context.Context, username, skillID string) (*models.UserSkill, error) {
 	log := logger.WithComponent("database").With("operation", "GetSkill", "username", username, "skill_id", skillID, "repository", "mock")
 	start := time.Now()
 
+	if err := ctx.Err(); err != nil {
+		log.Info("Context done before mock operation", "error", err.Error(), "duration", time.Since(start))
+		return nil, contextError(ctx, err)
+	}
+
 	log.Debug("Starting skill retrieval from mock repository")
 
 	m.mutex.RLock()
@@ -52,9 +136,19 @@ func (m *MockRepository) GetSkill(username, skillID string) (*models.UserSkill,
 
 // UpdateSkill updates a user skill in memory
 func (m *MockRepository) UpdateSkill(skill *models.UserSkill) error {
+	return m.UpdateSkillWithContext(context.Background(), skill)
+}
+
+// UpdateSkillWithContext is UpdateSkill bounded by ctx
+func (m *MockRepository) UpdateSkillWithContext(ctx context.Context, skill *models.UserSkill) error {
 	log := logger.WithComponent("database").With("operation", "UpdateSkill", "username", skill.Username, "skill_id", skill.SkillID, "repository", "mock")
 	start := time.Now()
 
+	if err := ctx.Err(); err != nil {
+		log.Info("Context done before mock operation", "error", err.Error(), "duration", time.Since(start))
+		return contextError(ctx, err)
+	}
+
 	log.Debug("Starting skill update in mock repository")
 
 	m.mutex.Lock()
@@ -71,11 +165,64 @@ func (m *MockRepository) UpdateSkill(skill *models.UserSkill) error {
 	return nil
 }
 
+// RenameSkill updates a user skill's SkillName and Category in memory
+func (m *MockRepository) RenameSkill(username, skillID, skillName, category string) error {
+	log := logger.WithComponent("database").With("operation", "RenameSkill", "username", username, "skill_id", skillID, "repository", "mock")
+	start := time.Now()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	skill, exists := m.skills[models.BuildUserSkillEntityID(username, skillID)]
+	if !exists {
+		log.Debug("Skill not found for rename", "duration", time.Since(start))
+		return apperrors.ErrSkillNotFound
+	}
+
+	skill.SkillName = skillName
+	skill.Category = category
+	log.Debug("Skill renamed in mock repository", "duration", time.Since(start))
+	return nil
+}
+
+// SetSkillLevel sets a user skill's ProficiencyLevel in memory if it still holds from
+func (m *MockRepository) SetSkillLevel(username, skillID string, from, level models.ProficiencyLevel) error {
+	log := logger.WithComponent("database").With("operation", "SetSkillLevel", "username", username, "skill_id", skillID, "from", from, "level", level, "repository", "mock")
+	start := time.Now()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	key := models.BuildUserSkillEntityID(username, skillID)
+	skill, exists := m.skills[key]
+	if !exists || skill.ProficiencyLevel != from {
+		log.Info("Skill level changed before update in mock repository", "duration", time.Since(start))
+		return ErrSkillLevelChanged
+	}
+
+	updated := *skill
+	updated.ProficiencyLevel = level
+	updated.UpdatedAt = time.Now()
+	m.skills[key] = &updated
+	log.Info("Skill level set successfully in mock repository", "duration", time.Since(start))
+	return nil
+}
+
 // DeleteSkill deletes a user skill from memory
 func (m *MockRepository) DeleteSkill(username, skillID string) error {
+	return m.DeleteSkillWithContext(context.Background(), username, skillID)
+}
+
+// DeleteSkillWithContext is DeleteSkill bounded by ctx
+func (m *MockRepository) DeleteSkillWithContext(ctx context.Context, username, skillID string) error {
 	log := logger.WithComponent("database").With("operation", "DeleteSkill", "username", username, "skill_id", skillID, "repository", "mock")
 	start := time.Now()
 
+	if err := ctx.Err(); err != nil {
+		log.Info("Context done before mock operation", "error", err.Error(), "duration", time.Since(start))
+		return contextError(ctx, err)
+	}
+
 	log.Debug("Starting skill deletion from mock repository")
 
 	m.mutex.Lock()
@@ -88,15 +235,143 @@ func (m *MockRepository) DeleteSkill(username, skillID string) error {
 	}
 
 	delete(m.skills, key)
+	m.adjustSkillCount(username, -1)
 	log.Info("Skill deleted successfully from mock repository", "duration", time.Since(start))
 	return nil
 }
 
+// AdjustSkillCount adds delta to a user's SkillCount in memory
+func (m *MockRepository) AdjustSkillCount(username string, delta int) error {
+	log := logger.WithComponent("database").With("operation", "AdjustSkillCount", "username", username, "delta", delta, "repository", "mock")
+	start := time.Now()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	user, exists := m.users[username]
+	if !exists {
+		log.Debug("User not found in mock repository", "duration", time.Since(start))
+		return apperrors.ErrUserNotFound
+	}
+
+	user.SkillCount += delta
+	log.Debug("Skill count adjusted in mock repository", "count", user.SkillCount, "duration", time.Since(start))
+	return nil
+}
+
+// adjustSkillCount adds delta to the owner's SkillCount; callers must hold the lock
+// Unlike DynamoDB, the mock accepts skills whose owner was never created, which many tests rely
+// on; such skills are simply not counted.
+func (m *MockRepository) adjustSkillCount(username string, delta int) {
+	if user, exists := m.users[username]; exists {
+		user.SkillCount += delta
+	}
+}
+
+// ReplaceSkillCount sets a user's SkillCount in memory if it still holds old
+func (m *MockRepository) ReplaceSkillCount(username string, old, count int) error {
+	log := logger.WithComponent("database").With("operation", "ReplaceSkillCount", "username", username, "old", old, "count", count, "repository", "mock")
+	start := time.Now()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	user, exists := m.users[username]
+	if !exists || user.SkillCount != old {
+		log.Debug("Skill count changed or user removed before replacement", "duration", time.Since(start))
+		return ErrSkillCountChanged
+	}
+
+	user.SkillCount = count
+	log.Debug("Skill count replaced in mock repository", "duration", time.Since(start))
+	return nil
+}
+
+// MergeUserSkill applies merge to memory, checking every condition before changing anything
+func (m *MockRepository) MergeUserSkill(merge *UserSkillMerge) error {
+	log := logger.WithComponent("database").With("operation", "MergeUserSkill", "username", merge.Source.Username,
+		"source", merge.Source.SkillID, "target", merge.Target.SkillID, "repository", "mock")
+	start := time.Now()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	sourceKey := models.BuildUserSkillEntityID(merge.Source.Username, merge.Source.SkillID)
+	targetKey := models.BuildUserSkillEntityID(merge.Target.Username, merge.Target.SkillID)
+	if _, exists := m.skills[sourceKey]; !exists {
+		log.Debug("Source skill not found", "duration", time.Since(start))
+		return apperrors.ErrSkillNotFound
+	}
+	if _, exists := m.skills[targetKey]; exists != merge.Replaces {
+		log.Debug("Target skill changed before merge", "exists", exists, "duration", time.Since(start))
+		if exists {
+			return apperrors.ErrSkillAlreadyExists
+		}
+		return apperrors.ErrSkillNotFound
+	}
+
+	for _, endorsement := range merge.MoveEndorsements {
+		moved := *endorsement
+		moved.SkillID = merge.Target.SkillID
+		moved.SetKeys()
+		delete(m.endorsements, endorsement.EntityID)
+		m.endorsements[moved.EntityID] = &moved
+	}
+	for _, endorsement := range merge.DropEndorsements {
+		delete(m.endorsements, endorsement.EntityID)
+	}
+	for _, history := range merge.MoveHistory {
+		moved := *history
+		moved.SkillID = merge.Target.SkillID
+		moved.SetKeys()
+		delete(m.histories, history.EntityID)
+		m.histories[moved.EntityID] = &moved
+	}
+
+	merge.Target.SetKeys()
+	delete(m.skills, sourceKey)
+	m.skills[targetKey] = merge.Target
+	if merge.Replaces {
+		m.adjustSkillCount(merge.Source.Username, -1)
+	}
+
+	log.Debug("User skill merged in mock repository", "duration", time.Since(start))
+	return nil
+}
+
+// CountSkillsForUser returns a user's SkillCount from memory
+func (m *MockRepository) CountSkillsForUser(username string) (int, error) {
+	log := logger.WithComponent("database").With("operation", "CountSkillsForUser", "username", username, "repository", "mock")
+	start := time.Now()
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	user, exists := m.users[username]
+	if !exists {
+		log.Debug("User not found in mock repository", "duration", time.Since(start))
+		return 0, apperrors.ErrUserNotFound
+	}
+
+	log.Debug("User skill count retrieved from mock repository", "count", user.SkillCount, "duration", time.Since(start))
+	return user.SkillCount, nil
+}
+
 // ListSkillsForUser retrieves all skills for a specific user from memory
 func (m *MockRepository) ListSkillsForUser(username string) ([]*models.UserSkill, error) {
+	return m.ListSkillsForUserWithContext(context.Background(), username)
+}
+
+// ListSkillsForUserWithContext is ListSkillsForUser bounded by ctx
+func (m *MockRepository) ListSkillsForUserWithContext(ctx context.Context, username string) ([]*models.UserSkill, error) {
 	log := logger.WithComponent("database").With("operation", "ListSkillsForUser", "username", username, "repository", "mock")
 	start := time.Now()
 
+	if err := ctx.Err(); err != nil {
+		log.Info("Context done before mock operation", "error", err.Error(), "duration", time.Since(start))
+		return nil, contextError(ctx, err)
+	}
+
 	log.Debug("Starting skills list retrieval for user from mock repository")
 
 	m.mutex.RLock()
@@ -113,25 +388,64 @@ func (m *MockRepository) ListSkillsForUser(username string) ([]*models.UserSkill
 	return skills, nil
 }
 
+// ListSkillsBySkillID retrieves all user skills referencing a master skill from memory
+func (m *MockRepository) ListSkillsBySkillID(skillID string) ([]*models.UserSkill, error) {
+	log := logger.WithComponent("database").With("operation", "ListSkillsBySkillID", "skill_id", skillID, "repository", "mock")
+	start := time.Now()
+
+	log.Debug("Starting skills list retrieval by skill ID from mock repository")
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var skills []*models.UserSkill
+	for _, skill := range m.skills {
+		if skill.SkillID == skillID {
+			skills = append(skills, skill)
+		}
+	}
+
+	log.Info("Skills retrieved successfully by skill ID from mock repository", "count", len(skills), "duration", time.Since(start))
+	return skills, nil
+}
+
 // ListUsersBySkill retrieves all users with a specific skill from memory
-func (m *MockRepository) ListUsersBySkill(category, skillName string) ([]*models.UserSkill, error) {
-	log := logger.WithComponent("database").With("operation", "ListUsersBySkill", "category", category, "skill", skillName, "repository", "mock")
+func (m *MockRepository) ListUsersBySkill(category, skillName string, limit int, nextToken string) ([]*models.UserSkill, string, error) {
+	log := logger.WithComponent("database").With("operation", "ListUsersBySkill", "category", category, "skill", skillName, "limit", limit, "repository", "mock")
 	start := time.Now()
 
 	log.Debug("Starting users list retrieval by skill from mock repository")
 
+	// Tokens encode the last username returned; results are sorted by username for stable pages
+	var after string
+	if nextToken != "" {
+		decoded, err := base64.RawURLEncoding.DecodeString(nextToken)
+		if err != nil || len(decoded) == 0 {
+			log.Info("Invalid pagination token", "duration", time.Since(start))
+			return nil, "", apperrors.ErrInvalidPageToken
+		}
+		after = string(decoded)
+	}
+
 	m.mutex.RLock()
 	defer m.mutex.RUnlock()
 
 	var skills []*models.UserSkill
 	for _, skill := range m.skills {
-		if skill.Category == category && skill.SkillName == skillName {
+		if skill.Category == category && skill.SkillName == skillName && skill.Username > after {
 			skills = append(skills, skill)
 		}
 	}
+	sort.Slice(skills, func(i, j int) bool { return skills[i].Username < skills[j].Username })
 
-	log.Info("Users retrieved successfully by skill from mock repository", "count", len(skills), "duration", time.Since(start))
-	return skills, nil
+	var token string
+	if limit > 0 && len(skills) > limit {
+		skills = skills[:limit]
+		token = base64.RawURLEncoding.EncodeToString([]byte(skills[limit-1].Username))
+	}
+
+	log.Info("Users retrieved successfully by skill from mock repository", "count", len(skills), "has_more", token != "", "duration", time.Since(start))
+	return skills, token, nil
 }
 
 // ListUsersBySkillAndLevel retrieves all users with a specific skill and proficiency level from memory
@@ -154,3 +468,28 @@ func (m *MockRepository) ListUsersBySkillAndLevel(category, skillName string, pr
 	log.Info("Users retrieved successfully by skill and level from mock repository", "count", len(skills), "duration", time.Since(start))
 	return skills, nil
 }
+
+// ListRecentSkills retrieves the most recently updated user skills from memory, newest first
+func (m *MockRepository) ListRecentSkills(limit int) ([]*models.UserSkill, error) {
+	log := logger.WithComponent("database").With("operation", "ListRecentSkills", "limit", limit, "repository", "mock")
+	start := time.Now()
+
+	log.Debug("Starting recent skills retrieval from mock repository")
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	skills := make([]*models.UserSkill, 0, len(m.skills))
+	for _, skill := range m.skills {
+		skills = append(skills, skill)
+	}
+	sort.Slice(skills, func(i, j int) bool {
+		return skills[i].UpdatedAt.After(skills[j].UpdatedAt)
+	})
+	if limit > 0 && len(skills) > limit {
+		skills = skills[:limit]
+	}
+
+	log.Info("Recent skills retrieved successfully from mock repository", "count", len(skills), "duration", time.Since(start))
+	return skills, nil
+}
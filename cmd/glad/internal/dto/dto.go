@@ -15,28 +15,126 @@ type LoginRequest struct {
 	Password string `json:"password" validate:"required"`
 }
 
+// RefreshRequest represents a request to exchange a refresh token for an access token
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
 // UpdateUserRequest represents a user update request
 type UpdateUserRequest struct {
 	Name     *string `json:"name,omitempty" validate:"omitempty,min=2,max=100"`
 	Password *string `json:"password,omitempty" validate:"omitempty,min=6"`
+	Email    *string `json:"email,omitempty" validate:"omitempty,email,max=254"`
 }
 
 // Response DTOs
 
+// ConfirmRequest echoes the target of a destructive request to guard against mistakes
+type ConfirmRequest struct {
+	Confirm string `json:"confirm" validate:"required"`
+}
+
 // MessageResponse represents a simple message response
 type MessageResponse struct {
 	Message string `json:"message"`
 }
 
 // ErrorResponse represents an error response
+// Code is stable for clients to branch on; Error is a human-readable message that may change.
 type ErrorResponse struct {
 	Error string `json:"error"`
+	Code  string `json:"code"`
+}
+
+// Error codes returned in ErrorResponse.Code
+// Errors without a domain code carry their HTTP status text in upper snake case instead,
+// e.g. BAD_REQUEST, UNAUTHORIZED, NOT_FOUND, TOO_MANY_REQUESTS or INTERNAL_SERVER_ERROR.
+const (
+	// User and authentication
+	CodeUserNotFound        = "USER_NOT_FOUND"
+	CodeUserExists          = "USER_EXISTS"
+	CodeEmailInUse          = "EMAIL_IN_USE"
+	CodeInvalidCredentials  = "INVALID_CREDENTIALS"
+	CodeInvalidRefreshToken = "INVALID_REFRESH_TOKEN"
+	CodeAccountArchived     = "ACCOUNT_ARCHIVED"
+
+	// User skills and endorsements
+	CodeSkillNotFound            = "SKILL_NOT_FOUND"
+	CodeSkillExists              = "SKILL_EXISTS"
+	CodeSelfEndorsement          = "SELF_ENDORSEMENT"
+	CodeAlreadyEndorsed          = "ALREADY_ENDORSED"
+	CodeEndorsementNotFound      = "ENDORSEMENT_NOT_FOUND"
+	CodeSkillAtHighestLevel      = "SKILL_AT_HIGHEST_LEVEL"
+	CodeSnapshotNotFound         = "SNAPSHOT_NOT_FOUND"
+	CodeEndorsementLimitExceeded = "ENDORSEMENT_LIMIT_EXCEEDED"
+	CodeTeamMatrixLimitExceeded  = "TEAM_MATRIX_LIMIT_EXCEEDED"
+	CodeBulkSkillsLimitExceeded  = "BULK_SKILLS_LIMIT_EXCEEDED"
+
+	// Master skills
+	CodeMasterSkillNotFound  = "MASTER_SKILL_NOT_FOUND"
+	CodeMasterSkillExists    = "MASTER_SKILL_EXISTS"
+	CodeMasterSkillNameTaken = "MASTER_SKILL_NAME_TAKEN"
+	CodeMasterSkillDeleted   = "MASTER_SKILL_DELETED"
+	CodeImportLimitExceeded  = "IMPORT_LIMIT_EXCEEDED"
+	CodeMergeIntoSelf        = "MERGE_INTO_SELF"
+
+	// Request validation
+	CodeValidationFailed   = "VALIDATION_FAILED" // see ValidationErrorResponse.Fields
+	CodeRequiredField      = "REQUIRED_FIELD"
+	CodeImmutableField     = "IMMUTABLE_FIELD"
+	CodeInvalidDate        = "INVALID_DATE"
+	CodeInvalidTimeZone    = "INVALID_TIME_ZONE"
+	CodeInvalidUsername    = "INVALID_USERNAME"
+	CodeInvalidName        = "INVALID_NAME"
+	CodeInvalidPassword    = "INVALID_PASSWORD"
+	CodeInvalidEmail       = "INVALID_EMAIL"
+	CodeInvalidProficiency = "INVALID_PROFICIENCY_LEVEL"
+	CodeInvalidYears       = "INVALID_YEARS_OF_EXPERIENCE"
+	CodeInconsistentYears  = "INCONSISTENT_EXPERIENCE"
+	CodeInvalidSkillName   = "INVALID_SKILL_NAME"
+	CodeSkillNameTooShort  = "SKILL_NAME_TOO_SHORT"
+	CodeReservedSkillName  = "RESERVED_SKILL_NAME"
+	CodeInvalidCategory    = "INVALID_CATEGORY"
+	CodeCategoryRequired   = "CATEGORY_REQUIRED"
+	CodeInvalidPageToken   = "INVALID_PAGE_TOKEN"
+	CodeInvalidSearchField = "INVALID_SEARCH_FIELD"
+
+	// Request deadline
+	CodeTimeout = "TIMEOUT"
+)
+
+// ValidationErrorResponse represents a 400 response listing each invalid field
+type ValidationErrorResponse struct {
+	Error  string            `json:"error"`
+	Code   string            `json:"code"`
+	Fields map[string]string `json:"fields"`
+}
+
+// InvalidCategoryResponse represents a 400 response for an unknown category, listing the allowed ones
+type InvalidCategoryResponse struct {
+	Error             string   `json:"error"`
+	Code              string   `json:"code"`
+	AllowedCategories []string `json:"allowed_categories"`
+}
+
+// SkillConflictResponse represents a 409 response for a skill the user already has
+type SkillConflictResponse struct {
+	Error    string                `json:"error"`
+	Code     string                `json:"code"`
+	Existing ExistingSkillResponse `json:"existing"`
+}
+
+// ExistingSkillResponse summarizes the stored skill in a conflict response
+type ExistingSkillResponse struct {
+	ProficiencyLevel string `json:"proficiency_level"`
+	UpdatedAt        string `json:"updated_at"`
 }
 
 // TokenResponse represents a token response
 type TokenResponse struct {
-	AccessToken string `json:"access_token"`
-	TokenType   string `json:"token_type"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	TokenType    string `json:"token_type"`
 }
 
 // ProtectedResponse represents a protected resource response
@@ -59,14 +157,22 @@ type CurrentUserResponse struct {
 	UpdatedAt string `json:"updated_at"`
 }
 
+// ProfileExportResponse is a user's full profile in one document, for data portability
+type ProfileExportResponse struct {
+	User   CurrentUserResponse `json:"user"`
+	Skills []SkillResponse     `json:"skills"`
+}
+
 // Skill Request DTOs
 
 // CreateSkillRequest represents a request to add a skill to a user
+// Either skill_id or skill_name identifies the master skill; skill_id wins when both are set.
 type CreateSkillRequest struct {
-	SkillName         string `json:"skill_name" validate:"required,min=1,max=100"`
-	ProficiencyLevel  string `json:"proficiency_level" validate:"required,oneof=Beginner Intermediate Advanced Expert"`
-	YearsOfExperience int    `json:"years_of_experience" validate:"min=0"`
-	Notes             string `json:"notes,omitempty" validate:"max=500"`
+	SkillID           string  `json:"skill_id,omitempty" validate:"max=50"`
+	SkillName         string  `json:"skill_name,omitempty" validate:"max=100"`
+	ProficiencyLevel  *string `json:"proficiency_level,omitempty" validate:"omitempty,oneof=Beginner Intermediate Advanced Expert"` // omitted: SKILL_DEFAULT_PROFICIENCY, Beginner by default
+	YearsOfExperience int     `json:"years_of_experience" validate:"min=0"`
+	Notes             string  `json:"notes,omitempty" validate:"max=500"`
 }
 
 // UpdateSkillRequest represents a request to update a user's skill
@@ -76,6 +182,33 @@ type UpdateSkillRequest struct {
 	Notes             *string `json:"notes,omitempty" validate:"omitempty,max=500"`
 }
 
+// BatchCreateSkillsRequest represents a request to add several skills to a user at once
+type BatchCreateSkillsRequest struct {
+	Skills []CreateSkillRequest `json:"skills" validate:"required,min=1,dive"`
+}
+
+// BatchDeleteSkillsRequest represents a request to remove several skills from a user at once
+type BatchDeleteSkillsRequest struct {
+	SkillNames []string `json:"skill_names" validate:"required,min=1"`
+}
+
+// BulkEndorseRequest represents a request to endorse one skill for several users
+type BulkEndorseRequest struct {
+	Usernames []string `json:"usernames" validate:"required,min=1"`
+	Category  string   `json:"category,omitempty"`
+}
+
+// TeamMatrixRequest represents a request for a team's skills matrix in one category
+type TeamMatrixRequest struct {
+	Usernames []string `json:"usernames" validate:"required,min=1"`
+	Category  string   `json:"category" validate:"required"`
+}
+
+// BulkSkillsRequest represents a request for several users' skills at once
+type BulkSkillsRequest struct {
+	Usernames []string `json:"usernames" validate:"required,min=1"`
+}
+
 // Skill Response DTOs
 
 // SkillResponse represents a skill in responses
@@ -86,6 +219,7 @@ type SkillResponse struct {
 	Endorsements      int    `json:"endorsements"`
 	LastUsedDate      string `json:"last_used_date"`
 	Notes             string `json:"notes,omitempty"`
+	PromotionEligible bool   `json:"promotion_eligible,omitempty"`
 	CreatedAt         string `json:"created_at"`
 	UpdatedAt         string `json:"updated_at"`
 }
@@ -101,6 +235,182 @@ type UserSkillResponse struct {
 	LastUsedDate      string `json:"last_used_date"`
 }
 
+// BatchResult is the per-item result shared by all batch endpoints
+// Input identifies the submitted item (e.g. a skill name or username) and Data holds the
+// resulting entity on success. Err carries the cause of a failure; handlers map it to
+// ErrorCode and ErrorMessage and it is never serialized.
+type BatchResult[T any] struct {
+	Index        int    `json:"index"`
+	Input        string `json:"input"`
+	Success      bool   `json:"success"`
+	ErrorCode    string `json:"error_code,omitempty"`
+	ErrorMessage string `json:"error_message,omitempty"`
+	Data         *T     `json:"data,omitempty"`
+	Err          error  `json:"-"`
+}
+
+// BatchSkillResponse represents the per-item results of a batch skill request
+type BatchSkillResponse struct {
+	Created int                          `json:"created"`
+	Failed  int                          `json:"failed"`
+	Results []BatchResult[SkillResponse] `json:"results"`
+}
+
+// BatchDeleteSkillsResponse represents the per-name results of a batch skill deletion
+// Data holds the removed skill for each deleted entry.
+type BatchDeleteSkillsResponse struct {
+	Deleted int                          `json:"deleted"`
+	Failed  int                          `json:"failed"`
+	Results []BatchResult[SkillResponse] `json:"results"`
+}
+
+// UserSkillPageResponse represents one page of users with a skill
+type UserSkillPageResponse struct {
+	Users []UserSkillResponse `json:"users"`
+	Next  string              `json:"next,omitempty"`
+}
+
+// BulkEndorseResponse represents the per-user results of a bulk endorsement
+type BulkEndorseResponse struct {
+	Endorsed int                          `json:"endorsed"`
+	Failed   int                          `json:"failed"`
+	Results  []BatchResult[SkillResponse] `json:"results"`
+}
+
+// ResyncUserSkillsResponse reports how many user skills a master skill resync updated
+type ResyncUserSkillsResponse struct {
+	SkillID string `json:"skill_id"`
+	Updated int    `json:"updated"`
+}
+
+// TeamMatrix is a pivot of team members against the skills they hold in one category
+// Skills lists the column headers; each row's Levels line up with Skills and are empty
+// where the member lacks that skill.
+type TeamMatrix struct {
+	Category string
+	Skills   []string
+	Rows     []TeamMatrixRow
+}
+
+// TeamMatrixRow holds one team member's proficiency levels in TeamMatrix column order
+type TeamMatrixRow struct {
+	Username string
+	Levels   []string
+}
+
+// RecentSkillResponse represents one entry of the recently updated skills feed
+type RecentSkillResponse struct {
+	Username         string `json:"username"`
+	SkillName        string `json:"skill_name"`
+	Category         string `json:"category"`
+	ProficiencyLevel string `json:"proficiency_level"`
+	UpdatedAt        string `json:"updated_at"`
+}
+
+// RelatedSkillResponse represents a skill commonly held alongside another one
+type RelatedSkillResponse struct {
+	SkillName string `json:"skill_name"`
+	Category  string `json:"category"`
+	Users     int    `json:"users"` // holders of the input skill who also have this one
+}
+
+// TagUsersResponse represents the users holding skills that carry a tag
+type TagUsersResponse struct {
+	Tag       string            `json:"tag"`
+	Users     []TagUserResponse `json:"users"`
+	Truncated bool              `json:"truncated"` // more skills carry the tag than were searched
+}
+
+// TagUserResponse represents a user and the tagged skills they hold
+type TagUserResponse struct {
+	Username string   `json:"username"`
+	Skills   []string `json:"skills"`
+}
+
+// SkillGapResponse represents the skills another user has over this one
+type SkillGapResponse struct {
+	Username string                  `json:"username"`
+	Against  string                  `json:"against"`
+	Missing  []SkillResponse         `json:"missing"` // the other user's skills this user lacks
+	Weaker   []SkillGapLevelResponse `json:"weaker"`  // shared skills the other user holds at a higher level
+}
+
+// SkillGapLevelResponse represents a shared skill and both users' proficiency levels
+type SkillGapLevelResponse struct {
+	Skill      string `json:"skill"`
+	Category   string `json:"category"`
+	YourLevel  string `json:"your_level"`
+	TheirLevel string `json:"their_level"`
+}
+
+// SkillHistoryResponse represents one proficiency change of a user's skill
+type SkillHistoryResponse struct {
+	SkillName string `json:"skill_name"`
+	OldLevel  string `json:"old_level"`
+	NewLevel  string `json:"new_level"`
+	ChangedAt string `json:"changed_at"`
+}
+
+// ActivityResponse represents one entry of a user's activity log
+// SkillName is omitted for single skill deletions, which only know the skill ID.
+// OldLevel and NewLevel are set when the proficiency level changed; added skills carry only NewLevel.
+type ActivityResponse struct {
+	Action     string `json:"action"`
+	SkillID    string `json:"skill_id"`
+	SkillName  string `json:"skill_name,omitempty"`
+	OldLevel   string `json:"old_level,omitempty"`
+	NewLevel   string `json:"new_level,omitempty"`
+	OccurredAt string `json:"occurred_at"`
+}
+
+// ActivityPageResponse represents one page of a user's activity log
+type ActivityPageResponse struct {
+	Activity []ActivityResponse `json:"activity"`
+	Next     string             `json:"next,omitempty"`
+}
+
+// EndorsementResponse represents one endorser of a user's skill
+type EndorsementResponse struct {
+	Endorser   string `json:"endorser"`
+	EndorsedAt string `json:"endorsed_at"`
+}
+
+// Skill Snapshot Response DTOs
+
+// SkillSnapshotResponse represents a stored point-in-time skill snapshot
+type SkillSnapshotResponse struct {
+	SnapshotID string `json:"snapshot_id"`
+	Username   string `json:"username"`
+	SkillCount int    `json:"skill_count"`
+	CreatedAt  string `json:"created_at"`
+}
+
+// SnapshotSkillResponse represents a skill added or removed since a snapshot
+type SnapshotSkillResponse struct {
+	SkillID           string `json:"skill_id"`
+	SkillName         string `json:"skill_name"`
+	ProficiencyLevel  string `json:"proficiency_level"`
+	YearsOfExperience int    `json:"years_of_experience"`
+}
+
+// SkillLevelChangeResponse represents a proficiency level change since a snapshot
+type SkillLevelChangeResponse struct {
+	SkillID   string `json:"skill_id"`
+	SkillName string `json:"skill_name"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+}
+
+// SkillDiffResponse represents the changes to a user's skills since a snapshot
+type SkillDiffResponse struct {
+	Username   string                     `json:"username"`
+	SnapshotID string                     `json:"snapshot_id"`
+	SnapshotAt string                     `json:"snapshot_at"`
+	Added      []SnapshotSkillResponse    `json:"added"`
+	Removed    []SnapshotSkillResponse    `json:"removed"`
+	Leveled    []SkillLevelChangeResponse `json:"leveled"`
+}
+
 // Master Skill Request DTOs
 
 // CreateMasterSkillRequest represents a request to create a master skill
@@ -112,8 +422,19 @@ type CreateMasterSkillRequest struct {
 	Tags        []string `json:"tags,omitempty"`
 }
 
+// ImportMasterSkillsResponse represents the per-item results of a master skill import
+// Entries whose skill_id already exists are counted as skipped rather than failed.
+type ImportMasterSkillsResponse struct {
+	Created int                                `json:"created"`
+	Skipped int                                `json:"skipped"`
+	Failed  int                                `json:"failed"`
+	Results []BatchResult[MasterSkillResponse] `json:"results"`
+}
+
 // UpdateMasterSkillRequest represents a request to update a master skill
+// Skill IDs are permanent; SkillID is accepted only when it matches the ID in the path.
 type UpdateMasterSkillRequest struct {
+	SkillID     string   `json:"skill_id,omitempty"`
 	SkillName   string   `json:"skill_name,omitempty" validate:"omitempty,min=1,max=100"`
 	Description string   `json:"description,omitempty" validate:"omitempty,max=500"`
 	Category    string   `json:"category,omitempty" validate:"omitempty,min=1,max=50"`
@@ -129,6 +450,54 @@ type MasterSkillResponse struct {
 	Description string   `json:"description"`
 	Category    string   `json:"category"`
 	Tags        []string `json:"tags,omitempty"`
+	Featured    bool     `json:"featured,omitempty"`
 	CreatedAt   string   `json:"created_at"`
 	UpdatedAt   string   `json:"updated_at"`
+	Deleted     bool     `json:"deleted,omitempty"`
+	DeletedAt   string   `json:"deleted_at,omitempty"`
+}
+
+// MasterSkillPageResponse represents one page of master skills
+type MasterSkillPageResponse struct {
+	Items     []MasterSkillResponse `json:"items"`
+	NextToken string                `json:"next_token,omitempty"`
+}
+
+// MasterSkillOverviewResponse combines a master skill with its usage across users
+type MasterSkillOverviewResponse struct {
+	Skill MasterSkillResponse `json:"skill"`
+	Usage SkillUsageStats     `json:"usage"`
+}
+
+// SkillUsageStats aggregates how users hold one skill
+type SkillUsageStats struct {
+	TotalUsers   int            `json:"total_users"`
+	ByLevel      map[string]int `json:"by_level"`
+	AverageYears float64        `json:"average_years"`
+}
+
+// MasterSkillMergeResponse reports the outcome of merging one master skill into another
+// Moved counts user skills repointed to the target; Merged counts users who already held the
+// target, of which only the higher proficiency was kept.
+type MasterSkillMergeResponse struct {
+	SourceID string `json:"source_id"`
+	TargetID string `json:"target_id"`
+	Moved    int    `json:"moved"`
+	Merged   int    `json:"merged"`
+}
+
+// CategorySuggestionResponse represents a ranked category suggestion for a new skill
+type CategorySuggestionResponse struct {
+	Category      string   `json:"category"`
+	Score         int      `json:"score"`
+	SimilarSkills []string `json:"similar_skills"`
+}
+
+// Health Response DTOs
+
+// HealthResponse represents the result of a health check
+type HealthResponse struct {
+	Status    string `json:"status"`
+	DB        string `json:"db"`
+	LatencyMs int64  `json:"latency_ms"`
 }
@@ -0,0 +1,17 @@
+package errors
+
+import "fmt"
+
+// BatchItemError identifies the item in a batch request that caused an error
+type BatchItemError struct {
+	Index int
+	Err   error
+}
+
+func (e *BatchItemError) Error() string {
+	return fmt.Sprintf("item %d: %v", e.Index, e.Err)
+}
+
+func (e *BatchItemError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,18 @@
+package errors
+
+import "strings"
+
+// InvalidCategoryError reports a category outside the allowed set, carrying that set
+// so clients can show the valid choices. It matches ErrInvalidCategory via errors.Is.
+type InvalidCategoryError struct {
+	Category string
+	Allowed  []string
+}
+
+func (e *InvalidCategoryError) Error() string {
+	return "category must be one of " + strings.Join(e.Allowed, ", ")
+}
+
+func (e *InvalidCategoryError) Is(target error) bool {
+	return target == ErrInvalidCategory
+}
@@ -7,25 +7,54 @@ var (
 	// ErrUserExists User existence errors
 	ErrUserExists   = errors.New("user already exists")
 	ErrUserNotFound = errors.New("user not found")
+	ErrEmailInUse   = errors.New("email is already used by another user")
+
+	// ErrAccountArchived Account status errors
+	ErrAccountArchived = errors.New("account is archived")
 
 	// ErrInvalidUsername Validation errors
 	ErrInvalidUsername = errors.New("username must be between 3 and 50 characters")
 	ErrInvalidName     = errors.New("name must be between 2 and 100 characters")
 	ErrInvalidPassword = errors.New("password must be at least 6 characters")
+	ErrInvalidEmail    = errors.New("email must be a valid address of at most 254 characters")
 
 	// ErrInvalidCredentials Authentication errors
-	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrInvalidCredentials  = errors.New("invalid credentials")
+	ErrInvalidRefreshToken = errors.New("refresh token is invalid or expired")
 
 	// ErrSkillNotFound Skill-related errors
-	ErrSkillNotFound            = errors.New("skill not found")
-	ErrSkillAlreadyExists       = errors.New("skill already exists for this user")
-	ErrInvalidProficiencyLevel  = errors.New("proficiency level must be Beginner, Intermediate, Advanced, or Expert")
-	ErrInvalidYearsOfExperience = errors.New("years of experience must be non-negative")
-	ErrInvalidSkillName         = errors.New("skill name must be between 1 and 100 characters")
+	ErrSkillNotFound              = errors.New("skill not found")
+	ErrSkillAlreadyExists         = errors.New("skill already exists for this user")
+	ErrInvalidProficiencyLevel    = errors.New("proficiency level must be Beginner, Intermediate, Advanced, or Expert")
+	ErrInvalidYearsOfExperience   = errors.New("years of experience must be non-negative")
+	ErrInconsistentExperience     = errors.New("years of experience is too low for the proficiency level")
+	ErrInvalidSkillName           = errors.New("skill name must be between 1 and 100 characters")
+	ErrSkillNameTooShort          = errors.New("skill name is too short for its category")
+	ErrInvalidSkillNameRule       = errors.New("skill name length rules need a valid category and a minimum of at least 1")
+	ErrSelfEndorsement            = errors.New("users cannot endorse their own skills")
+	ErrAlreadyEndorsed            = errors.New("you have already endorsed this skill")
+	ErrEndorsementNotFound        = errors.New("endorsement not found")
+	ErrSkillAtHighestLevel        = errors.New("skill is already at Expert, the highest proficiency level")
+	ErrSnapshotNotFound           = errors.New("skill snapshot not found")
+	ErrEndorsementLimitExceeded   = errors.New("at most 50 users can be endorsed in one request")
+	ErrInvalidPageToken           = errors.New("invalid pagination token")
+	ErrInvalidPromotionThresholds = errors.New("promotion thresholds must be three positive, increasing endorsement counts")
+	ErrTeamMatrixLimitExceeded    = errors.New("at most 100 users can be included in a team matrix")
+	ErrBulkSkillsLimitExceeded    = errors.New("skills can be fetched for at most 50 users at once")
+
+	// ErrTimeout Request deadline errors
+	ErrTimeout = errors.New("request timed out")
 
 	// ErrMasterSkillNotFound Master skill errors
-	ErrMasterSkillNotFound = errors.New("master skill not found")
-	ErrMasterSkillExists   = errors.New("master skill already exists")
-	ErrInvalidSkillID      = errors.New("skill ID must be between 1 and 50 characters")
-	ErrInvalidCategory     = errors.New("category must be between 1 and 50 characters")
+	ErrMasterSkillNotFound  = errors.New("master skill not found")
+	ErrMasterSkillExists    = errors.New("master skill already exists")
+	ErrMasterSkillNameTaken = errors.New("another master skill already uses this name")
+	ErrReservedSkillName    = errors.New("skill ID or name is reserved by an API route")
+	ErrMasterSkillDeleted   = errors.New("master skill has been deleted and can no longer be added")
+	ErrImportLimitExceeded  = errors.New("at most 500 master skills can be imported in one request")
+	ErrInvalidSkillID       = errors.New("skill ID must be between 1 and 50 characters")
+	ErrInvalidCategory      = errors.New("category must be one of Programming, Cloud, DevOps, Database, Frontend, Backend, Mobile, Data, Security, Other")
+	ErrCategoryRequired     = errors.New("category is required: use one of Programming, Cloud, DevOps, Database, Frontend, Backend, Mobile, Data, Security, Other, or set allCategories=true")
+	ErrInvalidSearchField   = errors.New("search fields must be name or description")
+	ErrMergeIntoSelf        = errors.New("a master skill cannot be merged into itself")
 )
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/dto"
+)
+
+// csvResponse renders a header row followed by rows as a text/csv response
+// encoding/csv quotes fields containing commas, quotes or line breaks as RFC 4180 requires.
+// A non-empty filename adds a Content-Disposition header so browsers download the file.
+func csvResponse(header []string, rows [][]string, filename string) events.APIGatewayProxyResponse {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(header); err != nil {
+		return errorResponse(http.StatusInternalServerError, "Internal server error")
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return errorResponse(http.StatusInternalServerError, "Internal server error")
+	}
+
+	headers := map[string]string{"Content-Type": "text/csv"}
+	if filename != "" {
+		headers["Content-Disposition"] = fmt.Sprintf("attachment; filename=%q", filename)
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusOK,
+		Headers:    headers,
+		Body:       buf.String(),
+	}
+}
+
+// usersBySkillCSVHeader lists the columns of the users-by-skill CSV export
+var usersBySkillCSVHeader = []string{"username", "skill_name", "proficiency_level", "years_of_experience", "endorsements"}
+
+// usersBySkillCSV renders users-by-skill results as a CSV response
+func usersBySkillCSV(users []dto.UserSkillResponse) events.APIGatewayProxyResponse {
+	rows := make([][]string, len(users))
+	for i, user := range users {
+		rows[i] = []string{
+			user.Username,
+			user.SkillName,
+			user.ProficiencyLevel,
+			strconv.Itoa(user.YearsOfExperience),
+			strconv.Itoa(user.Endorsements),
+		}
+	}
+	return csvResponse(usersBySkillCSVHeader, rows, "")
+}
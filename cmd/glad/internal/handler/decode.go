@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/dto"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// unknownFieldPrefix starts the message encoding/json gives for a field the target does not declare
+const unknownFieldPrefix = "json: unknown field "
+
+// UnknownFieldError reports a request body field the endpoint does not accept
+type UnknownFieldError struct {
+	Field string
+}
+
+func (e *UnknownFieldError) Error() string {
+	return fmt.Sprintf("unknown field %q", e.Field)
+}
+
+// decodeStrict unmarshals a JSON request body into dst, rejecting fields dst does not declare
+// A misspelled field then fails loudly as an *UnknownFieldError instead of being dropped and
+// surfacing later as a confusing "required" error.
+func decodeStrict(body string, dst any) error {
+	decoder := json.NewDecoder(strings.NewReader(body))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(dst); err != nil {
+		if field, ok := strings.CutPrefix(err.Error(), unknownFieldPrefix); ok {
+			return &UnknownFieldError{Field: strings.Trim(field, `"`)}
+		}
+		return err
+	}
+	if err := decoder.Decode(&struct{}{}); err != io.EOF {
+		return errors.New("unexpected data after JSON body")
+	}
+	return nil
+}
+
+// invalidBodyResponse creates the 400 response for a body decodeStrict rejected
+// Unknown fields are named in the same shape as other field validation errors.
+func invalidBodyResponse(err error) events.APIGatewayProxyResponse {
+	var unknown *UnknownFieldError
+	if errors.As(err, &unknown) {
+		return successResponse(http.StatusBadRequest, dto.ValidationErrorResponse{
+			Error:  unknown.Error(),
+			Code:   dto.CodeValidationFailed,
+			Fields: map[string]string{unknown.Field: "unknown field"},
+		})
+	}
+	return errorResponse(http.StatusBadRequest, "Invalid request body")
+}
@@ -1,8 +1,11 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
+	"strings"
 
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/dto"
 	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
 	pkgerrors "github.com/hackmajoris/glad-stack/pkg/errors"
 )
@@ -15,49 +18,140 @@ func NewErrorMapper() *ErrorMapper {
 	return &ErrorMapper{}
 }
 
-// MapToHTTP converts a service error to HTTP status code and message
-func (em *ErrorMapper) MapToHTTP(err error) (int, string) {
+// MapToHTTP converts a service error to an HTTP status code, a stable error code, and a message
+// The code identifies the domain error for clients to branch on (see the dto.Code constants);
+// the message is for display and may change.
+func (em *ErrorMapper) MapToHTTP(err error) (int, string, string) {
+	// Batch item errors are mapped by their cause and prefixed with the item index
+	var itemErr *apperrors.BatchItemError
+	if pkgerrors.As(err, &itemErr) {
+		status, code, message := em.MapToHTTP(itemErr.Err)
+		return status, code, fmt.Sprintf("Item %d: %s", itemErr.Index, message)
+	}
+
 	switch {
 	// User existence errors
 	case pkgerrors.Is(err, apperrors.ErrUserNotFound):
-		return http.StatusNotFound, "User not found"
+		return http.StatusNotFound, dto.CodeUserNotFound, "User not found"
 	case pkgerrors.Is(err, apperrors.ErrUserExists):
-		return http.StatusConflict, "User already exists"
+		return http.StatusConflict, dto.CodeUserExists, "User already exists"
+	case pkgerrors.Is(err, apperrors.ErrEmailInUse):
+		return http.StatusConflict, dto.CodeEmailInUse, err.Error()
 
 	// Authentication errors
 	case pkgerrors.Is(err, apperrors.ErrInvalidCredentials):
-		return http.StatusUnauthorized, "Invalid credentials"
+		return http.StatusUnauthorized, dto.CodeInvalidCredentials, "Invalid credentials"
+	case pkgerrors.Is(err, apperrors.ErrInvalidRefreshToken):
+		return http.StatusUnauthorized, dto.CodeInvalidRefreshToken, "Invalid or expired refresh token"
+	case pkgerrors.Is(err, apperrors.ErrAccountArchived):
+		return http.StatusForbidden, dto.CodeAccountArchived, "Account is archived"
 
 	// Skill errors
 	case pkgerrors.Is(err, apperrors.ErrSkillNotFound):
-		return http.StatusNotFound, "Skill not found"
+		return http.StatusNotFound, dto.CodeSkillNotFound, "Skill not found"
 	case pkgerrors.Is(err, apperrors.ErrSkillAlreadyExists):
-		return http.StatusConflict, "Skill already exists for this user"
+		return http.StatusConflict, dto.CodeSkillExists, "Skill already exists for this user"
+	case pkgerrors.Is(err, apperrors.ErrSelfEndorsement):
+		return http.StatusForbidden, dto.CodeSelfEndorsement, "You cannot endorse your own skill"
+	case pkgerrors.Is(err, apperrors.ErrAlreadyEndorsed):
+		return http.StatusConflict, dto.CodeAlreadyEndorsed, "You have already endorsed this skill"
+	case pkgerrors.Is(err, apperrors.ErrEndorsementNotFound):
+		return http.StatusNotFound, dto.CodeEndorsementNotFound, "Endorsement not found"
+	case pkgerrors.Is(err, apperrors.ErrSkillAtHighestLevel):
+		return http.StatusConflict, dto.CodeSkillAtHighestLevel, err.Error()
+	case pkgerrors.Is(err, apperrors.ErrSnapshotNotFound):
+		return http.StatusNotFound, dto.CodeSnapshotNotFound, "Skill snapshot not found"
+	case pkgerrors.Is(err, apperrors.ErrEndorsementLimitExceeded):
+		return http.StatusBadRequest, dto.CodeEndorsementLimitExceeded, err.Error()
+	case pkgerrors.Is(err, apperrors.ErrTeamMatrixLimitExceeded):
+		return http.StatusBadRequest, dto.CodeTeamMatrixLimitExceeded, err.Error()
+	case pkgerrors.Is(err, apperrors.ErrBulkSkillsLimitExceeded):
+		return http.StatusBadRequest, dto.CodeBulkSkillsLimitExceeded, err.Error()
 
 	// Master skill errors
 	case pkgerrors.Is(err, apperrors.ErrMasterSkillNotFound):
-		return http.StatusNotFound, "Master skill not found"
+		return http.StatusNotFound, dto.CodeMasterSkillNotFound, "Master skill not found"
 	case pkgerrors.Is(err, apperrors.ErrMasterSkillExists):
-		return http.StatusConflict, "Master skill already exists"
+		return http.StatusConflict, dto.CodeMasterSkillExists, "Master skill already exists"
+	case pkgerrors.Is(err, apperrors.ErrMasterSkillNameTaken):
+		return http.StatusConflict, dto.CodeMasterSkillNameTaken, err.Error()
+	case pkgerrors.Is(err, apperrors.ErrMasterSkillDeleted):
+		return http.StatusGone, dto.CodeMasterSkillDeleted, "Master skill has been deleted"
+	case pkgerrors.Is(err, apperrors.ErrImportLimitExceeded):
+		return http.StatusBadRequest, dto.CodeImportLimitExceeded, err.Error()
+	case pkgerrors.Is(err, apperrors.ErrMergeIntoSelf):
+		return http.StatusBadRequest, dto.CodeMergeIntoSelf, err.Error()
 
 	// Validation errors
+	case pkgerrors.As(err, new(pkgerrors.ValidationErrors)):
+		return http.StatusBadRequest, dto.CodeValidationFailed, "validation failed"
 	case pkgerrors.Is(err, pkgerrors.ErrRequiredField):
-		return http.StatusBadRequest, "Required field missing"
+		return http.StatusBadRequest, dto.CodeRequiredField, "Required field missing"
+	case pkgerrors.Is(err, pkgerrors.ErrImmutableField):
+		return http.StatusBadRequest, dto.CodeImmutableField, err.Error()
+	case pkgerrors.Is(err, pkgerrors.ErrInvalidDate):
+		return http.StatusBadRequest, dto.CodeInvalidDate, err.Error()
+	case pkgerrors.Is(err, pkgerrors.ErrInvalidTimeZone):
+		return http.StatusBadRequest, dto.CodeInvalidTimeZone, err.Error()
 	case pkgerrors.Is(err, apperrors.ErrInvalidUsername):
-		return http.StatusBadRequest, err.Error()
+		return http.StatusBadRequest, dto.CodeInvalidUsername, err.Error()
 	case pkgerrors.Is(err, apperrors.ErrInvalidName):
-		return http.StatusBadRequest, err.Error()
+		return http.StatusBadRequest, dto.CodeInvalidName, err.Error()
 	case pkgerrors.Is(err, apperrors.ErrInvalidPassword):
-		return http.StatusBadRequest, err.Error()
+		return http.StatusBadRequest, dto.CodeInvalidPassword, err.Error()
+	case pkgerrors.Is(err, apperrors.ErrInvalidEmail):
+		return http.StatusBadRequest, dto.CodeInvalidEmail, err.Error()
 	case pkgerrors.Is(err, apperrors.ErrInvalidProficiencyLevel):
-		return http.StatusBadRequest, err.Error()
+		return http.StatusBadRequest, dto.CodeInvalidProficiency, err.Error()
 	case pkgerrors.Is(err, apperrors.ErrInvalidYearsOfExperience):
-		return http.StatusBadRequest, err.Error()
+		return http.StatusBadRequest, dto.CodeInvalidYears, err.Error()
+	case pkgerrors.Is(err, apperrors.ErrInconsistentExperience):
+		return http.StatusBadRequest, dto.CodeInconsistentYears, err.Error()
 	case pkgerrors.Is(err, apperrors.ErrInvalidSkillName):
-		return http.StatusBadRequest, err.Error()
+		return http.StatusBadRequest, dto.CodeInvalidSkillName, err.Error()
+	case pkgerrors.Is(err, apperrors.ErrSkillNameTooShort):
+		return http.StatusBadRequest, dto.CodeSkillNameTooShort, err.Error()
+	case pkgerrors.Is(err, apperrors.ErrReservedSkillName):
+		return http.StatusBadRequest, dto.CodeReservedSkillName, err.Error()
+	case pkgerrors.Is(err, apperrors.ErrInvalidCategory):
+		return http.StatusBadRequest, dto.CodeInvalidCategory, err.Error()
+	case pkgerrors.Is(err, apperrors.ErrCategoryRequired):
+		return http.StatusBadRequest, dto.CodeCategoryRequired, err.Error()
+	case pkgerrors.Is(err, apperrors.ErrInvalidPageToken):
+		return http.StatusBadRequest, dto.CodeInvalidPageToken, "Invalid pagination token"
+	case pkgerrors.Is(err, apperrors.ErrInvalidSearchField):
+		return http.StatusBadRequest, dto.CodeInvalidSearchField, err.Error()
+
+	// Deadline errors
+	case pkgerrors.Is(err, apperrors.ErrTimeout):
+		return http.StatusGatewayTimeout, dto.CodeTimeout, "Request timed out"
 
 	// Default: Internal server error
 	default:
-		return http.StatusInternalServerError, "Internal server error"
+		return http.StatusInternalServerError, statusErrorCode(http.StatusInternalServerError), "Internal server error"
+	}
+}
+
+// mapBatchErrors fills ErrorCode and ErrorMessage of the failed entries of a batch result
+// Both are what the error would carry as a response on its own, e.g. SKILL_NOT_FOUND and
+// "Skill not found", so internal errors are never exposed.
+func mapBatchErrors[T any](em *ErrorMapper, results []dto.BatchResult[T]) {
+	for i := range results {
+		if results[i].Err == nil {
+			continue
+		}
+		_, code, message := em.MapToHTTP(results[i].Err)
+		results[i].ErrorCode = code
+		results[i].ErrorMessage = message
 	}
 }
+
+// errorCode converts an HTTP status into a snake_case error code, e.g. 404 -> "not_found"
+func errorCode(status int) string {
+	return strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_")
+}
+
+// statusErrorCode is the response error code for errors without a domain code, e.g. 404 -> "NOT_FOUND"
+func statusErrorCode(status int) string {
+	return strings.ToUpper(errorCode(status))
+}
@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/dto"
+	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/service"
+	pkgerrors "github.com/hackmajoris/glad-stack/pkg/errors"
+)
+
+func TestErrorMapper_MapToHTTP_Codes(t *testing.T) {
+	tests := []struct {
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{apperrors.ErrUserNotFound, http.StatusNotFound, "USER_NOT_FOUND"},
+		{apperrors.ErrUserExists, http.StatusConflict, "USER_EXISTS"},
+		{apperrors.ErrEmailInUse, http.StatusConflict, "EMAIL_IN_USE"},
+		{apperrors.ErrInvalidCredentials, http.StatusUnauthorized, "INVALID_CREDENTIALS"},
+		{apperrors.ErrInvalidRefreshToken, http.StatusUnauthorized, "INVALID_REFRESH_TOKEN"},
+		{apperrors.ErrAccountArchived, http.StatusForbidden, "ACCOUNT_ARCHIVED"},
+		{apperrors.ErrSkillNotFound, http.StatusNotFound, "SKILL_NOT_FOUND"},
+		{apperrors.ErrSkillAlreadyExists, http.StatusConflict, "SKILL_EXISTS"},
+		{apperrors.ErrSelfEndorsement, http.StatusForbidden, "SELF_ENDORSEMENT"},
+		{apperrors.ErrAlreadyEndorsed, http.StatusConflict, "ALREADY_ENDORSED"},
+		{apperrors.ErrEndorsementNotFound, http.StatusNotFound, "ENDORSEMENT_NOT_FOUND"},
+		{apperrors.ErrSkillAtHighestLevel, http.StatusConflict, "SKILL_AT_HIGHEST_LEVEL"},
+		{apperrors.ErrSnapshotNotFound, http.StatusNotFound, "SNAPSHOT_NOT_FOUND"},
+		{apperrors.ErrEndorsementLimitExceeded, http.StatusBadRequest, "ENDORSEMENT_LIMIT_EXCEEDED"},
+		{apperrors.ErrTeamMatrixLimitExceeded, http.StatusBadRequest, "TEAM_MATRIX_LIMIT_EXCEEDED"},
+		{apperrors.ErrBulkSkillsLimitExceeded, http.StatusBadRequest, "BULK_SKILLS_LIMIT_EXCEEDED"},
+		{apperrors.ErrMasterSkillNotFound, http.StatusNotFound, "MASTER_SKILL_NOT_FOUND"},
+		{apperrors.ErrMasterSkillExists, http.StatusConflict, "MASTER_SKILL_EXISTS"},
+		{apperrors.ErrMasterSkillNameTaken, http.StatusConflict, "MASTER_SKILL_NAME_TAKEN"},
+		{apperrors.ErrMasterSkillDeleted, http.StatusGone, "MASTER_SKILL_DELETED"},
+		{apperrors.ErrImportLimitExceeded, http.StatusBadRequest, "IMPORT_LIMIT_EXCEEDED"},
+		{apperrors.ErrMergeIntoSelf, http.StatusBadRequest, "MERGE_INTO_SELF"},
+		{pkgerrors.ValidationErrors{"name": "required"}, http.StatusBadRequest, "VALIDATION_FAILED"},
+		{pkgerrors.ErrRequiredField, http.StatusBadRequest, "REQUIRED_FIELD"},
+		{pkgerrors.ErrImmutableField, http.StatusBadRequest, "IMMUTABLE_FIELD"},
+		{pkgerrors.ErrInvalidDate, http.StatusBadRequest, "INVALID_DATE"},
+		{pkgerrors.ErrInvalidTimeZone, http.StatusBadRequest, "INVALID_TIME_ZONE"},
+		{apperrors.ErrInvalidUsername, http.StatusBadRequest, "INVALID_USERNAME"},
+		{apperrors.ErrInvalidName, http.StatusBadRequest, "INVALID_NAME"},
+		{apperrors.ErrInvalidPassword, http.StatusBadRequest, "INVALID_PASSWORD"},
+		{apperrors.ErrInvalidEmail, http.StatusBadRequest, "INVALID_EMAIL"},
+		{apperrors.ErrInvalidProficiencyLevel, http.StatusBadRequest, "INVALID_PROFICIENCY_LEVEL"},
+		{apperrors.ErrInvalidYearsOfExperience, http.StatusBadRequest, "INVALID_YEARS_OF_EXPERIENCE"},
+		{apperrors.ErrInconsistentExperience, http.StatusBadRequest, "INCONSISTENT_EXPERIENCE"},
+		{apperrors.ErrInvalidSkillName, http.StatusBadRequest, "INVALID_SKILL_NAME"},
+		{apperrors.ErrSkillNameTooShort, http.StatusBadRequest, "SKILL_NAME_TOO_SHORT"},
+		{apperrors.ErrInvalidCategory, http.StatusBadRequest, "INVALID_CATEGORY"},
+		{&apperrors.InvalidCategoryError{Category: "Cooking", Allowed: []string{"Cloud"}}, http.StatusBadRequest, "INVALID_CATEGORY"},
+		{apperrors.ErrCategoryRequired, http.StatusBadRequest, "CATEGORY_REQUIRED"},
+		{apperrors.ErrInvalidPageToken, http.StatusBadRequest, "INVALID_PAGE_TOKEN"},
+		{apperrors.ErrInvalidSearchField, http.StatusBadRequest, "INVALID_SEARCH_FIELD"},
+		{apperrors.ErrTimeout, http.StatusGatewayTimeout, "TIMEOUT"},
+		{errors.New("boom"), http.StatusInternalServerError, "INTERNAL_SERVER_ERROR"},
+	}
+
+	mapper := NewErrorMapper()
+	for _, tt := range tests {
+		t.Run(tt.wantCode, func(t *testing.T) {
+			// Wrapped errors map the same as their sentinel
+			status, code, message := mapper.MapToHTTP(fmt.Errorf("operation failed: %w", tt.err))
+			if status != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, status)
+			}
+			if code != tt.wantCode {
+				t.Errorf("Expected code %s, got %s", tt.wantCode, code)
+			}
+			if message == "" {
+				t.Error("Expected a human-readable message")
+			}
+		})
+	}
+}
+
+func TestErrorMapper_MapToHTTP_BatchItemKeepsCode(t *testing.T) {
+	err := &apperrors.BatchItemError{Index: 2, Err: apperrors.ErrSkillNotFound}
+
+	status, code, message := NewErrorMapper().MapToHTTP(err)
+	if status != http.StatusNotFound || code != dto.CodeSkillNotFound {
+		t.Errorf("Expected 404 %s, got %d %s", dto.CodeSkillNotFound, status, code)
+	}
+	if message != "Item 2: Skill not found" {
+		t.Errorf("Expected item-prefixed message, got %q", message)
+	}
+}
+
+func TestHandler_ErrorResponseBodiesIncludeCode(t *testing.T) {
+	h := &Handler{errorMapper: NewErrorMapper()}
+
+	tests := []struct {
+		name     string
+		err      error
+		wantCode string
+	}{
+		{"plain", apperrors.ErrSkillNotFound, "SKILL_NOT_FOUND"},
+		{"field validation", pkgerrors.ValidationErrors{"name": "required"}, "VALIDATION_FAILED"},
+		{"skill conflict", &service.SkillExistsError{Existing: &models.UserSkill{ProficiencyLevel: models.ProficiencyBeginner}}, "SKILL_EXISTS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := h.handleServiceError(tt.err)
+
+			var body dto.ErrorResponse
+			if err := json.Unmarshal([]byte(response.Body), &body); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if body.Code != tt.wantCode {
+				t.Errorf("Expected code %s, got %s", tt.wantCode, body.Code)
+			}
+			if body.Error == "" {
+				t.Error("Expected the error message to be kept")
+			}
+		})
+	}
+
+	// Responses built from a status alone carry the status-derived code
+	var body dto.ErrorResponse
+	if err := json.Unmarshal([]byte(errorResponse(http.StatusBadRequest, "Invalid request body").Body), &body); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if body.Code != "BAD_REQUEST" {
+		t.Errorf("Expected code BAD_REQUEST, got %s", body.Code)
+	}
+}
+
+func TestMapBatchErrors_UsesResponseMessage(t *testing.T) {
+	results := []dto.BatchResult[dto.SkillResponse]{
+		{Index: 0, Success: true},
+		{Index: 1, Err: apperrors.ErrSkillNotFound},
+		{Index: 2, Err: errors.New("dynamodb: connection reset by peer")},
+	}
+	mapBatchErrors(NewErrorMapper(), results)
+
+	if results[0].ErrorCode != "" || results[0].ErrorMessage != "" {
+		t.Errorf("Expected a successful item to stay without error, got %+v", results[0])
+	}
+	if results[1].ErrorCode != dto.CodeSkillNotFound || results[1].ErrorMessage != "Skill not found" {
+		t.Errorf("Expected SKILL_NOT_FOUND with the response message, got %s: %s", results[1].ErrorCode, results[1].ErrorMessage)
+	}
+	if results[2].ErrorCode != "INTERNAL_SERVER_ERROR" || results[2].ErrorMessage != "Internal server error" {
+		t.Errorf("Expected the internal error to be hidden, got %s: %s", results[2].ErrorCode, results[2].ErrorMessage)
+	}
+}
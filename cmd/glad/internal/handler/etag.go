@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// masterSkillCacheControl lets clients and shared caches reuse master skill reads for five minutes
+const masterSkillCacheControl = "public, max-age=300"
+
+// weakETag derives a weak ETag from a response body
+// Master skill bodies carry updated_at, so the tag changes whenever the skill content or its
+// timestamp does. The tag is weak because equal JSON does not promise byte-identical encodings.
+func weakETag(body string) string {
+	sum := sha256.Sum256([]byte(body))
+	return `W/"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagMatches reports whether an If-None-Match header value matches etag
+// The comparison is weak as RFC 9110 requires for If-None-Match: the W/ prefix is ignored,
+// the header may list several tags separated by commas, and "*" matches any tag.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// cachedResponse adds Cache-Control and ETag headers to a successful read
+// When the request's If-None-Match matches the ETag it returns 304 Not Modified with no body,
+// keeping the caching headers so clients can refresh their copy. Other responses pass through.
+func cachedResponse(request events.APIGatewayProxyRequest, response events.APIGatewayProxyResponse) events.APIGatewayProxyResponse {
+	if response.StatusCode != http.StatusOK {
+		return response
+	}
+
+	etag := weakETag(response.Body)
+	headers := map[string]string{
+		"Cache-Control": masterSkillCacheControl,
+		"ETag":          etag,
+	}
+
+	// API Gateway keeps header casing as sent, while HTTP/2 clients send lowercase names
+	ifNoneMatch, ok := request.Headers["If-None-Match"]
+	if !ok {
+		ifNoneMatch = request.Headers["if-none-match"]
+	}
+	if ifNoneMatch != "" && etagMatches(ifNoneMatch, etag) {
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusNotModified, Headers: headers}
+	}
+
+	for name, value := range headers {
+		response.Headers[name] = value
+	}
+	return response
+}
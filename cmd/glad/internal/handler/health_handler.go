@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/dto"
+	"github.com/hackmajoris/glad-stack/pkg/logger"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// Pinger verifies connectivity to a backing data store
+type Pinger interface {
+	Ping() error
+}
+
+// HealthHandler handles health check HTTP requests
+type HealthHandler struct {
+	db Pinger
+}
+
+// NewHealthHandler creates a new HealthHandler
+func NewHealthHandler(db Pinger) *HealthHandler {
+	return &HealthHandler{
+		db: db,
+	}
+}
+
+// Check reports service health and database connectivity
+// GET /health
+func (h *HealthHandler) Check(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	log := logger.WithComponent("handler").With("operation", "HealthCheck")
+	start := time.Now()
+
+	err := h.db.Ping()
+	latency := time.Since(start).Milliseconds()
+
+	if err != nil {
+		log.Error("Health check failed: database unreachable", "error", err.Error(), "duration", time.Since(start))
+		return successResponse(http.StatusServiceUnavailable, dto.HealthResponse{
+			Status:    "error",
+			DB:        "unreachable",
+			LatencyMs: latency,
+		}), nil
+	}
+
+	log.Debug("Health check succeeded", "duration", time.Since(start))
+	return successResponse(http.StatusOK, dto.HealthResponse{
+		Status:    "ok",
+		DB:        "reachable",
+		LatencyMs: latency,
+	}), nil
+}
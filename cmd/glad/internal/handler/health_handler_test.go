@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/database"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/dto"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// failingPinger simulates an unreachable database
+type failingPinger struct{}
+
+func (f *failingPinger) Ping() error {
+	return errors.New("connection refused")
+}
+
+func TestHealthHandler_Check(t *testing.T) {
+	tests := []struct {
+		name           string
+		db             Pinger
+		expectedStatus int
+		expectedBody   dto.HealthResponse
+	}{
+		{
+			name:           "database reachable",
+			db:             database.NewMockRepository(),
+			expectedStatus: 200,
+			expectedBody:   dto.HealthResponse{Status: "ok", DB: "reachable"},
+		},
+		{
+			name:           "database unreachable",
+			db:             &failingPinger{},
+			expectedStatus: 503,
+			expectedBody:   dto.HealthResponse{Status: "error", DB: "unreachable"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHealthHandler(tt.db)
+
+			response, err := h.Check(events.APIGatewayProxyRequest{})
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+
+			if response.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, response.StatusCode)
+			}
+
+			var result dto.HealthResponse
+			if err := json.Unmarshal([]byte(response.Body), &result); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if result.Status != tt.expectedBody.Status {
+				t.Errorf("Expected status '%s', got '%s'", tt.expectedBody.Status, result.Status)
+			}
+			if result.DB != tt.expectedBody.DB {
+				t.Errorf("Expected db '%s', got '%s'", tt.expectedBody.DB, result.DB)
+			}
+		})
+	}
+}
@@ -1,27 +1,49 @@
 package handler
 
 import (
-	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/hackmajoris/glad-stack/cmd/glad/internal/dto"
+	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
 	"github.com/hackmajoris/glad-stack/cmd/glad/internal/service"
+	pkgerrors "github.com/hackmajoris/glad-stack/pkg/errors"
+	"github.com/hackmajoris/glad-stack/pkg/middleware"
 
 	"github.com/aws/aws-lambda-go/events"
 )
 
 // MasterSkillHandler handles master skill HTTP requests
 type MasterSkillHandler struct {
-	service     *service.MasterSkillService
-	errorMapper *ErrorMapper
+	service            *service.MasterSkillService
+	errorMapper        *ErrorMapper
+	confirmDestructive bool
+}
+
+// MasterSkillHandlerOption configures optional MasterSkillHandler behaviour
+type MasterSkillHandlerOption func(*MasterSkillHandler)
+
+// WithDestructiveConfirmation sets whether deletes and merges must echo the skill ID in a confirm field
+func WithDestructiveConfirmation(required bool) MasterSkillHandlerOption {
+	return func(h *MasterSkillHandler) {
+		h.confirmDestructive = required
+	}
 }
 
 // NewMasterSkillHandler creates a new MasterSkillHandler
-func NewMasterSkillHandler(service *service.MasterSkillService) *MasterSkillHandler {
-	return &MasterSkillHandler{
-		service:     service,
-		errorMapper: NewErrorMapper(),
+// Destructive requests require confirmation unless disabled with WithDestructiveConfirmation.
+func NewMasterSkillHandler(service *service.MasterSkillService, opts ...MasterSkillHandlerOption) *MasterSkillHandler {
+	h := &MasterSkillHandler{
+		service:            service,
+		errorMapper:        NewErrorMapper(),
+		confirmDestructive: true,
+	}
+	for _, opt := range opts {
+		opt(h)
 	}
+	return h
 }
 
 // CreateMasterSkill handles creating a new master skill
@@ -29,8 +51,8 @@ func NewMasterSkillHandler(service *service.MasterSkillService) *MasterSkillHand
 func (h *MasterSkillHandler) CreateMasterSkill(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	// Parse request body
 	var req dto.CreateMasterSkillRequest
-	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
-		return errorResponse(http.StatusBadRequest, "Invalid request body"), nil
+	if err := decodeStrict(request.Body, &req); err != nil {
+		return invalidBodyResponse(err), nil
 	}
 
 	// Create master skill
@@ -45,11 +67,83 @@ func (h *MasterSkillHandler) CreateMasterSkill(request events.APIGatewayProxyReq
 		Description: skill.Description,
 		Category:    skill.Category,
 		Tags:        skill.Tags,
+		Featured:    skill.Featured,
 		CreatedAt:   skill.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:   skill.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}), nil
 }
 
+// ImportMasterSkills handles creating many master skills from a JSON array
+// POST /master-skills/import
+func (h *MasterSkillHandler) ImportMasterSkills(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	// Parse request body
+	var req []dto.CreateMasterSkillRequest
+	if err := decodeStrict(request.Body, &req); err != nil {
+		return invalidBodyResponse(err), nil
+	}
+
+	inputs := make([]service.MasterSkillInput, len(req))
+	for i, item := range req {
+		inputs[i] = service.MasterSkillInput{
+			SkillID:     item.SkillID,
+			SkillName:   item.SkillName,
+			Description: item.Description,
+			Category:    item.Category,
+			Tags:        item.Tags,
+		}
+	}
+
+	// Import master skills
+	results, err := h.service.ImportMasterSkills(inputs)
+	if err != nil {
+		return h.handleServiceError(err), nil
+	}
+
+	mapBatchErrors(h.errorMapper, results)
+
+	response := dto.ImportMasterSkillsResponse{Results: results}
+	for _, result := range results {
+		switch {
+		case result.Success:
+			response.Created++
+		case pkgerrors.Is(result.Err, apperrors.ErrMasterSkillExists):
+			response.Skipped++
+		default:
+			response.Failed++
+		}
+	}
+
+	// Failed entries are reported per item with 207 Multi-Status
+	statusCode := http.StatusCreated
+	if response.Failed > 0 {
+		statusCode = http.StatusMultiStatus
+	}
+
+	return successResponse(statusCode, response), nil
+}
+
+// GetMasterSkillStats handles counting active master skills per category
+// GET /master-skills/stats
+func (h *MasterSkillHandler) GetMasterSkillStats(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	counts, err := h.service.CountByCategory()
+	if err != nil {
+		return h.handleServiceError(err), nil
+	}
+
+	return successResponse(http.StatusOK, counts), nil
+}
+
+// ListCategories handles listing the categories that have active master skills
+// GET /categories
+func (h *MasterSkillHandler) ListCategories(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	categories, err := h.service.ListUsedCategories()
+	if err != nil {
+		return h.handleServiceError(err), nil
+	}
+
+	return successResponse(http.StatusOK, categories), nil
+}
+
 // GetMasterSkill handles retrieving a master skill by ID
 // GET /skills/{skillID}
 func (h *MasterSkillHandler) GetMasterSkill(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
@@ -65,19 +159,114 @@ func (h *MasterSkillHandler) GetMasterSkill(request events.APIGatewayProxyReques
 		return h.handleServiceError(err), nil
 	}
 
+	return cachedResponse(request, successResponse(http.StatusOK, dto.MasterSkillResponse{
+		SkillID:     skill.SkillID,
+		SkillName:   skill.SkillName,
+		Description: skill.Description,
+		Category:    skill.Category,
+		Tags:        skill.Tags,
+		Featured:    skill.Featured,
+		CreatedAt:   skill.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:   skill.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	})), nil
+}
+
+// FeatureMasterSkill handles adding a master skill to the featured list
+// PUT /master-skills/{skillID}/feature
+func (h *MasterSkillHandler) FeatureMasterSkill(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return h.setFeatured(request, true)
+}
+
+// UnfeatureMasterSkill handles removing a master skill from the featured list
+// PUT /master-skills/{skillID}/unfeature
+func (h *MasterSkillHandler) UnfeatureMasterSkill(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return h.setFeatured(request, false)
+}
+
+// setFeatured sets the featured flag of the master skill in the path
+func (h *MasterSkillHandler) setFeatured(request events.APIGatewayProxyRequest, featured bool) (events.APIGatewayProxyResponse, error) {
+	// Get skill ID from path parameter
+	skillID, ok := request.PathParameters["skillID"]
+	if !ok || skillID == "" {
+		return errorResponse(http.StatusBadRequest, "Skill ID is required"), nil
+	}
+
+	skill, err := h.service.SetFeatured(skillID, featured)
+	if err != nil {
+		return h.handleServiceError(err), nil
+	}
+
 	return successResponse(http.StatusOK, dto.MasterSkillResponse{
 		SkillID:     skill.SkillID,
 		SkillName:   skill.SkillName,
 		Description: skill.Description,
 		Category:    skill.Category,
 		Tags:        skill.Tags,
+		Featured:    skill.Featured,
 		CreatedAt:   skill.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:   skill.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}), nil
 }
 
+// MergeMasterSkill handles folding a duplicate master skill into another
+// POST /master-skills/{skillID}/merge-into/{targetID}
+// The body must be {"confirm":"<skillID>"} unless confirmation is disabled
+func (h *MasterSkillHandler) MergeMasterSkill(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	skillID, ok := request.PathParameters["skillID"]
+	if !ok || skillID == "" {
+		return errorResponse(http.StatusBadRequest, "Skill ID is required"), nil
+	}
+
+	targetID, ok := request.PathParameters["targetID"]
+	if !ok || targetID == "" {
+		return errorResponse(http.StatusBadRequest, "Target skill ID is required"), nil
+	}
+
+	if h.confirmDestructive {
+		if response, ok := checkConfirmation(request, skillID); !ok {
+			return response, nil
+		}
+	}
+
+	result, err := h.service.Merge(skillID, targetID)
+	if err != nil {
+		return h.handleServiceError(err), nil
+	}
+
+	return successResponse(http.StatusOK, result), nil
+}
+
+// ListFeaturedMasterSkills handles listing the featured master skills, sorted by name
+// GET /master-skills/featured
+func (h *MasterSkillHandler) ListFeaturedMasterSkills(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	skills, err := h.service.ListFeatured()
+	if err != nil {
+		return h.handleServiceError(err), nil
+	}
+
+	return successResponse(http.StatusOK, skills), nil
+}
+
+// GetMasterSkillOverview handles retrieving a master skill with its usage across users
+// GET /master-skills/{skillID}/overview
+func (h *MasterSkillHandler) GetMasterSkillOverview(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	// Get skill ID from path parameter
+	skillID, ok := request.PathParameters["skillID"]
+	if !ok || skillID == "" {
+		return errorResponse(http.StatusBadRequest, "Skill ID is required"), nil
+	}
+
+	overview, err := h.service.GetOverview(skillID)
+	if err != nil {
+		return h.handleServiceError(err), nil
+	}
+
+	return successResponse(http.StatusOK, overview), nil
+}
+
 // UpdateMasterSkill handles updating an existing master skill
 // PUT /skills/{skillID}
+// The skill_id is permanent; a body skill_id that differs from the path is rejected
 func (h *MasterSkillHandler) UpdateMasterSkill(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	// Get skill ID from path parameter
 	skillID, ok := request.PathParameters["skillID"]
@@ -87,8 +276,13 @@ func (h *MasterSkillHandler) UpdateMasterSkill(request events.APIGatewayProxyReq
 
 	// Parse request body
 	var req dto.UpdateMasterSkillRequest
-	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
-		return errorResponse(http.StatusBadRequest, "Invalid request body"), nil
+	if err := decodeStrict(request.Body, &req); err != nil {
+		return invalidBodyResponse(err), nil
+	}
+
+	// Skill IDs are permanent: user skills reference them, so a rename must go through skill_name
+	if req.SkillID != "" && req.SkillID != skillID {
+		return h.handleServiceError(fmt.Errorf("%w: skill_id", pkgerrors.ErrImmutableField)), nil
 	}
 
 	// Update master skill
@@ -103,6 +297,7 @@ func (h *MasterSkillHandler) UpdateMasterSkill(request events.APIGatewayProxyReq
 		Description: skill.Description,
 		Category:    skill.Category,
 		Tags:        skill.Tags,
+		Featured:    skill.Featured,
 		CreatedAt:   skill.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:   skill.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}), nil
@@ -110,6 +305,7 @@ func (h *MasterSkillHandler) UpdateMasterSkill(request events.APIGatewayProxyReq
 
 // DeleteMasterSkill handles deleting a master skill
 // DELETE /skills/{skillID}
+// The body must be {"confirm":"<skillID>"} unless confirmation is disabled
 func (h *MasterSkillHandler) DeleteMasterSkill(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	// Get skill ID from path parameter
 	skillID, ok := request.PathParameters["skillID"]
@@ -117,6 +313,12 @@ func (h *MasterSkillHandler) DeleteMasterSkill(request events.APIGatewayProxyReq
 		return errorResponse(http.StatusBadRequest, "Skill ID is required"), nil
 	}
 
+	if h.confirmDestructive {
+		if response, ok := checkConfirmation(request, skillID); !ok {
+			return response, nil
+		}
+	}
+
 	// Delete master skill
 	if err := h.service.DeleteMasterSkill(skillID); err != nil {
 		return h.handleServiceError(err), nil
@@ -127,11 +329,42 @@ func (h *MasterSkillHandler) DeleteMasterSkill(request events.APIGatewayProxyReq
 	}), nil
 }
 
-// ListMasterSkills handles listing all master skills
-// GET /skills
-func (h *MasterSkillHandler) ListMasterSkills(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	// List all master skills
-	skills, err := h.service.ListMasterSkills()
+// SearchMasterSkills handles prefix search over master skill names for autocomplete
+// GET /master-skills/search?q=<prefix>&limit=<n>
+// With in=<fields> (comma-separated name, description) it matches q as a substring of the
+// chosen fields instead, ranking name matches first.
+// GET /master-skills/search?q=<text>&in=name,description
+func (h *MasterSkillHandler) SearchMasterSkills(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	query := request.QueryStringParameters["q"]
+	if query == "" {
+		return errorResponse(http.StatusBadRequest, "Query parameter q is required"), nil
+	}
+
+	if in, ok := request.QueryStringParameters["in"]; ok {
+		var fields []string
+		for _, field := range strings.Split(in, ",") {
+			if field = strings.ToLower(strings.TrimSpace(field)); field != "" {
+				fields = append(fields, field)
+			}
+		}
+
+		skills, err := h.service.Search(query, fields)
+		if err != nil {
+			return h.handleServiceError(err), nil
+		}
+		return successResponse(http.StatusOK, skills), nil
+	}
+
+	limit := 0
+	if value := request.QueryStringParameters["limit"]; value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			return errorResponse(http.StatusBadRequest, "limit must be a positive integer"), nil
+		}
+		limit = parsed
+	}
+
+	skills, err := h.service.SearchByNamePrefix(query, limit)
 	if err != nil {
 		return h.handleServiceError(err), nil
 	}
@@ -139,8 +372,87 @@ func (h *MasterSkillHandler) ListMasterSkills(request events.APIGatewayProxyRequ
 	return successResponse(http.StatusOK, skills), nil
 }
 
+// SuggestCategory handles ranking categories for a prospective skill name
+// GET /master-skills/suggest-category?name=<name>
+func (h *MasterSkillHandler) SuggestCategory(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	name := request.QueryStringParameters["name"]
+	if name == "" {
+		return errorResponse(http.StatusBadRequest, "Query parameter name is required"), nil
+	}
+
+	suggestions, err := h.service.SuggestCategory(name)
+	if err != nil {
+		return h.handleServiceError(err), nil
+	}
+
+	return successResponse(http.StatusOK, suggestions), nil
+}
+
+// maxMasterSkillsPageSize caps the limit query parameter for master skill pages
+const maxMasterSkillsPageSize = 100
+
+// ListMasterSkills handles listing master skills, optionally filtered by category and tags
+// GET /skills?category=<category>&tag=<tag>&includeDeleted=true&limit=<n>&next=<token>
+// Only admins may include soft-deleted skills
+func (h *MasterSkillHandler) ListMasterSkills(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	category := request.QueryStringParameters["category"]
+
+	includeDeleted, err := boolQueryParameter(request, "includeDeleted")
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, err.Error()), nil
+	}
+
+	if includeDeleted {
+		identity, err := middleware.ExtractIdentity(request)
+		if err != nil {
+			return errorResponse(http.StatusUnauthorized, "Invalid token claims"), nil
+		}
+		if !identity.IsAdmin {
+			return errorResponse(http.StatusForbidden, "Admin access required"), nil
+		}
+	}
+
+	// Multiple tags may be supplied as repeated query parameters
+	tags := request.MultiValueQueryStringParameters["tag"]
+	if len(tags) == 0 {
+		if tag := request.QueryStringParameters["tag"]; tag != "" {
+			tags = []string{tag}
+		}
+	}
+
+	// Paginated requests get a page object with the next token; others keep the plain array
+	limitParam, nextToken := request.QueryStringParameters["limit"], request.QueryStringParameters["next"]
+	if limitParam != "" || nextToken != "" {
+		limit := 0
+		if limitParam != "" {
+			parsed, err := strconv.Atoi(limitParam)
+			if err != nil || parsed < 1 || parsed > maxMasterSkillsPageSize {
+				return errorResponse(http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxMasterSkillsPageSize)), nil
+			}
+			limit = parsed
+		}
+
+		page, err := h.service.ListMasterSkillsPage(category, tags, includeDeleted, limit, nextToken)
+		if err != nil {
+			return h.handleServiceError(err), nil
+		}
+		return cachedResponse(request, successResponse(http.StatusOK, page)), nil
+	}
+
+	// List master skills
+	skills, err := h.service.ListMasterSkills(category, tags, includeDeleted)
+	if err != nil {
+		return h.handleServiceError(err), nil
+	}
+
+	return cachedResponse(request, successResponse(http.StatusOK, skills)), nil
+}
+
 // handleServiceError converts service errors to HTTP responses using the error mapper
 func (h *MasterSkillHandler) handleServiceError(err error) events.APIGatewayProxyResponse {
-	statusCode, message := h.errorMapper.MapToHTTP(err)
-	return errorResponse(statusCode, message)
+	statusCode, code, message := h.errorMapper.MapToHTTP(err)
+	if response, ok := invalidCategoryResponse(err, statusCode, code, message); ok {
+		return response
+	}
+	return codedErrorResponse(statusCode, code, message)
 }
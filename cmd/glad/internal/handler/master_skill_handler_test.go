@@ -0,0 +1,1096 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/database"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/dto"
+	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/service"
+	"github.com/hackmajoris/glad-stack/pkg/auth"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// newTestMasterSkillHandler creates a MasterSkillHandler backed by a seeded mock repository
+func newTestMasterSkillHandler(t *testing.T, skills ...*models.Skill) (*MasterSkillHandler, *database.MockRepository) {
+	t.Helper()
+
+	repo := database.NewMockRepository()
+	for _, skill := range skills {
+		if err := repo.CreateMasterSkill(skill); err != nil {
+			t.Fatalf("Failed to create master skill: %v", err)
+		}
+	}
+
+	return NewMasterSkillHandler(service.NewMasterSkillService(repo, repo, repo, repo)), repo
+}
+
+func TestMasterSkillHandler_ListMasterSkills_Filters(t *testing.T) {
+	lambda, _ := models.NewSkill("aws-lambda", "AWS Lambda", "Serverless compute", "Cloud", []string{"serverless"})
+	s3, _ := models.NewSkill("aws-s3", "AWS S3", "Object storage", "Cloud", []string{"storage"})
+	golang, _ := models.NewSkill("go", "Go", "Go language", "Programming", nil)
+
+	h, _ := newTestMasterSkillHandler(t, lambda, s3, golang)
+
+	tests := []struct {
+		name           string
+		query          map[string]string
+		expectedStatus int
+		expectedCount  int
+	}{
+		{name: "no filters returns everything", query: nil, expectedStatus: 200, expectedCount: 3},
+		{name: "category filter", query: map[string]string{"category": "Cloud"}, expectedStatus: 200, expectedCount: 2},
+		{name: "category and tag filter", query: map[string]string{"category": "Cloud", "tag": "serverless"}, expectedStatus: 200, expectedCount: 1},
+		{name: "invalid category", query: map[string]string{"category": "Cooking"}, expectedStatus: 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := h.ListMasterSkills(events.APIGatewayProxyRequest{QueryStringParameters: tt.query})
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+
+			if response.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, response.StatusCode, response.Body)
+			}
+
+			if tt.expectedStatus != 200 {
+				return
+			}
+
+			var skills []dto.MasterSkillResponse
+			if err := json.Unmarshal([]byte(response.Body), &skills); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if len(skills) != tt.expectedCount {
+				t.Errorf("Expected %d skills, got %d", tt.expectedCount, len(skills))
+			}
+		})
+	}
+}
+
+func TestMasterSkillHandler_CreateMasterSkill_InvalidCategoryListsAllowed(t *testing.T) {
+	h, _ := newTestMasterSkillHandler(t)
+
+	response, err := h.CreateMasterSkill(events.APIGatewayProxyRequest{
+		Body: `{"skill_id":"sourdough","skill_name":"Sourdough","category":"Cooking"}`,
+	})
+	if err != nil {
+		t.Fatalf("Handler returned unexpected error: %v", err)
+	}
+	if response.StatusCode != 400 {
+		t.Fatalf("Expected status 400, got %d: %s", response.StatusCode, response.Body)
+	}
+
+	var body dto.InvalidCategoryResponse
+	if err := json.Unmarshal([]byte(response.Body), &body); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if body.Code != dto.CodeInvalidCategory || body.Error == "" {
+		t.Errorf("Expected an %s error, got %+v", dto.CodeInvalidCategory, body)
+	}
+
+	allowed := models.Categories()
+	if len(body.AllowedCategories) != len(allowed) {
+		t.Fatalf("Expected %d allowed categories, got %v", len(allowed), body.AllowedCategories)
+	}
+	for i, category := range allowed {
+		if body.AllowedCategories[i] != category {
+			t.Errorf("Expected allowed category %d to be %s, got %s", i, category, body.AllowedCategories[i])
+		}
+	}
+}
+
+func TestMasterSkillHandler_SkillNameUniqueness(t *testing.T) {
+	golang, _ := models.NewSkill("go", "Go", "Go language", "Programming", nil)
+	python, _ := models.NewSkill("python", "Python", "Python language", "Programming", nil)
+	legacy, _ := models.NewSkill("cobol-legacy", "COBOL", "Retired entry", "Programming", nil)
+	legacy.MarkDeleted()
+
+	tests := []struct {
+		name           string
+		create         bool
+		skillID        string // updated skill; ignored for creates
+		body           string
+		expectedStatus int
+	}{
+		{name: "create with a taken name", create: true, body: `{"skill_id":"golang","skill_name":"GO","category":"Programming"}`, expectedStatus: 409},
+		{name: "create with a soft-deleted skill's name", create: true, body: `{"skill_id":"cobol","skill_name":"Cobol","category":"Programming"}`, expectedStatus: 201},
+		{name: "create with a new name", create: true, body: `{"skill_id":"rust","skill_name":"Rust","category":"Programming"}`, expectedStatus: 201},
+		{name: "rename to a taken name", skillID: "python", body: `{"skill_name":" go "}`, expectedStatus: 409},
+		{name: "rename to the same name in another case", skillID: "go", body: `{"skill_name":"GO"}`, expectedStatus: 200},
+		{name: "update without renaming", skillID: "python", body: `{"description":"Snakes"}`, expectedStatus: 200},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _ := newTestMasterSkillHandler(t, golang, python, legacy)
+
+			var response events.APIGatewayProxyResponse
+			var err error
+			if tt.create {
+				response, err = h.CreateMasterSkill(events.APIGatewayProxyRequest{Body: tt.body})
+			} else {
+				response, err = h.UpdateMasterSkill(events.APIGatewayProxyRequest{PathParameters: map[string]string{"skillID": tt.skillID}, Body: tt.body})
+			}
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+			if response.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, response.StatusCode, response.Body)
+			}
+
+			if tt.expectedStatus == 409 {
+				var errResp dto.ErrorResponse
+				if err := json.Unmarshal([]byte(response.Body), &errResp); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if errResp.Code != dto.CodeMasterSkillNameTaken {
+					t.Errorf("Expected code %s, got %s", dto.CodeMasterSkillNameTaken, errResp.Code)
+				}
+			}
+		})
+	}
+
+	t.Run("import", func(t *testing.T) {
+		h, repo := newTestMasterSkillHandler(t, golang, python, legacy)
+
+		response, _ := h.ImportMasterSkills(events.APIGatewayProxyRequest{Body: `[
+			{"skill_id":"golang","skill_name":"go","category":"Programming"},
+			{"skill_id":"rust","skill_name":"Rust","category":"Programming"},
+			{"skill_id":"rust-lang","skill_name":"RUST","category":"Programming"}
+		]`})
+		if response.StatusCode != 207 {
+			t.Fatalf("Expected status 207, got %d: %s", response.StatusCode, response.Body)
+		}
+
+		var result dto.ImportMasterSkillsResponse
+		if err := json.Unmarshal([]byte(response.Body), &result); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		if result.Created != 1 || result.Failed != 2 {
+			t.Errorf("Expected 1 created and 2 failed, got %d/%d", result.Created, result.Failed)
+		}
+		for _, skillID := range []string{"golang", "rust-lang"} {
+			if _, err := repo.GetMasterSkill(skillID); err == nil {
+				t.Errorf("Expected %s not to be imported", skillID)
+			}
+		}
+	})
+}
+
+func TestMasterSkillHandler_ListMasterSkills_Pagination(t *testing.T) {
+	lambda, _ := models.NewSkill("aws-lambda", "AWS Lambda", "Serverless compute", "Cloud", nil)
+	s3, _ := models.NewSkill("aws-s3", "AWS S3", "Object storage", "Cloud", nil)
+	ec2, _ := models.NewSkill("aws-ec2", "AWS EC2", "Virtual machines", "Cloud", nil)
+	golang, _ := models.NewSkill("go", "Go", "Go language", "Programming", nil)
+
+	h, _ := newTestMasterSkillHandler(t, lambda, s3, ec2, golang)
+
+	list := func(query map[string]string) (int, dto.MasterSkillPageResponse) {
+		t.Helper()
+		response, err := h.ListMasterSkills(events.APIGatewayProxyRequest{QueryStringParameters: query})
+		if err != nil {
+			t.Fatalf("Handler returned unexpected error: %v", err)
+		}
+		var page dto.MasterSkillPageResponse
+		if response.StatusCode == 200 {
+			if err := json.Unmarshal([]byte(response.Body), &page); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+		}
+		return response.StatusCode, page
+	}
+
+	// Walk the Cloud category two skills at a time
+	var ids []string
+	query := map[string]string{"category": "Cloud", "limit": "2"}
+	for pages := 0; ; pages++ {
+		if pages > 3 {
+			t.Fatal("Expected pagination to terminate")
+		}
+		status, page := list(query)
+		if status != 200 {
+			t.Fatalf("Expected status 200, got %d", status)
+		}
+		for _, item := range page.Items {
+			ids = append(ids, item.SkillID)
+		}
+		if page.NextToken == "" {
+			break
+		}
+		query = map[string]string{"category": "Cloud", "limit": "2", "next": page.NextToken}
+	}
+	if fmt.Sprint(ids) != "[aws-ec2 aws-lambda aws-s3]" {
+		t.Errorf("Expected [aws-ec2 aws-lambda aws-s3], got %v", ids)
+	}
+
+	tests := []struct {
+		name           string
+		query          map[string]string
+		expectedStatus int
+	}{
+		{name: "limit below range", query: map[string]string{"limit": "0"}, expectedStatus: 400},
+		{name: "limit above range", query: map[string]string{"limit": "101"}, expectedStatus: 400},
+		{name: "non-numeric limit", query: map[string]string{"limit": "ten"}, expectedStatus: 400},
+		{name: "malformed token", query: map[string]string{"next": "!!not-a-token!!"}, expectedStatus: 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if status, _ := list(tt.query); status != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, status)
+			}
+		})
+	}
+}
+
+func TestMasterSkillHandler_DeleteMasterSkill_SoftDeletes(t *testing.T) {
+	golang, _ := models.NewSkill("go", "Go", "Go language", "Programming", nil)
+	python, _ := models.NewSkill("python", "Python", "Python language", "Programming", nil)
+
+	h, repo := newTestMasterSkillHandler(t, golang, python)
+
+	response, err := h.DeleteMasterSkill(events.APIGatewayProxyRequest{PathParameters: map[string]string{"skillID": "go"}, Body: `{"confirm":"go"}`})
+	if err != nil {
+		t.Fatalf("Handler returned unexpected error: %v", err)
+	}
+	if response.StatusCode != 200 {
+		t.Fatalf("Expected status 200, got %d: %s", response.StatusCode, response.Body)
+	}
+
+	stored, err := repo.GetMasterSkill("go")
+	if err != nil {
+		t.Fatalf("Expected soft-deleted skill to remain stored, got %v", err)
+	}
+	if !stored.Deleted || stored.DeletedAt.IsZero() {
+		t.Error("Expected skill to be marked deleted with a timestamp")
+	}
+
+	response, _ = h.GetMasterSkill(events.APIGatewayProxyRequest{PathParameters: map[string]string{"skillID": "go"}})
+	if response.StatusCode != 404 {
+		t.Errorf("Expected status 404 for soft-deleted skill, got %d", response.StatusCode)
+	}
+
+	// Adding a user skill that references the deleted master skill is rejected
+	skillService := service.NewSkillService(repo, repo, repo, repo, repo, repo)
+	if _, err := skillService.AddSkill(context.Background(), "testuser", "", "go", models.ProficiencyBeginner, 1, ""); err == nil {
+		t.Error("Expected error adding a soft-deleted skill")
+	} else if status, _, _ := NewErrorMapper().MapToHTTP(err); status != 410 {
+		t.Errorf("Expected status 410 for soft-deleted skill, got %d", status)
+	}
+
+	tests := []struct {
+		name           string
+		query          map[string]string
+		claims         *auth.JWTClaims
+		expectedStatus int
+		expectedCount  int
+	}{
+		{name: "deleted skills hidden by default", claims: &auth.JWTClaims{Username: "user"}, expectedStatus: 200, expectedCount: 1},
+		{name: "includeDeleted requires admin", query: map[string]string{"includeDeleted": "true"}, claims: &auth.JWTClaims{Username: "user"}, expectedStatus: 403},
+		{name: "admin can include deleted", query: map[string]string{"includeDeleted": "true"}, claims: &auth.JWTClaims{Username: "admin", Role: auth.RoleAdmin}, expectedStatus: 200, expectedCount: 2},
+		{name: "invalid includeDeleted", query: map[string]string{"includeDeleted": "maybe"}, claims: &auth.JWTClaims{Username: "admin", Role: auth.RoleAdmin}, expectedStatus: 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := events.APIGatewayProxyRequest{
+				QueryStringParameters: tt.query,
+				RequestContext: events.APIGatewayProxyRequestContext{
+					Authorizer: map[string]interface{}{"claims": tt.claims},
+				},
+			}
+
+			response, err := h.ListMasterSkills(request)
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+
+			if response.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, response.StatusCode, response.Body)
+			}
+
+			if tt.expectedStatus != 200 {
+				return
+			}
+
+			var skills []dto.MasterSkillResponse
+			if err := json.Unmarshal([]byte(response.Body), &skills); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if len(skills) != tt.expectedCount {
+				t.Errorf("Expected %d skills, got %d", tt.expectedCount, len(skills))
+			}
+		})
+	}
+}
+
+func TestMasterSkillHandler_SearchMasterSkills(t *testing.T) {
+	pytorch, _ := models.NewSkill("pytorch", "PyTorch", "ML framework", "Data", nil)
+	python, _ := models.NewSkill("python", "Python", "Python language", "Programming", nil)
+	pandas, _ := models.NewSkill("pandas", "pandas", "Data analysis", "Data", nil)
+	pyspark, _ := models.NewSkill("pyspark", "PySpark", "Spark for Python", "Data", nil)
+	pyspark.MarkDeleted()
+
+	h, _ := newTestMasterSkillHandler(t, pytorch, python, pandas, pyspark)
+
+	tests := []struct {
+		name           string
+		query          map[string]string
+		expectedStatus int
+		expectedNames  []string
+	}{
+		{name: "case-insensitive prefix sorted by name", query: map[string]string{"q": "PY"}, expectedStatus: 200, expectedNames: []string{"Python", "PyTorch"}},
+		{name: "limit caps results", query: map[string]string{"q": "py", "limit": "1"}, expectedStatus: 200, expectedNames: []string{"Python"}},
+		{name: "no matches", query: map[string]string{"q": "rust"}, expectedStatus: 200, expectedNames: []string{}},
+		{name: "missing query", query: nil, expectedStatus: 400},
+		{name: "invalid limit", query: map[string]string{"q": "py", "limit": "0"}, expectedStatus: 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := h.SearchMasterSkills(events.APIGatewayProxyRequest{QueryStringParameters: tt.query})
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+
+			if response.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, response.StatusCode, response.Body)
+			}
+
+			if tt.expectedStatus != 200 {
+				return
+			}
+
+			var skills []dto.MasterSkillResponse
+			if err := json.Unmarshal([]byte(response.Body), &skills); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if len(skills) != len(tt.expectedNames) {
+				t.Fatalf("Expected %d skills, got %d", len(tt.expectedNames), len(skills))
+			}
+			for i, name := range tt.expectedNames {
+				if skills[i].SkillName != name {
+					t.Errorf("Expected skill %d to be %s, got %s", i, name, skills[i].SkillName)
+				}
+			}
+		})
+	}
+}
+
+func TestMasterSkillHandler_SearchMasterSkills_Substring(t *testing.T) {
+	kubernetes, _ := models.NewSkill("kubernetes", "Kubernetes", "Container orchestration", "DevOps", nil)
+	docker, _ := models.NewSkill("docker", "Docker", "Build and run containers", "DevOps", nil)
+	containerd, _ := models.NewSkill("containerd", "containerd", "Industry-standard runtime", "DevOps", nil)
+	podman, _ := models.NewSkill("podman", "Podman", "Daemonless container engine", "DevOps", nil)
+	lxc, _ := models.NewSkill("lxc", "LXC", "Linux containers", "DevOps", nil)
+	lxc.MarkDeleted()
+	terraform, _ := models.NewSkill("terraform", "Terraform", "Infrastructure as code", "Cloud", nil)
+
+	h, _ := newTestMasterSkillHandler(t, kubernetes, docker, containerd, podman, lxc, terraform)
+
+	tests := []struct {
+		name           string
+		query          map[string]string
+		expectedStatus int
+		expectedNames  []string
+	}{
+		{
+			name:           "name matches rank above description matches",
+			query:          map[string]string{"q": "CONTAINER", "in": "name,description"},
+			expectedStatus: 200,
+			expectedNames:  []string{"containerd", "Docker", "Kubernetes", "Podman"},
+		},
+		{name: "empty in defaults to both fields", query: map[string]string{"q": "container", "in": ""}, expectedStatus: 200, expectedNames: []string{"containerd", "Docker", "Kubernetes", "Podman"}},
+		{name: "name only", query: map[string]string{"q": "container", "in": "name"}, expectedStatus: 200, expectedNames: []string{"containerd"}},
+		{name: "description only", query: map[string]string{"q": "as code", "in": "description"}, expectedStatus: 200, expectedNames: []string{"Terraform"}},
+		{name: "substring inside name", query: map[string]string{"q": "form", "in": "name"}, expectedStatus: 200, expectedNames: []string{"Terraform"}},
+		{name: "unknown field", query: map[string]string{"q": "container", "in": "tags"}, expectedStatus: 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := h.SearchMasterSkills(events.APIGatewayProxyRequest{QueryStringParameters: tt.query})
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+
+			if response.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, response.StatusCode, response.Body)
+			}
+
+			if tt.expectedStatus != 200 {
+				return
+			}
+
+			var skills []dto.MasterSkillResponse
+			if err := json.Unmarshal([]byte(response.Body), &skills); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if len(skills) != len(tt.expectedNames) {
+				t.Fatalf("Expected %d skills, got %d", len(tt.expectedNames), len(skills))
+			}
+			for i, name := range tt.expectedNames {
+				if skills[i].SkillName != name {
+					t.Errorf("Expected skill %d to be %s, got %s", i, name, skills[i].SkillName)
+				}
+			}
+		})
+	}
+}
+
+func TestMasterSkillHandler_SuggestCategory(t *testing.T) {
+	helm, _ := models.NewSkill("helm", "Helm", "Kubernetes package manager", "DevOps", []string{"kubernetes", "containers"})
+	docker, _ := models.NewSkill("docker", "Docker", "Containers", "DevOps", []string{"containers"})
+	eks, _ := models.NewSkill("aws-eks", "AWS EKS", "Managed Kubernetes", "Cloud", []string{"aws"})
+	golang, _ := models.NewSkill("go", "Go", "Go language", "Programming", nil)
+
+	h, _ := newTestMasterSkillHandler(t, helm, docker, eks, golang)
+
+	response, err := h.SuggestCategory(events.APIGatewayProxyRequest{QueryStringParameters: map[string]string{"name": "Kubernetes Containers"}})
+	if err != nil {
+		t.Fatalf("Handler returned unexpected error: %v", err)
+	}
+	if response.StatusCode != 200 {
+		t.Fatalf("Expected status 200, got %d: %s", response.StatusCode, response.Body)
+	}
+
+	var suggestions []dto.CategorySuggestionResponse
+	if err := json.Unmarshal([]byte(response.Body), &suggestions); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(suggestions) != 1 {
+		t.Fatalf("Expected 1 suggestion, got %+v", suggestions)
+	}
+	if suggestions[0].Category != "DevOps" || suggestions[0].Score != 3 {
+		t.Errorf("Expected DevOps with score 3, got %s with score %d", suggestions[0].Category, suggestions[0].Score)
+	}
+	if len(suggestions[0].SimilarSkills) != 2 || suggestions[0].SimilarSkills[0] != "Docker" {
+		t.Errorf("Expected similar skills [Docker Helm], got %v", suggestions[0].SimilarSkills)
+	}
+
+	// Name tokens match too, and unrelated names get no suggestions
+	response, _ = h.SuggestCategory(events.APIGatewayProxyRequest{QueryStringParameters: map[string]string{"name": "aws-lambda"}})
+	if err := json.Unmarshal([]byte(response.Body), &suggestions); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(suggestions) != 1 || suggestions[0].Category != "Cloud" {
+		t.Errorf("Expected a Cloud suggestion, got %+v", suggestions)
+	}
+
+	response, _ = h.SuggestCategory(events.APIGatewayProxyRequest{QueryStringParameters: map[string]string{"name": "cooking"}})
+	if response.StatusCode != 200 || response.Body != "[]" {
+		t.Errorf("Expected empty suggestions, got %d: %s", response.StatusCode, response.Body)
+	}
+
+	response, _ = h.SuggestCategory(events.APIGatewayProxyRequest{})
+	if response.StatusCode != 400 {
+		t.Errorf("Expected status 400 without name, got %d", response.StatusCode)
+	}
+}
+
+func TestMasterSkillHandler_UpdateMasterSkill_ImmutableSkillID(t *testing.T) {
+	golang, _ := models.NewSkill("go", "Go", "Go language", "Programming", nil)
+	h, repo := newTestMasterSkillHandler(t, golang)
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedName   string
+	}{
+		{name: "mismatched skill_id is rejected", body: `{"skill_id":"golang","skill_name":"Golang"}`, expectedStatus: 400, expectedName: "Go"},
+		{name: "matching skill_id is accepted", body: `{"skill_id":"go","skill_name":"Go Lang"}`, expectedStatus: 200, expectedName: "Go Lang"},
+		{name: "omitted skill_id is accepted", body: `{"skill_name":"Golang"}`, expectedStatus: 200, expectedName: "Golang"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := h.UpdateMasterSkill(events.APIGatewayProxyRequest{
+				PathParameters: map[string]string{"skillID": "go"},
+				Body:           tt.body,
+			})
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+			if response.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, response.StatusCode, response.Body)
+			}
+
+			stored, _ := repo.GetMasterSkill("go")
+			if stored.SkillName != tt.expectedName {
+				t.Errorf("Expected stored name %s, got %s", tt.expectedName, stored.SkillName)
+			}
+		})
+	}
+
+	if _, err := repo.GetMasterSkill("golang"); err == nil {
+		t.Error("Expected no skill to be created under the new ID")
+	}
+}
+
+func TestMasterSkillHandler_CachingHeaders(t *testing.T) {
+	golang, _ := models.NewSkill("go", "Go", "Go language", "Programming", nil)
+	h, _ := newTestMasterSkillHandler(t, golang)
+
+	reads := map[string]func(headers map[string]string) (events.APIGatewayProxyResponse, error){
+		"get": func(headers map[string]string) (events.APIGatewayProxyResponse, error) {
+			return h.GetMasterSkill(events.APIGatewayProxyRequest{PathParameters: map[string]string{"skillID": "go"}, Headers: headers})
+		},
+		"list": func(headers map[string]string) (events.APIGatewayProxyResponse, error) {
+			return h.ListMasterSkills(events.APIGatewayProxyRequest{Headers: headers})
+		},
+		"list page": func(headers map[string]string) (events.APIGatewayProxyResponse, error) {
+			return h.ListMasterSkills(events.APIGatewayProxyRequest{QueryStringParameters: map[string]string{"limit": "10"}, Headers: headers})
+		},
+	}
+
+	etags := make(map[string]string)
+	for name, read := range reads {
+		response, err := read(nil)
+		if err != nil {
+			t.Fatalf("%s: handler returned unexpected error: %v", name, err)
+		}
+		if response.StatusCode != 200 {
+			t.Fatalf("%s: expected status 200, got %d: %s", name, response.StatusCode, response.Body)
+		}
+		if response.Headers["Cache-Control"] != "public, max-age=300" {
+			t.Errorf("%s: expected Cache-Control public, max-age=300, got %q", name, response.Headers["Cache-Control"])
+		}
+		etag := response.Headers["ETag"]
+		if !strings.HasPrefix(etag, `W/"`) {
+			t.Fatalf("%s: expected a weak ETag, got %q", name, etag)
+		}
+		etags[name] = etag
+
+		// A matching If-None-Match is a cache hit, whatever the header casing or tag list
+		for _, headers := range []map[string]string{
+			{"If-None-Match": etag},
+			{"if-none-match": `"other", ` + strings.TrimPrefix(etag, "W/")},
+		} {
+			response, _ = read(headers)
+			if response.StatusCode != 304 {
+				t.Fatalf("%s: expected status 304 for %v, got %d", name, headers, response.StatusCode)
+			}
+			if response.Body != "" {
+				t.Errorf("%s: expected an empty body on 304, got %s", name, response.Body)
+			}
+			if response.Headers["ETag"] != etag {
+				t.Errorf("%s: expected the 304 to repeat ETag %s, got %q", name, etag, response.Headers["ETag"])
+			}
+		}
+	}
+
+	// Changing the skill invalidates every cached representation of it
+	update, err := h.UpdateMasterSkill(events.APIGatewayProxyRequest{
+		PathParameters: map[string]string{"skillID": "go"},
+		Body:           `{"skill_name":"Golang"}`,
+	})
+	if err != nil || update.StatusCode != 200 {
+		t.Fatalf("Failed to update master skill: %v %s", err, update.Body)
+	}
+
+	for name, read := range reads {
+		response, _ := read(map[string]string{"If-None-Match": etags[name]})
+		if response.StatusCode != 200 {
+			t.Fatalf("%s: expected status 200 after an update, got %d", name, response.StatusCode)
+		}
+		if !strings.Contains(response.Body, "Golang") {
+			t.Errorf("%s: expected the updated skill in the body, got %s", name, response.Body)
+		}
+		if etag := response.Headers["ETag"]; etag == "" || etag == etags[name] {
+			t.Errorf("%s: expected a new ETag, got %q", name, etag)
+		}
+	}
+}
+
+func TestMasterSkillHandler_ImportMasterSkills(t *testing.T) {
+	golang, _ := models.NewSkill("go", "Go", "Go language", "Programming", nil)
+	h, repo := newTestMasterSkillHandler(t, golang)
+
+	body := `[
+		{"skill_id":"python","skill_name":"Python","category":"Programming"},
+		{"skill_id":"go","skill_name":"Go","category":"Programming"},
+		{"skill_id":"kubernetes","skill_name":"Kubernetes","category":"Cooking"},
+		{"skill_id":"python","skill_name":"Python 3","category":"Programming"},
+		{"skill_id":"aws-s3","skill_name":"AWS S3","category":"Cloud","tags":["storage"]}
+	]`
+
+	response, err := h.ImportMasterSkills(events.APIGatewayProxyRequest{Body: body})
+	if err != nil {
+		t.Fatalf("Handler returned unexpected error: %v", err)
+	}
+	if response.StatusCode != 207 {
+		t.Fatalf("Expected status 207, got %d: %s", response.StatusCode, response.Body)
+	}
+
+	var result dto.ImportMasterSkillsResponse
+	if err := json.Unmarshal([]byte(response.Body), &result); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if result.Created != 2 || result.Skipped != 2 || result.Failed != 1 {
+		t.Errorf("Expected 2 created, 2 skipped, 1 failed, got %d/%d/%d", result.Created, result.Skipped, result.Failed)
+	}
+
+	expectedCodes := []string{"", dto.CodeMasterSkillExists, dto.CodeInvalidCategory, dto.CodeMasterSkillExists, ""}
+	for i, expected := range expectedCodes {
+		item := result.Results[i]
+		if item.Index != i || item.Success != (expected == "") || item.ErrorCode != expected {
+			t.Errorf("Unexpected result at index %d: %+v", i, item)
+		}
+	}
+	if created := result.Results[0]; created.Input != "python" || created.Data == nil || created.Data.SkillName != "Python" {
+		t.Errorf("Expected created python skill in data, got %+v", created)
+	}
+	if result.Results[2].ErrorMessage == "" {
+		t.Error("Expected an error message for the invalid item")
+	}
+
+	python, err := repo.GetMasterSkill("python")
+	if err != nil {
+		t.Fatalf("Expected imported skill to be stored: %v", err)
+	}
+	if python.SkillName != "Python" {
+		t.Errorf("Expected first occurrence to win, got %s", python.SkillName)
+	}
+}
+
+func TestMasterSkillHandler_ImportMasterSkills_Limits(t *testing.T) {
+	h, _ := newTestMasterSkillHandler(t)
+
+	oversized := make([]dto.CreateMasterSkillRequest, 501)
+	for i := range oversized {
+		oversized[i] = dto.CreateMasterSkillRequest{SkillID: fmt.Sprintf("skill-%d", i), SkillName: "Skill", Category: "Other"}
+	}
+	oversizedBody, _ := json.Marshal(oversized)
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+	}{
+		{name: "empty import", body: `[]`, expectedStatus: 400},
+		{name: "not an array", body: `{"skill_id":"go"}`, expectedStatus: 400},
+		{name: "over the cap", body: string(oversizedBody), expectedStatus: 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := h.ImportMasterSkills(events.APIGatewayProxyRequest{Body: tt.body})
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+			if response.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.expectedStatus, response.StatusCode, response.Body)
+			}
+		})
+	}
+}
+
+func TestMasterSkillHandler_GetMasterSkillStats(t *testing.T) {
+	golang, _ := models.NewSkill("go", "Go", "Go language", "Programming", nil)
+	python, _ := models.NewSkill("python", "Python", "Python language", "Programming", nil)
+	cobol, _ := models.NewSkill("cobol", "COBOL", "Legacy language", "Programming", nil)
+	lambda, _ := models.NewSkill("aws-lambda", "AWS Lambda", "Serverless compute", "Cloud", nil)
+	terraform, _ := models.NewSkill("terraform", "Terraform", "Infrastructure as code", "DevOps", nil)
+
+	h, repo := newTestMasterSkillHandler(t, golang, python, cobol, lambda, terraform)
+
+	// Soft-deleted skills are not counted, and a category left empty is omitted
+	for _, skillID := range []string{"cobol", "terraform"} {
+		if response, _ := h.DeleteMasterSkill(events.APIGatewayProxyRequest{PathParameters: map[string]string{"skillID": skillID}, Body: fmt.Sprintf(`{"confirm":%q}`, skillID)}); response.StatusCode != 200 {
+			t.Fatalf("Failed to delete %s: %d", skillID, response.StatusCode)
+		}
+	}
+	if _, err := repo.GetMasterSkill("terraform"); err != nil {
+		t.Fatalf("Expected soft-deleted skill to remain stored: %v", err)
+	}
+
+	response, err := h.GetMasterSkillStats(events.APIGatewayProxyRequest{})
+	if err != nil {
+		t.Fatalf("Handler returned unexpected error: %v", err)
+	}
+	if response.StatusCode != 200 {
+		t.Fatalf("Expected status 200, got %d: %s", response.StatusCode, response.Body)
+	}
+
+	if expected := `{"Cloud":1,"Programming":2}`; response.Body != expected {
+		t.Errorf("Expected body %s, got %s", expected, response.Body)
+	}
+}
+
+func TestMasterSkillHandler_ListCategories(t *testing.T) {
+	golang, _ := models.NewSkill("go", "Go", "Go language", "Programming", nil)
+	python, _ := models.NewSkill("python", "Python", "Python language", "Programming", nil)
+	lambda, _ := models.NewSkill("aws-lambda", "AWS Lambda", "Serverless compute", "Cloud", nil)
+	terraform, _ := models.NewSkill("terraform", "Terraform", "Infrastructure as code", "DevOps", nil)
+
+	tests := []struct {
+		name         string
+		skills       []*models.Skill
+		deleted      []string
+		expectedBody string
+	}{
+		{name: "sorted and deduplicated", skills: []*models.Skill{golang, python, lambda}, expectedBody: `["Cloud","Programming"]`},
+		{name: "category with only deleted skills omitted", skills: []*models.Skill{golang, terraform}, deleted: []string{"terraform"}, expectedBody: `["Programming"]`},
+		{name: "empty catalog", expectedBody: `[]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _ := newTestMasterSkillHandler(t, tt.skills...)
+			for _, skillID := range tt.deleted {
+				if response, _ := h.DeleteMasterSkill(events.APIGatewayProxyRequest{PathParameters: map[string]string{"skillID": skillID}, Body: fmt.Sprintf(`{"confirm":%q}`, skillID)}); response.StatusCode != 200 {
+					t.Fatalf("Failed to delete %s: %d", skillID, response.StatusCode)
+				}
+			}
+
+			response, err := h.ListCategories(events.APIGatewayProxyRequest{})
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+			if response.StatusCode != 200 {
+				t.Fatalf("Expected status 200, got %d: %s", response.StatusCode, response.Body)
+			}
+			if response.Body != tt.expectedBody {
+				t.Errorf("Expected body %s, got %s", tt.expectedBody, response.Body)
+			}
+		})
+	}
+}
+
+func TestMasterSkillHandler_GetMasterSkillOverview(t *testing.T) {
+	golang, _ := models.NewSkill("go", "Go", "Go language", "Programming", []string{"backend"})
+	rust, _ := models.NewSkill("rust", "Rust", "Rust language", "Programming", nil)
+
+	h, repo := newTestMasterSkillHandler(t, golang, rust)
+	holders := []struct {
+		username string
+		level    models.ProficiencyLevel
+		years    int
+	}{
+		{"alice", models.ProficiencyExpert, 6},
+		{"bob", models.ProficiencyIntermediate, 2},
+		{"carol", models.ProficiencyIntermediate, 1},
+	}
+	for _, holder := range holders {
+		skill, _ := models.NewUserSkill(holder.username, "go", "Go", "Programming", holder.level, holder.years)
+		if err := repo.CreateSkill(skill); err != nil {
+			t.Fatalf("Failed to create skill: %v", err)
+		}
+	}
+
+	overview := func(skillID string) (int, dto.MasterSkillOverviewResponse) {
+		response, err := h.GetMasterSkillOverview(events.APIGatewayProxyRequest{PathParameters: map[string]string{"skillID": skillID}})
+		if err != nil {
+			t.Fatalf("Handler returned unexpected error: %v", err)
+		}
+		var result dto.MasterSkillOverviewResponse
+		if response.StatusCode == 200 {
+			if err := json.Unmarshal([]byte(response.Body), &result); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+		}
+		return response.StatusCode, result
+	}
+
+	status, result := overview("go")
+	if status != 200 {
+		t.Fatalf("Expected status 200, got %d", status)
+	}
+	if result.Skill.SkillID != "go" || result.Skill.SkillName != "Go" || result.Skill.Description != "Go language" {
+		t.Errorf("Expected the Go master record, got %+v", result.Skill)
+	}
+	if result.Usage.TotalUsers != 3 {
+		t.Errorf("Expected 3 users, got %d", result.Usage.TotalUsers)
+	}
+	expectedLevels := map[string]int{"Beginner": 0, "Intermediate": 2, "Advanced": 0, "Expert": 1}
+	for level, count := range expectedLevels {
+		if result.Usage.ByLevel[level] != count {
+			t.Errorf("Expected %d %s users, got %d", count, level, result.Usage.ByLevel[level])
+		}
+	}
+	if result.Usage.AverageYears != 3 {
+		t.Errorf("Expected average years 3, got %v", result.Usage.AverageYears)
+	}
+
+	// A catalog skill nobody holds still has an overview
+	status, result = overview("rust")
+	if status != 200 || result.Skill.SkillID != "rust" || result.Usage.TotalUsers != 0 || result.Usage.AverageYears != 0 {
+		t.Errorf("Expected an empty usage overview for rust, got %d %+v", status, result)
+	}
+
+	if status, _ := overview("missing"); status != 404 {
+		t.Errorf("Expected status 404 for missing skill, got %d", status)
+	}
+}
+
+func TestMasterSkillHandler_DeleteMasterSkill_Confirmation(t *testing.T) {
+	golang, _ := models.NewSkill("golang", "Go", "Go language", "Programming", nil)
+
+	h, repo := newTestMasterSkillHandler(t, golang)
+	deleteSkill := func(h *MasterSkillHandler, body string) int {
+		response, err := h.DeleteMasterSkill(events.APIGatewayProxyRequest{PathParameters: map[string]string{"skillID": "golang"}, Body: body})
+		if err != nil {
+			t.Fatalf("Handler returned unexpected error: %v", err)
+		}
+		return response.StatusCode
+	}
+
+	rejected := map[string]string{
+		"missing body":     "",
+		"missing confirm":  `{}`,
+		"mismatched id":    `{"confirm":"golnag"}`,
+		"different case":   `{"confirm":"Golang"}`,
+		"malformed body":   `{"confirm":`,
+		"non-string value": `{"confirm":true}`,
+	}
+	for name, body := range rejected {
+		if status := deleteSkill(h, body); status != 400 {
+			t.Errorf("%s: expected status 400, got %d", name, status)
+		}
+	}
+	if stored, _ := repo.GetMasterSkill("golang"); stored.Deleted {
+		t.Fatal("Expected skill to survive rejected deletes")
+	}
+
+	if status := deleteSkill(h, `{"confirm":"golang"}`); status != 200 {
+		t.Errorf("Expected status 200 for matching confirm, got %d", status)
+	}
+	if stored, _ := repo.GetMasterSkill("golang"); !stored.Deleted {
+		t.Error("Expected skill to be deleted after matching confirm")
+	}
+
+	// Confirmation can be switched off in configuration
+	other, _ := models.NewSkill("golang", "Go", "Go language", "Programming", nil)
+	unguarded, _ := newTestMasterSkillHandler(t, other)
+	unguarded = NewMasterSkillHandler(unguarded.service, WithDestructiveConfirmation(false))
+	if status := deleteSkill(unguarded, ""); status != 200 {
+		t.Errorf("Expected status 200 with confirmation disabled, got %d", status)
+	}
+}
+
+func TestMasterSkillHandler_FeaturedSkills(t *testing.T) {
+	python, _ := models.NewSkill("python", "Python", "Python language", "Programming", nil)
+	golang, _ := models.NewSkill("go", "Go", "Go language", "Programming", nil)
+	awsLambda, _ := models.NewSkill("aws-lambda", "AWS Lambda", "Serverless functions", "Cloud", nil)
+	rust, _ := models.NewSkill("rust", "Rust", "Rust language", "Programming", nil)
+
+	h, repo := newTestMasterSkillHandler(t, python, golang, awsLambda, rust)
+
+	listFeatured := func() []string {
+		t.Helper()
+		response, err := h.ListFeaturedMasterSkills(events.APIGatewayProxyRequest{})
+		if err != nil {
+			t.Fatalf("Handler returned unexpected error: %v", err)
+		}
+		if response.StatusCode != 200 {
+			t.Fatalf("Expected status 200, got %d: %s", response.StatusCode, response.Body)
+		}
+		var skills []dto.MasterSkillResponse
+		if err := json.Unmarshal([]byte(response.Body), &skills); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		ids := make([]string, len(skills))
+		for i, skill := range skills {
+			if !skill.Featured {
+				t.Errorf("Expected %s to be marked featured", skill.SkillID)
+			}
+			ids[i] = skill.SkillID
+		}
+		return ids
+	}
+
+	if ids := listFeatured(); len(ids) != 0 {
+		t.Fatalf("Expected no featured skills, got %v", ids)
+	}
+
+	for _, skillID := range []string{"python", "go", "aws-lambda", "rust", "python"} {
+		response, err := h.FeatureMasterSkill(events.APIGatewayProxyRequest{PathParameters: map[string]string{"skillID": skillID}})
+		if err != nil {
+			t.Fatalf("Handler returned unexpected error: %v", err)
+		}
+		if response.StatusCode != 200 {
+			t.Fatalf("Expected status 200 featuring %s, got %d: %s", skillID, response.StatusCode, response.Body)
+		}
+	}
+
+	response, _ := h.UnfeatureMasterSkill(events.APIGatewayProxyRequest{PathParameters: map[string]string{"skillID": "rust"}})
+	if response.StatusCode != 200 {
+		t.Fatalf("Expected status 200 unfeaturing rust, got %d: %s", response.StatusCode, response.Body)
+	}
+	if stored, _ := repo.GetMasterSkill("rust"); stored.Featured {
+		t.Error("Expected rust to be unfeatured")
+	}
+
+	// Sorted by name, ignoring case: "AWS Lambda", "Go", "Python"
+	if ids := fmt.Sprint(listFeatured()); ids != "[aws-lambda go python]" {
+		t.Errorf("Expected featured skills [aws-lambda go python], got %s", ids)
+	}
+
+	// Soft-deleted skills drop out of the list and cannot be featured
+	if response, _ := h.DeleteMasterSkill(events.APIGatewayProxyRequest{PathParameters: map[string]string{"skillID": "go"}, Body: `{"confirm":"go"}`}); response.StatusCode != 200 {
+		t.Fatalf("Expected status 200 deleting go, got %d: %s", response.StatusCode, response.Body)
+	}
+	if ids := fmt.Sprint(listFeatured()); ids != "[aws-lambda python]" {
+		t.Errorf("Expected featured skills [aws-lambda python], got %s", ids)
+	}
+	if response, _ := h.FeatureMasterSkill(events.APIGatewayProxyRequest{PathParameters: map[string]string{"skillID": "go"}}); response.StatusCode != 404 {
+		t.Errorf("Expected status 404 featuring a deleted skill, got %d", response.StatusCode)
+	}
+	if response, _ := h.FeatureMasterSkill(events.APIGatewayProxyRequest{PathParameters: map[string]string{"skillID": "cobol"}}); response.StatusCode != 404 {
+		t.Errorf("Expected status 404 featuring a missing skill, got %d", response.StatusCode)
+	}
+}
+
+func TestMasterSkillHandler_MergeMasterSkill(t *testing.T) {
+	golang, _ := models.NewSkill("go", "Go", "Go language", "Programming", nil)
+	golangDup, _ := models.NewSkill("golang", "Golang", "Duplicate of Go", "Backend", nil)
+	rust, _ := models.NewSkill("rust", "Rust", "Rust language", "Programming", nil)
+	rust.MarkDeleted()
+
+	h, repo := newTestMasterSkillHandler(t, golang, golangDup, rust)
+	seed := []struct {
+		username  string
+		skillID   string
+		name      string
+		level     models.ProficiencyLevel
+		endorsers []string
+	}{
+		// alice only holds the duplicate, so her skill moves
+		{"alice", "golang", "Golang", models.ProficiencyAdvanced, []string{"dave"}},
+		// bob holds both and the duplicate is higher, so it replaces the target; dave endorsed both
+		{"bob", "golang", "Golang", models.ProficiencyExpert, []string{"dave", "erin"}},
+		{"bob", "go", "Go", models.ProficiencyBeginner, []string{"dave"}},
+		// carol holds both at the same level, so the target is kept
+		{"carol", "golang", "Golang", models.ProficiencyIntermediate, nil},
+		{"carol", "go", "Go", models.ProficiencyIntermediate, nil},
+	}
+	for _, s := range seed {
+		category := "Programming"
+		if s.skillID == "golang" {
+			category = "Backend"
+		}
+		skill, _ := models.NewUserSkill(s.username, s.skillID, s.name, category, s.level, 1)
+		skill.Endorsements = len(s.endorsers)
+		if err := repo.CreateSkill(skill); err != nil {
+			t.Fatalf("Failed to create skill: %v", err)
+		}
+		for _, endorser := range s.endorsers {
+			if err := repo.CreateEndorsement(models.NewEndorsement(s.username, s.skillID, endorser)); err != nil {
+				t.Fatalf("Failed to create endorsement: %v", err)
+			}
+		}
+	}
+	if err := repo.CreateSkillHistory(models.NewSkillHistory("alice", "golang", models.ProficiencyBeginner, models.ProficiencyAdvanced)); err != nil {
+		t.Fatalf("Failed to create skill history: %v", err)
+	}
+
+	merge := func(skillID, targetID, confirm string) events.APIGatewayProxyResponse {
+		response, err := h.MergeMasterSkill(events.APIGatewayProxyRequest{
+			PathParameters: map[string]string{"skillID": skillID, "targetID": targetID},
+			Body:           `{"confirm":"` + confirm + `"}`,
+		})
+		if err != nil {
+			t.Fatalf("Handler returned unexpected error: %v", err)
+		}
+		return response
+	}
+
+	if response := merge("golang", "go", "go"); response.StatusCode != 400 {
+		t.Fatalf("Expected status 400 without the source ID confirmed, got %d: %s", response.StatusCode, response.Body)
+	}
+
+	response := merge("golang", "go", "golang")
+	if response.StatusCode != 200 {
+		t.Fatalf("Expected status 200, got %d: %s", response.StatusCode, response.Body)
+	}
+	var result dto.MasterSkillMergeResponse
+	if err := json.Unmarshal([]byte(response.Body), &result); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if result.SourceID != "golang" || result.TargetID != "go" || result.Moved != 1 || result.Merged != 2 {
+		t.Errorf("Expected 1 moved and 2 merged, got %+v", result)
+	}
+
+	expectedLevels := map[string]models.ProficiencyLevel{
+		"alice": models.ProficiencyAdvanced,
+		"bob":   models.ProficiencyExpert,
+		"carol": models.ProficiencyIntermediate,
+	}
+	for username, level := range expectedLevels {
+		skill, err := repo.GetSkill(username, "go")
+		if err != nil {
+			t.Fatalf("Expected %s to hold go, got %v", username, err)
+		}
+		if skill.ProficiencyLevel != level || skill.SkillName != "Go" || skill.Category != "Programming" {
+			t.Errorf("Expected %s to hold Go/Programming at %s, got %+v", username, level, skill)
+		}
+	}
+
+	expectedEndorsers := map[string]int{"alice": 1, "bob": 2, "carol": 0}
+	for username, count := range expectedEndorsers {
+		skill, _ := repo.GetSkill(username, "go")
+		endorsements, _ := repo.ListEndorsements(username, "go")
+		if skill.Endorsements != count || len(endorsements) != count {
+			t.Errorf("Expected %s to have %d endorsements, got %d with %d records", username, count, skill.Endorsements, len(endorsements))
+		}
+		if left, _ := repo.ListEndorsements(username, "golang"); len(left) != 0 {
+			t.Errorf("Expected no endorsements of %s left on the source, got %d", username, len(left))
+		}
+	}
+	if err := repo.CreateEndorsement(models.NewEndorsement("bob", "go", "erin")); !errors.Is(err, apperrors.ErrAlreadyEndorsed) {
+		t.Errorf("Expected the moved endorsement to block re-endorsing, got %v", err)
+	}
+	if history, _ := repo.ListSkillHistory("alice", "go"); len(history) != 1 {
+		t.Errorf("Expected alice's history to move to the target, got %d entries", len(history))
+	}
+
+	remaining, _ := repo.ListSkillsBySkillID("golang")
+	if len(remaining) != 0 {
+		t.Errorf("Expected no user skills left on the source, got %d", len(remaining))
+	}
+	if _, err := repo.GetMasterSkill("golang"); err == nil {
+		t.Error("Expected the source master skill to be removed")
+	}
+
+	tests := []struct {
+		name           string
+		skillID        string
+		targetID       string
+		expectedStatus int
+		expectedCode   string
+	}{
+		{name: "merge into self", skillID: "go", targetID: "go", expectedStatus: 400, expectedCode: dto.CodeMergeIntoSelf},
+		{name: "missing source", skillID: "golang", targetID: "go", expectedStatus: 404},
+		{name: "missing target", skillID: "go", targetID: "missing", expectedStatus: 404},
+		{name: "deleted target", skillID: "go", targetID: "rust", expectedStatus: 410, expectedCode: dto.CodeMasterSkillDeleted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := merge(tt.skillID, tt.targetID, tt.skillID)
+			if response.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, response.StatusCode, response.Body)
+			}
+			if tt.expectedCode == "" {
+				return
+			}
+			var body dto.ErrorResponse
+			if err := json.Unmarshal([]byte(response.Body), &body); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if body.Code != tt.expectedCode {
+				t.Errorf("Expected code %s, got %s", tt.expectedCode, body.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/router"
+	"github.com/hackmajoris/glad-stack/pkg/logger"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// RouteLister exposes the registered route table
+type RouteLister interface {
+	Routes() []router.RouteInfo
+}
+
+// RoutesHandler handles route map HTTP requests
+type RoutesHandler struct {
+	routes RouteLister
+}
+
+// NewRoutesHandler creates a new RoutesHandler
+func NewRoutesHandler(routes RouteLister) *RoutesHandler {
+	return &RoutesHandler{
+		routes: routes,
+	}
+}
+
+// ListRoutes returns every registered route with its auth requirement and middleware
+// GET /routes
+func (h *RoutesHandler) ListRoutes(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	log := logger.WithComponent("handler").With("operation", "ListRoutes")
+
+	routes := h.routes.Routes()
+
+	log.Debug("Listing registered routes", "count", len(routes))
+	return successResponse(http.StatusOK, routes), nil
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/dto"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/service"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// SkillSnapshotHandler handles skill snapshot HTTP requests
+type SkillSnapshotHandler struct {
+	service     *service.SkillSnapshotService
+	errorMapper *ErrorMapper
+}
+
+// NewSkillSnapshotHandler creates a new SkillSnapshotHandler
+func NewSkillSnapshotHandler(service *service.SkillSnapshotService) *SkillSnapshotHandler {
+	return &SkillSnapshotHandler{
+		service:     service,
+		errorMapper: NewErrorMapper(),
+	}
+}
+
+// CreateSnapshot handles recording a point-in-time snapshot of a user's skills
+// POST /users/{username}/skills/snapshot
+func (h *SkillSnapshotHandler) CreateSnapshot(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	// Get username from path parameter
+	username, ok := request.PathParameters["username"]
+	if !ok || username == "" {
+		return errorResponse(http.StatusBadRequest, "Username is required"), nil
+	}
+
+	snapshot, err := h.service.CreateSnapshot(username)
+	if err != nil {
+		return h.handleServiceError(err), nil
+	}
+
+	return successResponse(http.StatusCreated, dto.SkillSnapshotResponse{
+		SnapshotID: snapshot.SnapshotID,
+		Username:   snapshot.Username,
+		SkillCount: len(snapshot.Skills),
+		CreatedAt:  snapshot.CreatedAt.Format(time.RFC3339),
+	}), nil
+}
+
+// GetSkillDiff handles comparing a user's current skills with a previous snapshot
+// GET /users/{username}/skills/diff?since=<snapshotId>
+func (h *SkillSnapshotHandler) GetSkillDiff(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	// Get username from path parameter
+	username, ok := request.PathParameters["username"]
+	if !ok || username == "" {
+		return errorResponse(http.StatusBadRequest, "Username is required"), nil
+	}
+
+	snapshotID := request.QueryStringParameters["since"]
+	if snapshotID == "" {
+		return errorResponse(http.StatusBadRequest, "Query parameter 'since' is required"), nil
+	}
+
+	diff, err := h.service.DiffSince(username, snapshotID)
+	if err != nil {
+		return h.handleServiceError(err), nil
+	}
+
+	return successResponse(http.StatusOK, diff), nil
+}
+
+// handleServiceError converts service errors to HTTP responses using the error mapper
+func (h *SkillSnapshotHandler) handleServiceError(err error) events.APIGatewayProxyResponse {
+	statusCode, code, message := h.errorMapper.MapToHTTP(err)
+	return codedErrorResponse(statusCode, code, message)
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/database"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/dto"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/service"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+func TestSkillSnapshotHandler_LevelChangeShowsInDiff(t *testing.T) {
+	repo := database.NewMockRepository()
+	user, _ := models.NewUser("testuser", "Test User", "password123")
+	if err := repo.CreateUser(user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	skill, _ := models.NewUserSkill("testuser", "go", "Go", "Programming", models.ProficiencyIntermediate, 2)
+	if err := repo.CreateSkill(skill); err != nil {
+		t.Fatalf("Failed to create skill: %v", err)
+	}
+
+	h := NewSkillSnapshotHandler(service.NewSkillSnapshotService(repo, repo, repo))
+	pathParams := map[string]string{"username": "testuser"}
+
+	response, err := h.CreateSnapshot(events.APIGatewayProxyRequest{PathParameters: pathParams})
+	if err != nil {
+		t.Fatalf("Handler returned unexpected error: %v", err)
+	}
+	if response.StatusCode != 201 {
+		t.Fatalf("Expected status 201, got %d: %s", response.StatusCode, response.Body)
+	}
+
+	var snapshot dto.SkillSnapshotResponse
+	if err := json.Unmarshal([]byte(response.Body), &snapshot); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	// Level up after the snapshot was taken
+	level, years := models.ProficiencyExpert, 5
+	skillService := service.NewSkillService(repo, repo, repo, repo, repo, repo)
+	if _, err := skillService.UpdateSkill(context.Background(), "testuser", "go", &level, &years, nil); err != nil {
+		t.Fatalf("Failed to update skill: %v", err)
+	}
+
+	response, err = h.GetSkillDiff(events.APIGatewayProxyRequest{
+		PathParameters:        pathParams,
+		QueryStringParameters: map[string]string{"since": snapshot.SnapshotID},
+	})
+	if err != nil {
+		t.Fatalf("Handler returned unexpected error: %v", err)
+	}
+	if response.StatusCode != 200 {
+		t.Fatalf("Expected status 200, got %d: %s", response.StatusCode, response.Body)
+	}
+
+	var diff dto.SkillDiffResponse
+	if err := json.Unmarshal([]byte(response.Body), &diff); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if len(diff.Added) != 0 || len(diff.Removed) != 0 {
+		t.Errorf("Expected no added or removed skills, got %+v", diff)
+	}
+	if len(diff.Leveled) != 1 {
+		t.Fatalf("Expected 1 leveled skill, got %d", len(diff.Leveled))
+	}
+	if change := diff.Leveled[0]; change.SkillID != "go" || change.From != "Intermediate" || change.To != "Expert" {
+		t.Errorf("Unexpected level change: %+v", change)
+	}
+}
+
+func TestSkillSnapshotHandler_GetSkillDiff_Errors(t *testing.T) {
+	repo := database.NewMockRepository()
+	h := NewSkillSnapshotHandler(service.NewSkillSnapshotService(repo, repo, repo))
+
+	tests := []struct {
+		name           string
+		query          map[string]string
+		expectedStatus int
+	}{
+		{name: "missing since", query: nil, expectedStatus: 400},
+		{name: "unknown snapshot", query: map[string]string{"since": "missing"}, expectedStatus: 404},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := h.GetSkillDiff(events.APIGatewayProxyRequest{
+				PathParameters:        map[string]string{"username": "testuser"},
+				QueryStringParameters: tt.query,
+			})
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+			if response.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, response.StatusCode)
+			}
+		})
+	}
+}
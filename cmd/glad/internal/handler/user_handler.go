@@ -1,17 +1,24 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/hackmajoris/glad-stack/cmd/glad/internal/dto"
+	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
 	"github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
 	"github.com/hackmajoris/glad-stack/cmd/glad/internal/service"
 	"github.com/hackmajoris/glad-stack/cmd/glad/internal/validation"
-	"github.com/hackmajoris/glad-stack/pkg/auth"
-	_ "github.com/hackmajoris/glad-stack/pkg/errors"
+	"github.com/hackmajoris/glad-stack/pkg/dateutil"
+	pkgerrors "github.com/hackmajoris/glad-stack/pkg/errors"
 	"github.com/hackmajoris/glad-stack/pkg/logger"
+	"github.com/hackmajoris/glad-stack/pkg/middleware"
 )
 
 // Handler handles HTTP requests
@@ -20,23 +27,54 @@ type Handler struct {
 	skillService *service.SkillService
 	errorMapper  *ErrorMapper
 	validator    *validation.Validator
+	timeout      time.Duration // deadline for repository calls made by context-aware handlers; 0 disables
+}
+
+// HandlerOption configures optional Handler behaviour
+type HandlerOption func(*Handler)
+
+// WithPasswordPolicy sets the rules passwords must satisfy on registration and update
+func WithPasswordPolicy(policy validation.PasswordPolicy) HandlerOption {
+	return func(h *Handler) {
+		h.validator = validation.New(validation.WithPasswordPolicy(policy))
+	}
+}
+
+// WithRequestTimeout bounds the repository calls of the skill hot path; a timed-out request gets 504
+func WithRequestTimeout(timeout time.Duration) HandlerOption {
+	return func(h *Handler) {
+		h.timeout = timeout
+	}
 }
 
 // New creates a new Handler
-func New(userService *service.UserService, skillService *service.SkillService) *Handler {
-	return &Handler{
+func New(userService *service.UserService, skillService *service.SkillService, opts ...HandlerOption) *Handler {
+	h := &Handler{
 		userService:  userService,
 		skillService: skillService,
 		errorMapper:  NewErrorMapper(),
 		validator:    validation.New(),
 	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// requestContext returns the context passed down to the service for one request
+// The caller must call the returned cancel function once the service call returns.
+func (h *Handler) requestContext() (context.Context, context.CancelFunc) {
+	if h.timeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), h.timeout)
 }
 
 // Register handles user registration
 func (h *Handler) Register(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	var req dto.RegisterRequest
-	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
-		return errorResponse(http.StatusBadRequest, "Invalid request body"), nil
+	if err := decodeStrict(request.Body, &req); err != nil {
+		return invalidBodyResponse(err), nil
 	}
 
 	// Validate input at handler layer
@@ -57,8 +95,8 @@ func (h *Handler) Register(request events.APIGatewayProxyRequest) (events.APIGat
 // Login handles user authentication
 func (h *Handler) Login(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	var req dto.LoginRequest
-	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
-		return errorResponse(http.StatusBadRequest, "Invalid request body"), nil
+	if err := decodeStrict(request.Body, &req); err != nil {
+		return invalidBodyResponse(err), nil
 	}
 
 	// Validate input at handler layer
@@ -71,6 +109,30 @@ func (h *Handler) Login(request events.APIGatewayProxyRequest) (events.APIGatewa
 		return h.handleServiceError(err), nil
 	}
 
+	return successResponse(http.StatusOK, dto.TokenResponse{
+		AccessToken:  result.AccessToken,
+		RefreshToken: result.RefreshToken,
+		TokenType:    result.TokenType,
+	}), nil
+}
+
+// Refresh handles exchanging a refresh token for a new access token
+// POST /refresh
+func (h *Handler) Refresh(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var req dto.RefreshRequest
+	if err := decodeStrict(request.Body, &req); err != nil {
+		return invalidBodyResponse(err), nil
+	}
+
+	if req.RefreshToken == "" {
+		return errorResponse(http.StatusBadRequest, "Refresh token is required"), nil
+	}
+
+	result, err := h.userService.Refresh(req.RefreshToken)
+	if err != nil {
+		return h.handleServiceError(err), nil
+	}
+
 	return successResponse(http.StatusOK, dto.TokenResponse{
 		AccessToken: result.AccessToken,
 		TokenType:   result.TokenType,
@@ -79,38 +141,35 @@ func (h *Handler) Login(request events.APIGatewayProxyRequest) (events.APIGatewa
 
 // Protected handles protected resource access
 func (h *Handler) Protected(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	claims, ok := request.RequestContext.Authorizer["claims"].(*auth.JWTClaims)
-	if !ok {
+	identity, err := middleware.ExtractIdentity(request)
+	if err != nil {
 		return errorResponse(http.StatusUnauthorized, "Invalid token claims"), nil
 	}
 
 	return successResponse(http.StatusOK, dto.ProtectedResponse{
 		Message:  "Access granted to protected resource",
-		Username: claims.Username,
+		Username: identity.Username,
 	}), nil
 }
 
 // UpdateUser handles user profile updates
 func (h *Handler) UpdateUser(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	claims, ok := request.RequestContext.Authorizer["claims"].(*auth.JWTClaims)
-	if !ok {
+	identity, err := middleware.ExtractIdentity(request)
+	if err != nil {
 		return errorResponse(http.StatusUnauthorized, "Invalid token claims"), nil
 	}
 
 	var req dto.UpdateUserRequest
-	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
-		return errorResponse(http.StatusBadRequest, "Invalid request body"), nil
+	if err := decodeStrict(request.Body, &req); err != nil {
+		return invalidBodyResponse(err), nil
 	}
 
 	// Validate optional inputs at handler layer
-	if err := h.validator.ValidateOptionalName(req.Name); err != nil {
-		return h.handleServiceError(err), nil
-	}
-	if err := h.validator.ValidateOptionalPassword(req.Password); err != nil {
+	if err := h.validator.ValidateUpdateUserInput(req.Name, req.Password, req.Email); err != nil {
 		return h.handleServiceError(err), nil
 	}
 
-	err := h.userService.UpdateUser(claims.Username, req.Name, req.Password)
+	err = h.userService.UpdateUser(identity.Username, req.Name, req.Password, req.Email)
 	if err != nil {
 		return h.handleServiceError(err), nil
 	}
@@ -121,7 +180,30 @@ func (h *Handler) UpdateUser(request events.APIGatewayProxyRequest) (events.APIG
 }
 
 // ListUsers handles listing all users
+// With q set, only users whose username or name contains it are returned, up to limit.
+// GET /users?q=<query>&limit=<n>
 func (h *Handler) ListUsers(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if query, ok := request.QueryStringParameters["q"]; ok {
+		if query == "" {
+			return errorResponse(http.StatusBadRequest, "q must not be empty"), nil
+		}
+
+		limit := 0
+		if value := request.QueryStringParameters["limit"]; value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil || parsed < 1 {
+				return errorResponse(http.StatusBadRequest, "limit must be a positive integer"), nil
+			}
+			limit = parsed
+		}
+
+		users, err := h.userService.SearchUsers(query, limit)
+		if err != nil {
+			return h.handleServiceError(err), nil
+		}
+		return successResponse(http.StatusOK, users), nil
+	}
+
 	users, err := h.userService.ListUsers()
 	if err != nil {
 		return h.handleServiceError(err), nil
@@ -132,15 +214,15 @@ func (h *Handler) ListUsers(request events.APIGatewayProxyRequest) (events.APIGa
 
 // GetCurrentUser handles retrieving the current authenticated user's information
 func (h *Handler) GetCurrentUser(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	claims, ok := request.RequestContext.Authorizer["claims"].(*auth.JWTClaims)
-	if !ok {
+	identity, err := middleware.ExtractIdentity(request)
+	if err != nil {
 		return errorResponse(http.StatusUnauthorized, "Invalid token claims"), nil
 	}
 
-	log := logger.WithComponent("handler").With("operation", "GetCurrentUser", "username", claims.Username)
+	log := logger.WithComponent("handler").With("operation", "GetCurrentUser", "username", identity.Username)
 	log.Debug("Fetching current user")
 
-	user, err := h.userService.GetUser(claims.Username)
+	user, err := h.userService.GetUser(identity.Username)
 	if err != nil {
 		return h.handleServiceError(err), nil
 	}
@@ -153,12 +235,84 @@ func (h *Handler) GetCurrentUser(request events.APIGatewayProxyRequest) (events.
 	}), nil
 }
 
+// ExportProfile handles downloading the authenticated user's profile and skills as JSON
+// GET /me/export
+func (h *Handler) ExportProfile(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	identity, err := middleware.ExtractIdentity(request)
+	if err != nil {
+		return errorResponse(http.StatusUnauthorized, "Invalid token claims"), nil
+	}
+
+	export, err := h.userService.ExportProfile(identity.Username, h.skillService)
+	if err != nil {
+		return h.handleServiceError(err), nil
+	}
+
+	response := successResponse(http.StatusOK, export)
+	if response.StatusCode == http.StatusOK {
+		response.Headers["Content-Disposition"] = `attachment; filename="profile.json"`
+	}
+	return response, nil
+}
+
+// DeleteCurrentUser handles permanently deleting the authenticated user's account and data
+// DELETE /me
+func (h *Handler) DeleteCurrentUser(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	identity, err := middleware.ExtractIdentity(request)
+	if err != nil {
+		return errorResponse(http.StatusUnauthorized, "Invalid token claims"), nil
+	}
+
+	if err := h.userService.DeleteUser(identity.Username); err != nil {
+		return h.handleServiceError(err), nil
+	}
+
+	return events.APIGatewayProxyResponse{StatusCode: http.StatusNoContent}, nil
+}
+
+// DeactivateCurrentUser handles archiving the authenticated user's account
+// POST /me/deactivate
+func (h *Handler) DeactivateCurrentUser(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	identity, err := middleware.ExtractIdentity(request)
+	if err != nil {
+		return errorResponse(http.StatusUnauthorized, "Invalid token claims"), nil
+	}
+
+	if err := h.userService.DeactivateUser(identity.Username); err != nil {
+		return h.handleServiceError(err), nil
+	}
+
+	return successResponse(http.StatusOK, dto.MessageResponse{
+		Message: "Account deactivated successfully",
+	}), nil
+}
+
+// ReactivateUser handles restoring an archived account (admin only)
+// POST /admin/users/{username}/reactivate
+func (h *Handler) ReactivateUser(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	username, ok := request.PathParameters["username"]
+	if !ok || username == "" {
+		return errorResponse(http.StatusBadRequest, "Username is required"), nil
+	}
+
+	if err := h.userService.ReactivateUser(username); err != nil {
+		return h.handleServiceError(err), nil
+	}
+
+	return successResponse(http.StatusOK, dto.MessageResponse{
+		Message: "Account reactivated successfully",
+	}), nil
+}
+
 // ============================================================================
 // SKILL HANDLERS
 // ============================================================================
 
 // AddSkill handles adding a new skill to a user
 // POST /users/{username}/skills
+// With upsert=true an existing skill is updated instead of rejected: 201 means the skill
+// was created, 200 that it was updated.
+// POST /users/{username}/skills?upsert=true
 func (h *Handler) AddSkill(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	// Get username from path parameter
 	username, ok := request.PathParameters["username"]
@@ -166,31 +320,111 @@ func (h *Handler) AddSkill(request events.APIGatewayProxyRequest) (events.APIGat
 		return errorResponse(http.StatusBadRequest, "Username is required"), nil
 	}
 
+	upsert, err := boolQueryParameter(request, "upsert")
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, err.Error()), nil
+	}
+
 	// Parse request body
 	var req dto.CreateSkillRequest
-	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
-		return errorResponse(http.StatusBadRequest, "Invalid request body"), nil
+	if err := decodeStrict(request.Body, &req); err != nil {
+		return invalidBodyResponse(err), nil
+	}
+
+	// An omitted level is left empty for the service to default; a given one, even "", must be valid
+	var proficiencyLevel models.ProficiencyLevel
+	effectiveLevel := h.skillService.DefaultProficiency()
+	if req.ProficiencyLevel != nil {
+		parsed, err := models.ParseProficiencyLevel(*req.ProficiencyLevel)
+		if err != nil {
+			return h.handleServiceError(err), nil
+		}
+		proficiencyLevel, effectiveLevel = parsed, parsed
+	}
+
+	if err := h.validator.ValidateSkillConsistency(effectiveLevel, req.YearsOfExperience); err != nil {
+		return h.handleServiceError(err), nil
 	}
 
-	// Convert proficiency level string to type
-	proficiencyLevel := models.ProficiencyLevel(req.ProficiencyLevel)
+	ctx, cancel := h.requestContext()
+	defer cancel()
+
+	if upsert {
+		skill, created, err := h.skillService.UpsertSkill(ctx, username, req.SkillID, req.SkillName, proficiencyLevel, req.YearsOfExperience, req.Notes)
+		if err != nil {
+			return h.handleServiceError(err), nil
+		}
+		if !created {
+			return successResponse(http.StatusOK, newSkillResponse(skill)), nil
+		}
+		return successResponse(http.StatusCreated, newSkillResponse(skill)), nil
+	}
 
 	// Add skill
-	skill, err := h.skillService.AddSkill(username, req.SkillName, proficiencyLevel, req.YearsOfExperience, req.Notes)
+	skill, err := h.skillService.AddSkill(ctx, username, req.SkillID, req.SkillName, proficiencyLevel, req.YearsOfExperience, req.Notes)
 	if err != nil {
 		return h.handleServiceError(err), nil
 	}
 
-	return successResponse(http.StatusCreated, dto.SkillResponse{
-		SkillName:         skill.SkillName,
-		ProficiencyLevel:  string(skill.ProficiencyLevel),
-		YearsOfExperience: skill.YearsOfExperience,
-		Endorsements:      skill.Endorsements,
-		LastUsedDate:      skill.LastUsedDate,
-		Notes:             skill.Notes,
-		CreatedAt:         skill.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt:         skill.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
-	}), nil
+	return successResponse(http.StatusCreated, newSkillResponse(skill)), nil
+}
+
+// AddSkillsBatch handles adding several skills to a user at once
+// POST /users/{username}/skills/batch
+func (h *Handler) AddSkillsBatch(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	// Get username from path parameter
+	username, ok := request.PathParameters["username"]
+	if !ok || username == "" {
+		return errorResponse(http.StatusBadRequest, "Username is required"), nil
+	}
+
+	// Parse request body
+	var req dto.BatchCreateSkillsRequest
+	if err := decodeStrict(request.Body, &req); err != nil {
+		return invalidBodyResponse(err), nil
+	}
+
+	inputs := make([]service.SkillInput, len(req.Skills))
+	for i, s := range req.Skills {
+		inputs[i] = service.SkillInput{
+			SkillID:           s.SkillID,
+			SkillName:         s.SkillName,
+			YearsOfExperience: s.YearsOfExperience,
+			Notes:             s.Notes,
+		}
+		// An omitted level is left empty for the service to default; a given one must be valid
+		if s.ProficiencyLevel != nil {
+			level, err := models.ParseProficiencyLevel(*s.ProficiencyLevel)
+			if err != nil {
+				return h.handleServiceError(&apperrors.BatchItemError{Index: i, Err: err}), nil
+			}
+			inputs[i].ProficiencyLevel = level
+		}
+	}
+
+	// Add skills
+	results, err := h.skillService.AddSkillsBatch(username, inputs)
+	if err != nil {
+		return h.handleServiceError(err), nil
+	}
+	mapBatchErrors(h.errorMapper, results)
+
+	response := dto.BatchSkillResponse{Results: results}
+	for _, result := range results {
+		if result.Success {
+			response.Created++
+		} else {
+			response.Failed++
+		}
+	}
+
+	// Partial write failures are reported per item with 207 Multi-Status
+	statusCode := http.StatusCreated
+	if response.Failed > 0 {
+		statusCode = http.StatusMultiStatus
+	}
+
+	return successResponse(statusCode, response), nil
 }
 
 // GetSkill handles retrieving a specific skill for a user
@@ -208,25 +442,19 @@ func (h *Handler) GetSkill(request events.APIGatewayProxyRequest) (events.APIGat
 	}
 
 	// Get skill
-	skill, err := h.skillService.GetSkill(username, skillName)
+	ctx, cancel := h.requestContext()
+	defer cancel()
+	skill, err := h.skillService.GetSkill(ctx, username, skillName)
 	if err != nil {
 		return h.handleServiceError(err), nil
 	}
 
-	return successResponse(http.StatusOK, dto.SkillResponse{
-		SkillName:         skill.SkillName,
-		ProficiencyLevel:  string(skill.ProficiencyLevel),
-		YearsOfExperience: skill.YearsOfExperience,
-		Endorsements:      skill.Endorsements,
-		LastUsedDate:      skill.LastUsedDate,
-		Notes:             skill.Notes,
-		CreatedAt:         skill.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt:         skill.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
-	}), nil
+	return successResponse(http.StatusOK, newSkillResponse(skill)), nil
 }
 
 // ListSkillsForUser handles listing all skills for a user
-// GET /users/{username}/skills
+// GET /users/{username}/skills?usedSince=<YYYY-MM-DD>&tz=<IANA zone>
+// usedSince is interpreted in tz (default UTC); stored last used dates are UTC.
 func (h *Handler) ListSkillsForUser(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	// Get username from path parameter
 	username, ok := request.PathParameters["username"]
@@ -234,8 +462,88 @@ func (h *Handler) ListSkillsForUser(request events.APIGatewayProxyRequest) (even
 		return errorResponse(http.StatusBadRequest, "Username is required"), nil
 	}
 
+	var usedSince time.Time
+	if value := request.QueryStringParameters["usedSince"]; value != "" {
+		loc, err := dateutil.LoadLocation(request.QueryStringParameters["tz"])
+		if err != nil {
+			return h.handleServiceError(err), nil
+		}
+		if usedSince, err = dateutil.StartOfDay(value, loc); err != nil {
+			return h.handleServiceError(err), nil
+		}
+	}
+
 	// Get skills
-	skills, err := h.skillService.ListSkillsForUser(username)
+	ctx, cancel := h.requestContext()
+	defer cancel()
+	skills, err := h.skillService.ListSkillsForUser(ctx, username, usedSince)
+	if err != nil {
+		return h.handleServiceError(err), nil
+	}
+
+	return successResponse(http.StatusOK, skills), nil
+}
+
+// ListUsersByTag handles finding users who hold any skill carrying a tag
+// GET /tags/{tag}/users
+func (h *Handler) ListUsersByTag(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	tag, ok := request.PathParameters["tag"]
+	if !ok || tag == "" {
+		return errorResponse(http.StatusBadRequest, "Tag is required"), nil
+	}
+
+	users, err := h.skillService.ListUsersByTag(tag)
+	if err != nil {
+		return h.handleServiceError(err), nil
+	}
+
+	return successResponse(http.StatusOK, users), nil
+}
+
+// GetSkillGap handles comparing a user's skills against another user's
+// GET /users/{username}/skills/gap?against=<username>
+func (h *Handler) GetSkillGap(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	username, ok := request.PathParameters["username"]
+	if !ok || username == "" {
+		return errorResponse(http.StatusBadRequest, "Username is required"), nil
+	}
+
+	against := request.QueryStringParameters["against"]
+	if against == "" {
+		return errorResponse(http.StatusBadRequest, "against query parameter is required"), nil
+	}
+
+	gap, err := h.skillService.SkillGap(username, against)
+	if err != nil {
+		return h.handleServiceError(err), nil
+	}
+
+	return successResponse(http.StatusOK, gap), nil
+}
+
+// defaultStaleMonths is how long a skill must go unused to be stale when months is omitted
+const defaultStaleMonths = 12
+
+// GetStaleSkills handles listing a user's skills not used for a number of months
+// GET /users/{username}/skills/stale?months=<n>
+func (h *Handler) GetStaleSkills(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	username, ok := request.PathParameters["username"]
+	if !ok || username == "" {
+		return errorResponse(http.StatusBadRequest, "Username is required"), nil
+	}
+
+	months := defaultStaleMonths
+	if value := request.QueryStringParameters["months"]; value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			return errorResponse(http.StatusBadRequest, "months must be a positive integer"), nil
+		}
+		months = parsed
+	}
+
+	// Calendar months back from now, so 12 means the same date last year
+	now := time.Now()
+	skills, err := h.skillService.FindStaleSkills(username, now.Sub(now.AddDate(0, -months, 0)))
 	if err != nil {
 		return h.handleServiceError(err), nil
 	}
@@ -243,9 +551,21 @@ func (h *Handler) ListSkillsForUser(request events.APIGatewayProxyRequest) (even
 	return successResponse(http.StatusOK, skills), nil
 }
 
-// UpdateSkill handles updating an existing skill
+// UpdateSkill handles replacing an existing skill's mutable fields
 // PUT /users/{username}/skills/{skillName}
+// proficiency_level and years_of_experience are required, and omitted notes are cleared.
 func (h *Handler) UpdateSkill(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return h.updateSkill(request, true)
+}
+
+// PatchSkill handles partially updating an existing skill; omitted fields are left unchanged
+// PATCH /users/{username}/skills/{skillName}
+func (h *Handler) PatchSkill(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return h.updateSkill(request, false)
+}
+
+// updateSkill applies an update request; with replace, every mutable field is taken from the request
+func (h *Handler) updateSkill(request events.APIGatewayProxyRequest, replace bool) (events.APIGatewayProxyResponse, error) {
 	// Get path parameters
 	username, ok := request.PathParameters["username"]
 	if !ok || username == "" {
@@ -259,33 +579,59 @@ func (h *Handler) UpdateSkill(request events.APIGatewayProxyRequest) (events.API
 
 	// Parse request body
 	var req dto.UpdateSkillRequest
-	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
-		return errorResponse(http.StatusBadRequest, "Invalid request body"), nil
+	if err := decodeStrict(request.Body, &req); err != nil {
+		return invalidBodyResponse(err), nil
+	}
+
+	if replace {
+		if req.ProficiencyLevel == nil || req.YearsOfExperience == nil {
+			return errorResponse(http.StatusBadRequest, "proficiency_level and years_of_experience are required; use PATCH for a partial update"), nil
+		}
+		if req.Notes == nil {
+			cleared := ""
+			req.Notes = &cleared
+		}
 	}
 
 	// Convert proficiency level if provided
 	var proficiencyLevel *models.ProficiencyLevel
 	if req.ProficiencyLevel != nil {
-		level := models.ProficiencyLevel(*req.ProficiencyLevel)
+		level, err := models.ParseProficiencyLevel(*req.ProficiencyLevel)
+		if err != nil {
+			return h.handleServiceError(err), nil
+		}
 		proficiencyLevel = &level
 	}
 
+	ctx, cancel := h.requestContext()
+	defer cancel()
+
+	// Check the resulting level and years together, taking whichever one is omitted from the stored skill
+	if proficiencyLevel != nil || req.YearsOfExperience != nil {
+		current, err := h.skillService.GetSkill(ctx, username, skillName)
+		if err != nil {
+			return h.handleServiceError(err), nil
+		}
+
+		level, years := current.ProficiencyLevel, current.YearsOfExperience
+		if proficiencyLevel != nil {
+			level = *proficiencyLevel
+		}
+		if req.YearsOfExperience != nil {
+			years = *req.YearsOfExperience
+		}
+		if err := h.validator.ValidateSkillConsistency(level, years); err != nil {
+			return h.handleServiceError(err), nil
+		}
+	}
+
 	// Update skill
-	skill, err := h.skillService.UpdateSkill(username, skillName, proficiencyLevel, req.YearsOfExperience, req.Notes)
+	skill, err := h.skillService.UpdateSkill(ctx, username, skillName, proficiencyLevel, req.YearsOfExperience, req.Notes)
 	if err != nil {
 		return h.handleServiceError(err), nil
 	}
 
-	return successResponse(http.StatusOK, dto.SkillResponse{
-		SkillName:         skill.SkillName,
-		ProficiencyLevel:  string(skill.ProficiencyLevel),
-		YearsOfExperience: skill.YearsOfExperience,
-		Endorsements:      skill.Endorsements,
-		LastUsedDate:      skill.LastUsedDate,
-		Notes:             skill.Notes,
-		CreatedAt:         skill.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt:         skill.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
-	}), nil
+	return successResponse(http.StatusOK, newSkillResponse(skill)), nil
 }
 
 // DeleteSkill handles deleting a skill from a user
@@ -303,7 +649,9 @@ func (h *Handler) DeleteSkill(request events.APIGatewayProxyRequest) (events.API
 	}
 
 	// Delete skill
-	if err := h.skillService.DeleteSkill(username, skillName); err != nil {
+	ctx, cancel := h.requestContext()
+	defer cancel()
+	if err := h.skillService.DeleteSkill(ctx, username, skillName); err != nil {
 		return h.handleServiceError(err), nil
 	}
 
@@ -312,54 +660,657 @@ func (h *Handler) DeleteSkill(request events.APIGatewayProxyRequest) (events.API
 	}), nil
 }
 
-// ListUsersBySkill handles finding all users with a specific skill
-// GET /skills/{skillName}/users?category=<category>&level=<level>
-func (h *Handler) ListUsersBySkill(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	// Get skill name from path parameter
-	skillName, ok := request.PathParameters["skillName"]
-	if !ok || skillName == "" {
-		return errorResponse(http.StatusBadRequest, "Skill name is required"), nil
+// DeleteSkillsBatch handles removing several of the authenticated user's skills at once
+// DELETE /users/{username}/skills
+func (h *Handler) DeleteSkillsBatch(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	identity, err := middleware.ExtractIdentity(request)
+	if err != nil {
+		return errorResponse(http.StatusUnauthorized, "Invalid token claims"), nil
 	}
 
-	// Get category from query parameters (required for multi-key GSI)
-	category, ok := request.QueryStringParameters["category"]
-	if !ok || category == "" {
-		return errorResponse(http.StatusBadRequest, "Category is required"), nil
+	// Get username from path parameter
+	username, ok := request.PathParameters["username"]
+	if !ok || username == "" {
+		return errorResponse(http.StatusBadRequest, "Username is required"), nil
 	}
 
-	// Check for proficiency level filter in query parameters
-	proficiencyLevel, ok := request.QueryStringParameters["level"]
-	if ok && proficiencyLevel != "" {
-		// Query with level filter
-		level := models.ProficiencyLevel(proficiencyLevel)
-		users, err := h.skillService.ListUsersBySkillAndLevel(category, skillName, level)
-		if err != nil {
-			return h.handleServiceError(err), nil
-		}
-		return successResponse(http.StatusOK, users), nil
+	// Users may only delete their own skills
+	if identity.Username != username {
+		return errorResponse(http.StatusForbidden, "You can only delete your own skills"), nil
+	}
+
+	// Parse request body
+	var req dto.BatchDeleteSkillsRequest
+	if err := decodeStrict(request.Body, &req); err != nil {
+		return invalidBodyResponse(err), nil
 	}
 
-	// Query all users with skill
-	users, err := h.skillService.ListUsersBySkill(category, skillName)
+	results, err := h.skillService.DeleteSkillsBatch(username, req.SkillNames)
 	if err != nil {
 		return h.handleServiceError(err), nil
 	}
+	mapBatchErrors(h.errorMapper, results)
 
-	return successResponse(http.StatusOK, users), nil
-}
+	response := dto.BatchDeleteSkillsResponse{Results: results}
+	for _, result := range results {
+		if result.Success {
+			response.Deleted++
+		} else {
+			response.Failed++
+		}
+	}
 
-// ============================================================================
-// HELPER METHODS
-// ============================================================================
+	// Skills that could not be deleted are reported per name with 207 Multi-Status
+	statusCode := http.StatusOK
+	if response.Failed > 0 {
+		statusCode = http.StatusMultiStatus
+	}
 
-// handleServiceError converts service errors to HTTP responses using the error mapper
-func (h *Handler) handleServiceError(err error) events.APIGatewayProxyResponse {
-	statusCode, message := h.errorMapper.MapToHTTP(err)
-	return errorResponse(statusCode, message)
+	return successResponse(statusCode, response), nil
 }
 
-func successResponse(statusCode int, data interface{}) events.APIGatewayProxyResponse {
-	body, err := json.Marshal(data)
+// TouchSkill handles marking the authenticated user's skill as used today
+// POST /users/{username}/skills/{skillName}/touch
+func (h *Handler) TouchSkill(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	identity, err := middleware.ExtractIdentity(request)
+	if err != nil {
+		return errorResponse(http.StatusUnauthorized, "Invalid token claims"), nil
+	}
+
+	// Get path parameters
+	username, ok := request.PathParameters["username"]
+	if !ok || username == "" {
+		return errorResponse(http.StatusBadRequest, "Username is required"), nil
+	}
+
+	skillName, ok := request.PathParameters["skillName"]
+	if !ok || skillName == "" {
+		return errorResponse(http.StatusBadRequest, "Skill name is required"), nil
+	}
+
+	// Users may only record usage of their own skills
+	if identity.Username != username {
+		return errorResponse(http.StatusForbidden, "You can only update your own skills"), nil
+	}
+
+	skill, err := h.skillService.MarkSkillUsed(username, skillName)
+	if err != nil {
+		return h.handleServiceError(err), nil
+	}
+
+	return successResponse(http.StatusOK, newSkillResponse(skill)), nil
+}
+
+// PromoteSkill handles raising the authenticated user's skill by one proficiency level
+// POST /users/{username}/skills/{skillName}/promote
+func (h *Handler) PromoteSkill(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	identity, err := middleware.ExtractIdentity(request)
+	if err != nil {
+		return errorResponse(http.StatusUnauthorized, "Invalid token claims"), nil
+	}
+
+	// Get path parameters
+	username, ok := request.PathParameters["username"]
+	if !ok || username == "" {
+		return errorResponse(http.StatusBadRequest, "Username is required"), nil
+	}
+
+	skillName, ok := request.PathParameters["skillName"]
+	if !ok || skillName == "" {
+		return errorResponse(http.StatusBadRequest, "Skill name is required"), nil
+	}
+
+	// Users may only promote their own skills
+	if identity.Username != username {
+		return errorResponse(http.StatusForbidden, "You can only update your own skills"), nil
+	}
+
+	skill, err := h.skillService.PromoteSkill(username, skillName)
+	if err != nil {
+		return h.handleServiceError(err), nil
+	}
+
+	return successResponse(http.StatusOK, newSkillResponse(skill)), nil
+}
+
+// GetSkillHistory handles retrieving the proficiency history of a user's skill
+// GET /users/{username}/skills/{skillName}/history
+func (h *Handler) GetSkillHistory(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	// Get path parameters
+	username, ok := request.PathParameters["username"]
+	if !ok || username == "" {
+		return errorResponse(http.StatusBadRequest, "Username is required"), nil
+	}
+
+	skillName, ok := request.PathParameters["skillName"]
+	if !ok || skillName == "" {
+		return errorResponse(http.StatusBadRequest, "Skill name is required"), nil
+	}
+
+	history, err := h.skillService.ListSkillHistory(username, skillName)
+	if err != nil {
+		return h.handleServiceError(err), nil
+	}
+
+	return successResponse(http.StatusOK, history), nil
+}
+
+// maxActivityPageSize caps the limit query parameter for activity log pages
+const maxActivityPageSize = 100
+
+// ListActivity handles retrieving one page of a user's activity log, newest first
+// GET /users/{username}/activity?limit=<n>&next=<token>
+func (h *Handler) ListActivity(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	username, ok := request.PathParameters["username"]
+	if !ok || username == "" {
+		return errorResponse(http.StatusBadRequest, "Username is required"), nil
+	}
+
+	limit := 0
+	if limitParam := request.QueryStringParameters["limit"]; limitParam != "" {
+		parsed, err := strconv.Atoi(limitParam)
+		if err != nil || parsed < 1 || parsed > maxActivityPageSize {
+			return errorResponse(http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxActivityPageSize)), nil
+		}
+		limit = parsed
+	}
+
+	page, err := h.skillService.ListActivity(username, limit, request.QueryStringParameters["next"])
+	if err != nil {
+		return h.handleServiceError(err), nil
+	}
+
+	return successResponse(http.StatusOK, page), nil
+}
+
+// EndorseSkill handles endorsing another user's skill
+// POST /users/{username}/skills/{skillName}/endorse
+func (h *Handler) EndorseSkill(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	identity, err := middleware.ExtractIdentity(request)
+	if err != nil {
+		return errorResponse(http.StatusUnauthorized, "Invalid token claims"), nil
+	}
+
+	// Get path parameters
+	username, ok := request.PathParameters["username"]
+	if !ok || username == "" {
+		return errorResponse(http.StatusBadRequest, "Username is required"), nil
+	}
+
+	skillName, ok := request.PathParameters["skillName"]
+	if !ok || skillName == "" {
+		return errorResponse(http.StatusBadRequest, "Skill name is required"), nil
+	}
+
+	// Endorse skill
+	skill, err := h.skillService.EndorseSkill(identity.Username, username, skillName)
+	if err != nil {
+		return h.handleServiceError(err), nil
+	}
+
+	return successResponse(http.StatusOK, newSkillResponse(skill)), nil
+}
+
+// RemoveEndorsement handles withdrawing the caller's endorsement of another user's skill
+// DELETE /users/{username}/skills/{skillName}/endorse
+func (h *Handler) RemoveEndorsement(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	identity, err := middleware.ExtractIdentity(request)
+	if err != nil {
+		return errorResponse(http.StatusUnauthorized, "Invalid token claims"), nil
+	}
+
+	// Get path parameters
+	username, ok := request.PathParameters["username"]
+	if !ok || username == "" {
+		return errorResponse(http.StatusBadRequest, "Username is required"), nil
+	}
+
+	skillName, ok := request.PathParameters["skillName"]
+	if !ok || skillName == "" {
+		return errorResponse(http.StatusBadRequest, "Skill name is required"), nil
+	}
+
+	// Remove endorsement
+	skill, err := h.skillService.RemoveEndorsement(identity.Username, username, skillName)
+	if err != nil {
+		return h.handleServiceError(err), nil
+	}
+
+	return successResponse(http.StatusOK, newSkillResponse(skill)), nil
+}
+
+// ListEndorsements handles listing who has endorsed a user's skill
+// GET /users/{username}/skills/{skillName}/endorsements
+func (h *Handler) ListEndorsements(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	// Get path parameters
+	username, ok := request.PathParameters["username"]
+	if !ok || username == "" {
+		return errorResponse(http.StatusBadRequest, "Username is required"), nil
+	}
+
+	skillName, ok := request.PathParameters["skillName"]
+	if !ok || skillName == "" {
+		return errorResponse(http.StatusBadRequest, "Skill name is required"), nil
+	}
+
+	endorsements, err := h.skillService.ListEndorsements(username, skillName)
+	if err != nil {
+		return h.handleServiceError(err), nil
+	}
+
+	return successResponse(http.StatusOK, endorsements), nil
+}
+
+// EndorseUsersForSkill handles endorsing a shared skill for several users at once
+// POST /skills/{skillName}/endorse-users
+func (h *Handler) EndorseUsersForSkill(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	identity, err := middleware.ExtractIdentity(request)
+	if err != nil {
+		return errorResponse(http.StatusUnauthorized, "Invalid token claims"), nil
+	}
+
+	skillName, ok := request.PathParameters["skillName"]
+	if !ok || skillName == "" {
+		return errorResponse(http.StatusBadRequest, "Skill name is required"), nil
+	}
+
+	// Parse request body
+	var req dto.BulkEndorseRequest
+	if err := decodeStrict(request.Body, &req); err != nil {
+		return invalidBodyResponse(err), nil
+	}
+
+	// Endorse skill for each user
+	results, err := h.skillService.EndorseUsersForSkill(identity.Username, skillName, req.Category, req.Usernames)
+	if err != nil {
+		return h.handleServiceError(err), nil
+	}
+	mapBatchErrors(h.errorMapper, results)
+
+	response := dto.BulkEndorseResponse{Results: results}
+	for _, result := range results {
+		if result.Success {
+			response.Endorsed++
+		} else {
+			response.Failed++
+		}
+	}
+
+	// Users that could not be endorsed are reported per item with 207 Multi-Status
+	statusCode := http.StatusOK
+	if response.Failed > 0 {
+		statusCode = http.StatusMultiStatus
+	}
+
+	return successResponse(statusCode, response), nil
+}
+
+// ResyncUserSkills handles copying a master skill's current name and category onto user skills
+// POST /master-skills/{skillID}/resync
+// Admin only
+func (h *Handler) ResyncUserSkills(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	skillID, ok := request.PathParameters["skillID"]
+	if !ok || skillID == "" {
+		return errorResponse(http.StatusBadRequest, "Skill ID is required"), nil
+	}
+
+	updated, err := h.skillService.ResyncUserSkills(skillID)
+	if err != nil {
+		return h.handleServiceError(err), nil
+	}
+
+	return successResponse(http.StatusOK, dto.ResyncUserSkillsResponse{SkillID: skillID, Updated: updated}), nil
+}
+
+// ExportTeamMatrix handles exporting a team's skills in one category as a CSV matrix
+// POST /analytics/team-matrix
+func (h *Handler) ExportTeamMatrix(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var req dto.TeamMatrixRequest
+	if err := decodeStrict(request.Body, &req); err != nil {
+		return invalidBodyResponse(err), nil
+	}
+
+	matrix, err := h.skillService.TeamSkillMatrix(req.Category, req.Usernames)
+	if err != nil {
+		return h.handleServiceError(err), nil
+	}
+
+	rows := make([][]string, len(matrix.Rows))
+	for i, row := range matrix.Rows {
+		rows[i] = append([]string{row.Username}, row.Levels...)
+	}
+
+	filename := fmt.Sprintf("team-matrix-%s.csv", strings.ToLower(matrix.Category))
+	return csvResponse(append([]string{"username"}, matrix.Skills...), rows, filename), nil
+}
+
+// ListSkillsForUsers handles retrieving several users' skills at once, keyed by username
+// POST /users/skills/bulk
+// Unknown users map to an empty list rather than failing the request.
+func (h *Handler) ListSkillsForUsers(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var req dto.BulkSkillsRequest
+	if err := decodeStrict(request.Body, &req); err != nil {
+		return invalidBodyResponse(err), nil
+	}
+
+	ctx, cancel := h.requestContext()
+	defer cancel()
+
+	skills, err := h.skillService.ListSkillsForUsers(ctx, req.Usernames)
+	if err != nil {
+		return h.handleServiceError(err), nil
+	}
+
+	return successResponse(http.StatusOK, skills), nil
+}
+
+// maxUsersBySkillPageSize caps the limit query parameter for users-by-skill pages
+const maxUsersBySkillPageSize = 100
+
+// ListUsersBySkill handles finding all users with a specific skill
+// GET /skills/{skillName}/users?category=<category>&level=<level>
+// GET /skills/{skillName}/users?allCategories=true&level=<level>
+// GET /skills/{skillName}/users?category=<category>&limit=<n>&next=<token>
+// Any of these with format=csv returns text/csv instead of JSON; a paged CSV carries the
+// next token in the X-Next-Token header.
+func (h *Handler) ListUsersBySkill(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	// Get skill name from path parameter
+	skillName, ok := request.PathParameters["skillName"]
+	if !ok || skillName == "" {
+		return errorResponse(http.StatusBadRequest, "Skill name is required"), nil
+	}
+
+	// Category is the GSI partition key; without it the query fans out only when explicitly requested
+	category := request.QueryStringParameters["category"]
+	proficiencyLevel := request.QueryStringParameters["level"]
+	asCSV := request.QueryStringParameters["format"] == "csv"
+	allCategories, err := boolQueryParameter(request, "allCategories")
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, err.Error()), nil
+	}
+
+	if category == "" && allCategories {
+		users, err := h.skillService.ListUsersBySkillAllCategories(skillName, models.ProficiencyLevel(proficiencyLevel))
+		if err != nil {
+			return h.handleServiceError(err), nil
+		}
+		if asCSV {
+			return usersBySkillCSV(users), nil
+		}
+		return successResponse(http.StatusOK, users), nil
+	}
+
+	// Check for proficiency level filter in query parameters
+	if proficiencyLevel != "" {
+		// Query with level filter
+		level := models.ProficiencyLevel(proficiencyLevel)
+		users, err := h.skillService.ListUsersBySkillAndLevel(category, skillName, level)
+		if err != nil {
+			return h.handleServiceError(err), nil
+		}
+		if asCSV {
+			return usersBySkillCSV(users), nil
+		}
+		return successResponse(http.StatusOK, users), nil
+	}
+
+	// Paginated requests get a page object with the next token; others keep the plain array
+	limitParam, nextToken := request.QueryStringParameters["limit"], request.QueryStringParameters["next"]
+	limit := 0
+	if limitParam != "" {
+		parsed, err := strconv.Atoi(limitParam)
+		if err != nil || parsed < 1 || parsed > maxUsersBySkillPageSize {
+			return errorResponse(http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxUsersBySkillPageSize)), nil
+		}
+		limit = parsed
+	}
+
+	// Query users with skill
+	users, next, err := h.skillService.ListUsersBySkill(category, skillName, limit, nextToken)
+	if err != nil {
+		return h.handleServiceError(err), nil
+	}
+
+	if asCSV {
+		response := usersBySkillCSV(users)
+		if next != "" && response.StatusCode == http.StatusOK {
+			response.Headers["X-Next-Token"] = next
+		}
+		return response, nil
+	}
+
+	if limitParam != "" || nextToken != "" {
+		return successResponse(http.StatusOK, dto.UserSkillPageResponse{Users: users, Next: next}), nil
+	}
+
+	return successResponse(http.StatusOK, users), nil
+}
+
+// GetSkillLevelStats handles counting users with a skill at each proficiency level
+// GET /skills/{skillName}/users/stats?category=<category>
+// GET /skills/{skillName}/users/stats?allCategories=true
+func (h *Handler) GetSkillLevelStats(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	skillName, ok := request.PathParameters["skillName"]
+	if !ok || skillName == "" {
+		return errorResponse(http.StatusBadRequest, "Skill name is required"), nil
+	}
+
+	// Category is required as for ListUsersBySkill unless the fan-out is explicitly requested
+	category := request.QueryStringParameters["category"]
+	allCategories, err := boolQueryParameter(request, "allCategories")
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, err.Error()), nil
+	}
+
+	var histogram map[string]int
+	if category == "" && allCategories {
+		histogram, err = h.skillService.ProficiencyHistogramAllCategories(skillName)
+	} else {
+		histogram, err = h.skillService.ProficiencyHistogram(category, skillName)
+	}
+	if err != nil {
+		return h.handleServiceError(err), nil
+	}
+
+	return successResponse(http.StatusOK, histogram), nil
+}
+
+// GetRecentSkills handles listing the most recently updated skills across all users
+// GET /skills/recent?limit=<n>
+func (h *Handler) GetRecentSkills(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	limit := 0
+	if value := request.QueryStringParameters["limit"]; value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			return errorResponse(http.StatusBadRequest, "limit must be a positive integer"), nil
+		}
+		limit = parsed
+	}
+
+	skills, err := h.skillService.ListRecentSkills(limit)
+	if err != nil {
+		return h.handleServiceError(err), nil
+	}
+
+	return successResponse(http.StatusOK, skills), nil
+}
+
+// GetRelatedSkills handles suggesting skills commonly held alongside a skill
+// GET /skills/{skillName}/related?category=<category>&limit=<n>
+func (h *Handler) GetRelatedSkills(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	skillName, ok := request.PathParameters["skillName"]
+	if !ok || skillName == "" {
+		return errorResponse(http.StatusBadRequest, "Skill name is required"), nil
+	}
+
+	limit := 0
+	if value := request.QueryStringParameters["limit"]; value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			return errorResponse(http.StatusBadRequest, "limit must be a positive integer"), nil
+		}
+		limit = parsed
+	}
+
+	related, err := h.skillService.RelatedSkills(request.QueryStringParameters["category"], skillName, limit)
+	if err != nil {
+		return h.handleServiceError(err), nil
+	}
+
+	return successResponse(http.StatusOK, related), nil
+}
+
+// ListMySkills handles listing the authenticated user's skills
+// GET /me/skills
+func (h *Handler) ListMySkills(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	request, ok := withClaimsUsername(request)
+	if !ok {
+		return errorResponse(http.StatusUnauthorized, "Invalid token claims"), nil
+	}
+	return h.ListSkillsForUser(request)
+}
+
+// AddMySkill handles adding a skill to the authenticated user
+// POST /me/skills
+func (h *Handler) AddMySkill(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	request, ok := withClaimsUsername(request)
+	if !ok {
+		return errorResponse(http.StatusUnauthorized, "Invalid token claims"), nil
+	}
+	return h.AddSkill(request)
+}
+
+// DeleteMySkills handles removing several skills from the authenticated user
+// DELETE /me/skills
+func (h *Handler) DeleteMySkills(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	request, ok := withClaimsUsername(request)
+	if !ok {
+		return errorResponse(http.StatusUnauthorized, "Invalid token claims"), nil
+	}
+	return h.DeleteSkillsBatch(request)
+}
+
+// DeleteMySkill handles removing a skill from the authenticated user
+// DELETE /me/skills/{skillName}
+func (h *Handler) DeleteMySkill(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	request, ok := withClaimsUsername(request)
+	if !ok {
+		return errorResponse(http.StatusUnauthorized, "Invalid token claims"), nil
+	}
+	return h.DeleteSkill(request)
+}
+
+// ============================================================================
+// HELPER METHODS
+// ============================================================================
+
+// handleServiceError converts service errors to HTTP responses using the error mapper
+func (h *Handler) handleServiceError(err error) events.APIGatewayProxyResponse {
+	statusCode, code, message := h.errorMapper.MapToHTTP(err)
+
+	// Field validation failures list each field; the top-level error stays for older clients
+	var fields pkgerrors.ValidationErrors
+	if pkgerrors.As(err, &fields) {
+		return successResponse(statusCode, dto.ValidationErrorResponse{Error: message, Code: code, Fields: fields})
+	}
+
+	if response, ok := invalidCategoryResponse(err, statusCode, code, message); ok {
+		return response
+	}
+
+	// Skill conflicts include the existing skill; other 409s keep the plain error body
+	var exists *service.SkillExistsError
+	if pkgerrors.As(err, &exists) {
+		return successResponse(statusCode, dto.SkillConflictResponse{
+			Error: message,
+			Code:  code,
+			Existing: dto.ExistingSkillResponse{
+				ProficiencyLevel: string(exists.Existing.ProficiencyLevel),
+				UpdatedAt:        exists.Existing.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			},
+		})
+	}
+
+	return codedErrorResponse(statusCode, code, message)
+}
+
+// invalidCategoryResponse creates the 400 response for an unknown category, listing the allowed ones
+func invalidCategoryResponse(err error, statusCode int, code, message string) (events.APIGatewayProxyResponse, bool) {
+	var invalid *apperrors.InvalidCategoryError
+	if !pkgerrors.As(err, &invalid) {
+		return events.APIGatewayProxyResponse{}, false
+	}
+	return successResponse(statusCode, dto.InvalidCategoryResponse{
+		Error:             message,
+		Code:              code,
+		AllowedCategories: invalid.Allowed,
+	}), true
+}
+
+// withClaimsUsername returns a copy of request with the username path parameter set from the caller's identity
+func withClaimsUsername(request events.APIGatewayProxyRequest) (events.APIGatewayProxyRequest, bool) {
+	identity, err := middleware.ExtractIdentity(request)
+	if err != nil {
+		return request, false
+	}
+
+	pathParameters := make(map[string]string, len(request.PathParameters)+1)
+	for key, value := range request.PathParameters {
+		pathParameters[key] = value
+	}
+	pathParameters["username"] = identity.Username
+	request.PathParameters = pathParameters
+
+	return request, true
+}
+
+// checkConfirmation requires the request body to echo target in its confirm field
+// It returns a 400 response and false when the confirmation is missing or does not match.
+func checkConfirmation(request events.APIGatewayProxyRequest, target string) (events.APIGatewayProxyResponse, bool) {
+	var req dto.ConfirmRequest
+	if request.Body != "" {
+		if err := decodeStrict(request.Body, &req); err != nil {
+			return invalidBodyResponse(err), false
+		}
+	}
+
+	if req.Confirm != target {
+		return errorResponse(http.StatusBadRequest, fmt.Sprintf("confirm must be %q to proceed", target)), false
+	}
+
+	return events.APIGatewayProxyResponse{}, true
+}
+
+// boolQueryParameter parses the query parameter name as strconv.ParseBool does; an absent one is false
+func boolQueryParameter(request events.APIGatewayProxyRequest, name string) (bool, error) {
+	value := request.QueryStringParameters[name]
+	if value == "" {
+		return false, nil
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s must be true or false", name)
+	}
+	return parsed, nil
+}
+
+// newSkillResponse converts a user skill into its response DTO
+func newSkillResponse(skill *models.UserSkill) dto.SkillResponse {
+	return dto.SkillResponse{
+		SkillName:         skill.SkillName,
+		ProficiencyLevel:  string(skill.ProficiencyLevel),
+		YearsOfExperience: skill.YearsOfExperience,
+		Endorsements:      skill.Endorsements,
+		LastUsedDate:      skill.LastUsedDate,
+		Notes:             skill.Notes,
+		PromotionEligible: skill.PromotionEligible,
+		CreatedAt:         skill.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:         skill.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+func successResponse(statusCode int, data interface{}) events.APIGatewayProxyResponse {
+	body, err := json.Marshal(data)
 	if err != nil {
 		// If marshaling fails, return an error response
 		return errorResponse(http.StatusInternalServerError, "Internal server error")
@@ -373,8 +1324,14 @@ func successResponse(statusCode int, data interface{}) events.APIGatewayProxyRes
 	}
 }
 
+// errorResponse creates an error response whose code is derived from the status, e.g. "BAD_REQUEST"
 func errorResponse(statusCode int, message string) events.APIGatewayProxyResponse {
-	body, err := json.Marshal(dto.ErrorResponse{Error: message})
+	return codedErrorResponse(statusCode, statusErrorCode(statusCode), message)
+}
+
+// codedErrorResponse creates an error response with an explicit error code
+func codedErrorResponse(status int, code, message string) events.APIGatewayProxyResponse {
+	body, err := json.Marshal(dto.ErrorResponse{Error: message, Code: code})
 	if err != nil {
 		// Fallback to plain text if JSON marshaling fails
 		return events.APIGatewayProxyResponse{
@@ -386,7 +1343,7 @@ func errorResponse(statusCode int, message string) events.APIGatewayProxyRespons
 		}
 	}
 	return events.APIGatewayProxyResponse{
-		StatusCode: statusCode,
+		StatusCode: status,
 		Headers: map[string]string{
 			"Content-Type": "application/json",
 		},
@@ -1,16 +1,24 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/hackmajoris/glad-stack/cmd/glad/internal/database"
 	"github.com/hackmajoris/glad-stack/cmd/glad/internal/dto"
+	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
 	"github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
 	"github.com/hackmajoris/glad-stack/cmd/glad/internal/service"
 	"github.com/hackmajoris/glad-stack/pkg/auth"
 	"github.com/hackmajoris/glad-stack/pkg/config"
+	"github.com/hackmajoris/glad-stack/pkg/dateutil"
 
 	"github.com/aws/aws-lambda-go/events"
 )
@@ -119,7 +127,7 @@ func TestHandler_GetCurrentUser(t *testing.T) {
 			// Create services with mock repository
 			tokenService := auth.NewTokenService(testConfig())
 			userService := service.NewUserService(mockRepo, tokenService)
-			skillService := service.NewSkillService(mockRepo, masterSkillsRepo, mockRepo)
+			skillService := service.NewSkillService(mockRepo, masterSkillsRepo, mockRepo, mockRepo, mockRepo, mockRepo)
 
 			// Create handler
 			h := New(userService, skillService)
@@ -163,6 +171,95 @@ func TestHandler_GetCurrentUser(t *testing.T) {
 }
 
 // TestHandler_GetCurrentUser_TimestampFormat verifies the timestamp format is ISO 8601
+func TestHandler_ExportProfile(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	user, _ := models.NewUser("testuser", "Test User", "password123")
+	if err := mockRepo.CreateUser(user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	skill, _ := models.NewUserSkill("testuser", "go", "Go", "Programming", models.ProficiencyAdvanced, 4)
+	if err := mockRepo.CreateSkill(skill); err != nil {
+		t.Fatalf("Failed to create skill: %v", err)
+	}
+
+	tokenService := auth.NewTokenService(testConfig())
+	h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+	exportFor := func(username string) events.APIGatewayProxyResponse {
+		response, err := h.ExportProfile(events.APIGatewayProxyRequest{
+			RequestContext: events.APIGatewayProxyRequestContext{
+				Authorizer: map[string]interface{}{"claims": &auth.JWTClaims{Username: username}},
+			},
+		})
+		if err != nil {
+			t.Fatalf("Handler returned unexpected error: %v", err)
+		}
+		return response
+	}
+
+	response := exportFor("testuser")
+	if response.StatusCode != 200 {
+		t.Fatalf("Expected status 200, got %d: %s", response.StatusCode, response.Body)
+	}
+	if disposition := response.Headers["Content-Disposition"]; disposition != `attachment; filename="profile.json"` {
+		t.Errorf("Expected attachment Content-Disposition, got %q", disposition)
+	}
+	if strings.Contains(response.Body, user.PasswordHash) || strings.Contains(strings.ToLower(response.Body), "password") {
+		t.Errorf("Expected export without password hash, got %s", response.Body)
+	}
+
+	var export dto.ProfileExportResponse
+	if err := json.Unmarshal([]byte(response.Body), &export); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if export.User.Username != "testuser" || export.User.Name != "Test User" {
+		t.Errorf("Expected testuser/Test User, got %s/%s", export.User.Username, export.User.Name)
+	}
+	if len(export.Skills) != 1 || export.Skills[0].SkillName != "Go" {
+		t.Errorf("Expected the Go skill, got %+v", export.Skills)
+	}
+
+	if response := exportFor("ghost"); response.StatusCode != 404 {
+		t.Errorf("Expected status 404 for unknown user, got %d", response.StatusCode)
+	}
+}
+
+func TestHandler_GetCurrentUser_CognitoClaims(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	user, _ := models.NewUser("testuser", "Test User", "password123")
+	if err := mockRepo.CreateUser(user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	tokenService := auth.NewTokenService(testConfig())
+	h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+	// An API Gateway Cognito authorizer passes the claims as a string map
+	response, err := h.GetCurrentUser(events.APIGatewayProxyRequest{
+		RequestContext: events.APIGatewayProxyRequestContext{
+			Authorizer: map[string]interface{}{"claims": map[string]interface{}{
+				"cognito:username": "testuser",
+				"email":            "testuser@example.com",
+				"sub":              "0b1c-42",
+			}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Handler returned unexpected error: %v", err)
+	}
+	if response.StatusCode != 200 {
+		t.Fatalf("Expected status 200, got %d: %s", response.StatusCode, response.Body)
+	}
+
+	var current dto.CurrentUserResponse
+	if err := json.Unmarshal([]byte(response.Body), &current); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if current.Username != "testuser" {
+		t.Errorf("Expected username testuser, got %s", current.Username)
+	}
+}
+
 func TestHandler_GetCurrentUser_TimestampFormat(t *testing.T) {
 	// Create unified mock repository
 	mockRepo := database.NewMockRepository()
@@ -180,7 +277,7 @@ func TestHandler_GetCurrentUser_TimestampFormat(t *testing.T) {
 	userService := service.NewUserService(mockRepo, tokenService)
 	mockRepository := database.NewMockRepository()
 	masterSkillRepository := database.NewMockRepository()
-	skillService := service.NewSkillService(mockRepository, masterSkillRepository, mockRepo)
+	skillService := service.NewSkillService(mockRepository, masterSkillRepository, mockRepo, mockRepository, mockRepository, mockRepository)
 	h := New(userService, skillService)
 
 	request := events.APIGatewayProxyRequest{
@@ -229,7 +326,7 @@ func TestHandler_GetCurrentUser_DoesNotExposePassword(t *testing.T) {
 	userService := service.NewUserService(mockRepo, tokenService)
 	skillMockRepo := database.NewMockRepository()
 	masterSkillMockRepo := database.NewMockRepository()
-	skillService := service.NewSkillService(skillMockRepo, masterSkillMockRepo, mockRepo)
+	skillService := service.NewSkillService(skillMockRepo, masterSkillMockRepo, mockRepo, skillMockRepo, skillMockRepo, skillMockRepo)
 	h := New(userService, skillService)
 
 	request := events.APIGatewayProxyRequest{
@@ -267,3 +364,3347 @@ func TestHandler_GetCurrentUser_DoesNotExposePassword(t *testing.T) {
 		}
 	}
 }
+
+func TestHandler_EndorseSkill(t *testing.T) {
+	tests := []struct {
+		name                 string
+		endorser             string
+		expectedStatus       int
+		expectedEndorsements int
+	}{
+		{
+			name:                 "endorse another user's skill",
+			endorser:             "endorser",
+			expectedStatus:       200,
+			expectedEndorsements: 1,
+		},
+		{
+			name:                 "endorse own skill is forbidden",
+			endorser:             "testuser",
+			expectedStatus:       403,
+			expectedEndorsements: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := database.NewMockRepository()
+			skill, _ := models.NewUserSkill("testuser", "go", "Go", "Programming", models.ProficiencyIntermediate, 3)
+			if err := mockRepo.CreateSkill(skill); err != nil {
+				t.Fatalf("Failed to create skill: %v", err)
+			}
+
+			tokenService := auth.NewTokenService(testConfig())
+			userService := service.NewUserService(mockRepo, tokenService)
+			skillService := service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo)
+			h := New(userService, skillService)
+
+			request := events.APIGatewayProxyRequest{
+				PathParameters: map[string]string{
+					"username":  "testuser",
+					"skillName": "go",
+				},
+				RequestContext: events.APIGatewayProxyRequestContext{
+					Authorizer: map[string]interface{}{
+						"claims": &auth.JWTClaims{Username: tt.endorser},
+					},
+				},
+			}
+
+			response, err := h.EndorseSkill(request)
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+
+			if response.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, response.StatusCode)
+			}
+
+			stored, _ := mockRepo.GetSkill("testuser", "go")
+			if stored.Endorsements != tt.expectedEndorsements {
+				t.Errorf("Expected %d endorsements, got %d", tt.expectedEndorsements, stored.Endorsements)
+			}
+
+			if tt.expectedStatus == 200 {
+				var result dto.SkillResponse
+				if err := json.Unmarshal([]byte(response.Body), &result); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if result.Endorsements != tt.expectedEndorsements {
+					t.Errorf("Expected response endorsements %d, got %d", tt.expectedEndorsements, result.Endorsements)
+				}
+			}
+		})
+	}
+}
+
+func TestHandler_EndorsementLifecycle(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	skill, _ := models.NewUserSkill("testuser", "go", "Go", "Programming", models.ProficiencyIntermediate, 3)
+	if err := mockRepo.CreateSkill(skill); err != nil {
+		t.Fatalf("Failed to create skill: %v", err)
+	}
+
+	tokenService := auth.NewTokenService(testConfig())
+	h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+	requestAs := func(endorser string) events.APIGatewayProxyRequest {
+		return events.APIGatewayProxyRequest{
+			PathParameters: map[string]string{
+				"username":  "testuser",
+				"skillName": "go",
+			},
+			RequestContext: events.APIGatewayProxyRequestContext{
+				Authorizer: map[string]interface{}{
+					"claims": &auth.JWTClaims{Username: endorser},
+				},
+			},
+		}
+	}
+
+	steps := []struct {
+		name                 string
+		call                 func(events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)
+		endorser             string
+		expectedStatus       int
+		expectedEndorsements int
+	}{
+		{"bob endorses", h.EndorseSkill, "bob", 200, 1},
+		{"alice endorses", h.EndorseSkill, "alice", 200, 2},
+		{"bob endorses again", h.EndorseSkill, "bob", 409, 2},
+		{"bob removes his endorsement", h.RemoveEndorsement, "bob", 200, 1},
+		{"bob removes it again", h.RemoveEndorsement, "bob", 404, 1},
+		{"carol never endorsed", h.RemoveEndorsement, "carol", 404, 1},
+	}
+
+	for _, step := range steps {
+		response, err := step.call(requestAs(step.endorser))
+		if err != nil {
+			t.Fatalf("%s: handler returned unexpected error: %v", step.name, err)
+		}
+		if response.StatusCode != step.expectedStatus {
+			t.Errorf("%s: expected status %d, got %d: %s", step.name, step.expectedStatus, response.StatusCode, response.Body)
+		}
+
+		stored, _ := mockRepo.GetSkill("testuser", "go")
+		if stored.Endorsements != step.expectedEndorsements {
+			t.Errorf("%s: expected %d endorsements, got %d", step.name, step.expectedEndorsements, stored.Endorsements)
+		}
+	}
+
+	response, err := h.ListEndorsements(requestAs("bob"))
+	if err != nil {
+		t.Fatalf("Handler returned unexpected error: %v", err)
+	}
+	if response.StatusCode != 200 {
+		t.Fatalf("Expected status 200, got %d", response.StatusCode)
+	}
+
+	var endorsements []dto.EndorsementResponse
+	if err := json.Unmarshal([]byte(response.Body), &endorsements); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(endorsements) != 1 || endorsements[0].Endorser != "alice" {
+		t.Errorf("Expected only alice's endorsement, got %+v", endorsements)
+	}
+
+	// Endorsements of a missing skill are a 404
+	missing := requestAs("bob")
+	missing.PathParameters["skillName"] = "rust"
+	response, _ = h.ListEndorsements(missing)
+	if response.StatusCode != 404 {
+		t.Errorf("Expected status 404 for a missing skill, got %d", response.StatusCode)
+	}
+
+	// Deleting the skill removes its endorsements, so a re-added skill can be endorsed again
+	deleteRequest := requestAs("testuser")
+	if response, _ := h.DeleteSkill(deleteRequest); response.StatusCode >= 300 {
+		t.Fatalf("Expected skill deletion to succeed, got %d: %s", response.StatusCode, response.Body)
+	}
+	if remaining, _ := mockRepo.ListEndorsements("testuser", "go"); len(remaining) != 0 {
+		t.Errorf("Expected endorsements to be deleted with the skill, got %d", len(remaining))
+	}
+}
+
+func TestHandler_EndorseSkill_ConcurrentEndorsersAllCounted(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	skill, _ := models.NewUserSkill("testuser", "go", "Go", "Programming", models.ProficiencyIntermediate, 3)
+	if err := mockRepo.CreateSkill(skill); err != nil {
+		t.Fatalf("Failed to create skill: %v", err)
+	}
+
+	tokenService := auth.NewTokenService(testConfig())
+	h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+	const endorsers = 20
+	var wg sync.WaitGroup
+	for i := 0; i < endorsers; i++ {
+		wg.Add(1)
+		go func(endorser string) {
+			defer wg.Done()
+			response, err := h.EndorseSkill(events.APIGatewayProxyRequest{
+				PathParameters: map[string]string{"username": "testuser", "skillName": "go"},
+				RequestContext: events.APIGatewayProxyRequestContext{
+					Authorizer: map[string]interface{}{"claims": &auth.JWTClaims{Username: endorser}},
+				},
+			})
+			if err != nil || response.StatusCode != 200 {
+				t.Errorf("Endorsement by %s failed: %v %d %s", endorser, err, response.StatusCode, response.Body)
+			}
+		}(fmt.Sprintf("endorser%d", i))
+	}
+	wg.Wait()
+
+	stored, _ := mockRepo.GetSkill("testuser", "go")
+	if stored.Endorsements != endorsers {
+		t.Errorf("Expected every one of %d endorsements to be counted, got %d", endorsers, stored.Endorsements)
+	}
+}
+
+func TestHandler_EndorseSkill_Promotion(t *testing.T) {
+	tests := []struct {
+		name              string
+		autoPromote       bool
+		endorsements      int
+		expectedLevel     models.ProficiencyLevel
+		expectedEligible  bool
+		expectedHistories int
+	}{
+		{
+			name:          "below threshold",
+			endorsements:  3,
+			expectedLevel: models.ProficiencyBeginner,
+		},
+		{
+			name:             "threshold reached flags eligibility",
+			endorsements:     4,
+			expectedLevel:    models.ProficiencyBeginner,
+			expectedEligible: true,
+		},
+		{
+			name:              "threshold reached with auto-promotion",
+			autoPromote:       true,
+			endorsements:      4,
+			expectedLevel:     models.ProficiencyIntermediate,
+			expectedHistories: 1,
+		},
+		{
+			name:              "auto-promotion moves up one level at a time",
+			autoPromote:       true,
+			endorsements:      29,
+			expectedLevel:     models.ProficiencyIntermediate,
+			expectedEligible:  true,
+			expectedHistories: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := database.NewMockRepository()
+			skill, _ := models.NewUserSkill("testuser", "go", "Go", "Programming", models.ProficiencyBeginner, 1)
+			skill.Endorsements = tt.endorsements
+			if err := mockRepo.CreateSkill(skill); err != nil {
+				t.Fatalf("Failed to create skill: %v", err)
+			}
+
+			policy, err := models.NewPromotionPolicy(tt.autoPromote, []int{5, 15, 30})
+			if err != nil {
+				t.Fatalf("Failed to create promotion policy: %v", err)
+			}
+
+			tokenService := auth.NewTokenService(testConfig())
+			userService := service.NewUserService(mockRepo, tokenService)
+			skillService := service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, service.WithPromotionPolicy(policy))
+			h := New(userService, skillService)
+
+			response, err := h.EndorseSkill(events.APIGatewayProxyRequest{
+				PathParameters: map[string]string{"username": "testuser", "skillName": "go"},
+				RequestContext: events.APIGatewayProxyRequestContext{
+					Authorizer: map[string]interface{}{"claims": &auth.JWTClaims{Username: "endorser"}},
+				},
+			})
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+			if response.StatusCode != 200 {
+				t.Fatalf("Expected status 200, got %d", response.StatusCode)
+			}
+
+			var result dto.SkillResponse
+			if err := json.Unmarshal([]byte(response.Body), &result); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if result.ProficiencyLevel != string(tt.expectedLevel) {
+				t.Errorf("Expected level %s, got %s", tt.expectedLevel, result.ProficiencyLevel)
+			}
+			if result.PromotionEligible != tt.expectedEligible {
+				t.Errorf("Expected promotion_eligible %v, got %v", tt.expectedEligible, result.PromotionEligible)
+			}
+
+			stored, _ := mockRepo.GetSkill("testuser", "go")
+			if stored.ProficiencyLevel != tt.expectedLevel {
+				t.Errorf("Expected stored level %s, got %s", tt.expectedLevel, stored.ProficiencyLevel)
+			}
+
+			histories, err := mockRepo.ListSkillHistory("testuser", "go")
+			if err != nil {
+				t.Fatalf("Failed to list skill history: %v", err)
+			}
+			if len(histories) != tt.expectedHistories {
+				t.Errorf("Expected %d history entries, got %d", tt.expectedHistories, len(histories))
+			}
+		})
+	}
+}
+
+func TestHandler_EndorseUsersForSkill(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	for _, username := range []string{"alice", "bob"} {
+		skill, _ := models.NewUserSkill(username, "go", "Go", "Programming", models.ProficiencyIntermediate, 3)
+		if err := mockRepo.CreateSkill(skill); err != nil {
+			t.Fatalf("Failed to create skill: %v", err)
+		}
+	}
+	python, _ := models.NewUserSkill("carol", "python", "Python", "Programming", models.ProficiencyAdvanced, 5)
+	if err := mockRepo.CreateSkill(python); err != nil {
+		t.Fatalf("Failed to create skill: %v", err)
+	}
+
+	tokenService := auth.NewTokenService(testConfig())
+	h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+	request := func(body string) events.APIGatewayProxyRequest {
+		return events.APIGatewayProxyRequest{
+			PathParameters: map[string]string{"skillName": "go"},
+			Body:           body,
+			RequestContext: events.APIGatewayProxyRequestContext{
+				Authorizer: map[string]interface{}{
+					"claims": &auth.JWTClaims{Username: "manager", Role: auth.RoleAdmin},
+				},
+			},
+		}
+	}
+
+	response, err := h.EndorseUsersForSkill(request(`{"usernames":["alice","bob","carol","alice"],"category":"Programming"}`))
+	if err != nil {
+		t.Fatalf("Handler returned unexpected error: %v", err)
+	}
+	if response.StatusCode != 207 {
+		t.Fatalf("Expected status 207, got %d: %s", response.StatusCode, response.Body)
+	}
+
+	var result dto.BulkEndorseResponse
+	if err := json.Unmarshal([]byte(response.Body), &result); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if result.Endorsed != 2 || result.Failed != 1 {
+		t.Errorf("Expected 2 endorsed and 1 failed, got %d and %d", result.Endorsed, result.Failed)
+	}
+	if len(result.Results) != 3 {
+		t.Fatalf("Expected 3 results (duplicates collapsed), got %d", len(result.Results))
+	}
+	if carol := result.Results[2]; carol.Input != "carol" || carol.Success || carol.ErrorCode != dto.CodeSkillNotFound || carol.ErrorMessage == "" {
+		t.Errorf("Expected carol to fail without the skill, got %+v", carol)
+	}
+
+	for _, username := range []string{"alice", "bob"} {
+		stored, _ := mockRepo.GetSkill(username, "go")
+		if stored.Endorsements != 1 {
+			t.Errorf("Expected %s to have 1 endorsement, got %d", username, stored.Endorsements)
+		}
+	}
+
+	// A category the users' skill is not in endorses nobody
+	response, _ = h.EndorseUsersForSkill(request(`{"usernames":["alice"],"category":"Cloud"}`))
+	if response.StatusCode != 207 {
+		t.Errorf("Expected status 207 for category mismatch, got %d", response.StatusCode)
+	}
+
+	tooMany := make([]string, 51)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("user%d", i)
+	}
+	tooManyBody, _ := json.Marshal(dto.BulkEndorseRequest{Usernames: tooMany})
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+	}{
+		{name: "empty usernames", body: `{"usernames":[]}`, expectedStatus: 400},
+		{name: "invalid category", body: `{"usernames":["alice"],"category":"Cooking"}`, expectedStatus: 400},
+		{name: "invalid body", body: `not json`, expectedStatus: 400},
+		{name: "over per-request cap", body: string(tooManyBody), expectedStatus: 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, _ := h.EndorseUsersForSkill(request(tt.body))
+			if response.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.expectedStatus, response.StatusCode, response.Body)
+			}
+		})
+	}
+}
+
+func TestHandler_BatchResultSharedShape(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	for _, username := range []string{"alice", "manager"} {
+		skill, _ := models.NewUserSkill(username, "go", "Go", "Programming", models.ProficiencyIntermediate, 3)
+		if err := mockRepo.CreateSkill(skill); err != nil {
+			t.Fatalf("Failed to create skill: %v", err)
+		}
+	}
+
+	tokenService := auth.NewTokenService(testConfig())
+	h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+	response, _ := h.EndorseUsersForSkill(events.APIGatewayProxyRequest{
+		PathParameters: map[string]string{"skillName": "go"},
+		Body:           `{"usernames":["alice","manager","nobody"]}`,
+		RequestContext: events.APIGatewayProxyRequestContext{
+			Authorizer: map[string]interface{}{
+				"claims": &auth.JWTClaims{Username: "manager", Role: auth.RoleAdmin},
+			},
+		},
+	})
+	if response.StatusCode != 207 {
+		t.Fatalf("Expected status 207, got %d: %s", response.StatusCode, response.Body)
+	}
+
+	var body struct {
+		Results []map[string]json.RawMessage `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(response.Body), &body); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	expected := []struct {
+		input     string
+		success   bool
+		errorCode string
+	}{
+		{input: "alice", success: true},
+		{input: "manager", errorCode: dto.CodeSelfEndorsement},
+		{input: "nobody", errorCode: dto.CodeSkillNotFound},
+	}
+	if len(body.Results) != len(expected) {
+		t.Fatalf("Expected %d results, got %d", len(expected), len(body.Results))
+	}
+
+	for i, want := range expected {
+		item := body.Results[i]
+		for _, key := range []string{"index", "input", "success"} {
+			if _, ok := item[key]; !ok {
+				t.Errorf("Result %d is missing %q", i, key)
+			}
+		}
+
+		var result dto.BatchResult[dto.SkillResponse]
+		raw, _ := json.Marshal(item)
+		if err := json.Unmarshal(raw, &result); err != nil {
+			t.Fatalf("Failed to unmarshal result %d: %v", i, err)
+		}
+		if result.Index != i || result.Input != want.input || result.Success != want.success || result.ErrorCode != want.errorCode {
+			t.Errorf("Unexpected result at index %d: %+v", i, result)
+		}
+		if want.success {
+			if result.Data == nil || result.Data.Endorsements != 1 || result.ErrorMessage != "" {
+				t.Errorf("Expected data and no error for result %d, got %+v", i, result)
+			}
+		} else if result.Data != nil || result.ErrorMessage == "" {
+			t.Errorf("Expected an error message and no data for result %d, got %+v", i, result)
+		}
+	}
+}
+
+func TestHandler_DeleteSkillsBatch(t *testing.T) {
+	tests := []struct {
+		name            string
+		caller          string
+		me              bool
+		body            string
+		expectedStatus  int
+		expectedDeleted int
+		expectedFailed  int
+		expectedLeft    int
+	}{
+		{
+			name:            "all skills exist",
+			caller:          "testuser",
+			body:            `{"skill_names":["go","python"]}`,
+			expectedStatus:  200,
+			expectedDeleted: 2,
+			expectedLeft:    1,
+		},
+		{
+			name:            "mix of existing and missing skills",
+			caller:          "testuser",
+			body:            `{"skill_names":["go","cobol","python","fortran"]}`,
+			expectedStatus:  207,
+			expectedDeleted: 2,
+			expectedFailed:  2,
+			expectedLeft:    1,
+		},
+		{
+			name:            "repeated names are deleted once",
+			caller:          "testuser",
+			body:            `{"skill_names":["go","go"]}`,
+			expectedStatus:  200,
+			expectedDeleted: 1,
+			expectedLeft:    2,
+		},
+		{
+			name:            "me variant",
+			caller:          "testuser",
+			me:              true,
+			body:            `{"skill_names":["rust","cobol"]}`,
+			expectedStatus:  207,
+			expectedDeleted: 1,
+			expectedFailed:  1,
+			expectedLeft:    2,
+		},
+		{
+			name:           "other users' skills",
+			caller:         "mallory",
+			body:           `{"skill_names":["go"]}`,
+			expectedStatus: 403,
+			expectedLeft:   3,
+		},
+		{
+			name:           "empty list",
+			caller:         "testuser",
+			body:           `{"skill_names":[]}`,
+			expectedStatus: 400,
+			expectedLeft:   3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := database.NewMockRepository()
+			user, _ := models.NewUser("testuser", "Test User", "password123")
+			if err := mockRepo.CreateUser(user); err != nil {
+				t.Fatalf("Failed to create user: %v", err)
+			}
+			for _, skillID := range []string{"go", "python", "rust"} {
+				skill, _ := models.NewUserSkill("testuser", skillID, skillID, "Programming", models.ProficiencyIntermediate, 3)
+				if err := mockRepo.CreateSkill(skill); err != nil {
+					t.Fatalf("Failed to create skill: %v", err)
+				}
+			}
+
+			tokenService := auth.NewTokenService(testConfig())
+			h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+			request := events.APIGatewayProxyRequest{
+				Body: tt.body,
+				RequestContext: events.APIGatewayProxyRequestContext{
+					Authorizer: map[string]interface{}{"claims": &auth.JWTClaims{Username: tt.caller}},
+				},
+			}
+
+			var response events.APIGatewayProxyResponse
+			var err error
+			if tt.me {
+				response, err = h.DeleteMySkills(request)
+			} else {
+				request.PathParameters = map[string]string{"username": "testuser"}
+				response, err = h.DeleteSkillsBatch(request)
+			}
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+
+			if response.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, response.StatusCode, response.Body)
+			}
+
+			stored, _ := mockRepo.ListSkillsForUser("testuser")
+			if len(stored) != tt.expectedLeft {
+				t.Errorf("Expected %d skills left, got %d", tt.expectedLeft, len(stored))
+			}
+			if count, _ := mockRepo.CountSkillsForUser("testuser"); count != tt.expectedLeft {
+				t.Errorf("Expected skill count %d, got %d", tt.expectedLeft, count)
+			}
+
+			if tt.expectedStatus != 200 && tt.expectedStatus != 207 {
+				return
+			}
+
+			var result dto.BatchDeleteSkillsResponse
+			if err := json.Unmarshal([]byte(response.Body), &result); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if result.Deleted != tt.expectedDeleted || result.Failed != tt.expectedFailed {
+				t.Errorf("Expected %d deleted and %d failed, got %d and %d", tt.expectedDeleted, tt.expectedFailed, result.Deleted, result.Failed)
+			}
+			for i, item := range result.Results {
+				if item.Index != i {
+					t.Errorf("Expected index %d, got %d", i, item.Index)
+				}
+				if item.Success && (item.Data == nil || item.Data.SkillName != item.Input) {
+					t.Errorf("Expected deleted skill %s in result, got %+v", item.Input, item.Data)
+				}
+				if !item.Success && item.ErrorCode != dto.CodeSkillNotFound {
+					t.Errorf("Expected %s for %s, got %+v", dto.CodeSkillNotFound, item.Input, item)
+				}
+			}
+		})
+	}
+}
+
+func TestHandler_AddSkillsBatch(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		missingUser    bool
+		expectedStatus int
+		expectedSkills int
+	}{
+		{
+			name:           "all entries valid",
+			body:           `{"skills":[{"skill_name":"go","proficiency_level":"Expert","years_of_experience":5},{"skill_name":"aws-lambda","proficiency_level":"Beginner","years_of_experience":1,"notes":"side projects"}]}`,
+			expectedStatus: 201,
+			expectedSkills: 2,
+		},
+		{
+			name:           "unknown user rejects whole batch",
+			body:           `{"skills":[{"skill_name":"go","proficiency_level":"Expert","years_of_experience":5}]}`,
+			missingUser:    true,
+			expectedStatus: 404,
+			expectedSkills: 0,
+		},
+		{
+			name:           "unknown skill rejects whole batch",
+			body:           `{"skills":[{"skill_name":"go","proficiency_level":"Expert","years_of_experience":5},{"skill_name":"cobol","proficiency_level":"Beginner","years_of_experience":1}]}`,
+			expectedStatus: 404,
+			expectedSkills: 0,
+		},
+		{
+			name:           "invalid proficiency rejects whole batch",
+			body:           `{"skills":[{"skill_name":"go","proficiency_level":"Guru","years_of_experience":5}]}`,
+			expectedStatus: 400,
+			expectedSkills: 0,
+		},
+		{
+			name:           "duplicate entries reject whole batch",
+			body:           `{"skills":[{"skill_name":"go","proficiency_level":"Expert","years_of_experience":5},{"skill_name":"go","proficiency_level":"Beginner","years_of_experience":1}]}`,
+			expectedStatus: 409,
+			expectedSkills: 0,
+		},
+		{
+			name:           "empty batch",
+			body:           `{"skills":[]}`,
+			expectedStatus: 400,
+			expectedSkills: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := database.NewMockRepository()
+			golang, _ := models.NewSkill("go", "Go", "Go language", "Programming", nil)
+			lambda, _ := models.NewSkill("aws-lambda", "AWS Lambda", "Serverless compute", "Cloud", nil)
+			for _, skill := range []*models.Skill{golang, lambda} {
+				if err := mockRepo.CreateMasterSkill(skill); err != nil {
+					t.Fatalf("Failed to create master skill: %v", err)
+				}
+			}
+			if !tt.missingUser {
+				user, _ := models.NewUser("testuser", "Test User", "password123")
+				if err := mockRepo.CreateUser(user); err != nil {
+					t.Fatalf("Failed to create user: %v", err)
+				}
+			}
+
+			tokenService := auth.NewTokenService(testConfig())
+			userService := service.NewUserService(mockRepo, tokenService)
+			skillService := service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo)
+			h := New(userService, skillService)
+
+			request := events.APIGatewayProxyRequest{
+				PathParameters: map[string]string{"username": "testuser"},
+				Body:           tt.body,
+			}
+
+			response, err := h.AddSkillsBatch(request)
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+
+			if response.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.expectedStatus, response.StatusCode, response.Body)
+			}
+
+			stored, _ := mockRepo.ListSkillsForUser("testuser")
+			if len(stored) != tt.expectedSkills {
+				t.Errorf("Expected %d stored skills, got %d", tt.expectedSkills, len(stored))
+			}
+
+			if tt.expectedStatus == 201 {
+				if user, _ := mockRepo.GetUser("testuser"); user.SkillCount != tt.expectedSkills {
+					t.Errorf("Expected skill count %d, got %d", tt.expectedSkills, user.SkillCount)
+				}
+
+				var result dto.BatchSkillResponse
+				if err := json.Unmarshal([]byte(response.Body), &result); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if result.Created != tt.expectedSkills || result.Failed != 0 {
+					t.Errorf("Expected %d created and 0 failed, got %d and %d", tt.expectedSkills, result.Created, result.Failed)
+				}
+				for i, item := range result.Results {
+					if item.Index != i || !item.Success || item.Data == nil {
+						t.Errorf("Unexpected result at index %d: %+v", i, item)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestHandler_DeactivateAndReactivateUser(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	for _, username := range []string{"testuser", "otheruser"} {
+		user, _ := models.NewUser(username, "Test User", "password123")
+		if err := mockRepo.CreateUser(user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+		skill, _ := models.NewUserSkill(username, "go", "Go", "Programming", models.ProficiencyIntermediate, 3)
+		if err := mockRepo.CreateSkill(skill); err != nil {
+			t.Fatalf("Failed to create skill: %v", err)
+		}
+	}
+
+	tokenService := auth.NewTokenService(testConfig())
+	userService := service.NewUserService(mockRepo, tokenService)
+	skillService := service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo)
+	h := New(userService, skillService)
+
+	login := func() int {
+		response, _ := h.Login(events.APIGatewayProxyRequest{Body: `{"username":"testuser","password":"password123"}`})
+		return response.StatusCode
+	}
+	listedUsers := func() int {
+		response, _ := h.ListUsers(events.APIGatewayProxyRequest{})
+		var users []dto.UserListResponse
+		if err := json.Unmarshal([]byte(response.Body), &users); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		return len(users)
+	}
+	usersWithSkill := func() int {
+		skills, _, err := skillService.ListUsersBySkill("Programming", "Go", 0, "")
+		if err != nil {
+			t.Fatalf("Failed to list users by skill: %v", err)
+		}
+		return len(skills)
+	}
+
+	response, err := h.DeactivateCurrentUser(events.APIGatewayProxyRequest{
+		RequestContext: events.APIGatewayProxyRequestContext{
+			Authorizer: map[string]interface{}{
+				"claims": &auth.JWTClaims{Username: "testuser"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Handler returned unexpected error: %v", err)
+	}
+	if response.StatusCode != 200 {
+		t.Fatalf("Expected status 200, got %d: %s", response.StatusCode, response.Body)
+	}
+
+	if status := login(); status != 403 {
+		t.Errorf("Expected login of archived user to return 403, got %d", status)
+	}
+	if count := listedUsers(); count != 1 {
+		t.Errorf("Expected 1 listed user after deactivation, got %d", count)
+	}
+	if count := usersWithSkill(); count != 1 {
+		t.Errorf("Expected 1 user with skill after deactivation, got %d", count)
+	}
+
+	response, err = h.ReactivateUser(events.APIGatewayProxyRequest{
+		PathParameters: map[string]string{"username": "testuser"},
+	})
+	if err != nil {
+		t.Fatalf("Handler returned unexpected error: %v", err)
+	}
+	if response.StatusCode != 200 {
+		t.Fatalf("Expected status 200, got %d: %s", response.StatusCode, response.Body)
+	}
+
+	if status := login(); status != 200 {
+		t.Errorf("Expected login after reactivation to return 200, got %d", status)
+	}
+	if count := listedUsers(); count != 2 {
+		t.Errorf("Expected 2 listed users after reactivation, got %d", count)
+	}
+	if count := usersWithSkill(); count != 2 {
+		t.Errorf("Expected 2 users with skill after reactivation, got %d", count)
+	}
+}
+
+func TestHandler_AddSkill_ConflictIncludesExisting(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	golang, _ := models.NewSkill("go", "Go", "Go language", "Programming", nil)
+	if err := mockRepo.CreateMasterSkill(golang); err != nil {
+		t.Fatalf("Failed to create master skill: %v", err)
+	}
+	existing, _ := models.NewUserSkill("testuser", "go", "Go", "Programming", models.ProficiencyExpert, 8)
+	if err := mockRepo.CreateSkill(existing); err != nil {
+		t.Fatalf("Failed to create skill: %v", err)
+	}
+
+	tokenService := auth.NewTokenService(testConfig())
+	h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+	response, err := h.AddSkill(events.APIGatewayProxyRequest{
+		PathParameters: map[string]string{"username": "testuser"},
+		Body:           `{"skill_name":"go","proficiency_level":"Beginner","years_of_experience":1}`,
+	})
+	if err != nil {
+		t.Fatalf("Handler returned unexpected error: %v", err)
+	}
+	if response.StatusCode != 409 {
+		t.Fatalf("Expected status 409, got %d: %s", response.StatusCode, response.Body)
+	}
+
+	var conflict dto.SkillConflictResponse
+	if err := json.Unmarshal([]byte(response.Body), &conflict); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if conflict.Error != "Skill already exists for this user" {
+		t.Errorf("Expected conflict message, got %q", conflict.Error)
+	}
+	if conflict.Existing.ProficiencyLevel != "Expert" {
+		t.Errorf("Expected existing proficiency Expert, got %s", conflict.Existing.ProficiencyLevel)
+	}
+	if conflict.Existing.UpdatedAt != existing.UpdatedAt.Format(time.RFC3339) {
+		t.Errorf("Expected existing updated_at %s, got %s", existing.UpdatedAt.Format(time.RFC3339), conflict.Existing.UpdatedAt)
+	}
+}
+
+func TestHandler_AddSkill_Upsert(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	golang, _ := models.NewSkill("go", "Go", "Go language", "Programming", nil)
+	rust, _ := models.NewSkill("rust", "Rust", "Rust language", "Programming", nil)
+	for _, skill := range []*models.Skill{golang, rust} {
+		if err := mockRepo.CreateMasterSkill(skill); err != nil {
+			t.Fatalf("Failed to create master skill: %v", err)
+		}
+	}
+	existing, _ := models.NewUserSkill("testuser", "go", "Go", "Programming", models.ProficiencyExpert, 8)
+	if err := mockRepo.CreateSkill(existing); err != nil {
+		t.Fatalf("Failed to create skill: %v", err)
+	}
+
+	tokenService := auth.NewTokenService(testConfig())
+	h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+	tests := []struct {
+		name           string
+		upsert         string
+		body           string
+		expectedStatus int
+		expectedLevel  string
+	}{
+		{
+			name:           "creates missing skill",
+			upsert:         "true",
+			body:           `{"skill_name":"rust","proficiency_level":"Beginner","years_of_experience":1}`,
+			expectedStatus: 201,
+			expectedLevel:  "Beginner",
+		},
+		{
+			name:           "updates existing skill",
+			upsert:         "true",
+			body:           `{"skill_name":"Go","proficiency_level":"Advanced","years_of_experience":5,"notes":"Mostly backend"}`,
+			expectedStatus: 200,
+			expectedLevel:  "Advanced",
+		},
+		{
+			name:           "without upsert existing skill conflicts",
+			upsert:         "",
+			body:           `{"skill_name":"go","proficiency_level":"Beginner","years_of_experience":1}`,
+			expectedStatus: 409,
+		},
+		{
+			name:           "invalid flag",
+			upsert:         "maybe",
+			body:           `{"skill_name":"go","proficiency_level":"Beginner","years_of_experience":1}`,
+			expectedStatus: 400,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := events.APIGatewayProxyRequest{
+				PathParameters: map[string]string{"username": "testuser"},
+				Body:           tt.body,
+			}
+			if tt.upsert != "" {
+				request.QueryStringParameters = map[string]string{"upsert": tt.upsert}
+			}
+
+			response, err := h.AddSkill(request)
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+			if response.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, response.StatusCode, response.Body)
+			}
+			if tt.expectedLevel == "" {
+				return
+			}
+
+			var skill dto.SkillResponse
+			if err := json.Unmarshal([]byte(response.Body), &skill); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if skill.ProficiencyLevel != tt.expectedLevel {
+				t.Errorf("Expected proficiency %s, got %s", tt.expectedLevel, skill.ProficiencyLevel)
+			}
+		})
+	}
+
+	// The update path replaces the stored skill rather than adding a second one
+	stored, err := mockRepo.GetSkill("testuser", "go")
+	if err != nil {
+		t.Fatalf("Failed to get skill: %v", err)
+	}
+	if stored.ProficiencyLevel != models.ProficiencyAdvanced || stored.YearsOfExperience != 5 || stored.Notes != "Mostly backend" {
+		t.Errorf("Expected upserted Advanced/5/notes, got %s/%d/%q", stored.ProficiencyLevel, stored.YearsOfExperience, stored.Notes)
+	}
+}
+
+func TestHandler_AddSkill_ResolvesMasterSkill(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedCode   string
+		expectedSkill  string
+	}{
+		{
+			name:           "by skill ID",
+			body:           `{"skill_id":"aws-lambda","proficiency_level":"Beginner","years_of_experience":1}`,
+			expectedStatus: 201,
+			expectedSkill:  "AWS Lambda",
+		},
+		{
+			name:           "skill ID wins over skill name",
+			body:           `{"skill_id":"aws-lambda","skill_name":"go","proficiency_level":"Beginner","years_of_experience":1}`,
+			expectedStatus: 201,
+			expectedSkill:  "AWS Lambda",
+		},
+		{
+			name:           "by skill name",
+			body:           `{"skill_name":" Go ","proficiency_level":"Beginner","years_of_experience":1}`,
+			expectedStatus: 201,
+			expectedSkill:  "Go",
+		},
+		{
+			name:           "skill ID is matched exactly",
+			body:           `{"skill_id":"AWS-Lambda","proficiency_level":"Beginner","years_of_experience":1}`,
+			expectedStatus: 404,
+			expectedCode:   dto.CodeMasterSkillNotFound,
+		},
+		{
+			name:           "unknown skill ID",
+			body:           `{"skill_id":"cobol","proficiency_level":"Beginner","years_of_experience":1}`,
+			expectedStatus: 404,
+			expectedCode:   dto.CodeMasterSkillNotFound,
+		},
+		{
+			name:           "unknown skill name",
+			body:           `{"skill_name":"cobol","proficiency_level":"Beginner","years_of_experience":1}`,
+			expectedStatus: 404,
+			expectedCode:   dto.CodeSkillNotFound,
+		},
+		{
+			name:           "neither skill ID nor name",
+			body:           `{"proficiency_level":"Beginner","years_of_experience":1}`,
+			expectedStatus: 400,
+			expectedCode:   dto.CodeRequiredField,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := database.NewMockRepository()
+			golang, _ := models.NewSkill("go", "Go", "Go language", "Programming", nil)
+			lambda, _ := models.NewSkill("aws-lambda", "AWS Lambda", "Serverless compute", "Cloud", nil)
+			for _, skill := range []*models.Skill{golang, lambda} {
+				if err := mockRepo.CreateMasterSkill(skill); err != nil {
+					t.Fatalf("Failed to create master skill: %v", err)
+				}
+			}
+
+			tokenService := auth.NewTokenService(testConfig())
+			h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+			response, err := h.AddSkill(events.APIGatewayProxyRequest{
+				PathParameters: map[string]string{"username": "testuser"},
+				Body:           tt.body,
+			})
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+			if response.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, response.StatusCode, response.Body)
+			}
+
+			if tt.expectedCode != "" {
+				var errResp dto.ErrorResponse
+				if err := json.Unmarshal([]byte(response.Body), &errResp); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if errResp.Code != tt.expectedCode {
+					t.Errorf("Expected code %s, got %s", tt.expectedCode, errResp.Code)
+				}
+				return
+			}
+
+			var skill dto.SkillResponse
+			if err := json.Unmarshal([]byte(response.Body), &skill); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if skill.SkillName != tt.expectedSkill {
+				t.Errorf("Expected skill %s, got %s", tt.expectedSkill, skill.SkillName)
+			}
+		})
+	}
+}
+
+func TestHandler_SkillWrites_CheckExperience(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	user, _ := models.NewUser("testuser", "Test User", "password123")
+	if err := mockRepo.CreateUser(user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	for _, id := range []string{"go", "rust", "python"} {
+		master, _ := models.NewSkill(id, strings.ToUpper(id[:1])+id[1:], "", "Programming", nil)
+		if err := mockRepo.CreateMasterSkill(master); err != nil {
+			t.Fatalf("Failed to create master skill: %v", err)
+		}
+	}
+	expert, _ := models.NewUserSkill("testuser", "go", "Go", "Programming", models.ProficiencyExpert, 5)
+	intermediate, _ := models.NewUserSkill("testuser", "python", "Python", "Programming", models.ProficiencyIntermediate, 1)
+	for _, skill := range []*models.UserSkill{expert, intermediate} {
+		if err := mockRepo.CreateSkill(skill); err != nil {
+			t.Fatalf("Failed to create skill: %v", err)
+		}
+	}
+
+	policy, _ := models.NewPromotionPolicy(true, []int{1, 2, 3})
+	skillService := service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, service.WithPromotionPolicy(policy))
+	h := New(service.NewUserService(mockRepo, auth.NewTokenService(testConfig())), skillService)
+
+	tests := []struct {
+		name   string
+		handle func(events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)
+		path   map[string]string
+		query  map[string]string
+		body   string
+	}{
+		{
+			name:   "upsert keeping the stored Expert level",
+			handle: h.AddSkill,
+			path:   map[string]string{"username": "testuser"},
+			query:  map[string]string{"upsert": "true"},
+			body:   `{"skill_id":"go","years_of_experience":0}`,
+		},
+		{
+			name:   "batch add",
+			handle: h.AddSkillsBatch,
+			path:   map[string]string{"username": "testuser"},
+			body:   `{"skills":[{"skill_id":"rust","proficiency_level":"Expert","years_of_experience":0}]}`,
+		},
+		{
+			name:   "promote",
+			handle: h.PromoteSkill,
+			path:   map[string]string{"username": "testuser", "skillName": "python"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := tt.handle(events.APIGatewayProxyRequest{
+				PathParameters:        tt.path,
+				QueryStringParameters: tt.query,
+				Body:                  tt.body,
+				RequestContext: events.APIGatewayProxyRequestContext{
+					Authorizer: map[string]interface{}{"claims": &auth.JWTClaims{Username: "testuser"}},
+				},
+			})
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+			if response.StatusCode != 400 || !strings.Contains(response.Body, dto.CodeInconsistentYears) {
+				t.Errorf("Expected status 400 with %s, got %d: %s", dto.CodeInconsistentYears, response.StatusCode, response.Body)
+			}
+		})
+	}
+
+	if skill, _ := mockRepo.GetSkill("testuser", "go"); skill.YearsOfExperience != 5 {
+		t.Errorf("Expected the upsert to leave the stored years alone, got %d", skill.YearsOfExperience)
+	}
+	if _, err := mockRepo.GetSkill("testuser", "rust"); !errors.Is(err, apperrors.ErrSkillNotFound) {
+		t.Errorf("Expected the batch to add nothing, got %v", err)
+	}
+
+	// An endorsement makes python eligible for Advanced, but one year is too few to be promoted to it
+	skill, err := skillService.EndorseSkill("alice", "testuser", "python")
+	if err != nil {
+		t.Fatalf("Failed to endorse skill: %v", err)
+	}
+	if skill.ProficiencyLevel != models.ProficiencyIntermediate {
+		t.Errorf("Expected auto-promotion to be skipped, got %s", skill.ProficiencyLevel)
+	}
+}
+
+func TestHandler_AddSkill_DefaultProficiency(t *testing.T) {
+	tests := []struct {
+		name           string
+		opts           []service.SkillServiceOption
+		body           string
+		expectedStatus int
+		expectedCode   string
+		expectedLevel  string
+	}{
+		{
+			name:           "omitted level defaults to Beginner",
+			body:           `{"skill_id":"go","years_of_experience":1}`,
+			expectedStatus: 201,
+			expectedLevel:  "Beginner",
+		},
+		{
+			name:           "omitted level uses the configured default",
+			opts:           []service.SkillServiceOption{service.WithDefaultProficiency(models.ProficiencyIntermediate)},
+			body:           `{"skill_id":"go","years_of_experience":1}`,
+			expectedStatus: 201,
+			expectedLevel:  "Intermediate",
+		},
+		{
+			name:           "configured default is checked against experience",
+			opts:           []service.SkillServiceOption{service.WithDefaultProficiency(models.ProficiencyExpert)},
+			body:           `{"skill_id":"go","years_of_experience":1}`,
+			expectedStatus: 400,
+			expectedCode:   dto.CodeInconsistentYears,
+		},
+		{
+			name:           "provided level is kept",
+			body:           `{"skill_id":"go","proficiency_level":"Advanced","years_of_experience":4}`,
+			expectedStatus: 201,
+			expectedLevel:  "Advanced",
+		},
+		{
+			name:           "provided level is still validated",
+			body:           `{"skill_id":"go","proficiency_level":"beginner","years_of_experience":1}`,
+			expectedStatus: 400,
+			expectedCode:   dto.CodeInvalidProficiency,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := database.NewMockRepository()
+			golang, _ := models.NewSkill("go", "Go", "Go language", "Programming", nil)
+			if err := mockRepo.CreateMasterSkill(golang); err != nil {
+				t.Fatalf("Failed to create master skill: %v", err)
+			}
+
+			tokenService := auth.NewTokenService(testConfig())
+			h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, tt.opts...))
+
+			response, err := h.AddSkill(events.APIGatewayProxyRequest{
+				PathParameters: map[string]string{"username": "testuser"},
+				Body:           tt.body,
+			})
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+			if response.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, response.StatusCode, response.Body)
+			}
+
+			if tt.expectedCode != "" {
+				var errResp dto.ErrorResponse
+				if err := json.Unmarshal([]byte(response.Body), &errResp); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if errResp.Code != tt.expectedCode {
+					t.Errorf("Expected code %s, got %s", tt.expectedCode, errResp.Code)
+				}
+				return
+			}
+
+			stored, err := mockRepo.GetSkill("testuser", "go")
+			if err != nil {
+				t.Fatalf("Expected skill to be stored: %v", err)
+			}
+			if string(stored.ProficiencyLevel) != tt.expectedLevel {
+				t.Errorf("Expected level %s, got %s", tt.expectedLevel, stored.ProficiencyLevel)
+			}
+		})
+	}
+
+	t.Run("upsert without a level keeps the stored one", func(t *testing.T) {
+		mockRepo := database.NewMockRepository()
+		golang, _ := models.NewSkill("go", "Go", "Go language", "Programming", nil)
+		if err := mockRepo.CreateMasterSkill(golang); err != nil {
+			t.Fatalf("Failed to create master skill: %v", err)
+		}
+		existing, _ := models.NewUserSkill("testuser", "go", "Go", "Programming", models.ProficiencyAdvanced, 4)
+		if err := mockRepo.CreateSkill(existing); err != nil {
+			t.Fatalf("Failed to create skill: %v", err)
+		}
+
+		tokenService := auth.NewTokenService(testConfig())
+		h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+		response, _ := h.AddSkill(events.APIGatewayProxyRequest{
+			PathParameters:        map[string]string{"username": "testuser"},
+			QueryStringParameters: map[string]string{"upsert": "true"},
+			Body:                  `{"skill_id":"go","years_of_experience":6}`,
+		})
+		if response.StatusCode != 200 {
+			t.Fatalf("Expected status 200, got %d: %s", response.StatusCode, response.Body)
+		}
+
+		stored, _ := mockRepo.GetSkill("testuser", "go")
+		if stored.ProficiencyLevel != models.ProficiencyAdvanced || stored.YearsOfExperience != 6 {
+			t.Errorf("Expected Advanced with 6 years, got %s with %d", stored.ProficiencyLevel, stored.YearsOfExperience)
+		}
+	})
+}
+
+func TestHandler_AddSkill_NormalizesSkillID(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	python, _ := models.NewSkill("python", "Python", "Python language", "Programming", nil)
+	if err := mockRepo.CreateMasterSkill(python); err != nil {
+		t.Fatalf("Failed to create master skill: %v", err)
+	}
+
+	tokenService := auth.NewTokenService(testConfig())
+	h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+	tests := []struct {
+		name           string
+		username       string
+		skillName      string
+		expectedStatus int
+	}{
+		{name: "padded id resolves", username: "alice", skillName: " python ", expectedStatus: 201},
+		{name: "plain id is the same skill", username: "alice", skillName: "python", expectedStatus: 409},
+		{name: "mixed case id resolves", username: "bob", skillName: "PyThon", expectedStatus: 201},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := h.AddSkill(events.APIGatewayProxyRequest{
+				PathParameters: map[string]string{"username": tt.username},
+				Body:           `{"skill_name":"` + tt.skillName + `","proficiency_level":"Beginner","years_of_experience":1}`,
+			})
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+			if response.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, response.StatusCode, response.Body)
+			}
+			if tt.expectedStatus != 201 {
+				return
+			}
+
+			var skill dto.SkillResponse
+			if err := json.Unmarshal([]byte(response.Body), &skill); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if skill.SkillName != "Python" {
+				t.Errorf("Expected master skill Python, got %s", skill.SkillName)
+			}
+			if _, err := mockRepo.GetSkill(tt.username, "python"); err != nil {
+				t.Errorf("Expected skill stored under python, got %v", err)
+			}
+		})
+	}
+}
+
+func TestHandler_SkillExperienceConsistency(t *testing.T) {
+	tests := []struct {
+		name           string
+		update         bool
+		body           string
+		expectedStatus int
+	}{
+		{name: "add expert with enough years", body: `{"skill_name":"go","proficiency_level":"Expert","years_of_experience":5}`, expectedStatus: 201},
+		{name: "add expert with zero years", body: `{"skill_name":"go","proficiency_level":"Expert","years_of_experience":0}`, expectedStatus: 400},
+		{name: "add beginner with zero years", body: `{"skill_name":"go","proficiency_level":"Beginner","years_of_experience":0}`, expectedStatus: 201},
+		{name: "update level beyond stored years", update: true, body: `{"proficiency_level":"Advanced"}`, expectedStatus: 400},
+		{name: "update level and years together", update: true, body: `{"proficiency_level":"Advanced","years_of_experience":3}`, expectedStatus: 200},
+		{name: "update notes only", update: true, body: `{"notes":"still learning"}`, expectedStatus: 200},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := database.NewMockRepository()
+			golang, _ := models.NewSkill("go", "Go", "Go language", "Programming", nil)
+			if err := mockRepo.CreateMasterSkill(golang); err != nil {
+				t.Fatalf("Failed to create master skill: %v", err)
+			}
+
+			tokenService := auth.NewTokenService(testConfig())
+			h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+			var response events.APIGatewayProxyResponse
+			if tt.update {
+				existing, _ := models.NewUserSkill("testuser", "go", "Go", "Programming", models.ProficiencyIntermediate, 1)
+				if err := mockRepo.CreateSkill(existing); err != nil {
+					t.Fatalf("Failed to create skill: %v", err)
+				}
+				response, _ = h.PatchSkill(events.APIGatewayProxyRequest{
+					PathParameters: map[string]string{"username": "testuser", "skillName": "go"},
+					Body:           tt.body,
+				})
+			} else {
+				response, _ = h.AddSkill(events.APIGatewayProxyRequest{
+					PathParameters: map[string]string{"username": "testuser"},
+					Body:           tt.body,
+				})
+			}
+
+			if response.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, response.StatusCode, response.Body)
+			}
+			if tt.expectedStatus == 400 {
+				if !strings.Contains(response.Body, "proficiency_level") || !strings.Contains(response.Body, "years_of_experience") {
+					t.Errorf("Expected error naming both fields, got %s", response.Body)
+				}
+			}
+		})
+	}
+}
+
+func TestHandler_UpdateSkill_PutReplacesPatchMerges(t *testing.T) {
+	tests := []struct {
+		name           string
+		patch          bool
+		body           string
+		expectedStatus int
+		expectedNotes  string
+		expectedYears  int
+	}{
+		{name: "put without notes clears them", body: `{"proficiency_level":"Intermediate","years_of_experience":3}`, expectedStatus: 200, expectedYears: 3},
+		{name: "put with notes replaces them", body: `{"proficiency_level":"Intermediate","years_of_experience":3,"notes":"new"}`, expectedStatus: 200, expectedNotes: "new", expectedYears: 3},
+		{name: "put missing years rejected", body: `{"proficiency_level":"Intermediate"}`, expectedStatus: 400, expectedNotes: "keep me", expectedYears: 1},
+		{name: "put missing level rejected", body: `{"years_of_experience":3}`, expectedStatus: 400, expectedNotes: "keep me", expectedYears: 1},
+		{name: "patch preserves notes", patch: true, body: `{"years_of_experience":3}`, expectedStatus: 200, expectedNotes: "keep me", expectedYears: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := database.NewMockRepository()
+			existing, _ := models.NewUserSkill("testuser", "go", "Go", "Programming", models.ProficiencyBeginner, 1)
+			existing.UpdateNotes("keep me")
+			if err := mockRepo.CreateSkill(existing); err != nil {
+				t.Fatalf("Failed to create skill: %v", err)
+			}
+
+			tokenService := auth.NewTokenService(testConfig())
+			h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+			request := events.APIGatewayProxyRequest{
+				PathParameters: map[string]string{"username": "testuser", "skillName": "go"},
+				Body:           tt.body,
+			}
+			var response events.APIGatewayProxyResponse
+			if tt.patch {
+				response, _ = h.PatchSkill(request)
+			} else {
+				response, _ = h.UpdateSkill(request)
+			}
+			if response.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, response.StatusCode, response.Body)
+			}
+
+			stored, err := mockRepo.GetSkill("testuser", "go")
+			if err != nil {
+				t.Fatalf("Failed to get skill: %v", err)
+			}
+			if stored.Notes != tt.expectedNotes {
+				t.Errorf("Expected notes %q, got %q", tt.expectedNotes, stored.Notes)
+			}
+			if stored.YearsOfExperience != tt.expectedYears {
+				t.Errorf("Expected %d years, got %d", tt.expectedYears, stored.YearsOfExperience)
+			}
+		})
+	}
+}
+
+func TestHandler_GetSkillHistory(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	skill, _ := models.NewUserSkill("testuser", "go", "Go", "Programming", models.ProficiencyBeginner, 1)
+	if err := mockRepo.CreateSkill(skill); err != nil {
+		t.Fatalf("Failed to create skill: %v", err)
+	}
+
+	tokenService := auth.NewTokenService(testConfig())
+	h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+	pathParameters := map[string]string{"username": "testuser", "skillName": "go"}
+
+	// Two level changes and one update that leaves the level alone
+	for _, body := range []string{
+		`{"proficiency_level":"Intermediate"}`,
+		`{"years_of_experience":3}`,
+		`{"proficiency_level":"Advanced"}`,
+	} {
+		response, _ := h.PatchSkill(events.APIGatewayProxyRequest{PathParameters: pathParameters, Body: body})
+		if response.StatusCode != 200 {
+			t.Fatalf("Expected status 200 updating skill, got %d: %s", response.StatusCode, response.Body)
+		}
+	}
+
+	response, err := h.GetSkillHistory(events.APIGatewayProxyRequest{PathParameters: pathParameters})
+	if err != nil {
+		t.Fatalf("Handler returned unexpected error: %v", err)
+	}
+	if response.StatusCode != 200 {
+		t.Fatalf("Expected status 200, got %d: %s", response.StatusCode, response.Body)
+	}
+
+	var history []dto.SkillHistoryResponse
+	if err := json.Unmarshal([]byte(response.Body), &history); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("Expected 2 history entries, got %d", len(history))
+	}
+	if history[0].OldLevel != "Intermediate" || history[0].NewLevel != "Advanced" {
+		t.Errorf("Expected newest entry Intermediate -> Advanced, got %s -> %s", history[0].OldLevel, history[0].NewLevel)
+	}
+	if history[1].OldLevel != "Beginner" || history[1].NewLevel != "Intermediate" {
+		t.Errorf("Expected oldest entry Beginner -> Intermediate, got %s -> %s", history[1].OldLevel, history[1].NewLevel)
+	}
+}
+
+// failingActivityRepo is a mock repository whose activity log cannot be written
+type failingActivityRepo struct {
+	*database.MockRepository
+}
+
+func (r failingActivityRepo) CreateActivity(*models.ActivityLog) error {
+	return errors.New("activity log unavailable")
+}
+
+func TestHandler_ListActivity(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	golang, _ := models.NewSkill("go", "Go", "Go language", "Programming", nil)
+	if err := mockRepo.CreateMasterSkill(golang); err != nil {
+		t.Fatalf("Failed to create master skill: %v", err)
+	}
+
+	tokenService := auth.NewTokenService(testConfig())
+	h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+	userPath := map[string]string{"username": "testuser"}
+	skillPath := map[string]string{"username": "testuser", "skillName": "go"}
+
+	// Add, change the level, promote, then delete the skill
+	steps := []struct {
+		handle func(events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)
+		path   map[string]string
+		body   string
+	}{
+		{handle: h.AddSkill, path: userPath, body: `{"skill_id":"go","proficiency_level":"Beginner","years_of_experience":3}`},
+		{handle: h.PatchSkill, path: skillPath, body: `{"proficiency_level":"Intermediate"}`},
+		{handle: h.PromoteSkill, path: skillPath},
+		{handle: h.DeleteSkill, path: skillPath},
+	}
+	for i, step := range steps {
+		response, _ := step.handle(events.APIGatewayProxyRequest{
+			PathParameters: step.path,
+			Body:           step.body,
+			RequestContext: events.APIGatewayProxyRequestContext{
+				Authorizer: map[string]interface{}{"claims": &auth.JWTClaims{Username: "testuser"}},
+			},
+		})
+		if response.StatusCode >= 300 {
+			t.Fatalf("Expected step %d to succeed, got %d: %s", i, response.StatusCode, response.Body)
+		}
+	}
+
+	// Page through the log two entries at a time
+	var entries []dto.ActivityResponse
+	next := ""
+	for page := 0; ; page++ {
+		query := map[string]string{"limit": "2"}
+		if next != "" {
+			query["next"] = next
+		}
+		response, err := h.ListActivity(events.APIGatewayProxyRequest{PathParameters: userPath, QueryStringParameters: query})
+		if err != nil {
+			t.Fatalf("Handler returned unexpected error: %v", err)
+		}
+		if response.StatusCode != 200 {
+			t.Fatalf("Expected status 200, got %d: %s", response.StatusCode, response.Body)
+		}
+
+		var result dto.ActivityPageResponse
+		if err := json.Unmarshal([]byte(response.Body), &result); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		if len(result.Activity) > 2 {
+			t.Fatalf("Expected at most 2 entries per page, got %d", len(result.Activity))
+		}
+		entries = append(entries, result.Activity...)
+		if next = result.Next; next == "" || page > 3 {
+			break
+		}
+	}
+
+	expected := []struct{ action, oldLevel, newLevel string }{
+		{action: "skill_deleted"},
+		{action: "skill_promoted", oldLevel: "Intermediate", newLevel: "Advanced"},
+		{action: "skill_updated", oldLevel: "Beginner", newLevel: "Intermediate"},
+		{action: "skill_added", newLevel: "Beginner"},
+	}
+	if len(entries) != len(expected) {
+		t.Fatalf("Expected %d activity entries, got %d: %+v", len(expected), len(entries), entries)
+	}
+	for i, want := range expected {
+		got := entries[i]
+		if got.Action != want.action || got.OldLevel != want.oldLevel || got.NewLevel != want.newLevel || got.SkillID != "go" {
+			t.Errorf("Expected entry %d to be %+v, got %+v", i, want, got)
+		}
+	}
+
+	t.Run("invalid limit", func(t *testing.T) {
+		response, _ := h.ListActivity(events.APIGatewayProxyRequest{PathParameters: userPath, QueryStringParameters: map[string]string{"limit": "0"}})
+		if response.StatusCode != 400 {
+			t.Errorf("Expected status 400, got %d", response.StatusCode)
+		}
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		response, _ := h.ListActivity(events.APIGatewayProxyRequest{PathParameters: userPath, QueryStringParameters: map[string]string{"next": "not a token!"}})
+		if response.StatusCode != 400 {
+			t.Errorf("Expected status 400, got %d: %s", response.StatusCode, response.Body)
+		}
+	})
+
+	t.Run("log failures do not fail the change", func(t *testing.T) {
+		repo := failingActivityRepo{mockRepo}
+		h := New(service.NewUserService(repo, tokenService), service.NewSkillService(repo, repo, repo, repo, repo, repo))
+
+		response, _ := h.AddSkill(events.APIGatewayProxyRequest{
+			PathParameters: userPath,
+			Body:           `{"skill_id":"go","proficiency_level":"Beginner","years_of_experience":1}`,
+		})
+		if response.StatusCode != 201 {
+			t.Fatalf("Expected status 201, got %d: %s", response.StatusCode, response.Body)
+		}
+	})
+}
+
+func TestHandler_DeleteCurrentUser(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	for _, username := range []string{"testuser", "otheruser"} {
+		user, _ := models.NewUser(username, "Test User", "password123")
+		if err := mockRepo.CreateUser(user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+		for _, skillID := range []string{"go", "python"} {
+			skill, _ := models.NewUserSkill(username, skillID, skillID, "Programming", models.ProficiencyBeginner, 1)
+			if err := mockRepo.CreateSkill(skill); err != nil {
+				t.Fatalf("Failed to create skill: %v", err)
+			}
+		}
+	}
+
+	// testuser endorsed otheruser's go skill and has a proficiency history of their own
+	skillService := service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo)
+	if _, err := skillService.EndorseSkill("testuser", "otheruser", "go"); err != nil {
+		t.Fatalf("Failed to endorse skill: %v", err)
+	}
+	if err := mockRepo.CreateSkillHistory(models.NewSkillHistory("testuser", "go", models.ProficiencyBeginner, models.ProficiencyIntermediate)); err != nil {
+		t.Fatalf("Failed to create skill history: %v", err)
+	}
+
+	tokenService := auth.NewTokenService(testConfig())
+	purge := service.NewPurgeService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo)
+	h := New(service.NewUserService(mockRepo, tokenService, service.WithPurgeService(purge)), skillService)
+
+	request := events.APIGatewayProxyRequest{
+		RequestContext: events.APIGatewayProxyRequestContext{
+			Authorizer: map[string]interface{}{
+				"claims": &auth.JWTClaims{Username: "testuser"},
+			},
+		},
+	}
+
+	response, err := h.DeleteCurrentUser(request)
+	if err != nil {
+		t.Fatalf("Handler returned unexpected error: %v", err)
+	}
+	if response.StatusCode != 204 {
+		t.Fatalf("Expected status 204, got %d: %s", response.StatusCode, response.Body)
+	}
+
+	if exists, _ := mockRepo.UserExists("testuser"); exists {
+		t.Error("Expected user to be deleted")
+	}
+	if skills, _ := mockRepo.ListSkillsForUser("testuser"); len(skills) != 0 {
+		t.Errorf("Expected user's skills to be deleted, got %d", len(skills))
+	}
+	if skills, _ := mockRepo.ListSkillsForUser("otheruser"); len(skills) != 2 {
+		t.Errorf("Expected other user's skills to remain, got %d", len(skills))
+	}
+	if given, _ := mockRepo.ListEndorsementsByEndorser("testuser"); len(given) != 0 {
+		t.Errorf("Expected endorsements given to be withdrawn, got %d", len(given))
+	}
+	if skill, _ := mockRepo.GetSkill("otheruser", "go"); skill.Endorsements != 0 {
+		t.Errorf("Expected the endorsed skill to be decremented, got %d", skill.Endorsements)
+	}
+	if history, _ := mockRepo.ListSkillHistoryForUser("testuser"); len(history) != 0 {
+		t.Errorf("Expected skill history to be deleted, got %d entries", len(history))
+	}
+
+	// Deleting again reports the account as missing
+	response, _ = h.DeleteCurrentUser(request)
+	if response.StatusCode != 404 {
+		t.Errorf("Expected status 404 for a deleted user, got %d", response.StatusCode)
+	}
+
+	response, _ = h.DeleteCurrentUser(events.APIGatewayProxyRequest{})
+	if response.StatusCode != 401 {
+		t.Errorf("Expected status 401 without claims, got %d", response.StatusCode)
+	}
+}
+
+func TestHandler_EmptyListsMarshalAsArrays(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	for _, username := range []string{"testuser", "newuser"} {
+		user, _ := models.NewUser(username, "Test User", "password123")
+		if err := mockRepo.CreateUser(user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+	golang, _ := models.NewSkill("go", "Go", "Go language", "Programming", nil)
+	if err := mockRepo.CreateMasterSkill(golang); err != nil {
+		t.Fatalf("Failed to create master skill: %v", err)
+	}
+	userSkill, _ := models.NewUserSkill("testuser", "go", "Go", "Programming", models.ProficiencyBeginner, 1)
+	if err := mockRepo.CreateSkill(userSkill); err != nil {
+		t.Fatalf("Failed to create skill: %v", err)
+	}
+
+	tokenService := auth.NewTokenService(testConfig())
+	h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+	msh, _ := newTestMasterSkillHandler(t)
+	emptyRepo := database.NewMockRepository()
+	empty := New(service.NewUserService(emptyRepo, tokenService), service.NewSkillService(emptyRepo, emptyRepo, emptyRepo, emptyRepo, emptyRepo, emptyRepo))
+	authorizer := map[string]interface{}{"claims": &auth.JWTClaims{Username: "newuser"}}
+
+	tests := []struct {
+		name    string
+		handle  func(events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)
+		request events.APIGatewayProxyRequest
+	}{
+		{name: "ListSkillsForUser", handle: h.ListSkillsForUser, request: events.APIGatewayProxyRequest{PathParameters: map[string]string{"username": "newuser"}}},
+		{name: "ListMySkills", handle: h.ListMySkills, request: events.APIGatewayProxyRequest{RequestContext: events.APIGatewayProxyRequestContext{Authorizer: authorizer}}},
+		{name: "ListUsersBySkill", handle: h.ListUsersBySkill, request: events.APIGatewayProxyRequest{
+			PathParameters:        map[string]string{"skillName": "Rust"},
+			QueryStringParameters: map[string]string{"category": "Programming"},
+		}},
+		{name: "GetRecentSkills", handle: empty.GetRecentSkills},
+		{name: "ListUsers", handle: empty.ListUsers},
+		{name: "ListEndorsements", handle: h.ListEndorsements, request: events.APIGatewayProxyRequest{PathParameters: map[string]string{"username": "testuser", "skillName": "go"}}},
+		{name: "GetRelatedSkills", handle: h.GetRelatedSkills, request: events.APIGatewayProxyRequest{
+			PathParameters:        map[string]string{"skillName": "Go"},
+			QueryStringParameters: map[string]string{"category": "Programming"},
+		}},
+		{name: "GetSkillHistory", handle: h.GetSkillHistory, request: events.APIGatewayProxyRequest{PathParameters: map[string]string{"username": "testuser", "skillName": "go"}}},
+		{name: "ListMasterSkills", handle: msh.ListMasterSkills},
+		{name: "ListFeaturedMasterSkills", handle: msh.ListFeaturedMasterSkills},
+		{name: "SearchMasterSkills", handle: msh.SearchMasterSkills, request: events.APIGatewayProxyRequest{QueryStringParameters: map[string]string{"q": "rust"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := tt.handle(tt.request)
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+			if response.StatusCode != 200 {
+				t.Fatalf("Expected status 200, got %d: %s", response.StatusCode, response.Body)
+			}
+			if response.Body != "[]" {
+				t.Errorf("Expected body [], got %s", response.Body)
+			}
+		})
+	}
+}
+
+func TestHandler_MySkills(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	user, _ := models.NewUser("testuser", "Test User", "password123")
+	if err := mockRepo.CreateUser(user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	golang, _ := models.NewSkill("go", "Go", "Go language", "Programming", nil)
+	if err := mockRepo.CreateMasterSkill(golang); err != nil {
+		t.Fatalf("Failed to create master skill: %v", err)
+	}
+
+	tokenService := auth.NewTokenService(testConfig())
+	h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+	authorizer := map[string]interface{}{"claims": &auth.JWTClaims{Username: "testuser"}}
+
+	response, _ := h.AddMySkill(events.APIGatewayProxyRequest{
+		Body:           `{"skill_name":"go","proficiency_level":"Advanced","years_of_experience":4}`,
+		RequestContext: events.APIGatewayProxyRequestContext{Authorizer: authorizer},
+	})
+	if response.StatusCode != 201 {
+		t.Fatalf("Expected status 201, got %d: %s", response.StatusCode, response.Body)
+	}
+
+	response, _ = h.ListMySkills(events.APIGatewayProxyRequest{
+		RequestContext: events.APIGatewayProxyRequestContext{Authorizer: authorizer},
+	})
+	var skills []dto.SkillResponse
+	if err := json.Unmarshal([]byte(response.Body), &skills); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(skills) != 1 {
+		t.Errorf("Expected 1 skill, got %d", len(skills))
+	}
+
+	response, _ = h.DeleteMySkill(events.APIGatewayProxyRequest{
+		PathParameters: map[string]string{"skillName": "go"},
+		RequestContext: events.APIGatewayProxyRequestContext{Authorizer: authorizer},
+	})
+	if response.StatusCode != 200 {
+		t.Errorf("Expected status 200, got %d: %s", response.StatusCode, response.Body)
+	}
+	if stored, _ := mockRepo.ListSkillsForUser("testuser"); len(stored) != 0 {
+		t.Errorf("Expected skill to be deleted, got %d skills", len(stored))
+	}
+
+	// Missing claims are rejected like GetCurrentUser
+	for name, handle := range map[string]func(events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error){
+		"ListMySkills":  h.ListMySkills,
+		"AddMySkill":    h.AddMySkill,
+		"DeleteMySkill": h.DeleteMySkill,
+	} {
+		response, _ := handle(events.APIGatewayProxyRequest{})
+		if response.StatusCode != 401 {
+			t.Errorf("%s: expected status 401 without claims, got %d", name, response.StatusCode)
+		}
+	}
+}
+
+func TestHandler_ListUsers_Search(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	users := []struct{ username, name string }{
+		{"alice", "Alice Smith"},
+		{"bob", "Bob Malinowski"},
+		{"carol", "Carol Alison"},
+		{"dave", "Dave Jones"},
+	}
+	for _, u := range users {
+		user, _ := models.NewUser(u.username, u.name, "password123")
+		if u.username == "dave" {
+			user.Archive()
+		}
+		if err := mockRepo.CreateUser(user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+
+	tokenService := auth.NewTokenService(testConfig())
+	h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+	tests := []struct {
+		name              string
+		query             map[string]string
+		expectedStatus    int
+		expectedUsernames []string
+	}{
+		{name: "matches username and name ignoring case", query: map[string]string{"q": "ALI"}, expectedStatus: 200, expectedUsernames: []string{"alice", "bob", "carol"}},
+		{name: "matches name only", query: map[string]string{"q": "smith"}, expectedStatus: 200, expectedUsernames: []string{"alice"}},
+		{name: "archived users are left out", query: map[string]string{"q": "dave"}, expectedStatus: 200, expectedUsernames: []string{}},
+		{name: "no match", query: map[string]string{"q": "zed"}, expectedStatus: 200, expectedUsernames: []string{}},
+		{name: "limit", query: map[string]string{"q": "ali", "limit": "2"}, expectedStatus: 200, expectedUsernames: []string{"alice", "bob"}},
+		{name: "without q lists everyone active", query: nil, expectedStatus: 200, expectedUsernames: []string{"alice", "bob", "carol"}},
+		{name: "empty q", query: map[string]string{"q": ""}, expectedStatus: 400},
+		{name: "invalid limit", query: map[string]string{"q": "ali", "limit": "0"}, expectedStatus: 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := h.ListUsers(events.APIGatewayProxyRequest{QueryStringParameters: tt.query})
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+			if response.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, response.StatusCode, response.Body)
+			}
+			if tt.expectedStatus != 200 {
+				return
+			}
+
+			if strings.Contains(strings.ToLower(response.Body), "password") {
+				t.Errorf("Expected no password fields in the response, got %s", response.Body)
+			}
+
+			var result []dto.UserListResponse
+			if err := json.Unmarshal([]byte(response.Body), &result); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			usernames := make([]string, 0, len(result))
+			for _, user := range result {
+				usernames = append(usernames, user.Username)
+			}
+			// The unfiltered list is not ordered
+			if tt.query == nil {
+				sort.Strings(usernames)
+			}
+			if strings.Join(usernames, ",") != strings.Join(tt.expectedUsernames, ",") {
+				t.Errorf("Expected users %v, got %v", tt.expectedUsernames, usernames)
+			}
+		})
+	}
+}
+
+func TestHandler_ListUsersBySkill_Categories(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	alice, _ := models.NewUserSkill("alice", "go", "Go", "Programming", models.ProficiencyExpert, 5)
+	bob, _ := models.NewUserSkill("bob", "go-backend", "Go", "Backend", models.ProficiencyBeginner, 1)
+	for _, skill := range []*models.UserSkill{alice, bob} {
+		if err := mockRepo.CreateSkill(skill); err != nil {
+			t.Fatalf("Failed to create skill: %v", err)
+		}
+	}
+
+	tokenService := auth.NewTokenService(testConfig())
+	h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+	tests := []struct {
+		name           string
+		query          map[string]string
+		expectedStatus int
+		expectedCount  int
+		expectedError  string
+	}{
+		{
+			name:           "missing category suggests valid categories",
+			query:          nil,
+			expectedStatus: 400,
+			expectedError:  apperrors.ErrCategoryRequired.Error(),
+		},
+		{
+			name:           "unknown category",
+			query:          map[string]string{"category": "Cooking"},
+			expectedStatus: 400,
+			expectedError:  apperrors.ErrInvalidCategory.Error(),
+		},
+		{
+			name:           "single category",
+			query:          map[string]string{"category": "Programming"},
+			expectedStatus: 200,
+			expectedCount:  1,
+		},
+		{
+			name:           "fan-out merges all categories",
+			query:          map[string]string{"allCategories": "true"},
+			expectedStatus: 200,
+			expectedCount:  2,
+		},
+		{
+			name:           "fan-out with level filter",
+			query:          map[string]string{"allCategories": "true", "level": "Expert"},
+			expectedStatus: 200,
+			expectedCount:  1,
+		},
+		{
+			name:           "fan-out flag accepts other boolean spellings",
+			query:          map[string]string{"allCategories": "1"},
+			expectedStatus: 200,
+			expectedCount:  2,
+		},
+		{
+			name:           "invalid fan-out flag",
+			query:          map[string]string{"allCategories": "yes"},
+			expectedStatus: 400,
+			expectedError:  "allCategories must be true or false",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := h.ListUsersBySkill(events.APIGatewayProxyRequest{
+				PathParameters:        map[string]string{"skillName": "Go"},
+				QueryStringParameters: tt.query,
+			})
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+
+			if response.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, response.StatusCode, response.Body)
+			}
+
+			if tt.expectedStatus != 200 {
+				var errResp dto.ErrorResponse
+				if err := json.Unmarshal([]byte(response.Body), &errResp); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if errResp.Error != tt.expectedError {
+					t.Errorf("Expected error %q, got %q", tt.expectedError, errResp.Error)
+				}
+				return
+			}
+
+			var users []dto.UserSkillResponse
+			if err := json.Unmarshal([]byte(response.Body), &users); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if len(users) != tt.expectedCount {
+				t.Errorf("Expected %d users, got %d", tt.expectedCount, len(users))
+			}
+		})
+	}
+}
+
+// smallPageSkillRepo is a mock repository whose unbounded users-by-skill queries stop after one
+// item, like DynamoDB stops a query page at its size cap
+type smallPageSkillRepo struct {
+	*database.MockRepository
+}
+
+func (r smallPageSkillRepo) ListUsersBySkill(category, skillName string, limit int, nextToken string) ([]*models.UserSkill, string, error) {
+	if limit == 0 {
+		limit = 1
+	}
+	return r.MockRepository.ListUsersBySkill(category, skillName, limit, nextToken)
+}
+
+func TestHandler_ListUsersBySkill_Pagination(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	for _, username := range []string{"carol", "alice", "bob"} {
+		skill, _ := models.NewUserSkill(username, "go", "Go", "Programming", models.ProficiencyIntermediate, 2)
+		if err := mockRepo.CreateSkill(skill); err != nil {
+			t.Fatalf("Failed to create skill: %v", err)
+		}
+	}
+
+	tokenService := auth.NewTokenService(testConfig())
+	h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+	listPage := func(query map[string]string) (int, dto.UserSkillPageResponse) {
+		t.Helper()
+		response, err := h.ListUsersBySkill(events.APIGatewayProxyRequest{
+			PathParameters:        map[string]string{"skillName": "Go"},
+			QueryStringParameters: query,
+		})
+		if err != nil {
+			t.Fatalf("Handler returned unexpected error: %v", err)
+		}
+		var page dto.UserSkillPageResponse
+		if response.StatusCode == 200 {
+			if err := json.Unmarshal([]byte(response.Body), &page); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+		}
+		return response.StatusCode, page
+	}
+
+	status, first := listPage(map[string]string{"category": "Programming", "limit": "2"})
+	if status != 200 {
+		t.Fatalf("Expected status 200, got %d", status)
+	}
+	if len(first.Users) != 2 || first.Users[0].Username != "alice" || first.Users[1].Username != "bob" {
+		t.Errorf("Expected first page [alice bob], got %+v", first.Users)
+	}
+	if first.Next == "" {
+		t.Fatal("Expected a next token on the first page")
+	}
+
+	status, second := listPage(map[string]string{"category": "Programming", "limit": "2", "next": first.Next})
+	if status != 200 {
+		t.Fatalf("Expected status 200, got %d", status)
+	}
+	if len(second.Users) != 1 || second.Users[0].Username != "carol" {
+		t.Errorf("Expected second page [carol], got %+v", second.Users)
+	}
+	if second.Next != "" {
+		t.Errorf("Expected no next token on the last page, got %q", second.Next)
+	}
+
+	for name, query := range map[string]map[string]string{
+		"invalid limit":  {"category": "Programming", "limit": "0"},
+		"limit too high": {"category": "Programming", "limit": "1000"},
+		"invalid token":  {"category": "Programming", "next": "%%%"},
+	} {
+		if status, _ := listPage(query); status != 400 {
+			t.Errorf("%s: expected status 400, got %d", name, status)
+		}
+	}
+
+	// Without limit or next every repository page is read into the plain array
+	h = New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(smallPageSkillRepo{mockRepo}, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+	response, _ := h.ListUsersBySkill(events.APIGatewayProxyRequest{
+		PathParameters:        map[string]string{"skillName": "Go"},
+		QueryStringParameters: map[string]string{"category": "Programming"},
+	})
+	var all []dto.UserSkillResponse
+	if err := json.Unmarshal([]byte(response.Body), &all); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(all) != 3 {
+		t.Errorf("Expected all 3 users across repository pages, got %d", len(all))
+	}
+}
+
+func TestHandler_ListSkillsForUsers(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	for _, username := range []string{"alice", "bob", "carol", "dave"} {
+		user, _ := models.NewUser(username, username, "password123")
+		if username == "dave" {
+			user.Archive()
+		}
+		if err := mockRepo.CreateUser(user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+	for _, s := range []struct{ username, id, name string }{
+		{"alice", "go", "Go"},
+		{"alice", "python", "Python"},
+		{"bob", "rust", "Rust"},
+		{"dave", "go", "Go"},
+	} {
+		skill, _ := models.NewUserSkill(s.username, s.id, s.name, "Programming", models.ProficiencyIntermediate, 2)
+		if err := mockRepo.CreateSkill(skill); err != nil {
+			t.Fatalf("Failed to create skill: %v", err)
+		}
+	}
+
+	tokenService := auth.NewTokenService(testConfig())
+	h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+	tooMany := make([]string, 51)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("user%d", i)
+	}
+	tooManyBody, _ := json.Marshal(dto.BulkSkillsRequest{Usernames: tooMany})
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedCode   string
+		expectedSkills map[string]int // skills per username in the response
+	}{
+		{
+			name:           "known, archived and unknown users",
+			body:           `{"usernames":["alice","bob","carol","dave","ghost","alice",""]}`,
+			expectedStatus: 200,
+			expectedSkills: map[string]int{"alice": 2, "bob": 1, "carol": 0, "dave": 0, "ghost": 0},
+		},
+		{name: "no usernames", body: `{"usernames":[]}`, expectedStatus: 400, expectedCode: dto.CodeRequiredField},
+		{name: "over the cap", body: string(tooManyBody), expectedStatus: 400, expectedCode: dto.CodeBulkSkillsLimitExceeded},
+		{name: "unknown field", body: `{"users":["alice"]}`, expectedStatus: 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := h.ListSkillsForUsers(events.APIGatewayProxyRequest{Body: tt.body})
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+			if response.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, response.StatusCode, response.Body)
+			}
+
+			if tt.expectedCode != "" {
+				var errResp dto.ErrorResponse
+				if err := json.Unmarshal([]byte(response.Body), &errResp); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if errResp.Code != tt.expectedCode {
+					t.Errorf("Expected code %s, got %s", tt.expectedCode, errResp.Code)
+				}
+			}
+			if tt.expectedSkills == nil {
+				return
+			}
+
+			// Unknown and archived users are present with an empty array, not null
+			var raw map[string]json.RawMessage
+			if err := json.Unmarshal([]byte(response.Body), &raw); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if len(raw) != len(tt.expectedSkills) {
+				t.Errorf("Expected %d users, got %d: %s", len(tt.expectedSkills), len(raw), response.Body)
+			}
+			for username, count := range tt.expectedSkills {
+				var skills []dto.SkillResponse
+				if string(raw[username]) == "null" || json.Unmarshal(raw[username], &skills) != nil {
+					t.Errorf("Expected a skills array for %s, got %s", username, raw[username])
+					continue
+				}
+				if len(skills) != count {
+					t.Errorf("Expected %d skills for %s, got %d", count, username, len(skills))
+				}
+			}
+		})
+	}
+}
+
+func TestHandler_ExportTeamMatrix(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	for _, username := range []string{"alice", "bob", "carol"} {
+		user, _ := models.NewUser(username, username, "password123")
+		if err := mockRepo.CreateUser(user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+	skills := []struct {
+		username string
+		id       string
+		name     string
+		category string
+		level    models.ProficiencyLevel
+	}{
+		{"alice", "go", "Go", "Programming", models.ProficiencyExpert},
+		{"alice", "python", "Python", "Programming", models.ProficiencyBeginner},
+		{"bob", "rust", "Rust", "Programming", models.ProficiencyIntermediate},
+		{"bob", "aws", "AWS", "Cloud", models.ProficiencyAdvanced},
+	}
+	for _, s := range skills {
+		skill, _ := models.NewUserSkill(s.username, s.id, s.name, s.category, s.level, 5)
+		if err := mockRepo.CreateSkill(skill); err != nil {
+			t.Fatalf("Failed to create skill: %v", err)
+		}
+	}
+
+	tokenService := auth.NewTokenService(testConfig())
+	h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+	response, err := h.ExportTeamMatrix(events.APIGatewayProxyRequest{
+		Body: `{"usernames":["bob","alice","carol","bob"],"category":"Programming"}`,
+	})
+	if err != nil {
+		t.Fatalf("Handler returned unexpected error: %v", err)
+	}
+	if response.StatusCode != 200 {
+		t.Fatalf("Expected status 200, got %d: %s", response.StatusCode, response.Body)
+	}
+	if contentType := response.Headers["Content-Type"]; contentType != "text/csv" {
+		t.Errorf("Expected Content-Type text/csv, got %q", contentType)
+	}
+
+	expected := "username,Go,Python,Rust\n" +
+		"bob,,,Intermediate\n" +
+		"alice,Expert,Beginner,\n" +
+		"carol,,,\n"
+	if response.Body != expected {
+		t.Errorf("Expected CSV:\n%s\ngot:\n%s", expected, response.Body)
+	}
+
+	invalid := map[string]string{
+		"missing category": `{"usernames":["alice"]}`,
+		"no users":         `{"usernames":[],"category":"Programming"}`,
+		"invalid category": `{"usernames":["alice"],"category":"Cooking"}`,
+	}
+	for name, body := range invalid {
+		response, _ := h.ExportTeamMatrix(events.APIGatewayProxyRequest{Body: body})
+		if response.StatusCode != 400 {
+			t.Errorf("%s: expected status 400, got %d", name, response.StatusCode)
+		}
+	}
+
+	response, _ = h.ExportTeamMatrix(events.APIGatewayProxyRequest{Body: `{"usernames":["nobody"],"category":"Programming"}`})
+	if response.StatusCode != 404 {
+		t.Errorf("Expected status 404 for unknown user, got %d", response.StatusCode)
+	}
+}
+
+func TestHandler_Login_TokenType(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured string
+		expected   string
+	}{
+		{name: "default", configured: "", expected: "Bearer"},
+		{name: "lowercase bearer", configured: "bearer", expected: "bearer"},
+		{name: "custom scheme", configured: "JWT", expected: "JWT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := database.NewMockRepository()
+			user, _ := models.NewUser("testuser", "Test User", "password123")
+			if err := mockRepo.CreateUser(user); err != nil {
+				t.Fatalf("Failed to create user: %v", err)
+			}
+
+			cfg := testConfig()
+			cfg.JWT.TokenType = tt.configured
+			h := New(service.NewUserService(mockRepo, auth.NewTokenService(cfg)), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+			response, err := h.Login(events.APIGatewayProxyRequest{Body: `{"username":"testuser","password":"password123"}`})
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+			if response.StatusCode != 200 {
+				t.Fatalf("Expected status 200, got %d: %s", response.StatusCode, response.Body)
+			}
+
+			var result dto.TokenResponse
+			if err := json.Unmarshal([]byte(response.Body), &result); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if result.TokenType != tt.expected {
+				t.Errorf("Expected token type %q, got %q", tt.expected, result.TokenType)
+			}
+		})
+	}
+}
+
+func TestHandler_TouchSkill(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	skill, _ := models.NewUserSkill("alice", "go", "Go", "Programming", models.ProficiencyIntermediate, 3)
+	skill.LastUsedDate = "2020-01-01"
+	if err := mockRepo.CreateSkill(skill); err != nil {
+		t.Fatalf("Failed to create skill: %v", err)
+	}
+
+	tokenService := auth.NewTokenService(testConfig())
+	h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+	touch := func(caller, username, skillName string) events.APIGatewayProxyResponse {
+		response, err := h.TouchSkill(events.APIGatewayProxyRequest{
+			PathParameters: map[string]string{"username": username, "skillName": skillName},
+			RequestContext: events.APIGatewayProxyRequestContext{
+				Authorizer: map[string]interface{}{
+					"claims": &auth.JWTClaims{Username: caller},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatalf("Handler returned unexpected error: %v", err)
+		}
+		return response
+	}
+
+	if response := touch("bob", "alice", "go"); response.StatusCode != 403 {
+		t.Errorf("Expected status 403 for another user's skill, got %d", response.StatusCode)
+	}
+	if response := touch("alice", "alice", "rust"); response.StatusCode != 404 {
+		t.Errorf("Expected status 404 for missing skill, got %d", response.StatusCode)
+	}
+
+	response := touch("alice", "alice", "go")
+	if response.StatusCode != 200 {
+		t.Fatalf("Expected status 200, got %d: %s", response.StatusCode, response.Body)
+	}
+
+	var result dto.SkillResponse
+	if err := json.Unmarshal([]byte(response.Body), &result); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if result.LastUsedDate != dateutil.Today() {
+		t.Errorf("Expected last used date %s, got %s", dateutil.Today(), result.LastUsedDate)
+	}
+
+	stored, _ := mockRepo.GetSkill("alice", "go")
+	if stored.LastUsedDate != dateutil.Today() {
+		t.Errorf("Expected stored last used date %s, got %s", dateutil.Today(), stored.LastUsedDate)
+	}
+}
+
+func TestHandler_Refresh(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	user, _ := models.NewUser("testuser", "Test User", "password123")
+	if err := mockRepo.CreateUser(user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	tokenService := auth.NewTokenService(testConfig())
+	h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+	response, _ := h.Login(events.APIGatewayProxyRequest{Body: `{"username":"testuser","password":"password123"}`})
+	var login dto.TokenResponse
+	if err := json.Unmarshal([]byte(response.Body), &login); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if login.RefreshToken == "" {
+		t.Fatal("Expected login to return a refresh token")
+	}
+
+	refresh := func(token string) events.APIGatewayProxyResponse {
+		response, err := h.Refresh(events.APIGatewayProxyRequest{Body: fmt.Sprintf(`{"refresh_token":%q}`, token)})
+		if err != nil {
+			t.Fatalf("Handler returned unexpected error: %v", err)
+		}
+		return response
+	}
+
+	response = refresh(login.RefreshToken)
+	if response.StatusCode != 200 {
+		t.Fatalf("Expected status 200, got %d: %s", response.StatusCode, response.Body)
+	}
+	var refreshed dto.TokenResponse
+	if err := json.Unmarshal([]byte(response.Body), &refreshed); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	claims, err := tokenService.ValidateToken(refreshed.AccessToken)
+	if err != nil {
+		t.Fatalf("Expected a valid access token: %v", err)
+	}
+	if claims.Username != "testuser" || claims.IsRefresh() {
+		t.Errorf("Expected an access token for testuser, got %+v", claims)
+	}
+
+	tests := map[string]string{
+		"access token":  login.AccessToken,
+		"invalid token": "invalid.token.here",
+	}
+	for name, token := range tests {
+		if response := refresh(token); response.StatusCode != 401 {
+			t.Errorf("%s: expected status 401, got %d", name, response.StatusCode)
+		}
+	}
+	if response := refresh(""); response.StatusCode != 400 {
+		t.Errorf("Expected status 400 for missing token, got %d", response.StatusCode)
+	}
+}
+
+func TestHandler_GetSkillLevelStats(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	holders := []struct {
+		username string
+		category string
+		level    models.ProficiencyLevel
+	}{
+		{"alice", "Cloud", models.ProficiencyBeginner},
+		{"bob", "Cloud", models.ProficiencyBeginner},
+		{"carol", "Cloud", models.ProficiencyIntermediate},
+		{"dave", "Cloud", models.ProficiencyExpert},
+		{"erin", "DevOps", models.ProficiencyIntermediate},
+	}
+	for _, holder := range holders {
+		skill, _ := models.NewUserSkill(holder.username, "aws-"+holder.category, "AWS", holder.category, holder.level, 5)
+		if err := mockRepo.CreateSkill(skill); err != nil {
+			t.Fatalf("Failed to create skill: %v", err)
+		}
+	}
+
+	tokenService := auth.NewTokenService(testConfig())
+	h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+	tests := []struct {
+		name           string
+		skillName      string
+		query          map[string]string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "category histogram",
+			skillName:      "AWS",
+			query:          map[string]string{"category": "Cloud"},
+			expectedStatus: 200,
+			expectedBody:   `{"Advanced":0,"Beginner":2,"Expert":1,"Intermediate":1}`,
+		},
+		{
+			name:           "skill nobody holds has all zeros",
+			skillName:      "Azure",
+			query:          map[string]string{"category": "Cloud"},
+			expectedStatus: 200,
+			expectedBody:   `{"Advanced":0,"Beginner":0,"Expert":0,"Intermediate":0}`,
+		},
+		{
+			name:           "all categories",
+			skillName:      "AWS",
+			query:          map[string]string{"allCategories": "true"},
+			expectedStatus: 200,
+			expectedBody:   `{"Advanced":0,"Beginner":2,"Expert":1,"Intermediate":2}`,
+		},
+		{name: "missing category", skillName: "AWS", expectedStatus: 400},
+		{name: "invalid category", skillName: "AWS", query: map[string]string{"category": "Cooking"}, expectedStatus: 400},
+		{name: "invalid fan-out flag", skillName: "AWS", query: map[string]string{"allCategories": "yes"}, expectedStatus: 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := h.GetSkillLevelStats(events.APIGatewayProxyRequest{
+				PathParameters:        map[string]string{"skillName": tt.skillName},
+				QueryStringParameters: tt.query,
+			})
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+			if response.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, response.StatusCode, response.Body)
+			}
+			if tt.expectedBody != "" && response.Body != tt.expectedBody {
+				t.Errorf("Expected body %s, got %s", tt.expectedBody, response.Body)
+			}
+		})
+	}
+}
+
+func TestHandler_GetRelatedSkills(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	held := map[string][]string{
+		"alice": {"go", "docker", "kubernetes"},
+		"bob":   {"go", "docker"},
+		"carol": {"go", "terraform"},
+		"dave":  {"docker", "kubernetes"}, // no Go, so not counted
+	}
+	names := map[string]string{"go": "Go", "docker": "Docker", "kubernetes": "Kubernetes", "terraform": "Terraform"}
+	for username, skillIDs := range held {
+		for _, skillID := range skillIDs {
+			skill, _ := models.NewUserSkill(username, skillID, names[skillID], "Programming", models.ProficiencyBeginner, 1)
+			if err := mockRepo.CreateSkill(skill); err != nil {
+				t.Fatalf("Failed to create skill: %v", err)
+			}
+		}
+	}
+
+	tokenService := auth.NewTokenService(testConfig())
+	h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+	tests := []struct {
+		name           string
+		query          map[string]string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "ranked by shared holders",
+			query:          map[string]string{"category": "Programming"},
+			expectedStatus: 200,
+			expectedBody:   `[{"skill_name":"Docker","category":"Programming","users":2},{"skill_name":"Kubernetes","category":"Programming","users":1},{"skill_name":"Terraform","category":"Programming","users":1}]`,
+		},
+		{
+			name:           "limit applied",
+			query:          map[string]string{"category": "Programming", "limit": "1"},
+			expectedStatus: 200,
+			expectedBody:   `[{"skill_name":"Docker","category":"Programming","users":2}]`,
+		},
+		{name: "missing category", expectedStatus: 400},
+		{name: "invalid limit", query: map[string]string{"category": "Programming", "limit": "0"}, expectedStatus: 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := h.GetRelatedSkills(events.APIGatewayProxyRequest{
+				PathParameters:        map[string]string{"skillName": "Go"},
+				QueryStringParameters: tt.query,
+			})
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+			if response.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, response.StatusCode, response.Body)
+			}
+			if tt.expectedBody != "" && response.Body != tt.expectedBody {
+				t.Errorf("Expected body %s, got %s", tt.expectedBody, response.Body)
+			}
+		})
+	}
+}
+
+func TestHandler_ListUsersBySkill_CSV(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	skillName := `Go "modern", 2e`
+	for _, holder := range []struct {
+		username string
+		level    models.ProficiencyLevel
+		years    int
+	}{
+		{"alice", models.ProficiencyExpert, 7},
+		{"bob", models.ProficiencyBeginner, 1},
+	} {
+		skill, _ := models.NewUserSkill(holder.username, "go", skillName, "Programming", holder.level, holder.years)
+		if err := mockRepo.CreateSkill(skill); err != nil {
+			t.Fatalf("Failed to create skill: %v", err)
+		}
+	}
+
+	tokenService := auth.NewTokenService(testConfig())
+	h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+	tests := []struct {
+		name         string
+		query        map[string]string
+		expectedType string
+		expectedBody string
+	}{
+		{
+			name:         "csv with escaped fields",
+			query:        map[string]string{"category": "Programming", "format": "csv"},
+			expectedType: "text/csv",
+			expectedBody: "username,skill_name,proficiency_level,years_of_experience,endorsements\n" +
+				"alice,\"Go \"\"modern\"\", 2e\",Expert,7,0\n" +
+				"bob,\"Go \"\"modern\"\", 2e\",Beginner,1,0\n",
+		},
+		{
+			name:         "csv filtered by level",
+			query:        map[string]string{"category": "Programming", "level": "Beginner", "format": "csv"},
+			expectedType: "text/csv",
+			expectedBody: "username,skill_name,proficiency_level,years_of_experience,endorsements\n" +
+				"bob,\"Go \"\"modern\"\", 2e\",Beginner,1,0\n",
+		},
+		{name: "json by default", query: map[string]string{"category": "Programming"}, expectedType: "application/json"},
+		{name: "unknown format falls back to json", query: map[string]string{"category": "Programming", "format": "xml"}, expectedType: "application/json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := h.ListUsersBySkill(events.APIGatewayProxyRequest{
+				PathParameters:        map[string]string{"skillName": skillName},
+				QueryStringParameters: tt.query,
+			})
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+			if response.StatusCode != 200 {
+				t.Fatalf("Expected status 200, got %d: %s", response.StatusCode, response.Body)
+			}
+			if contentType := response.Headers["Content-Type"]; contentType != tt.expectedType {
+				t.Errorf("Expected Content-Type %s, got %s", tt.expectedType, contentType)
+			}
+			if tt.expectedBody != "" && response.Body != tt.expectedBody {
+				t.Errorf("Expected CSV:\n%s\ngot:\n%s", tt.expectedBody, response.Body)
+			}
+		})
+	}
+}
+
+func TestHandler_GetRecentSkills(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	archived, _ := models.NewUser("gone", "Gone User", "password123")
+	archived.Archive()
+	if err := mockRepo.CreateUser(archived); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	base := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	for i, entry := range []struct {
+		username string
+		skillID  string
+	}{
+		{"alice", "go"},
+		{"gone", "rust"},
+		{"bob", "python"},
+		{"carol", "java"},
+	} {
+		skill, _ := models.NewUserSkill(entry.username, entry.skillID, strings.ToUpper(entry.skillID), "Programming", models.ProficiencyBeginner, 1)
+		skill.UpdatedAt = base.Add(time.Duration(i) * time.Hour)
+		if err := mockRepo.CreateSkill(skill); err != nil {
+			t.Fatalf("Failed to create skill: %v", err)
+		}
+	}
+
+	tokenService := auth.NewTokenService(testConfig())
+	h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+	tests := []struct {
+		name           string
+		query          map[string]string
+		expectedStatus int
+		expectedUsers  []string
+	}{
+		{name: "newest first without archived users", expectedStatus: 200, expectedUsers: []string{"carol", "bob", "alice"}},
+		{name: "limit applied before archived users are dropped", query: map[string]string{"limit": "3"}, expectedStatus: 200, expectedUsers: []string{"carol", "bob"}},
+		{name: "invalid limit", query: map[string]string{"limit": "-1"}, expectedStatus: 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := h.GetRecentSkills(events.APIGatewayProxyRequest{QueryStringParameters: tt.query})
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+			if response.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, response.StatusCode, response.Body)
+			}
+			if tt.expectedStatus != 200 {
+				return
+			}
+
+			var feed []dto.RecentSkillResponse
+			if err := json.Unmarshal([]byte(response.Body), &feed); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			var users []string
+			for _, entry := range feed {
+				users = append(users, entry.Username)
+			}
+			if strings.Join(users, ",") != strings.Join(tt.expectedUsers, ",") {
+				t.Errorf("Expected users %v, got %v", tt.expectedUsers, users)
+			}
+			if feed[0].UpdatedAt != "2025-06-01T15:00:00Z" {
+				t.Errorf("Expected newest updated_at 2025-06-01T15:00:00Z, got %s", feed[0].UpdatedAt)
+			}
+		})
+	}
+}
+
+func TestHandler_ValidationErrorsListFields(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	tokenService := auth.NewTokenService(testConfig())
+	h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+	tests := []struct {
+		name           string
+		call           func(events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)
+		request        events.APIGatewayProxyRequest
+		expectedFields map[string]string
+	}{
+		{
+			name:    "register reports every invalid field",
+			call:    h.Register,
+			request: events.APIGatewayProxyRequest{Body: `{"username":"ab","name":"A","password":"123"}`},
+			expectedFields: map[string]string{
+				"username": "username must be between 3 and 50 characters",
+				"name":     "name must be between 2 and 100 characters",
+				"password": "password must be at least 6 characters",
+			},
+		},
+		{
+			name:    "register reports only the invalid field",
+			call:    h.Register,
+			request: events.APIGatewayProxyRequest{Body: `{"username":"validuser","name":"Valid Name","password":"123"}`},
+			expectedFields: map[string]string{
+				"password": "password must be at least 6 characters",
+			},
+		},
+		{
+			name:    "login reports missing fields",
+			call:    h.Login,
+			request: events.APIGatewayProxyRequest{Body: `{}`},
+			expectedFields: map[string]string{
+				"username": "required",
+				"password": "required",
+			},
+		},
+		{
+			name: "update reports every invalid field",
+			call: h.UpdateUser,
+			request: events.APIGatewayProxyRequest{
+				RequestContext: events.APIGatewayProxyRequestContext{
+					Authorizer: map[string]interface{}{"claims": &auth.JWTClaims{Username: "testuser"}},
+				},
+				Body: `{"name":"A","password":"123","email":"not-an-email"}`,
+			},
+			expectedFields: map[string]string{
+				"name":     "name must be between 2 and 100 characters",
+				"password": "password must be at least 6 characters",
+				"email":    "email must be a valid address of at most 254 characters",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := tt.call(tt.request)
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+			if response.StatusCode != 400 {
+				t.Fatalf("Expected status 400, got %d: %s", response.StatusCode, response.Body)
+			}
+
+			var body dto.ValidationErrorResponse
+			if err := json.Unmarshal([]byte(response.Body), &body); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if body.Error != "validation failed" {
+				t.Errorf("Expected error 'validation failed', got %q", body.Error)
+			}
+			if len(body.Fields) != len(tt.expectedFields) {
+				t.Errorf("Expected fields %v, got %v", tt.expectedFields, body.Fields)
+			}
+			for field, message := range tt.expectedFields {
+				if body.Fields[field] != message {
+					t.Errorf("Expected %s message %q, got %q", field, message, body.Fields[field])
+				}
+			}
+		})
+	}
+}
+
+func TestHandler_UpdateUser_Email(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	for _, username := range []string{"alice", "bob"} {
+		user, _ := models.NewUser(username, "Test User", "password123")
+		if err := mockRepo.CreateUser(user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+	tokenService := auth.NewTokenService(testConfig())
+	h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+	update := func(username, body string) events.APIGatewayProxyResponse {
+		response, err := h.UpdateUser(events.APIGatewayProxyRequest{
+			RequestContext: events.APIGatewayProxyRequestContext{
+				Authorizer: map[string]interface{}{"claims": &auth.JWTClaims{Username: username}},
+			},
+			Body: body,
+		})
+		if err != nil {
+			t.Fatalf("Handler returned unexpected error: %v", err)
+		}
+		return response
+	}
+
+	if response := update("alice", `{"email":"alice@example.com"}`); response.StatusCode != 200 {
+		t.Fatalf("Expected status 200, got %d: %s", response.StatusCode, response.Body)
+	}
+	alice, _ := mockRepo.GetUser("alice")
+	if alice.Email != "alice@example.com" {
+		t.Errorf("Expected email alice@example.com, got %q", alice.Email)
+	}
+
+	tests := []struct {
+		name           string
+		username       string
+		body           string
+		expectedStatus int
+		expectedCode   string
+	}{
+		{name: "taken by another user", username: "bob", body: `{"email":"alice@example.com"}`, expectedStatus: 409, expectedCode: dto.CodeEmailInUse},
+		{name: "taken ignoring case", username: "bob", body: `{"email":"Alice@Example.com"}`, expectedStatus: 409, expectedCode: dto.CodeEmailInUse},
+		{name: "own email again", username: "alice", body: `{"email":"alice@example.com"}`, expectedStatus: 200},
+		{name: "free address", username: "bob", body: `{"email":"bob@example.com"}`, expectedStatus: 200},
+		{name: "display name", username: "bob", body: `{"email":"Bob <bob@example.com>"}`, expectedStatus: 400, expectedCode: dto.CodeValidationFailed},
+		{name: "empty", username: "bob", body: `{"email":""}`, expectedStatus: 400, expectedCode: dto.CodeValidationFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := update(tt.username, tt.body)
+			if response.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, response.StatusCode, response.Body)
+			}
+			if tt.expectedCode == "" {
+				return
+			}
+			var body dto.ErrorResponse
+			if err := json.Unmarshal([]byte(response.Body), &body); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if body.Code != tt.expectedCode {
+				t.Errorf("Expected code %s, got %s", tt.expectedCode, body.Code)
+			}
+		})
+	}
+
+	bob, _ := mockRepo.GetUser("bob")
+	if bob.Email != "bob@example.com" {
+		t.Errorf("Expected rejected updates to leave bob@example.com, got %q", bob.Email)
+	}
+
+	// Moving to another address releases the previous one
+	if response := update("alice", `{"email":"alice@work.example.com"}`); response.StatusCode != 200 {
+		t.Fatalf("Expected status 200, got %d: %s", response.StatusCode, response.Body)
+	}
+	if response := update("bob", `{"email":"alice@example.com"}`); response.StatusCode != 200 {
+		t.Errorf("Expected the released address to be available, got %d: %s", response.StatusCode, response.Body)
+	}
+}
+
+func TestHandler_GetStaleSkills(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	user, _ := models.NewUser("alice", "Alice", "password123")
+	if err := mockRepo.CreateUser(user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	now := time.Now().UTC()
+	for _, entry := range []struct {
+		skillID  string
+		lastUsed string
+	}{
+		{"go", dateutil.FormatDate(now)},                                       // used today
+		{"python", dateutil.FormatDate(now.AddDate(0, -6, 0))},                 // half a year ago
+		{"cobol", dateutil.FormatDate(now.AddDate(-3, 0, 0))},                  // long unused
+		{"perl", now.AddDate(-2, 0, 0).Format(time.RFC3339)},                   // timestamp from an older record
+		{"fortran", "not-a-date"},                                              // malformed, skipped
+		{"pascal", ""},                                                         // missing, skipped
+		{"java", dateutil.FormatDate(now.AddDate(0, -12, 0).AddDate(0, 0, 2))}, // just inside a year
+	} {
+		skill, _ := models.NewUserSkill("alice", entry.skillID, strings.ToUpper(entry.skillID), "Programming", models.ProficiencyIntermediate, 2)
+		skill.LastUsedDate = entry.lastUsed
+		if err := mockRepo.CreateSkill(skill); err != nil {
+			t.Fatalf("Failed to create skill: %v", err)
+		}
+	}
+
+	tokenService := auth.NewTokenService(testConfig())
+	h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+	tests := []struct {
+		name           string
+		username       string
+		query          map[string]string
+		expectedStatus int
+		expectedSkills []string
+	}{
+		{name: "defaults to twelve months, oldest first", username: "alice", expectedStatus: 200, expectedSkills: []string{"COBOL", "PERL"}},
+		{name: "shorter window", username: "alice", query: map[string]string{"months": "3"}, expectedStatus: 200, expectedSkills: []string{"COBOL", "PERL", "JAVA", "PYTHON"}},
+		{name: "nothing that old", username: "alice", query: map[string]string{"months": "48"}, expectedStatus: 200, expectedSkills: []string{}},
+		{name: "invalid months", username: "alice", query: map[string]string{"months": "0"}, expectedStatus: 400},
+		{name: "non-numeric months", username: "alice", query: map[string]string{"months": "a year"}, expectedStatus: 400},
+		{name: "unknown user", username: "ghost", expectedStatus: 404},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := h.GetStaleSkills(events.APIGatewayProxyRequest{
+				PathParameters:        map[string]string{"username": tt.username},
+				QueryStringParameters: tt.query,
+			})
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+			if response.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, response.StatusCode, response.Body)
+			}
+			if tt.expectedStatus != 200 {
+				return
+			}
+
+			var skills []dto.SkillResponse
+			if err := json.Unmarshal([]byte(response.Body), &skills); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			ids := make([]string, 0, len(skills))
+			for _, skill := range skills {
+				ids = append(ids, skill.SkillName)
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.expectedSkills, ",") {
+				t.Errorf("Expected stale skills %v, got %v", tt.expectedSkills, ids)
+			}
+		})
+	}
+
+	// Finding stale skills must not touch them
+	cobol, _ := mockRepo.GetSkill("alice", "cobol")
+	if cobol.LastUsedDate != dateutil.FormatDate(now.AddDate(-3, 0, 0)) {
+		t.Errorf("Expected cobol's last used date to be unchanged, got %s", cobol.LastUsedDate)
+	}
+}
+
+func TestHandler_GetSkillGap(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	for _, username := range []string{"mentee", "mentor", "newbie"} {
+		user, _ := models.NewUser(username, "Test User", "password123")
+		if err := mockRepo.CreateUser(user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+	for _, entry := range []struct {
+		username string
+		skillID  string
+		level    models.ProficiencyLevel
+		years    int
+	}{
+		{"mentee", "go", models.ProficiencyBeginner, 1},
+		{"mentee", "python", models.ProficiencyExpert, 8},
+		{"mentor", "go", models.ProficiencyExpert, 9},
+		{"mentor", "python", models.ProficiencyIntermediate, 2},
+		{"mentor", "rust", models.ProficiencyAdvanced, 4},
+	} {
+		skill, _ := models.NewUserSkill(entry.username, entry.skillID, strings.ToUpper(entry.skillID), "Programming", entry.level, entry.years)
+		if err := mockRepo.CreateSkill(skill); err != nil {
+			t.Fatalf("Failed to create skill: %v", err)
+		}
+	}
+
+	tokenService := auth.NewTokenService(testConfig())
+	h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+	tests := []struct {
+		name            string
+		username        string
+		against         string
+		expectedStatus  int
+		expectedMissing []string
+		expectedWeaker  []dto.SkillGapLevelResponse
+	}{
+		{
+			name:            "missing and weaker skills",
+			username:        "mentee",
+			against:         "mentor",
+			expectedStatus:  200,
+			expectedMissing: []string{"RUST"},
+			expectedWeaker:  []dto.SkillGapLevelResponse{{Skill: "GO", Category: "Programming", YourLevel: "Beginner", TheirLevel: "Expert"}},
+		},
+		{name: "user without skills has every skill missing", username: "newbie", against: "mentor", expectedStatus: 200, expectedMissing: []string{"GO", "PYTHON", "RUST"}},
+		{name: "other user without skills leaves no gap", username: "mentee", against: "newbie", expectedStatus: 200},
+		{name: "unknown user", username: "ghost", against: "mentor", expectedStatus: 404},
+		{name: "unknown other user", username: "mentee", against: "ghost", expectedStatus: 404},
+		{name: "missing against", username: "mentee", expectedStatus: 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := h.GetSkillGap(events.APIGatewayProxyRequest{
+				PathParameters:        map[string]string{"username": tt.username},
+				QueryStringParameters: map[string]string{"against": tt.against},
+			})
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+			if response.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, response.StatusCode, response.Body)
+			}
+			if tt.expectedStatus != 200 {
+				return
+			}
+
+			var gap dto.SkillGapResponse
+			if err := json.Unmarshal([]byte(response.Body), &gap); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if gap.Missing == nil || gap.Weaker == nil {
+				t.Errorf("Expected empty lists rather than null, got %s", response.Body)
+			}
+			var missing []string
+			for _, skill := range gap.Missing {
+				missing = append(missing, skill.SkillName)
+			}
+			if strings.Join(missing, ",") != strings.Join(tt.expectedMissing, ",") {
+				t.Errorf("Expected missing %v, got %v", tt.expectedMissing, missing)
+			}
+			if fmt.Sprint(gap.Weaker) != fmt.Sprint(tt.expectedWeaker) && len(gap.Weaker)+len(tt.expectedWeaker) > 0 {
+				t.Errorf("Expected weaker %v, got %v", tt.expectedWeaker, gap.Weaker)
+			}
+		})
+	}
+}
+
+func TestHandler_ListUsersByTag(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	archived, _ := models.NewUser("gone", "Gone User", "password123")
+	archived.Archive()
+	if err := mockRepo.CreateUser(archived); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	golang, _ := models.NewSkill("go", "Go", "Go language", "Programming", []string{"backend", "compiled"})
+	rust, _ := models.NewSkill("rust", "Rust", "Rust language", "Programming", []string{"compiled"})
+	cobol, _ := models.NewSkill("cobol", "COBOL", "Legacy language", "Programming", []string{"compiled"})
+	cobol.MarkDeleted()
+	react, _ := models.NewSkill("react", "React", "UI library", "Frontend", []string{"ui"})
+	for _, skill := range []*models.Skill{golang, rust, cobol, react} {
+		if err := mockRepo.CreateMasterSkill(skill); err != nil {
+			t.Fatalf("Failed to create master skill: %v", err)
+		}
+	}
+
+	for _, entry := range []struct {
+		username string
+		master   *models.Skill
+	}{
+		{"alice", golang},
+		{"alice", rust},
+		{"bob", rust},
+		{"carol", react},
+		{"dave", cobol},
+		{"gone", golang},
+	} {
+		skill, _ := models.NewUserSkill(entry.username, entry.master.SkillID, entry.master.SkillName, entry.master.Category, models.ProficiencyBeginner, 1)
+		if err := mockRepo.CreateSkill(skill); err != nil {
+			t.Fatalf("Failed to create skill: %v", err)
+		}
+	}
+
+	tokenService := auth.NewTokenService(testConfig())
+	h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+	tests := []struct {
+		name           string
+		tag            string
+		expectedStatus int
+		expectedUsers  []dto.TagUserResponse
+	}{
+		{
+			name:           "users deduped across tagged skills",
+			tag:            "compiled",
+			expectedStatus: 200,
+			expectedUsers: []dto.TagUserResponse{
+				{Username: "alice", Skills: []string{"Go", "Rust"}},
+				{Username: "bob", Skills: []string{"Rust"}},
+			},
+		},
+		{name: "single skill tag", tag: "ui", expectedStatus: 200, expectedUsers: []dto.TagUserResponse{{Username: "carol", Skills: []string{"React"}}}},
+		{name: "unknown tag", tag: "unknown", expectedStatus: 200, expectedUsers: []dto.TagUserResponse{}},
+		{name: "blank tag", tag: " ", expectedStatus: 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := h.ListUsersByTag(events.APIGatewayProxyRequest{PathParameters: map[string]string{"tag": tt.tag}})
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+			if response.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, response.StatusCode, response.Body)
+			}
+			if tt.expectedStatus != 200 {
+				return
+			}
+
+			var result dto.TagUsersResponse
+			if err := json.Unmarshal([]byte(response.Body), &result); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if result.Truncated {
+				t.Error("Expected untruncated result")
+			}
+			if fmt.Sprint(result.Users) != fmt.Sprint(tt.expectedUsers) {
+				t.Errorf("Expected users %v, got %v", tt.expectedUsers, result.Users)
+			}
+		})
+	}
+
+	t.Run("skills beyond the cap are not searched", func(t *testing.T) {
+		mockRepo.Reset()
+		for i := 1; i <= 26; i++ {
+			skill, _ := models.NewSkill(fmt.Sprintf("skill%02d", i), fmt.Sprintf("Skill %02d", i), "", "Programming", []string{"popular"})
+			if err := mockRepo.CreateMasterSkill(skill); err != nil {
+				t.Fatalf("Failed to create master skill: %v", err)
+			}
+		}
+		first, _ := models.NewUserSkill("alice", "skill01", "Skill 01", "Programming", models.ProficiencyBeginner, 1)
+		last, _ := models.NewUserSkill("bob", "skill26", "Skill 26", "Programming", models.ProficiencyBeginner, 1)
+		for _, skill := range []*models.UserSkill{first, last} {
+			if err := mockRepo.CreateSkill(skill); err != nil {
+				t.Fatalf("Failed to create skill: %v", err)
+			}
+		}
+
+		response, err := h.ListUsersByTag(events.APIGatewayProxyRequest{PathParameters: map[string]string{"tag": "popular"}})
+		if err != nil {
+			t.Fatalf("Handler returned unexpected error: %v", err)
+		}
+
+		var result dto.TagUsersResponse
+		if err := json.Unmarshal([]byte(response.Body), &result); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		if !result.Truncated {
+			t.Error("Expected truncated result")
+		}
+		if len(result.Users) != 1 || result.Users[0].Username != "alice" {
+			t.Errorf("Expected only alice from the searched skills, got %v", result.Users)
+		}
+	})
+}
+
+func TestHandler_RejectsUnknownFields(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	golang, _ := models.NewSkill("go", "Go", "Go language", "Programming", nil)
+	if err := mockRepo.CreateMasterSkill(golang); err != nil {
+		t.Fatalf("Failed to create master skill: %v", err)
+	}
+
+	tokenService := auth.NewTokenService(testConfig())
+	h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+	path := map[string]string{"username": "testuser"}
+
+	tests := []struct {
+		name           string
+		call           func(events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)
+		body           string
+		expectedStatus int
+		expectedField  string
+	}{
+		{name: "register typo", call: h.Register, body: `{"username":"newuser","name":"New User","pasword":"password123"}`, expectedStatus: 400, expectedField: "pasword"},
+		{name: "add skill typo", call: h.AddSkill, body: `{"skill_name":"go","proficiency":"Expert","years_of_experience":6}`, expectedStatus: 400, expectedField: "proficiency"},
+		{name: "nested batch typo", call: h.AddSkillsBatch, body: `{"skills":[{"skill_name":"go","level":"Expert"}]}`, expectedStatus: 400, expectedField: "level"},
+		{name: "trailing data", call: h.AddSkill, body: `{"skill_name":"go","proficiency_level":"Expert","years_of_experience":6} {}`, expectedStatus: 400},
+		{name: "valid body", call: h.AddSkill, body: `{"skill_name":"go","proficiency_level":"Expert","years_of_experience":6}`, expectedStatus: 201},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := tt.call(events.APIGatewayProxyRequest{PathParameters: path, Body: tt.body})
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+			if response.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, response.StatusCode, response.Body)
+			}
+			if tt.expectedField == "" {
+				return
+			}
+
+			var body dto.ValidationErrorResponse
+			if err := json.Unmarshal([]byte(response.Body), &body); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if body.Error != fmt.Sprintf("unknown field %q", tt.expectedField) {
+				t.Errorf("Expected error naming %s, got %q", tt.expectedField, body.Error)
+			}
+			if body.Fields[tt.expectedField] != "unknown field" {
+				t.Errorf("Expected fields to flag %s, got %v", tt.expectedField, body.Fields)
+			}
+		})
+	}
+}
+
+func TestHandler_PromoteSkill(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	skill, _ := models.NewUserSkill("testuser", "go", "Go", "Programming", models.ProficiencyBeginner, 6)
+	if err := mockRepo.CreateSkill(skill); err != nil {
+		t.Fatalf("Failed to create skill: %v", err)
+	}
+
+	tokenService := auth.NewTokenService(testConfig())
+	h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+	promote := func(caller, skillName string) events.APIGatewayProxyResponse {
+		t.Helper()
+		response, err := h.PromoteSkill(events.APIGatewayProxyRequest{
+			PathParameters: map[string]string{"username": "testuser", "skillName": skillName},
+			RequestContext: events.APIGatewayProxyRequestContext{
+				Authorizer: map[string]interface{}{"claims": &auth.JWTClaims{Username: caller}},
+			},
+		})
+		if err != nil {
+			t.Fatalf("Handler returned unexpected error: %v", err)
+		}
+		return response
+	}
+
+	// Each promotion moves up exactly one level
+	for _, expected := range []string{"Intermediate", "Advanced", "Expert"} {
+		response := promote("testuser", "go")
+		if response.StatusCode != 200 {
+			t.Fatalf("Expected status 200 promoting to %s, got %d: %s", expected, response.StatusCode, response.Body)
+		}
+		var promoted dto.SkillResponse
+		if err := json.Unmarshal([]byte(response.Body), &promoted); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		if promoted.ProficiencyLevel != expected {
+			t.Errorf("Expected level %s, got %s", expected, promoted.ProficiencyLevel)
+		}
+	}
+
+	// Expert is the ceiling
+	if response := promote("testuser", "go"); response.StatusCode != 409 {
+		t.Errorf("Expected status 409 promoting beyond Expert, got %d: %s", response.StatusCode, response.Body)
+	}
+	if stored, _ := mockRepo.GetSkill("testuser", "go"); stored.ProficiencyLevel != models.ProficiencyExpert {
+		t.Errorf("Expected skill to stay at Expert, got %s", stored.ProficiencyLevel)
+	}
+
+	history, _ := mockRepo.ListSkillHistory("testuser", "go")
+	if len(history) != 3 {
+		t.Errorf("Expected 3 history entries, got %d", len(history))
+	}
+
+	if response := promote("someoneelse", "go"); response.StatusCode != 403 {
+		t.Errorf("Expected status 403 promoting another user's skill, got %d", response.StatusCode)
+	}
+	if response := promote("testuser", "rust"); response.StatusCode != 404 {
+		t.Errorf("Expected status 404 for a missing skill, got %d", response.StatusCode)
+	}
+}
+
+func TestHandler_ResyncUserSkills(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	master, _ := models.NewSkill("golang", "Go", "Go language", "Programming", nil)
+	if err := mockRepo.CreateMasterSkill(master); err != nil {
+		t.Fatalf("Failed to create master skill: %v", err)
+	}
+
+	// Three users hold the skill under its old name, one is already current
+	for _, username := range []string{"alice", "bob", "carol"} {
+		skill, _ := models.NewUserSkill(username, "golang", "Golang", "Languages", models.ProficiencyBeginner, 1)
+		if err := mockRepo.CreateSkill(skill); err != nil {
+			t.Fatalf("Failed to create skill: %v", err)
+		}
+	}
+	current, _ := models.NewUserSkill("dave", "golang", "Go", "Programming", models.ProficiencyExpert, 8)
+	unrelated, _ := models.NewUserSkill("alice", "rust", "Rust", "Languages", models.ProficiencyBeginner, 1)
+	for _, skill := range []*models.UserSkill{current, unrelated} {
+		if err := mockRepo.CreateSkill(skill); err != nil {
+			t.Fatalf("Failed to create skill: %v", err)
+		}
+	}
+
+	tokenService := auth.NewTokenService(testConfig())
+	h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+	// Admin access is enforced by the route's RequireAdmin middleware
+	resync := func(skillID string) events.APIGatewayProxyResponse {
+		t.Helper()
+		response, err := h.ResyncUserSkills(events.APIGatewayProxyRequest{
+			PathParameters: map[string]string{"skillID": skillID},
+		})
+		if err != nil {
+			t.Fatalf("Handler returned unexpected error: %v", err)
+		}
+		return response
+	}
+
+	response := resync("golang")
+	if response.StatusCode != 200 {
+		t.Fatalf("Expected status 200, got %d: %s", response.StatusCode, response.Body)
+	}
+	var result dto.ResyncUserSkillsResponse
+	if err := json.Unmarshal([]byte(response.Body), &result); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if result.Updated != 3 {
+		t.Errorf("Expected 3 updated skills, got %d", result.Updated)
+	}
+
+	for _, username := range []string{"alice", "bob", "carol", "dave"} {
+		stored, err := mockRepo.GetSkill(username, "golang")
+		if err != nil {
+			t.Fatalf("Failed to get skill: %v", err)
+		}
+		if stored.SkillName != "Go" || stored.Category != "Programming" {
+			t.Errorf("Expected %s's skill to be Go/Programming, got %s/%s", username, stored.SkillName, stored.Category)
+		}
+	}
+	if stored, _ := mockRepo.GetSkill("alice", "rust"); stored.SkillName != "Rust" {
+		t.Errorf("Expected unrelated skill to be untouched, got %s", stored.SkillName)
+	}
+
+	// A second run finds nothing stale
+	if err := json.Unmarshal([]byte(resync("golang").Body), &result); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if result.Updated != 0 {
+		t.Errorf("Expected 0 updated skills on a second run, got %d", result.Updated)
+	}
+
+	if response := resync("missing"); response.StatusCode != 404 {
+		t.Errorf("Expected status 404 for an unknown skill, got %d", response.StatusCode)
+	}
+}
+
+func TestHandler_InvalidProficiencyLevel(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	golang, _ := models.NewSkill("go", "Go", "Go language", "Programming", nil)
+	if err := mockRepo.CreateMasterSkill(golang); err != nil {
+		t.Fatalf("Failed to create master skill: %v", err)
+	}
+	existing, _ := models.NewUserSkill("testuser", "go", "Go", "Programming", models.ProficiencyBeginner, 1)
+	if err := mockRepo.CreateSkill(existing); err != nil {
+		t.Fatalf("Failed to create skill: %v", err)
+	}
+
+	tokenService := auth.NewTokenService(testConfig())
+	h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+	tests := []struct {
+		name           string
+		handler        func(events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)
+		username       string
+		body           string
+		expectedStatus int
+	}{
+		{name: "add with unknown level", handler: h.AddSkill, username: "alice", body: `{"skill_name":"go","proficiency_level":"Guru","years_of_experience":1}`, expectedStatus: 400},
+		{name: "add with empty level", handler: h.AddSkill, username: "alice", body: `{"skill_name":"go","proficiency_level":"","years_of_experience":1}`, expectedStatus: 400},
+		{name: "add with valid level", handler: h.AddSkill, username: "alice", body: `{"skill_name":"go","proficiency_level":"Beginner","years_of_experience":1}`, expectedStatus: 201},
+		{name: "put with unknown level", handler: h.UpdateSkill, username: "testuser", body: `{"proficiency_level":"Guru","years_of_experience":1}`, expectedStatus: 400},
+		{name: "patch with empty level", handler: h.PatchSkill, username: "testuser", body: `{"proficiency_level":""}`, expectedStatus: 400},
+		{name: "patch with valid level", handler: h.PatchSkill, username: "testuser", body: `{"proficiency_level":"Intermediate"}`, expectedStatus: 200},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := tt.handler(events.APIGatewayProxyRequest{
+				PathParameters: map[string]string{"username": tt.username, "skillName": "go"},
+				Body:           tt.body,
+			})
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+			if response.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, response.StatusCode, response.Body)
+			}
+			if tt.expectedStatus == 400 && !strings.Contains(response.Body, "Beginner, Intermediate, Advanced, Expert") {
+				t.Errorf("Expected the allowed levels in the error, got %s", response.Body)
+			}
+		})
+	}
+
+	if stored, _ := mockRepo.GetSkill("testuser", "go"); stored.ProficiencyLevel != models.ProficiencyIntermediate {
+		t.Errorf("Expected only the valid patch to apply, got %s", stored.ProficiencyLevel)
+	}
+}
+
+func TestHandler_RequestTimeout(t *testing.T) {
+	mockRepo := database.NewMockRepository()
+	user, _ := models.NewUser("testuser", "Test User", "password123")
+	if err := mockRepo.CreateUser(user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	skill, _ := models.NewUserSkill("testuser", "go", "Go", "Programming", models.ProficiencyIntermediate, 3)
+	if err := mockRepo.CreateSkill(skill); err != nil {
+		t.Fatalf("Failed to create skill: %v", err)
+	}
+
+	tokenService := auth.NewTokenService(testConfig())
+	h := New(service.NewUserService(mockRepo, tokenService), service.NewSkillService(mockRepo, mockRepo, mockRepo, mockRepo, mockRepo, mockRepo))
+
+	// A context that is already done stands in for a request past its deadline
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := map[string]func() error{
+		"GetSkill": func() error {
+			_, err := h.skillService.GetSkill(ctx, "testuser", "go")
+			return err
+		},
+		"ListSkillsForUser": func() error {
+			_, err := h.skillService.ListSkillsForUser(ctx, "testuser", time.Time{})
+			return err
+		},
+		"AddSkill": func() error {
+			_, err := h.skillService.AddSkill(ctx, "testuser", "", "python", models.ProficiencyBeginner, 1, "")
+			return err
+		},
+		"DeleteSkill": func() error {
+			return h.skillService.DeleteSkill(ctx, "testuser", "go")
+		},
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			err := call()
+			if !errors.Is(err, apperrors.ErrTimeout) {
+				t.Fatalf("Expected ErrTimeout, got %v", err)
+			}
+			if response := h.handleServiceError(err); response.StatusCode != 504 {
+				t.Errorf("Expected status 504, got %d", response.StatusCode)
+			}
+		})
+	}
+
+	// The skill is untouched and a live request still succeeds
+	response, err := h.GetSkill(events.APIGatewayProxyRequest{PathParameters: map[string]string{"username": "testuser", "skillName": "go"}})
+	if err != nil {
+		t.Fatalf("Handler returned unexpected error: %v", err)
+	}
+	if response.StatusCode != 200 {
+		t.Errorf("Expected status 200, got %d", response.StatusCode)
+	}
+}
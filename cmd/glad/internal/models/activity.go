@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// ActivityAction names the kind of event recorded in a user's activity log
+type ActivityAction string
+
+const (
+	ActivitySkillAdded    ActivityAction = "skill_added"
+	ActivitySkillUpdated  ActivityAction = "skill_updated"
+	ActivitySkillDeleted  ActivityAction = "skill_deleted"
+	ActivitySkillPromoted ActivityAction = "skill_promoted"
+)
+
+// ActivityLog records one meaningful change to a user's skills
+// OldLevel and NewLevel are only set when the proficiency level changed.
+// This entity uses single table design with the following key structure:
+//   - entity_id: ACTIVITY#<username>#<timestamp>#<skill_id>
+type ActivityLog struct {
+	// Business attributes
+	Username   string           `json:"username" dynamodbav:"Username"`
+	Action     ActivityAction   `json:"action" dynamodbav:"Action"`
+	SkillID    string           `json:"skill_id" dynamodbav:"skill_id"`
+	SkillName  string           `json:"skill_name" dynamodbav:"SkillName"`
+	OldLevel   ProficiencyLevel `json:"old_level,omitempty" dynamodbav:"OldLevel,omitempty"`
+	NewLevel   ProficiencyLevel `json:"new_level,omitempty" dynamodbav:"NewLevel,omitempty"`
+	OccurredAt time.Time        `json:"occurred_at" dynamodbav:"OccurredAt"`
+
+	// DynamoDB attributes
+	EntityID   string `json:"-" dynamodbav:"entity_id"`
+	EntityType string `json:"entity_type" dynamodbav:"EntityType"`
+}
+
+// NewActivityLog records action on a user's skill happening now
+func NewActivityLog(username string, action ActivityAction, skillID, skillName string) *ActivityLog {
+	activity := &ActivityLog{
+		Username:   username,
+		Action:     action,
+		SkillID:    skillID,
+		SkillName:  skillName,
+		OccurredAt: time.Now().UTC(),
+	}
+
+	activity.SetKeys()
+	return activity
+}
+
+// SetKeys configures the entity_id for DynamoDB
+// The timestamp shares the fixed-width layout of skill history so entries sort chronologically.
+func (a *ActivityLog) SetKeys() {
+	a.EntityID = BuildActivityEntityID(a.Username, a.OccurredAt.UTC().Format(skillHistoryTimestampLayout), a.SkillID)
+	a.EntityType = "ActivityLog"
+}
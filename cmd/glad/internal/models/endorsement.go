@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// Endorsement records that one user endorsed another user's skill
+// The skill's Endorsements counter stays the source for totals; these records make each
+// endorser's endorsement unique and removable.
+// This entity uses single table design with the following key structure:
+//   - entity_id: ENDORSEMENT#<username>#<skill_id>#<endorser>
+type Endorsement struct {
+	// Business attributes
+	Username   string    `json:"username" dynamodbav:"Username"` // owner of the endorsed skill
+	SkillID    string    `json:"skill_id" dynamodbav:"skill_id"`
+	Endorser   string    `json:"endorser" dynamodbav:"Endorser"`
+	EndorsedAt time.Time `json:"endorsed_at" dynamodbav:"EndorsedAt"`
+
+	// DynamoDB attributes
+	EntityID   string `json:"-" dynamodbav:"entity_id"`
+	EntityType string `json:"entity_type" dynamodbav:"EntityType"`
+}
+
+// NewEndorsement records endorser endorsing username's skill now
+func NewEndorsement(username, skillID, endorser string) *Endorsement {
+	endorsement := &Endorsement{
+		Username:   username,
+		SkillID:    skillID,
+		Endorser:   endorser,
+		EndorsedAt: time.Now().UTC(),
+	}
+
+	endorsement.SetKeys()
+	return endorsement
+}
+
+// SetKeys configures the entity_id for DynamoDB
+func (e *Endorsement) SetKeys() {
+	e.EntityID = BuildEndorsementEntityID(e.Username, e.SkillID, e.Endorser)
+	e.EntityType = "Endorsement"
+}
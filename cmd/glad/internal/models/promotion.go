@@ -0,0 +1,67 @@
+package models
+
+import (
+	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
+)
+
+// proficiencyOrder lists proficiency levels from lowest to highest
+var proficiencyOrder = []ProficiencyLevel{
+	ProficiencyBeginner,
+	ProficiencyIntermediate,
+	ProficiencyAdvanced,
+	ProficiencyExpert,
+}
+
+// DefaultPromotionThresholds are the endorsements required to reach Intermediate, Advanced and Expert
+var DefaultPromotionThresholds = []int{5, 15, 30}
+
+// PromotionPolicy decides when endorsements make a skill eligible for the next proficiency level
+type PromotionPolicy struct {
+	AutoPromote bool                     // promote eligible skills instead of only flagging them
+	Thresholds  map[ProficiencyLevel]int // endorsements required to reach each level above Beginner
+}
+
+// NewPromotionPolicy creates a PromotionPolicy from thresholds for Intermediate, Advanced and Expert
+// Thresholds must be positive and strictly increasing.
+func NewPromotionPolicy(autoPromote bool, thresholds []int) (PromotionPolicy, error) {
+	levels := proficiencyOrder[1:]
+	if len(thresholds) != len(levels) {
+		return PromotionPolicy{}, apperrors.ErrInvalidPromotionThresholds
+	}
+
+	policy := PromotionPolicy{AutoPromote: autoPromote, Thresholds: make(map[ProficiencyLevel]int, len(levels))}
+	previous := 0
+	for i, level := range levels {
+		if thresholds[i] <= previous {
+			return PromotionPolicy{}, apperrors.ErrInvalidPromotionThresholds
+		}
+		policy.Thresholds[level] = thresholds[i]
+		previous = thresholds[i]
+	}
+
+	return policy, nil
+}
+
+// DefaultPromotionPolicy returns a flag-only policy using DefaultPromotionThresholds
+func DefaultPromotionPolicy() PromotionPolicy {
+	policy, _ := NewPromotionPolicy(false, DefaultPromotionThresholds)
+	return policy
+}
+
+// NextLevel returns the level above the skill's current one when its endorsements meet that level's threshold
+func (p PromotionPolicy) NextLevel(skill *UserSkill) (ProficiencyLevel, bool) {
+	for i, level := range proficiencyOrder[:len(proficiencyOrder)-1] {
+		if level != skill.ProficiencyLevel {
+			continue
+		}
+
+		next := proficiencyOrder[i+1]
+		threshold, ok := p.Thresholds[next]
+		if !ok || skill.Endorsements < threshold {
+			return "", false
+		}
+		return next, true
+	}
+
+	return "", false
+}
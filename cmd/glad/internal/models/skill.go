@@ -2,8 +2,10 @@ package models
 
 import (
 	"errors"
+	"strings"
 	"time"
 
+	skillerrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
 	apperrors "github.com/hackmajoris/glad-stack/pkg/errors"
 )
 
@@ -17,9 +19,17 @@ type Skill struct {
 	Description string    `json:"description" dynamodbav:"Description"`
 	Category    string    `json:"category" dynamodbav:"Category"` // e.g., "Programming", "Cloud", "DevOps"
 	Tags        []string  `json:"tags,omitempty" dynamodbav:"Tags,omitempty"`
+	Featured    bool      `json:"featured,omitempty" dynamodbav:"Featured,omitempty"` // curated by admins; omitted when false
 	CreatedAt   time.Time `json:"created_at" dynamodbav:"CreatedAt"`
 	UpdatedAt   time.Time `json:"updated_at" dynamodbav:"UpdatedAt"`
 
+	// Lowercased SkillName for case-insensitive prefix search; maintained by SetKeys
+	SkillNameLower string `json:"-" dynamodbav:"SkillNameLower"`
+
+	// Soft-delete attributes; UserSkills may still reference deleted skills
+	Deleted   bool      `json:"deleted,omitempty" dynamodbav:"Deleted,omitempty"`
+	DeletedAt time.Time `json:"deleted_at" dynamodbav:"DeletedAt"`
+
 	// DynamoDB attributes
 	EntityID   string `json:"-" dynamodbav:"entity_id"`
 	EntityType string `json:"entity_type" dynamodbav:"EntityType"`
@@ -30,6 +40,7 @@ type Skill struct {
 // skillName is the display name (e.g., "Python", "AWS Lambda", "React.js")
 // category should be a valid category (e.g., "Programming", "Cloud", "DevOps", "Database")
 func NewSkill(skillID, skillName, description, category string, tags []string) (*Skill, error) {
+	skillName = NormalizeSkillName(skillName)
 	if skillID == "" || skillName == "" || category == "" {
 		return nil, apperrors.ErrRequiredField
 	}
@@ -38,12 +49,23 @@ func NewSkill(skillID, skillName, description, category string, tags []string) (
 		return nil, errors.New("invalid skill_id: must be lowercase alphanumeric with dashes, max 50 chars")
 	}
 
-	if len(skillName) < 2 || len(skillName) > 100 {
-		return nil, errors.New("invalid skill_name: must be between 2 and 100 characters")
+	if len(skillName) > 100 {
+		return nil, errors.New("invalid skill_name: must be at most 100 characters")
+	}
+
+	for _, name := range []string{skillID, skillName} {
+		if err := ValidateSkillNameNotReserved(name); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := ValidateCategory(category); err != nil {
+		return nil, err
 	}
 
-	if !isValidCategory(category) {
-		return nil, errors.New("invalid category: must be one of Programming, Cloud, DevOps, Database, Frontend, Backend, Mobile, Data, Security, Other")
+	// The minimum length depends on the category, so it is checked once the category is known
+	if err := ValidateSkillNameLength(skillName, category); err != nil {
+		return nil, err
 	}
 
 	now := time.Now()
@@ -78,29 +100,53 @@ func isValidSkillID(id string) bool {
 	return true
 }
 
-// validCategories defines the allowed skill categories
-var validCategories = map[string]bool{
-	"Programming": true,
-	"Cloud":       true,
-	"DevOps":      true,
-	"Database":    true,
-	"Frontend":    true,
-	"Backend":     true,
-	"Mobile":      true,
-	"Data":        true,
-	"Security":    true,
-	"Other":       true,
+// categories lists the allowed skill categories in display order
+var categories = []string{
+	"Programming",
+	"Cloud",
+	"DevOps",
+	"Database",
+	"Frontend",
+	"Backend",
+	"Mobile",
+	"Data",
+	"Security",
+	"Other",
+}
+
+// validCategories indexes the allowed skill categories for lookup
+var validCategories = func() map[string]bool {
+	valid := make(map[string]bool, len(categories))
+	for _, category := range categories {
+		valid[category] = true
+	}
+	return valid
+}()
+
+// Categories returns the allowed skill categories in display order
+func Categories() []string {
+	return append([]string(nil), categories...)
 }
 
-// isValidCategory checks if the category is in the allowed list
-func isValidCategory(category string) bool {
+// IsValidCategory checks if the category is in the allowed list
+func IsValidCategory(category string) bool {
 	return validCategories[category]
 }
 
-// SetKeys configures the entity_id for DynamoDB
+// ValidateCategory returns an *InvalidCategoryError listing the allowed categories
+// when category is not one of them
+func ValidateCategory(category string) error {
+	if !validCategories[category] {
+		return &skillerrors.InvalidCategoryError{Category: category, Allowed: Categories()}
+	}
+	return nil
+}
+
+// SetKeys configures the entity_id for DynamoDB and refreshes denormalized search attributes
 func (s *Skill) SetKeys() {
 	s.EntityID = BuildMasterSkillEntityID(s.SkillID)
 	s.EntityType = "Skill"
+	s.SkillNameLower = strings.ToLower(s.SkillName)
 }
 
 // UpdateMetadata updates skill display name, description, and category
@@ -123,3 +169,17 @@ func (s *Skill) UpdateTags(tags []string) {
 	s.Tags = tags
 	s.UpdatedAt = time.Now()
 }
+
+// SetFeatured adds the skill to, or removes it from, the curated featured list
+func (s *Skill) SetFeatured(featured bool) {
+	s.Featured = featured
+	s.UpdatedAt = time.Now()
+}
+
+// MarkDeleted soft-deletes the skill so existing UserSkill references stay valid
+func (s *Skill) MarkDeleted() {
+	now := time.Now()
+	s.Deleted = true
+	s.DeletedAt = now
+	s.UpdatedAt = now
+}
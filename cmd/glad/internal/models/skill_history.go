@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// skillHistoryTimestampLayout is a fixed-width UTC layout so entity_ids sort chronologically
+const skillHistoryTimestampLayout = "2006-01-02T15:04:05.000000000Z"
+
+// SkillHistory records a change to a user's proficiency level for one skill
+// This entity uses single table design with the following key structure:
+//   - entity_id: SKILLHISTORY#<username>#<skill_id>#<timestamp>
+type SkillHistory struct {
+	// Business attributes
+	Username  string           `json:"username" dynamodbav:"Username"`
+	SkillID   string           `json:"skill_id" dynamodbav:"skill_id"`
+	OldLevel  ProficiencyLevel `json:"old_level" dynamodbav:"OldLevel"`
+	NewLevel  ProficiencyLevel `json:"new_level" dynamodbav:"NewLevel"`
+	ChangedAt time.Time        `json:"changed_at" dynamodbav:"ChangedAt"`
+
+	// DynamoDB attributes
+	EntityID   string `json:"-" dynamodbav:"entity_id"`
+	EntityType string `json:"entity_type" dynamodbav:"EntityType"`
+}
+
+// NewSkillHistory records a proficiency change from oldLevel to newLevel made now
+func NewSkillHistory(username, skillID string, oldLevel, newLevel ProficiencyLevel) *SkillHistory {
+	history := &SkillHistory{
+		Username:  username,
+		SkillID:   skillID,
+		OldLevel:  oldLevel,
+		NewLevel:  newLevel,
+		ChangedAt: time.Now().UTC(),
+	}
+
+	history.SetKeys()
+	return history
+}
+
+// SetKeys configures the entity_id for DynamoDB
+func (h *SkillHistory) SetKeys() {
+	h.EntityID = BuildSkillHistoryEntityID(h.Username, h.SkillID, h.ChangedAt.UTC().Format(skillHistoryTimestampLayout))
+	h.EntityType = "SkillHistory"
+}
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
+)
+
+// defaultSkillNameMinLength is the minimum skill name length for categories without their own rule
+const defaultSkillNameMinLength = 2
+
+// skillNameMinLengths overrides defaultSkillNameMinLength per category
+// Set once at startup via SetSkillNameMinLengths.
+var skillNameMinLengths = map[string]int{}
+
+// SetSkillNameMinLengths sets per-category minimum skill name lengths
+// Categories not listed fall back to the global minimum of defaultSkillNameMinLength.
+func SetSkillNameMinLengths(minLengths map[string]int) error {
+	rules := make(map[string]int, len(minLengths))
+	for category, minLength := range minLengths {
+		if !IsValidCategory(category) || minLength < 1 {
+			return fmt.Errorf("%w: %s=%d", apperrors.ErrInvalidSkillNameRule, category, minLength)
+		}
+		rules[category] = minLength
+	}
+
+	skillNameMinLengths = rules
+	return nil
+}
+
+// SkillNameMinLength returns the minimum skill name length for category
+func SkillNameMinLength(category string) int {
+	if minLength, ok := skillNameMinLengths[category]; ok {
+		return minLength
+	}
+	return defaultSkillNameMinLength
+}
+
+// ValidateSkillNameLength checks skillName against the minimum length configured for category
+func ValidateSkillNameLength(skillName, category string) error {
+	if minLength := SkillNameMinLength(category); len(skillName) < minLength {
+		return fmt.Errorf("%w: %s skill names need at least %d characters", apperrors.ErrSkillNameTooShort, category, minLength)
+	}
+	return nil
+}
+
+// reservedSkillNames are the static path segments registered beside a skill ID or name
+// parameter, e.g. /master-skills/search beside /master-skills/{skillID}. The router prefers
+// static segments, so a skill called one of them could not be reached through those routes.
+var reservedSkillNames = map[string]bool{
+	"import":           true,
+	"search":           true,
+	"stats":            true,
+	"suggest-category": true,
+	"featured":         true,
+	"batch":            true,
+	"snapshot":         true,
+	"diff":             true,
+	"gap":              true,
+	"stale":            true,
+	"recent":           true,
+}
+
+// IsReservedSkillName reports whether name, ignoring case and surrounding whitespace, is a path
+// segment that routes use beside a skill ID or name
+func IsReservedSkillName(name string) bool {
+	return reservedSkillNames[NormalizeSkillID(name)]
+}
+
+// ValidateSkillNameNotReserved returns ErrReservedSkillName when name is reserved by a route
+func ValidateSkillNameNotReserved(name string) error {
+	if IsReservedSkillName(name) {
+		return fmt.Errorf("%w: %s", apperrors.ErrReservedSkillName, NormalizeSkillName(name))
+	}
+	return nil
+}
+
+// NormalizeSkillName trims surrounding whitespace from a skill display name
+// so " Go " and "Go" are stored as the same name.
+func NormalizeSkillName(skillName string) string {
+	return strings.TrimSpace(skillName)
+}
+
+// NormalizeSkillID trims and lowercases a skill ID supplied by a client
+// Stored IDs are always lowercase, so lookups by the result are case-insensitive.
+func NormalizeSkillID(skillID string) string {
+	return strings.ToLower(strings.TrimSpace(skillID))
+}
@@ -0,0 +1,129 @@
+package models
+
+import (
+	"testing"
+
+	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
+	pkgerrors "github.com/hackmajoris/glad-stack/pkg/errors"
+)
+
+func TestNewSkill_CategoryNameMinLength(t *testing.T) {
+	if err := SetSkillNameMinLengths(map[string]int{"Programming": 1, "Security": 4}); err != nil {
+		t.Fatalf("Failed to set skill name rules: %v", err)
+	}
+	t.Cleanup(func() { _ = SetSkillNameMinLengths(nil) })
+
+	tests := []struct {
+		name      string
+		skillName string
+		category  string
+		wantErr   bool
+	}{
+		{name: "short name allowed in Programming", skillName: "R", category: "Programming"},
+		{name: "short name rejected in stricter category", skillName: "R", category: "Security", wantErr: true},
+		{name: "name below stricter minimum", skillName: "XSS", category: "Security", wantErr: true},
+		{name: "name meeting stricter minimum", skillName: "OWASP", category: "Security"},
+		{name: "global minimum applies to other categories", skillName: "R", category: "Data", wantErr: true},
+		{name: "global minimum met", skillName: "ML", category: "Data"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSkill("skill-id", tt.skillName, "", tt.category, nil)
+			if tt.wantErr {
+				if !pkgerrors.Is(err, apperrors.ErrSkillNameTooShort) {
+					t.Errorf("Expected ErrSkillNameTooShort, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSetSkillNameMinLengths_RejectsInvalidRules(t *testing.T) {
+	t.Cleanup(func() { _ = SetSkillNameMinLengths(nil) })
+
+	for _, rules := range []map[string]int{
+		{"Cooking": 3},
+		{"Programming": 0},
+	} {
+		if err := SetSkillNameMinLengths(rules); !pkgerrors.Is(err, apperrors.ErrInvalidSkillNameRule) {
+			t.Errorf("Expected ErrInvalidSkillNameRule for %v, got %v", rules, err)
+		}
+	}
+}
+
+func TestNewSkill_RejectsReservedNames(t *testing.T) {
+	tests := []struct {
+		name      string
+		skillID   string
+		skillName string
+	}{
+		{name: "reserved ID", skillID: "search", skillName: "Search Engines"},
+		{name: "reserved name", skillID: "stats-tool", skillName: "Stats"},
+		{name: "reserved name with whitespace", skillID: "recent-tool", skillName: " RECENT "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewSkill(tt.skillID, tt.skillName, "", "Programming", nil); !pkgerrors.Is(err, apperrors.ErrReservedSkillName) {
+				t.Errorf("Expected ErrReservedSkillName, got %v", err)
+			}
+		})
+	}
+
+	if _, err := NewSkill("statistics", "Statistics", "", "Data", nil); err != nil {
+		t.Errorf("Expected a name merely containing a reserved word to be accepted, got %v", err)
+	}
+}
+
+func TestNewSkill_TrimsSkillName(t *testing.T) {
+	tests := []struct {
+		name      string
+		skillName string
+		want      string
+		wantErr   bool
+	}{
+		{name: "surrounding spaces trimmed", skillName: "  Go  ", want: "Go"},
+		{name: "tabs and newlines trimmed", skillName: "\tPython\n", want: "Python"},
+		{name: "inner spaces kept", skillName: " AWS Lambda ", want: "AWS Lambda"},
+		{name: "whitespace only rejected", skillName: "   ", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			skill, err := NewSkill("skill-id", tt.skillName, "", "Programming", nil)
+			if tt.wantErr {
+				if !pkgerrors.Is(err, pkgerrors.ErrRequiredField) {
+					t.Errorf("Expected ErrRequiredField, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if skill.SkillName != tt.want {
+				t.Errorf("Expected skill name %q, got %q", tt.want, skill.SkillName)
+			}
+
+			userSkill, err := NewUserSkill("alice", "skill-id", tt.skillName, "Programming", ProficiencyBeginner, 1)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if userSkill.SkillName != tt.want {
+				t.Errorf("Expected user skill name %q, got %q", tt.want, userSkill.SkillName)
+			}
+		})
+	}
+}
+
+func TestNormalizeSkillID(t *testing.T) {
+	for _, id := range []string{"python", " python ", "Python", "PYTHON\t"} {
+		if got := NormalizeSkillID(id); got != "python" {
+			t.Errorf("Expected %q to normalize to python, got %q", id, got)
+		}
+	}
+}
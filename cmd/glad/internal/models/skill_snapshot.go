@@ -0,0 +1,120 @@
+package models
+
+import (
+	"sort"
+	"strconv"
+	"time"
+)
+
+// SkillSnapshot captures a user's skills at a point in time for later comparison
+// This entity uses single table design with the following key structure:
+//   - entity_id: SNAPSHOT#<username>#<snapshot_id>
+type SkillSnapshot struct {
+	// Business attributes
+	SnapshotID string          `json:"snapshot_id" dynamodbav:"snapshot_id"`
+	Username   string          `json:"username" dynamodbav:"Username"`
+	Skills     []SnapshotSkill `json:"skills" dynamodbav:"Skills"`
+	CreatedAt  time.Time       `json:"created_at" dynamodbav:"CreatedAt"`
+
+	// DynamoDB attributes
+	EntityID   string `json:"-" dynamodbav:"entity_id"`
+	EntityType string `json:"entity_type" dynamodbav:"EntityType"`
+}
+
+// SnapshotSkill is the subset of a UserSkill recorded in a snapshot
+type SnapshotSkill struct {
+	SkillID           string           `json:"skill_id" dynamodbav:"skill_id"`
+	SkillName         string           `json:"skill_name" dynamodbav:"SkillName"`
+	ProficiencyLevel  ProficiencyLevel `json:"proficiency_level" dynamodbav:"ProficiencyLevel"`
+	YearsOfExperience int              `json:"years_of_experience" dynamodbav:"YearsOfExperience"`
+}
+
+// SkillLevelChange describes a skill whose proficiency level changed
+type SkillLevelChange struct {
+	SkillID   string
+	SkillName string
+	From      ProficiencyLevel
+	To        ProficiencyLevel
+}
+
+// SkillDiff lists the differences between a snapshot and a user's current skills
+type SkillDiff struct {
+	Added   []SnapshotSkill
+	Removed []SnapshotSkill
+	Leveled []SkillLevelChange
+}
+
+// NewSkillSnapshot records the given skills for username
+// The snapshot ID is derived from the creation time so snapshots sort chronologically
+func NewSkillSnapshot(username string, skills []*UserSkill) *SkillSnapshot {
+	now := time.Now()
+	snapshot := &SkillSnapshot{
+		SnapshotID: strconv.FormatInt(now.UnixNano(), 36),
+		Username:   username,
+		Skills:     make([]SnapshotSkill, len(skills)),
+		CreatedAt:  now,
+	}
+
+	for i, skill := range skills {
+		snapshot.Skills[i] = newSnapshotSkill(skill)
+	}
+
+	snapshot.SetKeys()
+	return snapshot
+}
+
+// SetKeys configures the entity_id for DynamoDB
+func (s *SkillSnapshot) SetKeys() {
+	s.EntityID = BuildSkillSnapshotEntityID(s.Username, s.SnapshotID)
+	s.EntityType = "SkillSnapshot"
+}
+
+// Diff compares the snapshot against current skills, keyed by skill_id
+// Results are sorted by skill_id for stable output
+func (s *SkillSnapshot) Diff(current []*UserSkill) SkillDiff {
+	previous := make(map[string]SnapshotSkill, len(s.Skills))
+	for _, skill := range s.Skills {
+		previous[skill.SkillID] = skill
+	}
+
+	var diff SkillDiff
+	seen := make(map[string]bool, len(current))
+	for _, skill := range current {
+		seen[skill.SkillID] = true
+
+		before, existed := previous[skill.SkillID]
+		switch {
+		case !existed:
+			diff.Added = append(diff.Added, newSnapshotSkill(skill))
+		case before.ProficiencyLevel != skill.ProficiencyLevel:
+			diff.Leveled = append(diff.Leveled, SkillLevelChange{
+				SkillID:   skill.SkillID,
+				SkillName: skill.SkillName,
+				From:      before.ProficiencyLevel,
+				To:        skill.ProficiencyLevel,
+			})
+		}
+	}
+
+	for _, skill := range s.Skills {
+		if !seen[skill.SkillID] {
+			diff.Removed = append(diff.Removed, skill)
+		}
+	}
+
+	sort.Slice(diff.Added, func(i, j int) bool { return diff.Added[i].SkillID < diff.Added[j].SkillID })
+	sort.Slice(diff.Removed, func(i, j int) bool { return diff.Removed[i].SkillID < diff.Removed[j].SkillID })
+	sort.Slice(diff.Leveled, func(i, j int) bool { return diff.Leveled[i].SkillID < diff.Leveled[j].SkillID })
+
+	return diff
+}
+
+// newSnapshotSkill copies the tracked fields of a UserSkill
+func newSnapshotSkill(skill *UserSkill) SnapshotSkill {
+	return SnapshotSkill{
+		SkillID:           skill.SkillID,
+		SkillName:         skill.SkillName,
+		ProficiencyLevel:  skill.ProficiencyLevel,
+		YearsOfExperience: skill.YearsOfExperience,
+	}
+}
@@ -0,0 +1,28 @@
+package models
+
+import "testing"
+
+func TestSkillSnapshot_Diff(t *testing.T) {
+	golang, _ := NewUserSkill("testuser", "go", "Go", "Programming", ProficiencyIntermediate, 2)
+	python, _ := NewUserSkill("testuser", "python", "Python", "Programming", ProficiencyBeginner, 1)
+
+	snapshot := NewSkillSnapshot("testuser", []*UserSkill{golang, python})
+
+	leveledGo, _ := NewUserSkill("testuser", "go", "Go", "Programming", ProficiencyAdvanced, 3)
+	rust, _ := NewUserSkill("testuser", "rust", "Rust", "Programming", ProficiencyBeginner, 0)
+
+	diff := snapshot.Diff([]*UserSkill{leveledGo, rust})
+
+	if len(diff.Added) != 1 || diff.Added[0].SkillID != "rust" {
+		t.Errorf("Expected rust to be added, got %+v", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].SkillID != "python" {
+		t.Errorf("Expected python to be removed, got %+v", diff.Removed)
+	}
+	if len(diff.Leveled) != 1 {
+		t.Fatalf("Expected 1 leveled skill, got %d", len(diff.Leveled))
+	}
+	if change := diff.Leveled[0]; change.SkillID != "go" || change.From != ProficiencyIntermediate || change.To != ProficiencyAdvanced {
+		t.Errorf("Unexpected level change: %+v", change)
+	}
+}
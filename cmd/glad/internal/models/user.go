@@ -1,14 +1,30 @@
 package models
 
 import (
+	"net/mail"
+	"strings"
 	"time"
 
 	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
+	"github.com/hackmajoris/glad-stack/pkg/auth"
 	"github.com/hackmajoris/glad-stack/pkg/errors"
+)
 
-	"golang.org/x/crypto/bcrypt"
+// User account statuses
+const (
+	UserStatusActive   = "active"
+	UserStatusArchived = "archived"
 )
 
+// passwordHasher hashes new passwords; stored hashes are verified by the algorithm they encode
+var passwordHasher auth.PasswordHasher = auth.BcryptHasher{}
+
+// SetPasswordHasher selects the algorithm used for newly hashed passwords
+// It should be called once at startup, before any users are created.
+func SetPasswordHasher(hasher auth.PasswordHasher) {
+	passwordHasher = hasher
+}
+
 // User represents a user in the system (domain model)
 // This entity uses single table design with the following key structure:
 //   - PK: USER#<username>
@@ -19,9 +35,15 @@ type User struct {
 	Name         string    `json:"name" dynamodbav:"Name"`
 	PasswordHash string    `json:"-" dynamodbav:"PasswordHash"`
 	Email        string    `json:"email,omitempty" dynamodbav:"Email,omitempty"`
+	Status       string    `json:"status,omitempty" dynamodbav:"Status,omitempty"` // active or archived; empty means active
+	SkillCount   int       `json:"skill_count" dynamodbav:"SkillCount"`            // maintained by the skill repository alongside each create/delete
 	CreatedAt    time.Time `json:"created_at" dynamodbav:"CreatedAt"`
 	UpdatedAt    time.Time `json:"updated_at" dynamodbav:"UpdatedAt"`
 
+	// Lowercased Username and Name for case-insensitive substring search; maintained by SetKeys
+	UsernameLower string `json:"-" dynamodbav:"UsernameLower"`
+	NameLower     string `json:"-" dynamodbav:"NameLower"`
+
 	// DynamoDB attributes
 	EntityID   string `json:"-" dynamodbav:"entity_id"`            // Unique: USER#<username>
 	EntityType string `json:"entity_type" dynamodbav:"EntityType"` // "User"
@@ -33,7 +55,7 @@ func NewUser(username, name, password string) (*User, error) {
 		return nil, errors.ErrRequiredField
 	}
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	hashedPassword, err := passwordHasher.Hash(password)
 	if err != nil {
 		return nil, err
 	}
@@ -42,7 +64,8 @@ func NewUser(username, name, password string) (*User, error) {
 	user := &User{
 		Username:     username,
 		Name:         name,
-		PasswordHash: string(hashedPassword),
+		PasswordHash: hashedPassword,
+		Status:       UserStatusActive,
 		CreatedAt:    now,
 		UpdatedAt:    now,
 		EntityType:   "User",
@@ -54,9 +77,12 @@ func NewUser(username, name, password string) (*User, error) {
 	return user, nil
 }
 
+// SetKeys configures the entity_id for DynamoDB and refreshes denormalized search attributes
 func (u *User) SetKeys() {
 	u.EntityID = BuildUserEntityID(u.Username)
 	u.EntityType = "User"
+	u.UsernameLower = strings.ToLower(u.Username)
+	u.NameLower = strings.ToLower(u.Name)
 }
 
 // UpdateName updates the user's name
@@ -74,18 +100,62 @@ func (u *User) UpdatePassword(password string) error {
 	if len(password) < 6 {
 		return apperrors.ErrInvalidPassword
 	}
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	hashedPassword, err := passwordHasher.Hash(password)
 	if err != nil {
 		return err
 	}
-	u.PasswordHash = string(hashedPassword)
+	u.PasswordHash = hashedPassword
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// maxEmailLength is the longest address accepted, per RFC 5321
+const maxEmailLength = 254
+
+// ValidEmail reports whether email is a bare address such as jane@example.com
+// Display names ("Jane <jane@example.com>") and addresses without a dotted domain are rejected.
+func ValidEmail(email string) bool {
+	if email == "" || len(email) > maxEmailLength {
+		return false
+	}
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return false
+	}
+	domain := email[strings.LastIndex(email, "@")+1:]
+	return strings.Contains(domain, ".") && !strings.HasPrefix(domain, ".") && !strings.HasSuffix(domain, ".")
+}
+
+// UpdateEmail updates the user's email address
+func (u *User) UpdateEmail(email string) error {
+	if !ValidEmail(email) {
+		return apperrors.ErrInvalidEmail
+	}
+	u.Email = email
 	u.UpdatedAt = time.Now()
 	return nil
 }
 
 // ValidatePassword checks if the provided password matches the user's password
 func (u *User) ValidatePassword(password string) bool {
-	return bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)) == nil
+	return auth.VerifyPassword(u.PasswordHash, password)
+}
+
+// IsArchived reports whether the account has been deactivated
+func (u *User) IsArchived() bool {
+	return u.Status == UserStatusArchived
+}
+
+// Archive deactivates the account without deleting any data
+func (u *User) Archive() {
+	u.Status = UserStatusArchived
+	u.UpdatedAt = time.Now()
+}
+
+// Reactivate restores an archived account
+func (u *User) Reactivate() {
+	u.Status = UserStatusActive
+	u.UpdatedAt = time.Now()
 }
 
 // GetUsername returns the username (implements auth.User interface)
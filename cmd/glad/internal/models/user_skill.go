@@ -1,9 +1,12 @@
 package models
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
+	"github.com/hackmajoris/glad-stack/pkg/dateutil"
 	"github.com/hackmajoris/glad-stack/pkg/errors"
 )
 
@@ -25,6 +28,63 @@ var validProficiencyLevels = map[ProficiencyLevel]bool{
 	ProficiencyExpert:       true,
 }
 
+// ProficiencyLevels returns all proficiency levels from lowest to highest
+func ProficiencyLevels() []ProficiencyLevel {
+	return append([]ProficiencyLevel(nil), proficiencyOrder...)
+}
+
+// InvalidProficiencyLevelError reports a proficiency level outside the known levels
+// It wraps apperrors.ErrInvalidProficiencyLevel so callers can keep matching on that.
+type InvalidProficiencyLevelError struct {
+	Value string
+}
+
+func (e *InvalidProficiencyLevelError) Error() string {
+	levels := make([]string, len(proficiencyOrder))
+	for i, level := range proficiencyOrder {
+		levels[i] = string(level)
+	}
+	allowed := strings.Join(levels, ", ")
+
+	if e.Value == "" {
+		return "proficiency level is required; must be one of " + allowed
+	}
+	return fmt.Sprintf("invalid proficiency level %q; must be one of %s", e.Value, allowed)
+}
+
+func (e *InvalidProficiencyLevelError) Unwrap() error {
+	return apperrors.ErrInvalidProficiencyLevel
+}
+
+// ParseProficiencyLevel converts s to a ProficiencyLevel, returning an
+// *InvalidProficiencyLevelError unless it names one of the levels exactly
+func ParseProficiencyLevel(s string) (ProficiencyLevel, error) {
+	level := ProficiencyLevel(s)
+	if !validProficiencyLevels[level] {
+		return "", &InvalidProficiencyLevelError{Value: s}
+	}
+	return level, nil
+}
+
+// Next returns the level directly above l; ok is false for Expert and unknown levels
+func (l ProficiencyLevel) Next() (next ProficiencyLevel, ok bool) {
+	rank := l.Rank()
+	if rank < 0 || rank == len(proficiencyOrder)-1 {
+		return "", false
+	}
+	return proficiencyOrder[rank+1], true
+}
+
+// Rank returns the level's position from lowest (0) to highest, or -1 for an unknown level
+func (l ProficiencyLevel) Rank() int {
+	for i, level := range proficiencyOrder {
+		if level == l {
+			return i
+		}
+	}
+	return -1
+}
+
 // UserSkill represents a skill associated with a user (domain model)
 // This entity uses single table design with multi-attribute composite keys:
 //   - entity_id: USERSKILL#<username>#<skill_id>
@@ -34,6 +94,7 @@ var validProficiencyLevels = map[ProficiencyLevel]bool{
 //
 // GSI SkillsByLevel uses: SkillName + ProficiencyLevel + YearsOfExperience + Username
 // GSI ByUser uses: Username + EntityType
+// GSI RecentlyUpdated uses: RecentFeed + UpdatedAt
 type UserSkill struct {
 	// Business attributes - used directly in GSI composite keys
 	Username          string           `json:"username" dynamodbav:"Username"`
@@ -43,17 +104,24 @@ type UserSkill struct {
 	ProficiencyLevel  ProficiencyLevel `json:"proficiency_level" dynamodbav:"ProficiencyLevel"`
 	YearsOfExperience int              `json:"years_of_experience" dynamodbav:"YearsOfExperience"`
 	Endorsements      int              `json:"endorsements" dynamodbav:"Endorsements"`
-	LastUsedDate      string           `json:"last_used_date" dynamodbav:"LastUsedDate"` // ISO 8601 format
+	LastUsedDate      string           `json:"last_used_date" dynamodbav:"LastUsedDate"` // ISO 8601 date in UTC
 	Notes             string           `json:"notes,omitempty" dynamodbav:"Notes,omitempty"`
 	CreatedAt         time.Time        `json:"created_at" dynamodbav:"CreatedAt"`
 	UpdatedAt         time.Time        `json:"updated_at" dynamodbav:"UpdatedAt"`
 
+	// PromotionEligible is set when endorsements qualify the skill for the next level; it is not persisted
+	PromotionEligible bool `json:"-" dynamodbav:"-"`
+
 	// DynamoDB attributes
 	EntityID           string `json:"-" dynamodbav:"entity_id"`
 	EntityType         string `json:"entity_type" dynamodbav:"EntityType"`
 	SkillCompositeSort string `json:"-" dynamodbav:"SkillCompositeSort"`
+	RecentFeed         string `json:"-" dynamodbav:"RecentFeed"` // constant partition of the RecentlyUpdated GSI
 }
 
+// RecentFeedUserSkills is the RecentFeed partition shared by every user skill
+const RecentFeedUserSkills = "UserSkill"
+
 // NewUserSkill creates a new UserSkill with proper validation
 // skillID: Immutable skill identifier (e.g., "python")
 // skillName: Display name (e.g., "Python") - denormalized from master Skill
@@ -63,6 +131,7 @@ func NewUserSkill(username, skillID, skillName, category string, proficiencyLeve
 		return nil, errors.ErrRequiredField
 	}
 
+	skillName = NormalizeSkillName(skillName)
 	if skillID == "" || skillName == "" {
 		return nil, errors.ErrRequiredField
 	}
@@ -84,7 +153,7 @@ func NewUserSkill(username, skillID, skillName, category string, proficiencyLeve
 		ProficiencyLevel:  proficiencyLevel,
 		YearsOfExperience: yearsOfExperience,
 		Endorsements:      0,
-		LastUsedDate:      now.Format("2006-01-02"), // ISO 8601 date format
+		LastUsedDate:      dateutil.FormatDate(now), // ISO 8601 date, always UTC
 		CreatedAt:         now,
 		UpdatedAt:         now,
 		EntityType:        "UserSkill",
@@ -100,6 +169,9 @@ func (s *UserSkill) SetKeys() {
 	// Base table key: Unique identifier
 	s.EntityID = BuildUserSkillEntityID(s.Username, s.SkillID)
 	s.EntityType = "UserSkill"
+
+	// RecentlyUpdated GSI: every skill shares one partition, sorted by UpdatedAt
+	s.RecentFeed = RecentFeedUserSkills
 }
 
 // UpdateProficiency updates the skill proficiency level
@@ -126,9 +198,9 @@ func (s *UserSkill) UpdateYearsOfExperience(years int) error {
 	return nil
 }
 
-// UpdateLastUsed updates the last used date to now
+// UpdateLastUsed updates the last used date to today (UTC)
 func (s *UserSkill) UpdateLastUsed() {
-	s.LastUsedDate = time.Now().Format("2006-01-02")
+	s.LastUsedDate = dateutil.Today()
 	s.UpdatedAt = time.Now()
 }
 
@@ -138,6 +210,14 @@ func (s *UserSkill) AddEndorsement() {
 	s.UpdatedAt = time.Now()
 }
 
+// RemoveEndorsement decrements the endorsement count, never below zero
+func (s *UserSkill) RemoveEndorsement() {
+	if s.Endorsements > 0 {
+		s.Endorsements--
+	}
+	s.UpdatedAt = time.Now()
+}
+
 // UpdateNotes updates the skill notes
 func (s *UserSkill) UpdateNotes(notes string) {
 	s.Notes = notes
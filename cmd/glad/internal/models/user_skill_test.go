@@ -0,0 +1,53 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
+)
+
+func TestParseProficiencyLevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    ProficiencyLevel
+		wantErr bool
+	}{
+		{name: "beginner", input: "Beginner", want: ProficiencyBeginner},
+		{name: "intermediate", input: "Intermediate", want: ProficiencyIntermediate},
+		{name: "advanced", input: "Advanced", want: ProficiencyAdvanced},
+		{name: "expert", input: "Expert", want: ProficiencyExpert},
+		{name: "unknown level", input: "Guru", wantErr: true},
+		{name: "wrong case", input: "expert", wantErr: true},
+		{name: "surrounding spaces", input: " Expert ", wantErr: true},
+		{name: "empty string", input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseProficiencyLevel(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseProficiencyLevel(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				if got != tt.want {
+					t.Errorf("Expected %s, got %s", tt.want, got)
+				}
+				return
+			}
+
+			var levelErr *InvalidProficiencyLevelError
+			if !errors.As(err, &levelErr) || levelErr.Value != tt.input {
+				t.Errorf("Expected *InvalidProficiencyLevelError for %q, got %v", tt.input, err)
+			}
+			if !errors.Is(err, apperrors.ErrInvalidProficiencyLevel) {
+				t.Errorf("Expected error to wrap ErrInvalidProficiencyLevel, got %v", err)
+			}
+			if !strings.Contains(err.Error(), "Beginner, Intermediate, Advanced, Expert") {
+				t.Errorf("Expected error to list the allowed levels, got %q", err.Error())
+			}
+		})
+	}
+}
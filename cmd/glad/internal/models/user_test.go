@@ -1,8 +1,11 @@
 package models
 
 import (
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/hackmajoris/glad-stack/pkg/auth"
 )
 
 func TestNewUser(t *testing.T) {
@@ -159,6 +162,40 @@ func TestUser_UpdateName(t *testing.T) {
 	}
 }
 
+func TestUser_UpdateEmail(t *testing.T) {
+	tests := []struct {
+		name    string
+		email   string
+		wantErr bool
+	}{
+		{name: "valid email", email: "jane.doe@example.com", wantErr: false},
+		{name: "subdomain and plus tag", email: "jane+skills@mail.example.co.uk", wantErr: false},
+		{name: "empty", email: "", wantErr: true},
+		{name: "missing at sign", email: "jane.example.com", wantErr: true},
+		{name: "missing domain dot", email: "jane@localhost", wantErr: true},
+		{name: "trailing domain dot", email: "jane@example.", wantErr: true},
+		{name: "display name", email: "Jane <jane@example.com>", wantErr: true},
+		{name: "surrounding spaces", email: " jane@example.com ", wantErr: true},
+		{name: "too long", email: strings.Repeat("a", 250) + "@example.com", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, _ := NewUser("testuser", "Test User", "password123")
+			err := user.UpdateEmail(tt.email)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("User.UpdateEmail() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && user.Email != tt.email {
+				t.Errorf("Expected email %s, got %s", tt.email, user.Email)
+			}
+			if tt.wantErr && user.Email != "" {
+				t.Errorf("Expected email to stay empty on error, got %s", user.Email)
+			}
+		})
+	}
+}
+
 func TestUser_UpdatePassword(t *testing.T) {
 	user, err := NewUser("testuser", "Test User", "password123")
 	if err != nil {
@@ -215,3 +252,44 @@ func TestUser_GetUsername(t *testing.T) {
 		t.Errorf("User.GetUsername() = %v, want %v", got, "testuser")
 	}
 }
+
+func TestUser_PasswordHasherSwitch(t *testing.T) {
+	t.Cleanup(func() { SetPasswordHasher(auth.BcryptHasher{}) })
+
+	SetPasswordHasher(auth.BcryptHasher{})
+	legacy, err := NewUser("legacyuser", "Legacy User", "password123")
+	if err != nil {
+		t.Fatalf("NewUser() error = %v", err)
+	}
+	if !strings.HasPrefix(legacy.PasswordHash, "$2a$") {
+		t.Fatalf("Expected bcrypt hash, got %q", legacy.PasswordHash)
+	}
+
+	// Switching the default must not invalidate existing bcrypt hashes
+	SetPasswordHasher(auth.NewArgon2idHasher())
+	if !legacy.ValidatePassword("password123") {
+		t.Error("Expected bcrypt-hashed password to verify after switching to argon2id")
+	}
+	if legacy.ValidatePassword("wrongpassword") {
+		t.Error("Expected wrong password to be rejected")
+	}
+
+	user, err := NewUser("newuser", "New User", "password123")
+	if err != nil {
+		t.Fatalf("NewUser() error = %v", err)
+	}
+	if !strings.HasPrefix(user.PasswordHash, "$argon2id$") {
+		t.Errorf("Expected argon2id hash, got %q", user.PasswordHash)
+	}
+	if !user.ValidatePassword("password123") {
+		t.Error("Expected argon2id-hashed password to verify")
+	}
+
+	// Password changes rehash with the current default
+	if err := legacy.UpdatePassword("newpassword456"); err != nil {
+		t.Fatalf("UpdatePassword() error = %v", err)
+	}
+	if !strings.HasPrefix(legacy.PasswordHash, "$argon2id$") {
+		t.Errorf("Expected updated password to use argon2id, got %q", legacy.PasswordHash)
+	}
+}
@@ -22,3 +22,64 @@ func BuildMasterSkillEntityID(skillID string) string {
 func BuildUserSkillEntityID(username, skillID string) string {
 	return fmt.Sprintf("USERSKILL#%s#%s", username, skillID)
 }
+
+// BuildSkillSnapshotEntityID constructs the entity_id for a Skill Snapshot
+// Format: SNAPSHOT#<username>#<snapshot_id>
+func BuildSkillSnapshotEntityID(username, snapshotID string) string {
+	return fmt.Sprintf("%s%s", BuildUserSnapshotPrefix(username), snapshotID)
+}
+
+// BuildUserSnapshotPrefix constructs the entity_id prefix shared by a user's snapshots
+// Format: SNAPSHOT#<username>#
+func BuildUserSnapshotPrefix(username string) string {
+	return fmt.Sprintf("SNAPSHOT#%s#", username)
+}
+
+// BuildSkillHistoryEntityID constructs the entity_id for a Skill History entry
+// Format: SKILLHISTORY#<username>#<skill_id>#<timestamp>
+func BuildSkillHistoryEntityID(username, skillID, timestamp string) string {
+	return fmt.Sprintf("%s%s", BuildSkillHistoryPrefix(username, skillID), timestamp)
+}
+
+// BuildSkillHistoryPrefix constructs the entity_id prefix shared by a skill's history entries
+// Format: SKILLHISTORY#<username>#<skill_id>#
+func BuildSkillHistoryPrefix(username, skillID string) string {
+	return fmt.Sprintf("%s%s#", BuildUserSkillHistoryPrefix(username), skillID)
+}
+
+// BuildUserSkillHistoryPrefix constructs the entity_id prefix shared by all of a user's history entries
+// Format: SKILLHISTORY#<username>#
+func BuildUserSkillHistoryPrefix(username string) string {
+	return fmt.Sprintf("SKILLHISTORY#%s#", username)
+}
+
+// BuildEndorsementEntityID constructs the entity_id for an Endorsement
+// Format: ENDORSEMENT#<username>#<skill_id>#<endorser>
+func BuildEndorsementEntityID(username, skillID, endorser string) string {
+	return fmt.Sprintf("%s%s", BuildSkillEndorsementPrefix(username, skillID), endorser)
+}
+
+// BuildSkillEndorsementPrefix constructs the entity_id prefix shared by a skill's endorsements
+// Format: ENDORSEMENT#<username>#<skill_id>#
+func BuildSkillEndorsementPrefix(username, skillID string) string {
+	return fmt.Sprintf("%s%s#", BuildUserEndorsementPrefix(username), skillID)
+}
+
+// BuildUserEndorsementPrefix constructs the entity_id prefix shared by all endorsements a user received
+// Format: ENDORSEMENT#<username>#
+func BuildUserEndorsementPrefix(username string) string {
+	return fmt.Sprintf("ENDORSEMENT#%s#", username)
+}
+
+// BuildActivityEntityID constructs the entity_id for an Activity Log entry
+// The skill_id suffix keeps entries recorded in the same instant, as in batch writes, apart.
+// Format: ACTIVITY#<username>#<timestamp>#<skill_id>
+func BuildActivityEntityID(username, timestamp, skillID string) string {
+	return fmt.Sprintf("%s%s#%s", BuildUserActivityPrefix(username), timestamp, skillID)
+}
+
+// BuildUserActivityPrefix constructs the entity_id prefix shared by all of a user's activity entries
+// Format: ACTIVITY#<username>#
+func BuildUserActivityPrefix(username string) string {
+	return fmt.Sprintf("ACTIVITY#%s#", username)
+}
@@ -2,6 +2,11 @@ package router
 
 import (
 	"net/http"
+	"reflect"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
 
 	"github.com/hackmajoris/glad-stack/pkg/middleware"
 
@@ -22,18 +27,68 @@ type Route struct {
 	Middleware []Middleware
 }
 
+// RouteInfo describes a registered route
+type RouteInfo struct {
+	Method       string   `json:"method"`
+	Path         string   `json:"path"`
+	AuthRequired bool     `json:"auth_required"`
+	Middleware   []string `json:"middleware"`
+}
+
+// PreflightFunc returns the CORS headers for an OPTIONS request, or none when its origin is not allowed
+type PreflightFunc func(events.APIGatewayProxyRequest) map[string]string
+
+// Registrar registers routes; implemented by Router and Group
+type Registrar interface {
+	GET(path string, handler HandlerFunc, middleware ...Middleware)
+	POST(path string, handler HandlerFunc, middleware ...Middleware)
+	PUT(path string, handler HandlerFunc, middleware ...Middleware)
+	PATCH(path string, handler HandlerFunc, middleware ...Middleware)
+	DELETE(path string, handler HandlerFunc, middleware ...Middleware)
+}
+
+// vendorMediaType matches versioned Accept values such as application/vnd.glad.v1+json
+var vendorMediaType = regexp.MustCompile(`^application/vnd\.glad\.(v[0-9]+)\+json$`)
+
 // Router handles HTTP routing for Lambda
 type Router struct {
-	routes map[string]map[string]Route // path -> method -> route
+	routes     map[string]map[string]Route // path -> method -> route
+	middleware []Middleware                // applied to every request, including unmatched ones
+	versions   map[string]bool             // API versions selectable via the Accept header
+	preflight  PreflightFunc               // CORS headers for OPTIONS responses; nil adds none
 }
 
 // New creates a new Router
 func New() *Router {
 	return &Router{
-		routes: make(map[string]map[string]Route),
+		routes:   make(map[string]map[string]Route),
+		versions: make(map[string]bool),
 	}
 }
 
+// Group returns a Registrar that registers routes under prefix
+func (r *Router) Group(prefix string) *Group {
+	return &Group{router: r, prefix: strings.TrimSuffix(prefix, "/")}
+}
+
+// Version returns a Group for routes under /<version> (e.g. "v1").
+// Requests may select the version either by path prefix or with an
+// Accept: application/vnd.glad.<version>+json header on the unprefixed path.
+func (r *Router) Version(version string) *Group {
+	r.versions[version] = true
+	return r.Group("/" + version)
+}
+
+// Use registers middleware that wraps every request handled by the router
+func (r *Router) Use(middleware ...Middleware) {
+	r.middleware = append(r.middleware, middleware...)
+}
+
+// Preflight sets the CORS headers added to the router's OPTIONS responses
+func (r *Router) Preflight(headers PreflightFunc) {
+	r.preflight = headers
+}
+
 // Handle registers a route with optional middleware
 func (r *Router) Handle(method, path string, handler HandlerFunc, middleware ...Middleware) {
 	if r.routes[path] == nil {
@@ -48,6 +103,122 @@ func (r *Router) Handle(method, path string, handler HandlerFunc, middleware ...
 	}
 }
 
+// Routes returns the registered routes sorted by path and method
+// Middleware are named after the function that produced them (e.g. "AuthMiddleware.RequireAdmin");
+// a route requires auth when any of its middleware comes from AuthMiddleware.
+func (r *Router) Routes() []RouteInfo {
+	routes := make([]RouteInfo, 0, len(r.routes))
+	for _, methods := range r.routes {
+		for _, route := range methods {
+			info := RouteInfo{Method: route.Method, Path: route.Path, Middleware: make([]string, 0, len(route.Middleware))}
+			for _, mw := range route.Middleware {
+				name := middlewareName(mw)
+				info.Middleware = append(info.Middleware, name)
+				if strings.HasPrefix(name, "AuthMiddleware.") {
+					info.AuthRequired = true
+				}
+			}
+			routes = append(routes, info)
+		}
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+	return routes
+}
+
+// Match resolves a concrete path such as /users/alice/skills to the registered resource
+// pattern (/users/{username}/skills) and its path parameters, the way API Gateway fills
+// Resource and PathParameters. Literal segments win over parameters, so /master-skills/search
+// matches its own route rather than /master-skills/{skillID}.
+func (r *Router) Match(path string) (resource string, params map[string]string, ok bool) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	var best []string
+	for pattern := range r.routes {
+		candidate := strings.Split(strings.Trim(pattern, "/"), "/")
+		if len(candidate) != len(segments) || !segmentsMatch(candidate, segments) {
+			continue
+		}
+		if best == nil || moreSpecific(candidate, best) {
+			best = candidate
+		}
+	}
+	if best == nil {
+		return "", nil, false
+	}
+
+	params = make(map[string]string)
+	for i, segment := range best {
+		if isParam(segment) {
+			params[segment[1:len(segment)-1]] = segments[i]
+		}
+	}
+	return "/" + strings.Join(best, "/"), params, true
+}
+
+// segmentsMatch reports whether every literal pattern segment equals the path segment
+func segmentsMatch(pattern, segments []string) bool {
+	for i, segment := range pattern {
+		if !isParam(segment) && segment != segments[i] {
+			return false
+		}
+		if isParam(segment) && segments[i] == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// moreSpecific reports whether a has a literal segment where b first has a parameter
+func moreSpecific(a, b []string) bool {
+	for i := range a {
+		if isParam(a[i]) != isParam(b[i]) {
+			return !isParam(a[i])
+		}
+	}
+	return false
+}
+
+// isParam reports whether a pattern segment is a {parameter}
+func isParam(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+// middlewareName derives a readable name from the middleware's function symbol, e.g.
+// ".../middleware.(*AuthMiddleware).ValidateJWT-fm" becomes "AuthMiddleware.ValidateJWT"
+func middlewareName(mw Middleware) string {
+	fn := runtime.FuncForPC(reflect.ValueOf(mw).Pointer())
+	if fn == nil {
+		return "unknown"
+	}
+
+	name := fn.Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	name = strings.TrimSuffix(name, "-fm")
+	name = strings.NewReplacer("(*", "", ")", "").Replace(name)
+
+	// Drop the package and any closure suffixes (.func1, .func1.2, ...)
+	parts := strings.Split(name, ".")[1:]
+	for len(parts) > 1 && (strings.HasPrefix(parts[len(parts)-1], "func") || isDigits(parts[len(parts)-1])) {
+		parts = parts[:len(parts)-1]
+	}
+	return strings.Join(parts, ".")
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
 // GET registers a GET route
 func (r *Router) GET(path string, handler HandlerFunc, middleware ...Middleware) {
 	r.Handle(http.MethodGet, path, handler, middleware...)
@@ -63,22 +234,89 @@ func (r *Router) PUT(path string, handler HandlerFunc, middleware ...Middleware)
 	r.Handle(http.MethodPut, path, handler, middleware...)
 }
 
+// PATCH registers a PATCH route
+func (r *Router) PATCH(path string, handler HandlerFunc, middleware ...Middleware) {
+	r.Handle(http.MethodPatch, path, handler, middleware...)
+}
+
 // DELETE registers a DELETE route
 func (r *Router) DELETE(path string, handler HandlerFunc, middleware ...Middleware) {
 	r.Handle(http.MethodDelete, path, handler, middleware...)
 }
 
+// Group registers routes under a common path prefix
+type Group struct {
+	router *Router
+	prefix string
+}
+
+// GET registers a GET route under the group prefix
+func (g *Group) GET(path string, handler HandlerFunc, middleware ...Middleware) {
+	g.router.Handle(http.MethodGet, g.prefix+path, handler, middleware...)
+}
+
+// POST registers a POST route under the group prefix
+func (g *Group) POST(path string, handler HandlerFunc, middleware ...Middleware) {
+	g.router.Handle(http.MethodPost, g.prefix+path, handler, middleware...)
+}
+
+// PUT registers a PUT route under the group prefix
+func (g *Group) PUT(path string, handler HandlerFunc, middleware ...Middleware) {
+	g.router.Handle(http.MethodPut, g.prefix+path, handler, middleware...)
+}
+
+// PATCH registers a PATCH route under the group prefix
+func (g *Group) PATCH(path string, handler HandlerFunc, middleware ...Middleware) {
+	g.router.Handle(http.MethodPatch, g.prefix+path, handler, middleware...)
+}
+
+// DELETE registers a DELETE route under the group prefix
+func (g *Group) DELETE(path string, handler HandlerFunc, middleware ...Middleware) {
+	g.router.Handle(http.MethodDelete, g.prefix+path, handler, middleware...)
+}
+
 // Route handles an incoming request
+// OPTIONS requests for paths without an explicit OPTIONS route are answered before any
+// middleware, the way API Gateway answers CORS preflights without invoking the Lambda.
 func (r *Router) Route(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if request.HTTPMethod == http.MethodOptions {
+		if _, explicit := r.routes[request.Resource][http.MethodOptions]; !explicit {
+			return r.options(request), nil
+		}
+	}
+
+	// Apply global middleware in reverse order (first registered is outermost)
+	handler := HandlerFunc(r.dispatch)
+	for i := len(r.middleware) - 1; i >= 0; i-- {
+		handler = r.middleware[i](handler)
+	}
+
+	return handler(request)
+}
+
+// dispatch matches the request to a registered route and invokes it
+func (r *Router) dispatch(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	// Use Resource instead of Path to match route patterns (handles stage prefix)
-	pathRoutes, exists := r.routes[request.Resource]
+	resource := request.Resource
+
+	// A vendor Accept header selects a version for unprefixed paths
+	if version := acceptedVersion(request.Headers); version != "" {
+		if !r.versions[version] {
+			return NotAcceptableResponse(), nil
+		}
+		if !strings.HasPrefix(resource, "/"+version+"/") {
+			resource = "/" + version + resource
+		}
+	}
+
+	pathRoutes, exists := r.routes[resource]
 	if !exists {
 		return NotFoundResponse(), nil
 	}
 
 	route, exists := pathRoutes[request.HTTPMethod]
 	if !exists {
-		return MethodNotAllowedResponse(), nil
+		return MethodNotAllowedResponse(allowedMethods(pathRoutes)...), nil
 	}
 
 	// Apply middleware in reverse order (last registered runs first around handler)
@@ -90,6 +328,53 @@ func (r *Router) Route(request events.APIGatewayProxyRequest) (events.APIGateway
 	return handler(request)
 }
 
+// options answers an OPTIONS request with 204, the path's methods in the Allow header and the
+// preflight CORS headers; unknown paths get 404
+func (r *Router) options(request events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
+	pathRoutes, exists := r.routes[request.Resource]
+	if !exists {
+		return NotFoundResponse()
+	}
+
+	headers := map[string]string{"Allow": strings.Join(allowedMethods(pathRoutes), ", ")}
+	if r.preflight != nil {
+		for name, value := range r.preflight(request) {
+			headers[name] = value
+		}
+	}
+	return events.APIGatewayProxyResponse{StatusCode: http.StatusNoContent, Headers: headers}
+}
+
+// allowedMethods lists the methods registered for a path in sorted order, plus OPTIONS, which
+// Route answers for every known path
+func allowedMethods(pathRoutes map[string]Route) []string {
+	allowed := make([]string, 0, len(pathRoutes)+1)
+	if _, explicit := pathRoutes[http.MethodOptions]; !explicit {
+		allowed = append(allowed, http.MethodOptions)
+	}
+	for method := range pathRoutes {
+		allowed = append(allowed, method)
+	}
+	sort.Strings(allowed)
+	return allowed
+}
+
+// acceptedVersion returns the API version requested via a vendor media type in the Accept header
+func acceptedVersion(headers map[string]string) string {
+	accept := headers["Accept"]
+	if accept == "" {
+		accept = headers["accept"]
+	}
+
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, _, _ := strings.Cut(mediaRange, ";")
+		if match := vendorMediaType.FindStringSubmatch(strings.TrimSpace(mediaType)); match != nil {
+			return match[1]
+		}
+	}
+	return ""
+}
+
 // NotFoundResponse returns a 404 response
 func NotFoundResponse() events.APIGatewayProxyResponse {
 	return events.APIGatewayProxyResponse{
@@ -97,17 +382,32 @@ func NotFoundResponse() events.APIGatewayProxyResponse {
 		Headers: map[string]string{
 			"Content-Type": "application/json",
 		},
-		Body: `{"error": "Not Found"}`,
+		Body: `{"error": "Not Found", "code": "NOT_FOUND"}`,
 	}
 }
 
-// MethodNotAllowedResponse returns a 405 response
-func MethodNotAllowedResponse() events.APIGatewayProxyResponse {
-	return events.APIGatewayProxyResponse{
+// MethodNotAllowedResponse returns a 405 response listing the allowed methods in the Allow header
+func MethodNotAllowedResponse(allowed ...string) events.APIGatewayProxyResponse {
+	response := events.APIGatewayProxyResponse{
 		StatusCode: http.StatusMethodNotAllowed,
 		Headers: map[string]string{
 			"Content-Type": "application/json",
 		},
-		Body: `{"error": "Method Not Allowed"}`,
+		Body: `{"error": "Method Not Allowed", "code": "METHOD_NOT_ALLOWED"}`,
+	}
+	if len(allowed) > 0 {
+		response.Headers["Allow"] = strings.Join(allowed, ", ")
+	}
+	return response
+}
+
+// NotAcceptableResponse returns a 406 response for unsupported API versions
+func NotAcceptableResponse() events.APIGatewayProxyResponse {
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusNotAcceptable,
+		Headers: map[string]string{
+			"Content-Type": "application/json",
+		},
+		Body: `{"error": "Not Acceptable", "code": "NOT_ACCEPTABLE"}`,
 	}
 }
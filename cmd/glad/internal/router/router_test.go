@@ -0,0 +1,341 @@
+package router
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hackmajoris/glad-stack/pkg/auth"
+	"github.com/hackmajoris/glad-stack/pkg/config"
+	"github.com/hackmajoris/glad-stack/pkg/middleware"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+func namedHandler(name string) HandlerFunc {
+	return func(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusOK, Body: name}, nil
+	}
+}
+
+func TestRouter_Versioning(t *testing.T) {
+	r := New()
+	r.GET("/users", namedHandler("unversioned"))
+	r.Version("v1").GET("/users", namedHandler("v1"))
+
+	tests := []struct {
+		name           string
+		resource       string
+		headers        map[string]string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "unversioned path",
+			resource:       "/users",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "unversioned",
+		},
+		{
+			name:           "v1 path prefix",
+			resource:       "/v1/users",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "v1",
+		},
+		{
+			name:           "vendor accept header",
+			resource:       "/users",
+			headers:        map[string]string{"Accept": "application/vnd.glad.v1+json"},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "v1",
+		},
+		{
+			name:           "lowercase accept header with params and alternatives",
+			resource:       "/users",
+			headers:        map[string]string{"accept": "text/html, application/vnd.glad.v1+json; q=0.9"},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "v1",
+		},
+		{
+			name:           "vendor accept header on prefixed path",
+			resource:       "/v1/users",
+			headers:        map[string]string{"Accept": "application/vnd.glad.v1+json"},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "v1",
+		},
+		{
+			name:           "generic accept header",
+			resource:       "/users",
+			headers:        map[string]string{"Accept": "application/json"},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "unversioned",
+		},
+		{
+			name:           "unknown version",
+			resource:       "/users",
+			headers:        map[string]string{"Accept": "application/vnd.glad.v2+json"},
+			expectedStatus: http.StatusNotAcceptable,
+		},
+		{
+			name:           "unknown versioned path",
+			resource:       "/v2/users",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := r.Route(events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodGet,
+				Resource:   tt.resource,
+				Headers:    tt.headers,
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if response.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, response.StatusCode)
+			}
+			if tt.expectedBody != "" && response.Body != tt.expectedBody {
+				t.Errorf("Expected body %q, got %q", tt.expectedBody, response.Body)
+			}
+		})
+	}
+}
+
+func TestRouter_GroupMiddleware(t *testing.T) {
+	r := New()
+	blocked := func(next HandlerFunc) HandlerFunc {
+		return func(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			return events.APIGatewayProxyResponse{StatusCode: http.StatusUnauthorized}, nil
+		}
+	}
+	r.Group("/v1/").POST("/users", namedHandler("v1"), blocked)
+
+	response, _ := r.Route(events.APIGatewayProxyRequest{HTTPMethod: http.MethodPost, Resource: "/v1/users"})
+	if response.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, response.StatusCode)
+	}
+
+	response, _ = r.Route(events.APIGatewayProxyRequest{HTTPMethod: http.MethodGet, Resource: "/v1/users"})
+	if response.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status %d, got %d", http.StatusMethodNotAllowed, response.StatusCode)
+	}
+}
+
+func TestRouter_NotFoundVersusMethodNotAllowed(t *testing.T) {
+	r := New()
+	r.GET("/users", namedHandler("list"))
+	r.GET("/users/{username}", namedHandler("get"))
+	r.PUT("/users/{username}", namedHandler("update"))
+	r.DELETE("/users/{username}", namedHandler("delete"))
+
+	tests := []struct {
+		name           string
+		method         string
+		resource       string
+		expectedStatus int
+		expectedAllow  string
+	}{
+		{name: "known path and method", method: http.MethodGet, resource: "/users", expectedStatus: http.StatusOK},
+		{name: "known path, unknown method", method: http.MethodPost, resource: "/users", expectedStatus: http.StatusMethodNotAllowed, expectedAllow: "GET, OPTIONS"},
+		{name: "several allowed methods", method: http.MethodPost, resource: "/users/{username}", expectedStatus: http.StatusMethodNotAllowed, expectedAllow: "DELETE, GET, OPTIONS, PUT"},
+		{name: "unknown path", method: http.MethodGet, resource: "/nonexistent", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := r.Route(events.APIGatewayProxyRequest{HTTPMethod: tt.method, Resource: tt.resource})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if response.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, response.StatusCode)
+			}
+			if allow := response.Headers["Allow"]; allow != tt.expectedAllow {
+				t.Errorf("Expected Allow header %q, got %q", tt.expectedAllow, allow)
+			}
+		})
+	}
+}
+
+func TestRouter_Options(t *testing.T) {
+	cors, err := middleware.NewCORSMiddleware([]string{"https://app.example.com"}, true, false)
+	if err != nil {
+		t.Fatalf("Failed to create CORS middleware: %v", err)
+	}
+
+	called := false
+	r := New()
+	r.Use(func(next HandlerFunc) HandlerFunc {
+		return func(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			called = true
+			return next(request)
+		}
+	})
+	r.Preflight(cors.PreflightHeaders)
+	r.GET("/users/{username}/skills", namedHandler("list"))
+	r.POST("/users/{username}/skills", namedHandler("add"))
+	r.DELETE("/users/{username}/skills", namedHandler("delete"))
+
+	tests := []struct {
+		name           string
+		resource       string
+		origin         string
+		expectedStatus int
+		expectedAllow  string
+		expectedACAO   string
+	}{
+		{
+			name:           "registered path from an allowed origin",
+			resource:       "/users/{username}/skills",
+			origin:         "https://app.example.com",
+			expectedStatus: http.StatusNoContent,
+			expectedAllow:  "DELETE, GET, OPTIONS, POST",
+			expectedACAO:   "https://app.example.com",
+		},
+		{
+			name:           "registered path from a disallowed origin",
+			resource:       "/users/{username}/skills",
+			origin:         "https://evil.example.com",
+			expectedStatus: http.StatusNoContent,
+			expectedAllow:  "DELETE, GET, OPTIONS, POST",
+		},
+		{
+			name:           "unknown path",
+			resource:       "/nonexistent",
+			origin:         "https://app.example.com",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := r.Route(events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodOptions,
+				Resource:   tt.resource,
+				Headers: map[string]string{
+					"Origin":                        tt.origin,
+					"Access-Control-Request-Method": "DELETE",
+				},
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if response.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, response.StatusCode)
+			}
+			if allow := response.Headers["Allow"]; allow != tt.expectedAllow {
+				t.Errorf("Expected Allow header %q, got %q", tt.expectedAllow, allow)
+			}
+			if acao := response.Headers["Access-Control-Allow-Origin"]; acao != tt.expectedACAO {
+				t.Errorf("Expected Access-Control-Allow-Origin %q, got %q", tt.expectedACAO, acao)
+			}
+			if tt.expectedACAO != "" && response.Headers["Access-Control-Allow-Methods"] == "" {
+				t.Error("Expected Access-Control-Allow-Methods to be set")
+			}
+		})
+	}
+
+	if called {
+		t.Error("Expected OPTIONS to be answered without running middleware")
+	}
+}
+
+func TestRouter_Routes(t *testing.T) {
+	authMiddleware := middleware.NewAuthMiddleware(auth.NewTokenService(&config.Config{JWT: config.JWTConfig{Secret: "test-secret-key"}}))
+
+	r := New()
+	r.POST("/login", namedHandler("login"))
+	r.GET("/users", namedHandler("list"), authMiddleware.RequireAuth())
+	r.Version("v1").POST("/admin/users/{username}/reactivate", namedHandler("reactivate"), authMiddleware.RequireAdmin())
+
+	routes := r.Routes()
+	if len(routes) != 3 {
+		t.Fatalf("Expected 3 routes, got %d", len(routes))
+	}
+
+	tests := []struct {
+		method             string
+		path               string
+		expectedAuth       bool
+		expectedMiddleware []string
+	}{
+		{method: http.MethodPost, path: "/login", expectedAuth: false, expectedMiddleware: []string{}},
+		{method: http.MethodGet, path: "/users", expectedAuth: true, expectedMiddleware: []string{"AuthMiddleware.ValidateJWT"}},
+		{method: http.MethodPost, path: "/v1/admin/users/{username}/reactivate", expectedAuth: true, expectedMiddleware: []string{"AuthMiddleware.RequireAdmin"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			var found *RouteInfo
+			for i := range routes {
+				if routes[i].Method == tt.method && routes[i].Path == tt.path {
+					found = &routes[i]
+				}
+			}
+			if found == nil {
+				t.Fatalf("Expected route %s %s to be listed", tt.method, tt.path)
+			}
+
+			if found.AuthRequired != tt.expectedAuth {
+				t.Errorf("Expected auth_required %v, got %v", tt.expectedAuth, found.AuthRequired)
+			}
+			if strings.Join(found.Middleware, ",") != strings.Join(tt.expectedMiddleware, ",") {
+				t.Errorf("Expected middleware %v, got %v", tt.expectedMiddleware, found.Middleware)
+			}
+		})
+	}
+}
+
+func TestRouter_Match(t *testing.T) {
+	r := New()
+	r.GET("/master-skills/search", namedHandler("search"))
+	r.GET("/master-skills/{skillID}", namedHandler("get"))
+	r.GET("/users/{username}/skills/{skillName}", namedHandler("skill"))
+	r.GET("/users/{username}/skills/gap", namedHandler("gap"))
+	r.Version("v1").GET("/master-skills/{skillID}", namedHandler("v1"))
+
+	tests := []struct {
+		name             string
+		path             string
+		expectedResource string
+		expectedParams   map[string]string
+		expectedOK       bool
+	}{
+		{name: "literal wins over parameter", path: "/master-skills/search", expectedResource: "/master-skills/search", expectedParams: map[string]string{}, expectedOK: true},
+		{name: "parameter", path: "/master-skills/golang", expectedResource: "/master-skills/{skillID}", expectedParams: map[string]string{"skillID": "golang"}, expectedOK: true},
+		{name: "later literal segment", path: "/users/alice/skills/gap", expectedResource: "/users/{username}/skills/gap", expectedParams: map[string]string{"username": "alice"}, expectedOK: true},
+		{name: "several parameters", path: "/users/alice/skills/Go", expectedResource: "/users/{username}/skills/{skillName}", expectedParams: map[string]string{"username": "alice", "skillName": "Go"}, expectedOK: true},
+		{name: "version prefix", path: "/v1/master-skills/golang", expectedResource: "/v1/master-skills/{skillID}", expectedParams: map[string]string{"skillID": "golang"}, expectedOK: true},
+		{name: "trailing slash", path: "/master-skills/golang/", expectedResource: "/master-skills/{skillID}", expectedParams: map[string]string{"skillID": "golang"}, expectedOK: true},
+		{name: "empty parameter", path: "/master-skills//", expectedOK: false},
+		{name: "unknown path", path: "/nowhere", expectedOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource, params, ok := r.Match(tt.path)
+			if ok != tt.expectedOK {
+				t.Fatalf("Expected ok %v, got %v", tt.expectedOK, ok)
+			}
+			if !ok {
+				return
+			}
+			if resource != tt.expectedResource {
+				t.Errorf("Expected resource %s, got %s", tt.expectedResource, resource)
+			}
+			if len(params) != len(tt.expectedParams) {
+				t.Errorf("Expected params %v, got %v", tt.expectedParams, params)
+			}
+			for name, value := range tt.expectedParams {
+				if params[name] != value {
+					t.Errorf("Expected param %s=%s, got %s", name, value, params[name])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,316 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/database"
+	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
+	pkgerrors "github.com/hackmajoris/glad-stack/pkg/errors"
+	"github.com/hackmajoris/glad-stack/pkg/logger"
+)
+
+// backfillAttempts bounds how often one item is re-read and retried when it changes mid-backfill
+const backfillAttempts = 3
+
+// BackfillReport counts the items a backfill inspected and the ones it changed, or would change on a dry run
+type BackfillReport struct {
+	Name    string
+	DryRun  bool
+	Scanned int
+	Updated int
+}
+
+// BackfillService brings items written by earlier releases in line with the current schema
+// Every backfill only writes items that differ from what they should hold, so runs can be
+// repeated safely, e.g. after an interrupted one.
+type BackfillService struct {
+	userRepo        database.UserRepository
+	skillRepo       database.SkillRepository
+	masterSkillRepo database.MasterSkillRepository
+}
+
+// NewBackfillService creates a new BackfillService
+func NewBackfillService(userRepo database.UserRepository, skillRepo database.SkillRepository, masterSkillRepo database.MasterSkillRepository) *BackfillService {
+	return &BackfillService{
+		userRepo:        userRepo,
+		skillRepo:       skillRepo,
+		masterSkillRepo: masterSkillRepo,
+	}
+}
+
+// BackfillSkillCounts recounts every user's skills and stores the result as their SkillCount
+// Users created before SkillCount existed have none, and profile updates by earlier releases
+// wrote back stale counts. The count is replaced only if it still holds the value read, so a
+// skill added or removed meanwhile makes the user be re-read rather than miscounted.
+func (s *BackfillService) BackfillSkillCounts(dryRun bool) (*BackfillReport, error) {
+	log := logger.WithComponent("service").With("operation", "BackfillSkillCounts", "dry_run", dryRun)
+	start := time.Now()
+
+	log.Info("Processing skill count backfill")
+
+	users, err := s.userRepo.ListUsers()
+	if err != nil {
+		log.Error("Failed to list users", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	report := &BackfillReport{Name: "skill-counts", DryRun: dryRun, Scanned: len(users)}
+	for _, user := range users {
+		stored := user.SkillCount
+		for attempt := 1; ; attempt++ {
+			skills, err := s.skillRepo.ListSkillsForUser(user.Username)
+			if err != nil {
+				log.Error("Failed to list skills", "error", err.Error(), "username", user.Username, "duration", time.Since(start))
+				return nil, err
+			}
+			if len(skills) == stored {
+				break
+			}
+			if dryRun {
+				report.Updated++
+				break
+			}
+
+			err = s.skillRepo.ReplaceSkillCount(user.Username, stored, len(skills))
+			if err == nil {
+				log.Debug("Skill count corrected", "username", user.Username, "old", stored, "count", len(skills))
+				report.Updated++
+				break
+			}
+			if !pkgerrors.Is(err, database.ErrSkillCountChanged) || attempt == backfillAttempts {
+				log.Error("Failed to replace skill count", "error", err.Error(), "username", user.Username, "attempt", attempt, "duration", time.Since(start))
+				return nil, err
+			}
+
+			// The count moved under us: re-read it, unless the user has been deleted meanwhile
+			current, err := s.userRepo.GetUser(user.Username)
+			if pkgerrors.Is(err, apperrors.ErrUserNotFound) {
+				break
+			}
+			if err != nil {
+				log.Error("Failed to re-read user", "error", err.Error(), "username", user.Username, "duration", time.Since(start))
+				return nil, err
+			}
+			stored = current.SkillCount
+		}
+	}
+
+	log.Info("Skill count backfill completed", "scanned", report.Scanned, "updated", report.Updated, "duration", time.Since(start))
+	return report, nil
+}
+
+// BackfillSkillNameLower sets SkillNameLower on master skills written before it existed
+// Name searches filter on it, so without it those skills are never found. A skill renamed
+// meanwhile is skipped, since the rename stored the attribute already.
+func (s *BackfillService) BackfillSkillNameLower(dryRun bool) (*BackfillReport, error) {
+	log := logger.WithComponent("service").With("operation", "BackfillSkillNameLower", "dry_run", dryRun)
+	start := time.Now()
+
+	log.Info("Processing skill name backfill")
+
+	skills, err := s.masterSkillRepo.ListMasterSkills()
+	if err != nil {
+		log.Error("Failed to list master skills", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	report := &BackfillReport{Name: "skill-name-lower", DryRun: dryRun, Scanned: len(skills)}
+	for _, skill := range skills {
+		if skill.SkillNameLower == strings.ToLower(skill.SkillName) {
+			continue
+		}
+		if dryRun {
+			report.Updated++
+			continue
+		}
+
+		err := s.masterSkillRepo.SetSkillNameLower(skill.SkillID, skill.SkillName)
+		if pkgerrors.Is(err, database.ErrMasterSkillChanged) {
+			log.Debug("Master skill changed meanwhile, skipping", "skill_id", skill.SkillID)
+			continue
+		}
+		if err != nil {
+			log.Error("Failed to set lowercased skill name", "error", err.Error(), "skill_id", skill.SkillID, "duration", time.Since(start))
+			return nil, err
+		}
+		report.Updated++
+	}
+
+	log.Info("Skill name backfill completed", "scanned", report.Scanned, "updated", report.Updated, "duration", time.Since(start))
+	return report, nil
+}
+
+// BackfillUserSearchNames sets UsernameLower and NameLower on users written before they existed
+// User search filters on them, so without them those users are never found. A user renamed
+// meanwhile is skipped, since the rename stored the attributes already.
+func (s *BackfillService) BackfillUserSearchNames(dryRun bool) (*BackfillReport, error) {
+	log := logger.WithComponent("service").With("operation", "BackfillUserSearchNames", "dry_run", dryRun)
+	start := time.Now()
+
+	log.Info("Processing user search name backfill")
+
+	users, err := s.userRepo.ListUsers()
+	if err != nil {
+		log.Error("Failed to list users", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	report := &BackfillReport{Name: "user-search-names", DryRun: dryRun, Scanned: len(users)}
+	for _, user := range users {
+		if user.UsernameLower == strings.ToLower(user.Username) && user.NameLower == strings.ToLower(user.Name) {
+			continue
+		}
+		if dryRun {
+			report.Updated++
+			continue
+		}
+
+		err := s.userRepo.SetSearchNames(user.Username, user.Name)
+		if pkgerrors.Is(err, database.ErrUserChanged) {
+			log.Debug("User changed meanwhile, skipping", "username", user.Username)
+			continue
+		}
+		if err != nil {
+			log.Error("Failed to set search names", "error", err.Error(), "username", user.Username, "duration", time.Since(start))
+			return nil, err
+		}
+		report.Updated++
+	}
+
+	log.Info("User search name backfill completed", "scanned", report.Scanned, "updated", report.Updated, "duration", time.Since(start))
+	return report, nil
+}
+
+// BackfillSkillNameClaims claims the names of active master skills created before name claims
+// existed, so later creates and renames cannot reuse them. Skills are handled oldest first, and
+// a skill whose name another one holds already is logged and left for an admin to rename or
+// merge. A skill changed meanwhile is skipped, since the write that changed it claimed its name.
+func (s *BackfillService) BackfillSkillNameClaims(dryRun bool) (*BackfillReport, error) {
+	log := logger.WithComponent("service").With("operation", "BackfillSkillNameClaims", "dry_run", dryRun)
+	start := time.Now()
+
+	log.Info("Processing skill name claim backfill")
+
+	skills, err := s.masterSkillRepo.ListMasterSkills()
+	if err != nil {
+		log.Error("Failed to list master skills", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+	sort.SliceStable(skills, func(i, j int) bool { return skills[i].CreatedAt.Before(skills[j].CreatedAt) })
+
+	report := &BackfillReport{Name: "skill-name-claims", DryRun: dryRun, Scanned: len(skills)}
+	planned := make(map[string]string) // lowercased name -> skill a dry run would claim it for
+	for _, skill := range skills {
+		if skill.Deleted {
+			continue
+		}
+
+		name := strings.ToLower(skill.SkillName)
+		owner, err := s.masterSkillRepo.SkillNameOwner(name)
+		if err != nil {
+			log.Error("Failed to read skill name claim", "error", err.Error(), "skill_id", skill.SkillID, "duration", time.Since(start))
+			return nil, err
+		}
+		if owner == "" {
+			owner = planned[name]
+		}
+		if owner == skill.SkillID {
+			continue
+		}
+		if owner != "" {
+			log.Warn("Master skill name held by another skill; rename or merge one of them", "skill_id", skill.SkillID, "holder", owner, "skill_name", skill.SkillName)
+			continue
+		}
+		if dryRun {
+			planned[name] = skill.SkillID
+			report.Updated++
+			continue
+		}
+
+		err = s.masterSkillRepo.ClaimSkillName(skill)
+		if pkgerrors.Is(err, database.ErrMasterSkillChanged) {
+			log.Debug("Master skill changed meanwhile, skipping", "skill_id", skill.SkillID)
+			continue
+		}
+		if pkgerrors.Is(err, apperrors.ErrMasterSkillNameTaken) {
+			log.Warn("Master skill name claimed meanwhile by another skill; rename or merge one of them", "skill_id", skill.SkillID, "skill_name", skill.SkillName)
+			continue
+		}
+		if err != nil {
+			log.Error("Failed to claim skill name", "error", err.Error(), "skill_id", skill.SkillID, "duration", time.Since(start))
+			return nil, err
+		}
+		report.Updated++
+	}
+
+	log.Info("Skill name claim backfill completed", "scanned", report.Scanned, "updated", report.Updated, "duration", time.Since(start))
+	return report, nil
+}
+
+// BackfillEmailClaims claims the emails of users who set them before email claims existed, so
+// no other user can take them. Users are handled oldest first, and a user whose email another
+// one holds already is logged and left for an admin to resolve. A user changed meanwhile is
+// skipped, since the write that changed them claimed their email.
+func (s *BackfillService) BackfillEmailClaims(dryRun bool) (*BackfillReport, error) {
+	log := logger.WithComponent("service").With("operation", "BackfillEmailClaims", "dry_run", dryRun)
+	start := time.Now()
+
+	log.Info("Processing email claim backfill")
+
+	users, err := s.userRepo.ListUsers()
+	if err != nil {
+		log.Error("Failed to list users", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+	sort.SliceStable(users, func(i, j int) bool { return users[i].CreatedAt.Before(users[j].CreatedAt) })
+
+	report := &BackfillReport{Name: "email-claims", DryRun: dryRun, Scanned: len(users)}
+	planned := make(map[string]string) // lowercased email -> user a dry run would claim it for
+	for _, user := range users {
+		if user.Email == "" {
+			continue
+		}
+
+		email := strings.ToLower(user.Email)
+		owner, err := s.userRepo.EmailOwner(email)
+		if err != nil {
+			log.Error("Failed to read email claim", "error", err.Error(), "username", user.Username, "duration", time.Since(start))
+			return nil, err
+		}
+		if owner == "" {
+			owner = planned[email]
+		}
+		if owner == user.Username {
+			continue
+		}
+		if owner != "" {
+			log.Warn("Email held by another user; ask one of them to change it", "username", user.Username, "holder", owner)
+			continue
+		}
+		if dryRun {
+			planned[email] = user.Username
+			report.Updated++
+			continue
+		}
+
+		err = s.userRepo.ClaimEmail(user)
+		if pkgerrors.Is(err, database.ErrUserChanged) {
+			log.Debug("User changed meanwhile, skipping", "username", user.Username)
+			continue
+		}
+		if pkgerrors.Is(err, apperrors.ErrEmailInUse) {
+			log.Warn("Email claimed meanwhile by another user; ask one of them to change it", "username", user.Username)
+			continue
+		}
+		if err != nil {
+			log.Error("Failed to claim email", "error", err.Error(), "username", user.Username, "duration", time.Since(start))
+			return nil, err
+		}
+		report.Updated++
+	}
+
+	log.Info("Email claim backfill completed", "scanned", report.Scanned, "updated", report.Updated, "duration", time.Since(start))
+	return report, nil
+}
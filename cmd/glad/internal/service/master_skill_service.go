@@ -1,23 +1,52 @@
 package service
 
 import (
+	"math"
+	"sort"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/hackmajoris/glad-stack/cmd/glad/internal/database"
 	"github.com/hackmajoris/glad-stack/cmd/glad/internal/dto"
+	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
 	"github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
+	pkgerrors "github.com/hackmajoris/glad-stack/pkg/errors"
 	"github.com/hackmajoris/glad-stack/pkg/logger"
 )
 
+// Result size bounds for master skill name search
+const (
+	defaultSearchLimit = 10
+	maxSearchLimit     = 50
+)
+
+// Fields Search can match a query against
+const (
+	SearchFieldName        = "name"
+	SearchFieldDescription = "description"
+)
+
+// maxMasterSkillImport caps how many master skills a single import request may contain
+const maxMasterSkillImport = 500
+
 // MasterSkillService handles master skill business logic
 type MasterSkillService struct {
-	repo database.MasterSkillRepository
+	repo            database.MasterSkillRepository
+	skillRepo       database.SkillRepository
+	endorsementRepo database.EndorsementRepository
+	historyRepo     database.SkillHistoryRepository
 }
 
 // NewMasterSkillService creates a new MasterSkillService
-func NewMasterSkillService(repo database.MasterSkillRepository) *MasterSkillService {
+// skillRepo provides user skill usage for catalog overviews; Merge also moves the endorsement
+// and history records of the user skills it repoints.
+func NewMasterSkillService(repo database.MasterSkillRepository, skillRepo database.SkillRepository, endorsementRepo database.EndorsementRepository, historyRepo database.SkillHistoryRepository) *MasterSkillService {
 	return &MasterSkillService{
-		repo: repo,
+		repo:            repo,
+		skillRepo:       skillRepo,
+		endorsementRepo: endorsementRepo,
+		historyRepo:     historyRepo,
 	}
 }
 
@@ -35,7 +64,7 @@ func (s *MasterSkillService) CreateMasterSkill(skillID, skillName, description,
 		return nil, err
 	}
 
-	// Save to database
+	// Save to database; the write claims the name, so it fails if another skill uses it
 	if err := s.repo.CreateMasterSkill(skill); err != nil {
 		log.Error("Failed to save master skill to database", "error", err.Error(), "duration", time.Since(start))
 		return nil, err
@@ -45,6 +74,69 @@ func (s *MasterSkillService) CreateMasterSkill(skillID, skillName, description,
 	return skill, nil
 }
 
+// MasterSkillInput describes one master skill to create in an import
+type MasterSkillInput struct {
+	SkillID     string
+	SkillName   string
+	Description string
+	Category    string
+	Tags        []string
+}
+
+// ImportMasterSkills creates many master skills at once
+// Entries are created one by one and independently: invalid entries are reported as failed, and
+// entries whose skill_id already exists (in the catalog, including soft-deleted skills, or earlier
+// in the same import) fail with ErrMasterSkillExists so callers can count them as skipped. Entries
+// whose name an active skill or an earlier entry already uses, ignoring case, fail with
+// ErrMasterSkillNameTaken. A single import may contain at most maxMasterSkillImport entries.
+func (s *MasterSkillService) ImportMasterSkills(inputs []MasterSkillInput) ([]dto.BatchResult[dto.MasterSkillResponse], error) {
+	log := logger.WithComponent("service").With("operation", "ImportMasterSkills", "count", len(inputs))
+	start := time.Now()
+
+	log.Info("Processing master skill import request")
+
+	if len(inputs) == 0 {
+		log.Error("Import contains no skills", "duration", time.Since(start))
+		return nil, pkgerrors.ErrRequiredField
+	}
+
+	if len(inputs) > maxMasterSkillImport {
+		log.Info("Import exceeds per-request cap", "duration", time.Since(start))
+		return nil, apperrors.ErrImportLimitExceeded
+	}
+
+	// Batch writes can neither check for existing skills nor claim names, so each entry is
+	// written on its own
+	results := make([]dto.BatchResult[dto.MasterSkillResponse], len(inputs))
+	created := 0
+	for i, input := range inputs {
+		results[i] = dto.BatchResult[dto.MasterSkillResponse]{Index: i, Input: input.SkillID}
+
+		skill, err := models.NewSkill(input.SkillID, input.SkillName, input.Description, input.Category, input.Tags)
+		if err != nil {
+			log.Debug("Invalid master skill in import", "error", err.Error(), "index", i)
+			results[i].Err = err
+			continue
+		}
+
+		if err := s.repo.CreateMasterSkill(skill); err != nil {
+			if pkgerrors.Is(err, apperrors.ErrSkillAlreadyExists) {
+				err = apperrors.ErrMasterSkillExists
+			}
+			log.Debug("Master skill not imported", "error", err.Error(), "index", i)
+			results[i].Err = err
+			continue
+		}
+
+		results[i].Success = true
+		results[i].Data = &toMasterSkillResponses([]*models.Skill{skill})[0]
+		created++
+	}
+
+	log.Info("Master skill import completed", "created", created, "duration", time.Since(start))
+	return results, nil
+}
+
 // GetMasterSkill retrieves a master skill by ID
 func (s *MasterSkillService) GetMasterSkill(skillID string) (*models.Skill, error) {
 	log := logger.WithComponent("service").With("operation", "GetMasterSkill", "skill_id", skillID)
@@ -58,10 +150,62 @@ func (s *MasterSkillService) GetMasterSkill(skillID string) (*models.Skill, erro
 		return nil, err
 	}
 
+	if skill.Deleted {
+		log.Debug("Master skill is soft-deleted", "duration", time.Since(start))
+		return nil, apperrors.ErrMasterSkillNotFound
+	}
+
 	log.Debug("Master skill retrieved successfully", "duration", time.Since(start))
 	return skill, nil
 }
 
+// GetOverview returns a master skill together with how users hold it
+// Usage is aggregated over every user skill sharing the master skill's category and name.
+func (s *MasterSkillService) GetOverview(skillID string) (*dto.MasterSkillOverviewResponse, error) {
+	log := logger.WithComponent("service").With("operation", "GetOverview", "skill_id", skillID)
+	start := time.Now()
+
+	log.Debug("Building master skill overview")
+
+	skill, err := s.GetMasterSkill(skillID)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := dto.SkillUsageStats{ByLevel: make(map[string]int, len(models.ProficiencyLevels()))}
+	for _, level := range models.ProficiencyLevels() {
+		usage.ByLevel[string(level)] = 0
+	}
+
+	totalYears := 0
+	var token string
+	for {
+		page, next, err := s.skillRepo.ListUsersBySkill(skill.Category, skill.SkillName, 0, token)
+		if err != nil {
+			log.Error("Failed to list users by skill", "error", err.Error(), "duration", time.Since(start))
+			return nil, err
+		}
+		for _, userSkill := range page {
+			usage.TotalUsers++
+			usage.ByLevel[string(userSkill.ProficiencyLevel)]++
+			totalYears += userSkill.YearsOfExperience
+		}
+		if next == "" {
+			break
+		}
+		token = next
+	}
+	if usage.TotalUsers > 0 {
+		usage.AverageYears = math.Round(float64(totalYears)/float64(usage.TotalUsers)*10) / 10
+	}
+
+	log.Debug("Master skill overview built", "total_users", usage.TotalUsers, "duration", time.Since(start))
+	return &dto.MasterSkillOverviewResponse{
+		Skill: toMasterSkillResponses([]*models.Skill{skill})[0],
+		Usage: usage,
+	}, nil
+}
+
 // UpdateMasterSkill updates an existing master skill
 func (s *MasterSkillService) UpdateMasterSkill(skillID, skillName, description, category string, tags []string) (*models.Skill, error) {
 	log := logger.WithComponent("service").With("operation", "UpdateMasterSkill", "skill_id", skillID)
@@ -76,34 +220,61 @@ func (s *MasterSkillService) UpdateMasterSkill(skillID, skillName, description,
 		return nil, err
 	}
 
+	if skill.Deleted {
+		log.Info("Cannot update soft-deleted master skill", "duration", time.Since(start))
+		return nil, apperrors.ErrMasterSkillNotFound
+	}
+
+	// Changes go to a copy, so the skill read stays as stored if the write is rejected
+	updated := *skill
+	skillName = models.NormalizeSkillName(skillName)
+	if err := models.ValidateSkillNameNotReserved(skillName); err != nil {
+		log.Info("Skill name reserved by a route", "skill_name", skillName, "duration", time.Since(start))
+		return nil, err
+	}
+
 	// Update fields if provided
 	if skillName != "" || description != "" || category != "" {
-		skill.UpdateMetadata(skillName, description, category)
+		updated.UpdateMetadata(skillName, description, category)
 	}
 
 	if tags != nil {
-		skill.UpdateTags(tags)
+		updated.UpdateTags(tags)
 	}
 
-	// Save updated skill
-	if err := s.repo.UpdateMasterSkill(skill); err != nil {
+	// Save updated skill; a rename claims the new name, so it fails if another skill uses it
+	if err := s.repo.UpdateMasterSkill(&updated); err != nil {
 		log.Error("Failed to update master skill in database", "error", err.Error(), "duration", time.Since(start))
 		return nil, err
 	}
 
 	log.Info("Master skill updated successfully", "duration", time.Since(start))
-	return skill, nil
+	return &updated, nil
 }
 
-// DeleteMasterSkill deletes a master skill
+// DeleteMasterSkill soft-deletes a master skill
+// The record is kept so UserSkills referencing it via skill_id do not dangle
 func (s *MasterSkillService) DeleteMasterSkill(skillID string) error {
 	log := logger.WithComponent("service").With("operation", "DeleteMasterSkill", "skill_id", skillID)
 	start := time.Now()
 
 	log.Info("Processing delete master skill request")
 
-	if err := s.repo.DeleteMasterSkill(skillID); err != nil {
-		log.Error("Failed to delete master skill", "error", err.Error(), "duration", time.Since(start))
+	skill, err := s.repo.GetMasterSkill(skillID)
+	if err != nil {
+		log.Error("Failed to get master skill", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
+
+	if skill.Deleted {
+		log.Info("Master skill already deleted", "duration", time.Since(start))
+		return apperrors.ErrMasterSkillNotFound
+	}
+
+	skill.MarkDeleted()
+
+	if err := s.repo.UpdateMasterSkill(skill); err != nil {
+		log.Error("Failed to soft-delete master skill", "error", err.Error(), "duration", time.Since(start))
 		return err
 	}
 
@@ -111,12 +282,282 @@ func (s *MasterSkillService) DeleteMasterSkill(skillID string) error {
 	return nil
 }
 
-// ListMasterSkills retrieves all master skills
-func (s *MasterSkillService) ListMasterSkills() ([]dto.MasterSkillResponse, error) {
-	log := logger.WithComponent("service").With("operation", "ListMasterSkills")
+// Merge folds the duplicate master skill sourceID into targetID
+// Every user skill referencing the source is repointed to the target, taking its name and
+// category. A user who already holds the target keeps whichever of the two skills has the
+// higher proficiency (the target on a tie) and the endorsements of both, counting an endorser
+// of both skills once. Endorsement records and skill history move to the target ID together
+// with the user skill. The source master skill is then removed outright; a soft-deleted source
+// can still be merged.
+func (s *MasterSkillService) Merge(sourceID, targetID string) (*dto.MasterSkillMergeResponse, error) {
+	log := logger.WithComponent("service").With("operation", "Merge", "source_id", sourceID, "target_id", targetID)
+	start := time.Now()
+
+	log.Info("Processing merge master skill request")
+
+	if sourceID == targetID {
+		log.Info("Cannot merge master skill into itself", "duration", time.Since(start))
+		return nil, apperrors.ErrMergeIntoSelf
+	}
+
+	if _, err := s.repo.GetMasterSkill(sourceID); err != nil {
+		log.Error("Failed to get source master skill", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	target, err := s.repo.GetMasterSkill(targetID)
+	if err != nil {
+		log.Error("Failed to get target master skill", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+	if target.Deleted {
+		log.Info("Cannot merge into soft-deleted master skill", "duration", time.Since(start))
+		return nil, apperrors.ErrMasterSkillDeleted
+	}
+
+	skills, err := s.skillRepo.ListSkillsBySkillID(sourceID)
+	if err != nil {
+		log.Error("Failed to list user skills", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	result := &dto.MasterSkillMergeResponse{SourceID: sourceID, TargetID: targetID}
+	for _, skill := range skills {
+		merge, err := s.planUserSkillMerge(skill, target)
+		if err != nil {
+			log.Error("Failed to plan user skill merge", "error", err.Error(), "username", skill.Username, "duration", time.Since(start))
+			return nil, err
+		}
+		if err := s.skillRepo.MergeUserSkill(merge); err != nil {
+			log.Error("Failed to merge user skill", "error", err.Error(), "username", skill.Username, "duration", time.Since(start))
+			return nil, err
+		}
+
+		if merge.Replaces {
+			result.Merged++
+		} else {
+			result.Moved++
+		}
+	}
+
+	if err := s.repo.DeleteMasterSkill(sourceID); err != nil {
+		log.Error("Failed to delete source master skill", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	log.Info("Master skill merged successfully", "moved", result.Moved, "merged", result.Merged, "duration", time.Since(start))
+	return result, nil
+}
+
+// planUserSkillMerge works out how skill moves onto the master skill target
+func (s *MasterSkillService) planUserSkillMerge(skill *models.UserSkill, target *models.Skill) (*database.UserSkillMerge, error) {
+	existing, err := s.skillRepo.GetSkill(skill.Username, target.SkillID)
+	if err != nil && !pkgerrors.Is(err, apperrors.ErrSkillNotFound) {
+		return nil, err
+	}
+	endorsements, err := s.endorsementRepo.ListEndorsements(skill.Username, skill.SkillID)
+	if err != nil {
+		return nil, err
+	}
+	history, err := s.historyRepo.ListSkillHistory(skill.Username, skill.SkillID)
+	if err != nil {
+		return nil, err
+	}
+
+	moved := *skill
+	moved.SkillID = target.SkillID
+	moved.SkillName = target.SkillName
+	moved.Category = target.Category
+
+	merge := &database.UserSkillMerge{Source: skill, Target: &moved, MoveHistory: history}
+	if existing == nil {
+		merge.MoveEndorsements = endorsements
+		return merge, nil
+	}
+
+	targetEndorsements, err := s.endorsementRepo.ListEndorsements(skill.Username, target.SkillID)
+	if err != nil {
+		return nil, err
+	}
+	endorsedTarget := make(map[string]bool, len(targetEndorsements))
+	for _, endorsement := range targetEndorsements {
+		endorsedTarget[endorsement.Endorser] = true
+	}
+	for _, endorsement := range endorsements {
+		if endorsedTarget[endorsement.Endorser] {
+			merge.DropEndorsements = append(merge.DropEndorsements, endorsement)
+		} else {
+			merge.MoveEndorsements = append(merge.MoveEndorsements, endorsement)
+		}
+	}
+
+	merge.Replaces = true
+	if skill.ProficiencyLevel.Rank() <= existing.ProficiencyLevel.Rank() {
+		kept := *existing
+		merge.Target = &kept
+	}
+	merge.Target.Endorsements = skill.Endorsements + existing.Endorsements - len(merge.DropEndorsements)
+	return merge, nil
+}
+
+// SetFeatured adds a master skill to, or removes it from, the curated featured list
+// Setting the flag a skill already has is a no-op that still returns the skill.
+func (s *MasterSkillService) SetFeatured(skillID string, featured bool) (*models.Skill, error) {
+	log := logger.WithComponent("service").With("operation", "SetFeatured", "skill_id", skillID, "featured", featured)
 	start := time.Now()
 
-	log.Info("Retrieving all master skills")
+	log.Info("Processing set featured request")
+
+	skill, err := s.repo.GetMasterSkill(skillID)
+	if err != nil {
+		log.Error("Failed to get master skill", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	if skill.Deleted {
+		log.Info("Cannot feature soft-deleted master skill", "duration", time.Since(start))
+		return nil, apperrors.ErrMasterSkillNotFound
+	}
+
+	if skill.Featured == featured {
+		log.Info("Featured flag unchanged", "duration", time.Since(start))
+		return skill, nil
+	}
+
+	skill.SetFeatured(featured)
+	if err := s.repo.UpdateMasterSkill(skill); err != nil {
+		log.Error("Failed to save featured flag", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	log.Info("Featured flag updated successfully", "duration", time.Since(start))
+	return skill, nil
+}
+
+// ListFeatured returns the active featured master skills sorted by name, ignoring case
+func (s *MasterSkillService) ListFeatured() ([]dto.MasterSkillResponse, error) {
+	log := logger.WithComponent("service").With("operation", "ListFeatured")
+	start := time.Now()
+
+	log.Debug("Retrieving featured master skills")
+
+	skills, err := s.repo.ListFeaturedMasterSkills()
+	if err != nil {
+		log.Error("Failed to retrieve featured master skills", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	// Soft-deleted skills keep their flag but are no longer offered
+	active := make([]*models.Skill, 0, len(skills))
+	for _, skill := range skills {
+		if !skill.Deleted {
+			active = append(active, skill)
+		}
+	}
+
+	sort.Slice(active, func(i, j int) bool {
+		a, b := strings.ToLower(active[i].SkillName), strings.ToLower(active[j].SkillName)
+		if a != b {
+			return a < b
+		}
+		return active[i].SkillID < active[j].SkillID
+	})
+
+	result := toMasterSkillResponses(active)
+
+	log.Info("Featured master skills retrieved successfully", "count", len(result), "duration", time.Since(start))
+	return result, nil
+}
+
+// ListMasterSkills retrieves master skills, optionally filtered by category and tags
+// When both filters are empty the full catalog is returned; multiple tags are matched as AND.
+// Soft-deleted skills are skipped unless includeDeleted is set.
+func (s *MasterSkillService) ListMasterSkills(category string, tags []string, includeDeleted bool) ([]dto.MasterSkillResponse, error) {
+	log := logger.WithComponent("service").With("operation", "ListMasterSkills", "category", category, "tags", tags, "include_deleted", includeDeleted)
+	start := time.Now()
+
+	if category != "" {
+		if err := models.ValidateCategory(category); err != nil {
+			log.Info("Invalid category filter", "duration", time.Since(start))
+			return nil, err
+		}
+	}
+
+	var skills []*models.Skill
+	var err error
+	if category == "" && len(tags) == 0 {
+		log.Info("Retrieving all master skills")
+		skills, err = s.repo.ListMasterSkills()
+	} else {
+		log.Info("Retrieving filtered master skills")
+		skills, err = s.repo.ListMasterSkillsFiltered(category, tags)
+	}
+	if err != nil {
+		log.Error("Failed to retrieve master skills", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	if !includeDeleted {
+		active := skills[:0]
+		for _, skill := range skills {
+			if !skill.Deleted {
+				active = append(active, skill)
+			}
+		}
+		skills = active
+	}
+
+	result := toMasterSkillResponses(skills)
+
+	log.Info("Master skills retrieved successfully", "count", len(result), "duration", time.Since(start))
+	return result, nil
+}
+
+// ListMasterSkillsPage retrieves one page of master skills, optionally filtered by category and tags
+// Soft-deleted skills are dropped after the page is read unless includeDeleted is set,
+// so a page may be short; callers continue while a next token is returned.
+func (s *MasterSkillService) ListMasterSkillsPage(category string, tags []string, includeDeleted bool, limit int, nextToken string) (*dto.MasterSkillPageResponse, error) {
+	log := logger.WithComponent("service").With("operation", "ListMasterSkillsPage", "category", category, "tags", tags, "include_deleted", includeDeleted, "limit", limit)
+	start := time.Now()
+
+	if category != "" {
+		if err := models.ValidateCategory(category); err != nil {
+			log.Info("Invalid category filter", "duration", time.Since(start))
+			return nil, err
+		}
+	}
+
+	skills, next, err := s.repo.ListMasterSkillsPage(category, tags, limit, nextToken)
+	if err != nil {
+		log.Error("Failed to retrieve master skills page", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	if !includeDeleted {
+		active := skills[:0]
+		for _, skill := range skills {
+			if !skill.Deleted {
+				active = append(active, skill)
+			}
+		}
+		skills = active
+	}
+
+	page := &dto.MasterSkillPageResponse{Items: toMasterSkillResponses(skills), NextToken: next}
+
+	log.Info("Master skills page retrieved successfully", "count", len(page.Items), "has_more", next != "", "duration", time.Since(start))
+	return page, nil
+}
+
+// CountByCategory returns the number of active master skills per category
+// Categories without skills are omitted. Counting is done over a single query of all master
+// skills grouped in memory: a Select: COUNT query per category would avoid transferring the
+// items, but costs one round trip per category (ten today) and still reads every item, and it
+// cannot exclude soft-deleted skills without a filter that counts the same reads. The catalog
+// is small enough that one query is cheaper, and it keeps DynamoDB and the mock identical.
+func (s *MasterSkillService) CountByCategory() (map[string]int, error) {
+	log := logger.WithComponent("service").With("operation", "CountByCategory")
+	start := time.Now()
 
 	skills, err := s.repo.ListMasterSkills()
 	if err != nil {
@@ -124,7 +565,224 @@ func (s *MasterSkillService) ListMasterSkills() ([]dto.MasterSkillResponse, erro
 		return nil, err
 	}
 
-	// Convert to response DTOs
+	counts := make(map[string]int)
+	for _, skill := range skills {
+		if !skill.Deleted {
+			counts[skill.Category]++
+		}
+	}
+
+	log.Info("Master skills counted by category", "categories", len(counts), "duration", time.Since(start))
+	return counts, nil
+}
+
+// ListUsedCategories returns the sorted categories that have at least one active master skill
+// Unlike the static category allowlist, categories nobody has used yet are left out.
+func (s *MasterSkillService) ListUsedCategories() ([]string, error) {
+	log := logger.WithComponent("service").With("operation", "ListUsedCategories")
+	start := time.Now()
+
+	categories, err := s.repo.ListMasterSkillCategories()
+	if err != nil {
+		log.Error("Failed to retrieve master skill categories", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	// An empty catalog is reported as an empty list, not null
+	used := make([]string, 0, len(categories))
+	used = append(used, categories...)
+	sort.Strings(used)
+
+	log.Info("Used categories retrieved", "count", len(used), "duration", time.Since(start))
+	return used, nil
+}
+
+// SearchByNamePrefix returns active master skills whose name starts with prefix, ignoring case
+// Results are sorted alphabetically by name and capped at limit (default 10, max 50).
+func (s *MasterSkillService) SearchByNamePrefix(prefix string, limit int) ([]dto.MasterSkillResponse, error) {
+	log := logger.WithComponent("service").With("operation", "SearchByNamePrefix", "prefix", prefix, "limit", limit)
+	start := time.Now()
+
+	if prefix == "" {
+		log.Info("Empty search prefix", "duration", time.Since(start))
+		return nil, pkgerrors.ErrRequiredField
+	}
+
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	skills, err := s.repo.SearchMasterSkillsByNamePrefix(prefix)
+	if err != nil {
+		log.Error("Failed to search master skills", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	active := make([]*models.Skill, 0, len(skills))
+	for _, skill := range skills {
+		if !skill.Deleted {
+			active = append(active, skill)
+		}
+	}
+
+	sort.Slice(active, func(i, j int) bool {
+		return strings.ToLower(active[i].SkillName) < strings.ToLower(active[j].SkillName)
+	})
+	if len(active) > limit {
+		active = active[:limit]
+	}
+
+	result := toMasterSkillResponses(active)
+
+	log.Info("Master skill search completed", "count", len(result), "duration", time.Since(start))
+	return result, nil
+}
+
+// Search returns active master skills containing query in any of fields, ignoring case
+// fields may hold SearchFieldName and SearchFieldDescription and defaults to both. Name
+// matches rank above description-only matches; ties sort by name, then ID. At most
+// maxSearchLimit skills are returned.
+func (s *MasterSkillService) Search(query string, fields []string) ([]dto.MasterSkillResponse, error) {
+	log := logger.WithComponent("service").With("operation", "Search", "query", query, "fields", fields)
+	start := time.Now()
+
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		log.Info("Empty search query", "duration", time.Since(start))
+		return nil, pkgerrors.ErrRequiredField
+	}
+
+	matchName, matchDescription := len(fields) == 0, len(fields) == 0
+	for _, field := range fields {
+		switch field {
+		case SearchFieldName:
+			matchName = true
+		case SearchFieldDescription:
+			matchDescription = true
+		default:
+			log.Info("Unknown search field", "field", field, "duration", time.Since(start))
+			return nil, apperrors.ErrInvalidSearchField
+		}
+	}
+
+	skills, err := s.repo.ListMasterSkills()
+	if err != nil {
+		log.Error("Failed to list master skills", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	// Rank 2 for a name match, 1 for a description-only match
+	ranks := make(map[*models.Skill]int)
+	matched := make([]*models.Skill, 0)
+	for _, skill := range skills {
+		if skill.Deleted {
+			continue
+		}
+		switch {
+		case matchName && strings.Contains(strings.ToLower(skill.SkillName), query):
+			ranks[skill] = 2
+		case matchDescription && strings.Contains(strings.ToLower(skill.Description), query):
+			ranks[skill] = 1
+		default:
+			continue
+		}
+		matched = append(matched, skill)
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		if ranks[matched[i]] != ranks[matched[j]] {
+			return ranks[matched[i]] > ranks[matched[j]]
+		}
+		nameI, nameJ := strings.ToLower(matched[i].SkillName), strings.ToLower(matched[j].SkillName)
+		if nameI != nameJ {
+			return nameI < nameJ
+		}
+		return matched[i].SkillID < matched[j].SkillID
+	})
+	if len(matched) > maxSearchLimit {
+		matched = matched[:maxSearchLimit]
+	}
+
+	result := toMasterSkillResponses(matched)
+
+	log.Info("Master skill search completed", "count", len(result), "duration", time.Since(start))
+	return result, nil
+}
+
+// SuggestCategory ranks categories for a new skill name by similarity to existing skills
+// Each active skill sharing a token with name (matched against the skill's name and tags)
+// adds one point per shared token to its category. Ties keep the catalog's category order.
+func (s *MasterSkillService) SuggestCategory(name string) ([]dto.CategorySuggestionResponse, error) {
+	log := logger.WithComponent("service").With("operation", "SuggestCategory", "name", name)
+	start := time.Now()
+
+	queryTokens := skillTokens(name)
+	if len(queryTokens) == 0 {
+		log.Info("Empty skill name for category suggestion", "duration", time.Since(start))
+		return nil, pkgerrors.ErrRequiredField
+	}
+
+	skills, err := s.repo.ListMasterSkills()
+	if err != nil {
+		log.Error("Failed to retrieve master skills", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	scores := make(map[string]int)
+	similar := make(map[string][]string)
+	for _, skill := range skills {
+		if skill.Deleted {
+			continue
+		}
+
+		skillTokenSet := skillTokens(append([]string{skill.SkillName}, skill.Tags...)...)
+		shared := 0
+		for token := range queryTokens {
+			if skillTokenSet[token] {
+				shared++
+			}
+		}
+		if shared > 0 {
+			scores[skill.Category] += shared
+			similar[skill.Category] = append(similar[skill.Category], skill.SkillName)
+		}
+	}
+
+	result := make([]dto.CategorySuggestionResponse, 0, len(scores))
+	for _, category := range models.Categories() {
+		if score := scores[category]; score > 0 {
+			sort.Strings(similar[category])
+			result = append(result, dto.CategorySuggestionResponse{
+				Category:      category,
+				Score:         score,
+				SimilarSkills: similar[category],
+			})
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Score > result[j].Score })
+
+	log.Info("Category suggestions computed", "suggestions", len(result), "duration", time.Since(start))
+	return result, nil
+}
+
+// skillTokens splits values into a set of lowercase alphanumeric tokens
+func skillTokens(values ...string) map[string]bool {
+	tokens := make(map[string]bool)
+	for _, value := range values {
+		for _, token := range strings.FieldsFunc(strings.ToLower(value), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			tokens[token] = true
+		}
+	}
+	return tokens
+}
+
+// toMasterSkillResponses converts master skills to response DTOs
+func toMasterSkillResponses(skills []*models.Skill) []dto.MasterSkillResponse {
 	result := make([]dto.MasterSkillResponse, len(skills))
 	for i, skill := range skills {
 		result[i] = dto.MasterSkillResponse{
@@ -133,11 +791,14 @@ func (s *MasterSkillService) ListMasterSkills() ([]dto.MasterSkillResponse, erro
 			Description: skill.Description,
 			Category:    skill.Category,
 			Tags:        skill.Tags,
+			Featured:    skill.Featured,
 			CreatedAt:   skill.CreatedAt.Format(time.RFC3339),
 			UpdatedAt:   skill.UpdatedAt.Format(time.RFC3339),
+			Deleted:     skill.Deleted,
+		}
+		if skill.Deleted {
+			result[i].DeletedAt = skill.DeletedAt.Format(time.RFC3339)
 		}
 	}
-
-	log.Info("Master skills retrieved successfully", "count", len(result), "duration", time.Since(start))
-	return result, nil
+	return result
 }
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"time"
+
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/database"
+	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
+	pkgerrors "github.com/hackmajoris/glad-stack/pkg/errors"
+	"github.com/hackmajoris/glad-stack/pkg/logger"
+)
+
+// PurgeReport lists what a user purge removed, or would remove on a dry run
+type PurgeReport struct {
+	Username     string
+	DryRun       bool
+	Endorsements int // endorsements the user gave, withdrawn from the endorsed skills
+	Snapshots    int
+	History      int
+	Activity     int
+	Skills       int // endorsements received go with these skills
+}
+
+// PurgeService permanently removes a user and everything stored about them
+type PurgeService struct {
+	userRepo        database.UserRepository
+	skillRepo       database.SkillRepository
+	historyRepo     database.SkillHistoryRepository
+	snapshotRepo    database.SkillSnapshotRepository
+	endorsementRepo database.EndorsementRepository
+	activityRepo    database.ActivityRepository
+}
+
+// NewPurgeService creates a new PurgeService
+func NewPurgeService(userRepo database.UserRepository, skillRepo database.SkillRepository, historyRepo database.SkillHistoryRepository, snapshotRepo database.SkillSnapshotRepository, endorsementRepo database.EndorsementRepository, activityRepo database.ActivityRepository) *PurgeService {
+	return &PurgeService{
+		userRepo:        userRepo,
+		skillRepo:       skillRepo,
+		historyRepo:     historyRepo,
+		snapshotRepo:    snapshotRepo,
+		endorsementRepo: endorsementRepo,
+		activityRepo:    activityRepo,
+	}
+}
+
+// PurgeUser removes username's data in this order:
+//  1. endorsements given, decrementing the endorsed skills
+//  2. skill snapshots
+//  3. skill history and activity log
+//  4. skills, endorsements received, and the user record, via the same cascade as account deletion
+//
+// The user record goes last so an interrupted purge can simply be run again.
+// With dryRun nothing is removed and the report counts what would be.
+func (s *PurgeService) PurgeUser(username string, dryRun bool) (*PurgeReport, error) {
+	log := logger.WithComponent("service").With("operation", "PurgeUser", "username", username, "dry_run", dryRun)
+	start := time.Now()
+
+	log.Info("Processing purge user request")
+
+	if _, err := s.userRepo.GetUser(username); err != nil {
+		log.Error("User not found", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	given, err := s.endorsementRepo.ListEndorsementsByEndorser(username)
+	if err != nil {
+		log.Error("Failed to list endorsements given", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	report := &PurgeReport{Username: username, DryRun: dryRun, Endorsements: len(given)}
+	if dryRun {
+		snapshots, err := s.snapshotRepo.ListSkillSnapshots(username)
+		if err != nil {
+			log.Error("Failed to list skill snapshots", "error", err.Error(), "duration", time.Since(start))
+			return nil, err
+		}
+		history, err := s.historyRepo.ListSkillHistoryForUser(username)
+		if err != nil {
+			log.Error("Failed to list skill history", "error", err.Error(), "duration", time.Since(start))
+			return nil, err
+		}
+		activity, err := s.countActivity(username)
+		if err != nil {
+			log.Error("Failed to list activity", "error", err.Error(), "duration", time.Since(start))
+			return nil, err
+		}
+		skills, err := s.skillRepo.ListSkillsForUser(username)
+		if err != nil {
+			log.Error("Failed to list skills", "error", err.Error(), "duration", time.Since(start))
+			return nil, err
+		}
+
+		report.Snapshots, report.History, report.Activity, report.Skills = len(snapshots), len(history), activity, len(skills)
+		log.Info("Purge dry run completed", "endorsements", report.Endorsements, "snapshots", report.Snapshots, "history", report.History, "activity", report.Activity, "skills", report.Skills, "duration", time.Since(start))
+		return report, nil
+	}
+
+	for _, endorsement := range given {
+		if err := s.withdrawEndorsement(endorsement); err != nil {
+			log.Error("Failed to withdraw endorsement", "error", err.Error(), "endorsed_user", endorsement.Username, "skill", endorsement.SkillID, "duration", time.Since(start))
+			return nil, err
+		}
+	}
+
+	if report.Snapshots, err = s.snapshotRepo.DeleteSkillSnapshotsForUser(username); err != nil {
+		log.Error("Failed to delete skill snapshots", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+	if report.History, err = s.historyRepo.DeleteSkillHistoryForUser(username); err != nil {
+		log.Error("Failed to delete skill history", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+	if report.Activity, err = s.activityRepo.DeleteActivityForUser(username); err != nil {
+		log.Error("Failed to delete activity", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	// Count skills before the cascade removes them
+	skills, err := s.skillRepo.ListSkillsForUser(username)
+	if err != nil {
+		log.Error("Failed to list skills", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+	if err := s.userRepo.DeleteUserCascade(username); err != nil {
+		log.Error("Failed to delete user", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+	report.Skills = len(skills)
+
+	log.Info("User purged successfully", "endorsements", report.Endorsements, "snapshots", report.Snapshots, "history", report.History, "activity", report.Activity, "skills", report.Skills, "duration", time.Since(start))
+	return report, nil
+}
+
+// countActivity returns how many activity log entries username has, reading every page
+func (s *PurgeService) countActivity(username string) (int, error) {
+	count, next := 0, ""
+	for {
+		entries, token, err := s.activityRepo.ListActivity(username, 0, next)
+		if err != nil {
+			return 0, err
+		}
+		count += len(entries)
+		if token == "" {
+			return count, nil
+		}
+		next = token
+	}
+}
+
+// withdrawEndorsement deletes an endorsement and decrements the endorsed skill's count
+// Both change in a single write; when the skill is already gone only the record is left to delete.
+func (s *PurgeService) withdrawEndorsement(endorsement *models.Endorsement) error {
+	err := s.endorsementRepo.RemoveEndorsement(endorsement.Username, endorsement.SkillID, endorsement.Endorser)
+	if pkgerrors.Is(err, apperrors.ErrSkillNotFound) {
+		err = s.endorsementRepo.DeleteEndorsement(endorsement.Username, endorsement.SkillID, endorsement.Endorser)
+	}
+	if pkgerrors.Is(err, apperrors.ErrEndorsementNotFound) {
+		return nil
+	}
+	return err
+}
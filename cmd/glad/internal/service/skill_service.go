@@ -1,12 +1,19 @@
 package service
 
 import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/hackmajoris/glad-stack/cmd/glad/internal/database"
 	"github.com/hackmajoris/glad-stack/cmd/glad/internal/dto"
 	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
 	"github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/validation"
+	"github.com/hackmajoris/glad-stack/pkg/dateutil"
+	pkgerrors "github.com/hackmajoris/glad-stack/pkg/errors"
 	"github.com/hackmajoris/glad-stack/pkg/logger"
 )
 
@@ -19,39 +26,134 @@ var (
 	ErrInvalidSkillName         = apperrors.ErrInvalidSkillName
 )
 
+// maxCategoryQueries bounds concurrent GSI queries when fanning out across categories
+const maxCategoryQueries = 4
+
+// maxBulkEndorsements caps how many users one endorser may endorse in a single request
+const maxBulkEndorsements = 50
+
+// maxTeamMatrixUsers caps how many team members one skills matrix may cover
+const maxTeamMatrixUsers = 100
+
+// maxBulkSkillsUsers caps how many users' skills ListSkillsForUsers fetches in one request
+const maxBulkSkillsUsers = 50
+
+// maxUserSkillQueries bounds concurrent skill queries when fanning out across users
+const maxUserSkillQueries = 8
+
+// Related skill result limits
+const (
+	defaultRelatedSkillsLimit = 10
+	maxRelatedSkillsLimit     = 50
+)
+
+// Activity log page size limits
+const (
+	defaultActivityLimit = 20
+	maxActivityLimit     = 100
+)
+
+// Recent skills feed limits
+const (
+	defaultRecentSkillsLimit = 20
+	maxRecentSkillsLimit     = 100
+)
+
+// maxTaggedSkills caps how many tagged master skills ListUsersByTag looks up holders for
+const maxTaggedSkills = 25
+
+// maxRelatedSkillHolders caps how many holders of a skill RelatedSkills inspects
+const maxRelatedSkillHolders = 100
+
 // SkillService handles skill business logic
 type SkillService struct {
 	repo            database.SkillRepository
 	masterSkillRepo database.MasterSkillRepository
 	userRepo        database.UserRepository
+	historyRepo     database.SkillHistoryRepository
+	endorsementRepo database.EndorsementRepository
+	activityRepo    database.ActivityRepository
+	promotion       models.PromotionPolicy
+	defaultLevel    models.ProficiencyLevel
+	validator       *validation.Validator
+}
+
+// SkillServiceOption configures optional SkillService behaviour
+type SkillServiceOption func(*SkillService)
+
+// WithPromotionPolicy sets how endorsements promote skills to the next proficiency level
+func WithPromotionPolicy(policy models.PromotionPolicy) SkillServiceOption {
+	return func(s *SkillService) {
+		s.promotion = policy
+	}
+}
+
+// WithDefaultProficiency sets the proficiency level given to added skills that omit one
+func WithDefaultProficiency(level models.ProficiencyLevel) SkillServiceOption {
+	return func(s *SkillService) {
+		s.defaultLevel = level
+	}
 }
 
 // NewSkillService creates a new SkillService
-func NewSkillService(repo database.SkillRepository, masterSkillRepo database.MasterSkillRepository, userRepo database.UserRepository) *SkillService {
-	return &SkillService{
+func NewSkillService(repo database.SkillRepository, masterSkillRepo database.MasterSkillRepository, userRepo database.UserRepository, historyRepo database.SkillHistoryRepository, endorsementRepo database.EndorsementRepository, activityRepo database.ActivityRepository, opts ...SkillServiceOption) *SkillService {
+	s := &SkillService{
 		repo:            repo,
 		masterSkillRepo: masterSkillRepo,
 		userRepo:        userRepo,
+		historyRepo:     historyRepo,
+		endorsementRepo: endorsementRepo,
+		activityRepo:    activityRepo,
+		promotion:       models.DefaultPromotionPolicy(),
+		defaultLevel:    models.ProficiencyBeginner,
+		validator:       validation.New(),
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// DefaultProficiency returns the proficiency level given to added skills that omit one
+func (s *SkillService) DefaultProficiency() models.ProficiencyLevel {
+	return s.defaultLevel
 }
 
 // AddSkill adds a new skill to a user
-// The skillName parameter is used as the skillID to look up the master skill
-func (s *SkillService) AddSkill(username, skillName string, proficiencyLevel models.ProficiencyLevel, yearsOfExperience int, notes string) (*models.UserSkill, error) {
-	log := logger.WithComponent("service").With("operation", "AddSkill", "username", username, "skill", skillName)
+// The master skill is looked up by skillID when given, exactly as stored; otherwise skillName
+// is normalized and used as the ID. An unknown skillID fails with ErrMasterSkillNotFound, an
+// unknown skillName with ErrSkillNotFound. An empty proficiencyLevel takes DefaultProficiency.
+func (s *SkillService) AddSkill(ctx context.Context, username, skillID, skillName string, proficiencyLevel models.ProficiencyLevel, yearsOfExperience int, notes string) (*models.UserSkill, error) {
+	log := logger.WithComponent("service").With("operation", "AddSkill", "username", username, "skill_id", skillID, "skill", skillName)
 	start := time.Now()
 
 	log.Info("Processing add skill request")
 
+	if proficiencyLevel == "" {
+		proficiencyLevel = s.defaultLevel
+		log.Debug("Proficiency level omitted, using default", "proficiency_level", proficiencyLevel)
+	}
+
+	if err := s.validator.ValidateSkillConsistency(proficiencyLevel, yearsOfExperience); err != nil {
+		log.Info("Years of experience too low for proficiency level", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
 	// Look up master skill to get skillID, skillName, and category
-	masterSkill, err := s.masterSkillRepo.GetMasterSkill(skillName)
+	masterSkill, err := s.resolveMasterSkill(ctx, skillID, skillName)
 	if err != nil {
-		log.Error("Master skill not found", "error", err.Error(), "skill_id", skillName, "duration", time.Since(start))
-		return nil, apperrors.ErrSkillNotFound
+		log.Info("Master skill not resolved", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
 	}
 
 	log.Debug("Master skill found", "skill_id", masterSkill.SkillID, "skill_name", masterSkill.SkillName, "category", masterSkill.Category)
 
+	// Catalog entries created before a stricter category rule are not handed out to users
+	if err := models.ValidateSkillNameLength(masterSkill.SkillName, masterSkill.Category); err != nil {
+		log.Info("Master skill name below category minimum", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
 	// Create new user skill with data from master skill
 	skill, err := models.NewUserSkill(username, masterSkill.SkillID, masterSkill.SkillName, masterSkill.Category, proficiencyLevel, yearsOfExperience)
 	if err != nil {
@@ -64,23 +166,224 @@ func (s *SkillService) AddSkill(username, skillName string, proficiencyLevel mod
 	}
 
 	// Save skill to database
-	if err := s.repo.CreateSkill(skill); err != nil {
+	if err := s.repo.CreateSkillWithContext(ctx, skill); err != nil {
 		log.Error("Failed to save skill to database", "error", err.Error(), "duration", time.Since(start))
+		if pkgerrors.Is(err, apperrors.ErrSkillAlreadyExists) {
+			// Surface the existing skill so clients can offer an update instead
+			if existing, getErr := s.repo.GetSkillWithContext(ctx, username, masterSkill.SkillID); getErr == nil {
+				return nil, &SkillExistsError{Existing: existing}
+			}
+		}
 		return nil, err
 	}
 
+	s.recordActivity(newSkillActivity(models.ActivitySkillAdded, skill, ""))
+
 	log.Info("Skill added successfully", "duration", time.Since(start))
 	return skill, nil
 }
 
+// resolveMasterSkill finds the active master skill a new user skill refers to
+// skillID is matched exactly; without it, skillName is normalized so " python " and "Python"
+// resolve to the same skill. Both empty fails with ErrRequiredField.
+func (s *SkillService) resolveMasterSkill(ctx context.Context, skillID, skillName string) (*models.Skill, error) {
+	log := logger.WithComponent("service").With("operation", "resolveMasterSkill", "skill_id", skillID, "skill", skillName)
+
+	notFound := apperrors.ErrMasterSkillNotFound
+	if skillID == "" {
+		skillID = models.NormalizeSkillID(skillName)
+		notFound = apperrors.ErrSkillNotFound
+	}
+	if skillID == "" {
+		return nil, pkgerrors.ErrRequiredField
+	}
+
+	masterSkill, err := s.masterSkillRepo.GetMasterSkillWithContext(ctx, skillID)
+	if pkgerrors.Is(err, apperrors.ErrTimeout) {
+		log.Error("Timed out looking up master skill", "error", err.Error())
+		return nil, err
+	}
+	if err != nil {
+		log.Debug("Master skill not found", "error", err.Error())
+		return nil, notFound
+	}
+
+	if masterSkill.Deleted {
+		log.Debug("Master skill is soft-deleted")
+		return nil, apperrors.ErrMasterSkillDeleted
+	}
+	return masterSkill, nil
+}
+
+// UpsertSkill adds a skill to a user, or updates it when the user already has it
+// On update the stored skill takes the given proficiency, years and notes, as a new skill
+// would, except that an empty proficiencyLevel keeps the stored level. created reports which
+// of the two happened.
+func (s *SkillService) UpsertSkill(ctx context.Context, username, skillID, skillName string, proficiencyLevel models.ProficiencyLevel, yearsOfExperience int, notes string) (skill *models.UserSkill, created bool, err error) {
+	log := logger.WithComponent("service").With("operation", "UpsertSkill", "username", username, "skill_id", skillID, "skill", skillName)
+	start := time.Now()
+
+	skill, err = s.AddSkill(ctx, username, skillID, skillName, proficiencyLevel, yearsOfExperience, notes)
+	if err == nil {
+		log.Info("Skill created by upsert", "duration", time.Since(start))
+		return skill, true, nil
+	}
+
+	var exists *SkillExistsError
+	if !pkgerrors.As(err, &exists) {
+		return nil, false, err
+	}
+
+	log.Debug("Skill already exists, updating instead", "skill_id", exists.Existing.SkillID)
+
+	var level *models.ProficiencyLevel
+	if proficiencyLevel != "" {
+		level = &proficiencyLevel
+	}
+
+	skill, err = s.UpdateSkill(ctx, username, exists.Existing.SkillID, level, &yearsOfExperience, &notes)
+	if err != nil {
+		log.Error("Failed to update existing skill", "error", err.Error(), "duration", time.Since(start))
+		return nil, false, err
+	}
+
+	log.Info("Skill updated by upsert", "duration", time.Since(start))
+	return skill, false, nil
+}
+
+// SkillExistsError reports that a user already has a skill, carrying the stored skill
+// It matches apperrors.ErrSkillAlreadyExists via errors.Is.
+type SkillExistsError struct {
+	Existing *models.UserSkill
+}
+
+func (e *SkillExistsError) Error() string {
+	return apperrors.ErrSkillAlreadyExists.Error()
+}
+
+func (e *SkillExistsError) Unwrap() error {
+	return apperrors.ErrSkillAlreadyExists
+}
+
+// SkillInput describes one skill to add in a batch request
+// SkillID takes precedence over SkillName and an empty ProficiencyLevel takes the default, as in AddSkill.
+type SkillInput struct {
+	SkillID           string
+	SkillName         string
+	ProficiencyLevel  models.ProficiencyLevel
+	YearsOfExperience int
+	Notes             string
+}
+
+// AddSkillsBatch adds several skills to a user at once
+// Every entry is validated and resolved against the master skills before anything is written;
+// the first invalid entry aborts the whole batch with an *apperrors.BatchItemError, and an
+// unknown user aborts it with ErrUserNotFound. Write failures are reported per item in the returned results.
+func (s *SkillService) AddSkillsBatch(username string, inputs []SkillInput) ([]dto.BatchResult[dto.SkillResponse], error) {
+	log := logger.WithComponent("service").With("operation", "AddSkillsBatch", "username", username, "count", len(inputs))
+	start := time.Now()
+
+	log.Info("Processing batch add skills request")
+
+	if len(inputs) == 0 {
+		log.Error("Batch contains no skills", "duration", time.Since(start))
+		return nil, pkgerrors.ErrRequiredField
+	}
+
+	if _, err := s.userRepo.GetUser(username); err != nil {
+		log.Error("User not found", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	skills := make([]*models.UserSkill, len(inputs))
+	seen := make(map[string]bool, len(inputs))
+	for i, input := range inputs {
+		masterSkill, err := s.resolveMasterSkill(context.Background(), input.SkillID, input.SkillName)
+		if err != nil {
+			log.Error("Master skill not resolved", "error", err.Error(), "index", i, "skill_id", input.SkillID, "skill", input.SkillName, "duration", time.Since(start))
+			return nil, &apperrors.BatchItemError{Index: i, Err: err}
+		}
+
+		if seen[masterSkill.SkillID] {
+			log.Error("Duplicate skill in batch", "index", i, "skill_id", masterSkill.SkillID, "duration", time.Since(start))
+			return nil, &apperrors.BatchItemError{Index: i, Err: apperrors.ErrSkillAlreadyExists}
+		}
+		seen[masterSkill.SkillID] = true
+
+		// The write skips existing skills too, but rejecting them here keeps the batch all-or-nothing
+		if _, err := s.repo.GetSkill(username, masterSkill.SkillID); err == nil {
+			log.Error("Skill already exists for user", "index", i, "skill_id", masterSkill.SkillID, "duration", time.Since(start))
+			return nil, &apperrors.BatchItemError{Index: i, Err: apperrors.ErrSkillAlreadyExists}
+		} else if !pkgerrors.Is(err, apperrors.ErrSkillNotFound) {
+			log.Error("Failed to check existing skill", "error", err.Error(), "index", i, "duration", time.Since(start))
+			return nil, err
+		}
+
+		level := input.ProficiencyLevel
+		if level == "" {
+			level = s.defaultLevel
+		}
+		if err := s.validator.ValidateSkillConsistency(level, input.YearsOfExperience); err != nil {
+			log.Error("Years of experience too low for proficiency level", "error", err.Error(), "index", i, "duration", time.Since(start))
+			return nil, &apperrors.BatchItemError{Index: i, Err: err}
+		}
+
+		skill, err := models.NewUserSkill(username, masterSkill.SkillID, masterSkill.SkillName, masterSkill.Category, level, input.YearsOfExperience)
+		if err != nil {
+			log.Error("Failed to create skill model", "error", err.Error(), "index", i, "duration", time.Since(start))
+			return nil, &apperrors.BatchItemError{Index: i, Err: err}
+		}
+
+		if input.Notes != "" {
+			skill.UpdateNotes(input.Notes)
+		}
+		skills[i] = skill
+	}
+
+	// Save skills to database, raising the owner's skill count in the same writes; a failure for
+	// the whole batch is reported against every item
+	failed := make(map[int]error)
+	if err := s.repo.BatchCreateSkills(username, skills); err != nil {
+		var writeErr *database.BatchWriteError
+		if pkgerrors.As(err, &writeErr) {
+			failed = writeErr.Failed
+		} else {
+			for i := range skills {
+				failed[i] = err
+			}
+		}
+		log.Error("Batch write reported failures", "error", err.Error(), "failed", len(failed))
+	}
+
+	results := make([]dto.BatchResult[dto.SkillResponse], len(skills))
+	for i, skill := range skills {
+		input := inputs[i].SkillName
+		if inputs[i].SkillID != "" {
+			input = inputs[i].SkillID
+		}
+		results[i] = dto.BatchResult[dto.SkillResponse]{Index: i, Input: input}
+		if err, ok := failed[i]; ok {
+			results[i].Err = err
+			continue
+		}
+		response := toSkillResponse(skill)
+		results[i].Success = true
+		results[i].Data = &response
+		s.recordActivity(newSkillActivity(models.ActivitySkillAdded, skill, ""))
+	}
+
+	log.Info("Batch add skills completed", "created", len(skills)-len(failed), "failed", len(failed), "duration", time.Since(start))
+	return results, nil
+}
+
 // GetSkill retrieves a specific skill for a user
-func (s *SkillService) GetSkill(username, skillName string) (*models.UserSkill, error) {
+func (s *SkillService) GetSkill(ctx context.Context, username, skillName string) (*models.UserSkill, error) {
 	log := logger.WithComponent("service").With("operation", "GetSkill", "username", username, "skill", skillName)
 	start := time.Now()
 
 	log.Debug("Retrieving skill")
 
-	skill, err := s.repo.GetSkill(username, skillName)
+	skill, err := s.repo.GetSkillWithContext(ctx, username, skillName)
 	if err != nil {
 		log.Error("Failed to get skill", "error", err.Error(), "duration", time.Since(start))
 		return nil, err
@@ -91,19 +394,34 @@ func (s *SkillService) GetSkill(username, skillName string) (*models.UserSkill,
 }
 
 // UpdateSkill updates an existing skill
-func (s *SkillService) UpdateSkill(username, skillName string, proficiencyLevel *models.ProficiencyLevel, yearsOfExperience *int, notes *string) (*models.UserSkill, error) {
+func (s *SkillService) UpdateSkill(ctx context.Context, username, skillName string, proficiencyLevel *models.ProficiencyLevel, yearsOfExperience *int, notes *string) (*models.UserSkill, error) {
 	log := logger.WithComponent("service").With("operation", "UpdateSkill", "username", username, "skill", skillName)
 	start := time.Now()
 
 	log.Info("Processing update skill request")
 
 	// Get existing skill
-	skill, err := s.repo.GetSkill(username, skillName)
+	skill, err := s.repo.GetSkillWithContext(ctx, username, skillName)
 	if err != nil {
 		log.Error("Failed to get skill", "error", err.Error(), "duration", time.Since(start))
 		return nil, err
 	}
 
+	previousLevel := skill.ProficiencyLevel
+
+	// Fields not given keep their stored values, so the check covers the resulting skill
+	level, years := skill.ProficiencyLevel, skill.YearsOfExperience
+	if proficiencyLevel != nil {
+		level = *proficiencyLevel
+	}
+	if yearsOfExperience != nil {
+		years = *yearsOfExperience
+	}
+	if err := s.validator.ValidateSkillConsistency(level, years); err != nil {
+		log.Info("Years of experience too low for proficiency level", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
 	// Update fields if provided
 	if proficiencyLevel != nil {
 		if err := skill.UpdateProficiency(*proficiencyLevel); err != nil {
@@ -124,125 +442,1341 @@ func (s *SkillService) UpdateSkill(username, skillName string, proficiencyLevel
 	}
 
 	// Save updated skill
-	if err := s.repo.UpdateSkill(skill); err != nil {
+	if err := s.repo.UpdateSkillWithContext(ctx, skill); err != nil {
 		log.Error("Failed to update skill in database", "error", err.Error(), "duration", time.Since(start))
 		return nil, err
 	}
 
+	// Record proficiency changes; the update itself has already succeeded, so a failure here is only logged
+	if skill.ProficiencyLevel != previousLevel {
+		history := models.NewSkillHistory(skill.Username, skill.SkillID, previousLevel, skill.ProficiencyLevel)
+		if err := s.historyRepo.CreateSkillHistory(history); err != nil {
+			log.Error("Failed to record skill history", "error", err.Error(), "old_level", previousLevel, "new_level", skill.ProficiencyLevel)
+		}
+	}
+	s.recordActivity(newSkillActivity(models.ActivitySkillUpdated, skill, previousLevel))
+
 	log.Info("Skill updated successfully", "duration", time.Since(start))
 	return skill, nil
 }
 
+// ListSkillHistory returns the proficiency history of a user's skill, newest first
+func (s *SkillService) ListSkillHistory(username, skillName string) ([]dto.SkillHistoryResponse, error) {
+	log := logger.WithComponent("service").With("operation", "ListSkillHistory", "username", username, "skill", skillName)
+	start := time.Now()
+
+	log.Debug("Retrieving skill history")
+
+	entries, err := s.historyRepo.ListSkillHistory(username, skillName)
+	if err != nil {
+		log.Error("Failed to retrieve skill history", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	result := make([]dto.SkillHistoryResponse, len(entries))
+	for i, entry := range entries {
+		result[i] = dto.SkillHistoryResponse{
+			SkillName: entry.SkillID,
+			OldLevel:  string(entry.OldLevel),
+			NewLevel:  string(entry.NewLevel),
+			ChangedAt: entry.ChangedAt.Format(time.RFC3339Nano),
+		}
+	}
+
+	log.Info("Skill history retrieved successfully", "count", len(result), "duration", time.Since(start))
+	return result, nil
+}
+
+// ListActivity returns one page of a user's activity log, newest first
+// limit defaults to 20 and is capped at 100; the returned page's Next token is empty on the last page.
+func (s *SkillService) ListActivity(username string, limit int, nextToken string) (*dto.ActivityPageResponse, error) {
+	log := logger.WithComponent("service").With("operation", "ListActivity", "username", username, "limit", limit)
+	start := time.Now()
+
+	log.Debug("Retrieving activity")
+
+	if limit <= 0 {
+		limit = defaultActivityLimit
+	}
+	if limit > maxActivityLimit {
+		limit = maxActivityLimit
+	}
+
+	entries, next, err := s.activityRepo.ListActivity(username, limit, nextToken)
+	if err != nil {
+		log.Error("Failed to retrieve activity", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	page := &dto.ActivityPageResponse{Activity: make([]dto.ActivityResponse, len(entries)), Next: next}
+	for i, entry := range entries {
+		page.Activity[i] = dto.ActivityResponse{
+			Action:     string(entry.Action),
+			SkillID:    entry.SkillID,
+			SkillName:  entry.SkillName,
+			OldLevel:   string(entry.OldLevel),
+			NewLevel:   string(entry.NewLevel),
+			OccurredAt: entry.OccurredAt.Format(time.RFC3339Nano),
+		}
+	}
+
+	log.Info("Activity retrieved successfully", "count", len(page.Activity), "has_more", next != "", "duration", time.Since(start))
+	return page, nil
+}
+
+// newSkillActivity describes action on skill for the activity log
+// Both levels are recorded when skill's level differs from previousLevel; pass an empty
+// previousLevel for a new skill to record only its starting level.
+func newSkillActivity(action models.ActivityAction, skill *models.UserSkill, previousLevel models.ProficiencyLevel) *models.ActivityLog {
+	activity := models.NewActivityLog(skill.Username, action, skill.SkillID, skill.SkillName)
+	if skill.ProficiencyLevel != previousLevel {
+		activity.OldLevel, activity.NewLevel = previousLevel, skill.ProficiencyLevel
+	}
+	return activity
+}
+
+// recordActivity appends an entry to the user's activity log
+// The change it describes has already been saved, so a failure here is only logged.
+func (s *SkillService) recordActivity(activity *models.ActivityLog) {
+	if err := s.activityRepo.CreateActivity(activity); err != nil {
+		logger.WithComponent("service").Error("Failed to record activity", "operation", "recordActivity", "username", activity.Username, "action", activity.Action, "skill", activity.SkillID, "error", err.Error())
+	}
+}
+
 // DeleteSkill removes a skill from a user
-func (s *SkillService) DeleteSkill(username, skillName string) error {
+func (s *SkillService) DeleteSkill(ctx context.Context, username, skillName string) error {
 	log := logger.WithComponent("service").With("operation", "DeleteSkill", "username", username, "skill", skillName)
 	start := time.Now()
 
 	log.Info("Processing delete skill request")
 
-	if err := s.repo.DeleteSkill(username, skillName); err != nil {
+	if err := s.repo.DeleteSkillWithContext(ctx, username, skillName); err != nil {
 		log.Error("Failed to delete skill", "error", err.Error(), "duration", time.Since(start))
 		return err
 	}
 
+	// The skill is gone either way; leftover endorsements only block re-endorsing a re-added skill
+	if deleted, err := s.endorsementRepo.DeleteEndorsementsForSkill(username, skillName); err != nil {
+		log.Error("Failed to delete skill endorsements", "error", err.Error())
+	} else if deleted > 0 {
+		log.Debug("Skill endorsements deleted", "count", deleted)
+	}
+
+	// The name is not read back before deleting, so the entry only carries the skill ID
+	s.recordActivity(models.NewActivityLog(username, models.ActivitySkillDeleted, skillName, ""))
+
 	log.Info("Skill deleted successfully", "duration", time.Since(start))
 	return nil
 }
 
-// ListSkillsForUser retrieves all skills for a user
-func (s *SkillService) ListSkillsForUser(username string) ([]dto.SkillResponse, error) {
-	log := logger.WithComponent("service").With("operation", "ListSkillsForUser", "username", username)
+// DeleteSkillsBatch removes several of a user's skills at once
+// Each name gets its own result: deleted, or failed with ErrSkillNotFound when the user does
+// not have it. Repeated names are only deleted once.
+func (s *SkillService) DeleteSkillsBatch(username string, names []string) ([]dto.BatchResult[dto.SkillResponse], error) {
+	log := logger.WithComponent("service").With("operation", "DeleteSkillsBatch", "username", username, "count", len(names))
 	start := time.Now()
 
-	log.Info("Retrieving skills for user")
+	log.Info("Processing batch delete skills request")
 
-	// Check if user exists
-	if _, err := s.userRepo.GetUser(username); err != nil {
-		log.Error("User not found", "error", err.Error(), "duration", time.Since(start))
-		return nil, err
+	if len(names) == 0 {
+		log.Error("Batch contains no skills", "duration", time.Since(start))
+		return nil, pkgerrors.ErrRequiredField
 	}
 
-	skills, err := s.repo.ListSkillsForUser(username)
-	if err != nil {
-		log.Error("Failed to retrieve skills", "error", err.Error(), "duration", time.Since(start))
-		return nil, err
+	unique := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if !seen[name] {
+			seen[name] = true
+			unique = append(unique, name)
+		}
 	}
 
-	// Convert to response DTOs
-	result := make([]dto.SkillResponse, len(skills))
-	for i, skill := range skills {
-		result[i] = dto.SkillResponse{
-			SkillName:         skill.SkillName,
-			ProficiencyLevel:  string(skill.ProficiencyLevel),
-			YearsOfExperience: skill.YearsOfExperience,
-			Endorsements:      skill.Endorsements,
-			LastUsedDate:      skill.LastUsedDate,
-			Notes:             skill.Notes,
-			CreatedAt:         skill.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:         skill.UpdatedAt.Format(time.RFC3339),
+	// Batch deletes succeed for missing items, so existing skills are looked up first
+	results := make([]dto.BatchResult[dto.SkillResponse], len(unique))
+	var skillIDs []string
+	var indexes []int
+	for i, name := range unique {
+		results[i] = dto.BatchResult[dto.SkillResponse]{Index: i, Input: name}
+
+		skill, err := s.repo.GetSkill(username, name)
+		if err != nil {
+			log.Info("Skill not deleted", "error", err.Error(), "index", i, "skill", name)
+			results[i].Err = err
+			continue
 		}
+
+		response := toSkillResponse(skill)
+		results[i].Data = &response
+		skillIDs = append(skillIDs, skill.SkillID)
+		indexes = append(indexes, i)
 	}
 
-	log.Info("Skills retrieved successfully", "count", len(result), "duration", time.Since(start))
-	return result, nil
+	if len(skillIDs) == 0 {
+		log.Info("No skills to delete", "duration", time.Since(start))
+		return results, nil
+	}
+
+	// The repository decrements SkillCount by what it removed, so a skill deleted concurrently
+	// since the lookup fails with ErrSkillNotFound instead of being counted twice.
+	// A failure for the whole batch is reported against every skill that was found
+	failed := make(map[int]error)
+	if err := s.repo.BatchDeleteSkills(username, skillIDs); err != nil {
+		var writeErr *database.BatchWriteError
+		if pkgerrors.As(err, &writeErr) {
+			failed = writeErr.Failed
+		} else {
+			for i := range skillIDs {
+				failed[i] = err
+			}
+		}
+		log.Error("Batch delete reported failures", "error", err.Error(), "failed", len(failed))
+	}
+
+	deleted := 0
+	for i, skillID := range skillIDs {
+		result := &results[indexes[i]]
+		if err, ok := failed[i]; ok {
+			result.Data = nil
+			result.Err = err
+			continue
+		}
+		result.Success = true
+		deleted++
+		s.recordActivity(models.NewActivityLog(username, models.ActivitySkillDeleted, skillID, result.Data.SkillName))
+
+		// The skill is gone either way; leftover endorsements only block re-endorsing a re-added skill
+		if _, err := s.endorsementRepo.DeleteEndorsementsForSkill(username, skillID); err != nil {
+			log.Error("Failed to delete skill endorsements", "error", err.Error(), "skill_id", skillID)
+		}
+	}
+
+	log.Info("Batch delete skills completed", "deleted", deleted, "failed", len(results)-deleted, "duration", time.Since(start))
+	return results, nil
 }
 
-// ListUsersBySkill retrieves all users who have a specific skill in a category
-func (s *SkillService) ListUsersBySkill(category, skillName string) ([]dto.UserSkillResponse, error) {
-	log := logger.WithComponent("service").With("operation", "ListUsersBySkill", "category", category, "skill", skillName)
+// MarkSkillUsed sets a user's skill last used date to today
+func (s *SkillService) MarkSkillUsed(username, skillName string) (*models.UserSkill, error) {
+	log := logger.WithComponent("service").With("operation", "MarkSkillUsed", "username", username, "skill", skillName)
 	start := time.Now()
 
-	log.Info("Retrieving users by skill")
+	log.Info("Processing mark skill used request")
 
-	skills, err := s.repo.ListUsersBySkill(category, skillName)
+	skill, err := s.repo.GetSkill(username, skillName)
 	if err != nil {
-		log.Error("Failed to retrieve users by skill", "error", err.Error(), "duration", time.Since(start))
+		log.Error("Failed to retrieve skill", "error", err.Error(), "duration", time.Since(start))
 		return nil, err
 	}
 
-	// Convert to response DTOs
-	result := make([]dto.UserSkillResponse, len(skills))
-	for i, skill := range skills {
-		result[i] = dto.UserSkillResponse{
-			Username:          skill.Username,
-			SkillName:         skill.SkillName,
-			ProficiencyLevel:  string(skill.ProficiencyLevel),
-			YearsOfExperience: skill.YearsOfExperience,
-			Endorsements:      skill.Endorsements,
-			LastUsedDate:      skill.LastUsedDate,
-		}
+	skill.UpdateLastUsed()
+	if err := s.repo.UpdateSkill(skill); err != nil {
+		log.Error("Failed to save last used date", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
 	}
 
-	log.Info("Users with skill retrieved successfully", "category", category, "skill", skillName, "count", len(result), "duration", time.Since(start))
-	return result, nil
+	log.Info("Skill marked as used", "last_used_date", skill.LastUsedDate, "duration", time.Since(start))
+	return skill, nil
 }
 
-// ListUsersBySkillAndLevel retrieves users with a skill at a specific proficiency level in a category
-func (s *SkillService) ListUsersBySkillAndLevel(category, skillName string, proficiencyLevel models.ProficiencyLevel) ([]dto.UserSkillResponse, error) {
-	log := logger.WithComponent("service").With("operation", "ListUsersBySkillAndLevel", "category", category, "skill", skillName, "level", proficiencyLevel)
+// PromoteSkill raises a user's skill by exactly one proficiency level and records the change in its history
+// A skill already at Expert is left unchanged and apperrors.ErrSkillAtHighestLevel is returned;
+// one whose years of experience are too low for the next level fails with ErrInconsistentExperience.
+func (s *SkillService) PromoteSkill(username, skillName string) (*models.UserSkill, error) {
+	log := logger.WithComponent("service").With("operation", "PromoteSkill", "username", username, "skill", skillName)
 	start := time.Now()
 
-	log.Info("Retrieving users by skill and level")
+	log.Info("Processing promote skill request")
 
-	skills, err := s.repo.ListUsersBySkillAndLevel(category, skillName, proficiencyLevel)
+	skill, err := s.repo.GetSkill(username, skillName)
 	if err != nil {
-		log.Error("Failed to retrieve users by skill and level", "error", err.Error(), "duration", time.Since(start))
+		log.Error("Failed to retrieve skill", "error", err.Error(), "duration", time.Since(start))
 		return nil, err
 	}
 
-	// Convert to response DTOs
-	result := make([]dto.UserSkillResponse, len(skills))
-	for i, skill := range skills {
-		result[i] = dto.UserSkillResponse{
-			Username:          skill.Username,
-			SkillName:         skill.SkillName,
-			ProficiencyLevel:  string(skill.ProficiencyLevel),
-			YearsOfExperience: skill.YearsOfExperience,
-			Endorsements:      skill.Endorsements,
-			LastUsedDate:      skill.LastUsedDate,
+	previousLevel := skill.ProficiencyLevel
+	next, ok := previousLevel.Next()
+	if !ok {
+		log.Info("Skill is already at the highest level", "level", previousLevel, "duration", time.Since(start))
+		return nil, apperrors.ErrSkillAtHighestLevel
+	}
+
+	if err := s.validator.ValidateSkillConsistency(next, skill.YearsOfExperience); err != nil {
+		log.Info("Years of experience too low for the next level", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	if err := skill.UpdateProficiency(next); err != nil {
+		log.Error("Failed to update proficiency level", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+	if err := s.repo.UpdateSkill(skill); err != nil {
+		log.Error("Failed to save promoted skill", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	s.recordPromotion(skill, previousLevel)
+
+	log.Info("Skill promoted successfully", "old_level", previousLevel, "new_level", next, "duration", time.Since(start))
+	return skill, nil
+}
+
+// ResyncUserSkills copies the master skill's current name and category onto every user skill
+// referencing it and returns how many were stale. UpdatedAt is left alone so the resync
+// does not flood the recently updated feed.
+func (s *SkillService) ResyncUserSkills(skillID string) (int, error) {
+	log := logger.WithComponent("service").With("operation", "ResyncUserSkills", "skill_id", skillID)
+	start := time.Now()
+
+	log.Info("Processing resync user skills request")
+
+	masterSkill, err := s.masterSkillRepo.GetMasterSkill(skillID)
+	if err != nil {
+		log.Error("Failed to retrieve master skill", "error", err.Error(), "duration", time.Since(start))
+		return 0, err
+	}
+
+	skills, err := s.repo.ListSkillsBySkillID(skillID)
+	if err != nil {
+		log.Error("Failed to list user skills", "error", err.Error(), "duration", time.Since(start))
+		return 0, err
+	}
+
+	// Only the two denormalized fields are written, so concurrent edits to a skill are kept;
+	// a skill deleted since it was listed is skipped
+	updated := 0
+	for _, skill := range skills {
+		if skill.SkillName == masterSkill.SkillName && skill.Category == masterSkill.Category {
+			continue
+		}
+		err := s.repo.RenameSkill(skill.Username, skill.SkillID, masterSkill.SkillName, masterSkill.Category)
+		if pkgerrors.Is(err, apperrors.ErrSkillNotFound) {
+			log.Debug("Skill deleted before resync", "username", skill.Username)
+			continue
 		}
+		if err != nil {
+			log.Error("Failed to save resynced skill", "error", err.Error(), "username", skill.Username, "updated", updated, "duration", time.Since(start))
+			return 0, err
+		}
+		updated++
 	}
 
-	log.Info("Users with skill and level retrieved successfully", "category", category, "skill", skillName, "level", proficiencyLevel, "count", len(result), "duration", time.Since(start))
-	return result, nil
+	log.Info("User skills resynced successfully", "checked", len(skills), "updated", updated, "duration", time.Since(start))
+	return updated, nil
+}
+
+// EndorseSkill records an endorsement from endorser on targetUser's skill
+// Each endorser may endorse a skill once (ErrAlreadyEndorsed otherwise); endorsers cannot
+// endorse their own skills
+func (s *SkillService) EndorseSkill(endorser, targetUser, skillName string) (*models.UserSkill, error) {
+	log := logger.WithComponent("service").With("operation", "EndorseSkill", "endorser", endorser, "username", targetUser, "skill", skillName)
+	start := time.Now()
+
+	log.Info("Processing endorse skill request")
+
+	if strings.EqualFold(endorser, targetUser) {
+		log.Info("Self-endorsement attempt rejected", "duration", time.Since(start))
+		return nil, apperrors.ErrSelfEndorsement
+	}
+
+	// Get existing skill
+	skill, err := s.repo.GetSkill(targetUser, skillName)
+	if err != nil {
+		log.Error("Failed to get skill", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	skill, err = s.endorse(skill.Username, skill.SkillID, endorser)
+	if err != nil {
+		log.Error("Failed to save endorsement", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	log.Info("Skill endorsed successfully", "endorsements", skill.Endorsements, "level", skill.ProficiencyLevel, "promotion_eligible", skill.PromotionEligible, "duration", time.Since(start))
+	return skill, nil
+}
+
+// endorse records endorser's endorsement of username's skill and returns the skill as counted
+// The record and the count change in a single write, so a duplicate never moves the count and
+// concurrent endorsers never lose one. A promotion the new count earns is saved afterwards.
+func (s *SkillService) endorse(username, skillID, endorser string) (*models.UserSkill, error) {
+	if err := s.endorsementRepo.AddEndorsement(models.NewEndorsement(username, skillID, endorser)); err != nil {
+		return nil, err
+	}
+
+	stored, err := s.repo.GetSkill(username, skillID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Promotion changes a copy, so the skill read stays as stored if the level write is rejected
+	skill := *stored
+	previousLevel := s.applyPromotion(&skill)
+	s.savePromotion(&skill, previousLevel)
+	return &skill, nil
+}
+
+// savePromotion saves and records a level change made by applyPromotion
+// The endorsement that earned it is already counted, so a failed write is only logged: the skill
+// is reported at its stored level and the promotion is offered again by the next endorsement.
+func (s *SkillService) savePromotion(skill *models.UserSkill, previousLevel models.ProficiencyLevel) {
+	if skill.ProficiencyLevel == previousLevel {
+		return
+	}
+
+	if err := s.repo.SetSkillLevel(skill.Username, skill.SkillID, previousLevel, skill.ProficiencyLevel); err != nil {
+		log := logger.WithComponent("service").With("operation", "savePromotion", "username", skill.Username, "skill", skill.SkillID)
+		if pkgerrors.Is(err, database.ErrSkillLevelChanged) {
+			log.Info("Skill level changed concurrently, promotion skipped", "old_level", previousLevel, "new_level", skill.ProficiencyLevel)
+		} else {
+			log.Error("Failed to save skill promotion", "error", err.Error(), "old_level", previousLevel, "new_level", skill.ProficiencyLevel)
+		}
+		skill.ProficiencyLevel = previousLevel
+		_, skill.PromotionEligible = s.promotion.NextLevel(skill)
+		return
+	}
+	s.recordPromotion(skill, previousLevel)
+}
+
+// RemoveEndorsement withdraws endorser's endorsement of targetUser's skill
+// The record and the count change in a single write. The proficiency level is left alone:
+// promotions already granted are not reverted.
+func (s *SkillService) RemoveEndorsement(endorser, targetUser, skillName string) (*models.UserSkill, error) {
+	log := logger.WithComponent("service").With("operation", "RemoveEndorsement", "endorser", endorser, "username", targetUser, "skill", skillName)
+	start := time.Now()
+
+	log.Info("Processing remove endorsement request")
+
+	skill, err := s.repo.GetSkill(targetUser, skillName)
+	if err != nil {
+		log.Error("Failed to get skill", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	if err := s.endorsementRepo.RemoveEndorsement(skill.Username, skill.SkillID, endorser); err != nil {
+		log.Info("Failed to delete endorsement", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	stored, err := s.repo.GetSkill(skill.Username, skill.SkillID)
+	if err != nil {
+		log.Error("Failed to get skill after endorsement removal", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	updated := *stored
+	_, updated.PromotionEligible = s.promotion.NextLevel(&updated)
+
+	log.Info("Endorsement removed successfully", "endorsements", updated.Endorsements, "duration", time.Since(start))
+	return &updated, nil
+}
+
+// ListEndorsements returns who has endorsed targetUser's skill, ordered by endorser
+func (s *SkillService) ListEndorsements(targetUser, skillName string) ([]dto.EndorsementResponse, error) {
+	log := logger.WithComponent("service").With("operation", "ListEndorsements", "username", targetUser, "skill", skillName)
+	start := time.Now()
+
+	log.Debug("Retrieving endorsements")
+
+	skill, err := s.repo.GetSkill(targetUser, skillName)
+	if err != nil {
+		log.Error("Failed to get skill", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	endorsements, err := s.endorsementRepo.ListEndorsements(skill.Username, skill.SkillID)
+	if err != nil {
+		log.Error("Failed to retrieve endorsements", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	result := make([]dto.EndorsementResponse, len(endorsements))
+	for i, endorsement := range endorsements {
+		result[i] = dto.EndorsementResponse{
+			Endorser:   endorsement.Endorser,
+			EndorsedAt: endorsement.EndorsedAt.Format(time.RFC3339Nano),
+		}
+	}
+
+	log.Info("Endorsements retrieved successfully", "count", len(result), "duration", time.Since(start))
+	return result, nil
+}
+
+// applyPromotion checks the skill against the promotion policy after an endorsement
+// With auto-promotion enabled an eligible skill is moved up one level, unless its years of
+// experience are too low for that level; otherwise it is only flagged as eligible. It returns
+// the level the skill held before, for recordPromotion.
+func (s *SkillService) applyPromotion(skill *models.UserSkill) models.ProficiencyLevel {
+	previousLevel := skill.ProficiencyLevel
+
+	next, ok := s.promotion.NextLevel(skill)
+	if !ok {
+		return previousLevel
+	}
+
+	if !s.promotion.AutoPromote {
+		skill.PromotionEligible = true
+		return previousLevel
+	}
+
+	if err := s.validator.ValidateSkillConsistency(next, skill.YearsOfExperience); err != nil {
+		return previousLevel
+	}
+	if err := skill.UpdateProficiency(next); err != nil {
+		return previousLevel
+	}
+	// The new level may itself already be within reach
+	_, skill.PromotionEligible = s.promotion.NextLevel(skill)
+
+	return previousLevel
+}
+
+// recordPromotion writes a history and an activity entry when a promotion changed the skill's level
+// The promoted skill has already been saved, so a failure here is only logged.
+func (s *SkillService) recordPromotion(skill *models.UserSkill, previousLevel models.ProficiencyLevel) {
+	if skill.ProficiencyLevel == previousLevel {
+		return
+	}
+
+	log := logger.WithComponent("service").With("operation", "recordPromotion", "username", skill.Username, "skill", skill.SkillID)
+	s.recordActivity(newSkillActivity(models.ActivitySkillPromoted, skill, previousLevel))
+
+	history := models.NewSkillHistory(skill.Username, skill.SkillID, previousLevel, skill.ProficiencyLevel)
+	if err := s.historyRepo.CreateSkillHistory(history); err != nil {
+		log.Error("Failed to record skill history", "error", err.Error(), "old_level", previousLevel, "new_level", skill.ProficiencyLevel)
+		return
+	}
+	log.Info("Skill promotion recorded", "old_level", previousLevel, "new_level", skill.ProficiencyLevel)
+}
+
+// EndorseUsersForSkill endorses skillName for each of usernames on behalf of endorser
+// Users are endorsed independently: a user who lacks the skill (or holds it outside category,
+// when one is given) is reported as failed without affecting the others. A single request may
+// endorse at most maxBulkEndorsements distinct users.
+func (s *SkillService) EndorseUsersForSkill(endorser, skillName, category string, usernames []string) ([]dto.BatchResult[dto.SkillResponse], error) {
+	log := logger.WithComponent("service").With("operation", "EndorseUsersForSkill", "endorser", endorser, "skill", skillName, "category", category, "count", len(usernames))
+	start := time.Now()
+
+	log.Info("Processing bulk endorse request")
+
+	if len(usernames) == 0 {
+		log.Error("Bulk endorsement contains no users", "duration", time.Since(start))
+		return nil, pkgerrors.ErrRequiredField
+	}
+
+	if category != "" {
+		if err := models.ValidateCategory(category); err != nil {
+			log.Info("Invalid category", "duration", time.Since(start))
+			return nil, err
+		}
+	}
+
+	// Each user is endorsed at most once per request
+	unique := make([]string, 0, len(usernames))
+	seen := make(map[string]bool, len(usernames))
+	for _, username := range usernames {
+		if username == "" || seen[username] {
+			continue
+		}
+		seen[username] = true
+		unique = append(unique, username)
+	}
+
+	if len(unique) > maxBulkEndorsements {
+		log.Info("Bulk endorsement exceeds per-request cap", "users", len(unique), "duration", time.Since(start))
+		return nil, apperrors.ErrEndorsementLimitExceeded
+	}
+
+	results := make([]dto.BatchResult[dto.SkillResponse], len(unique))
+	endorsed := 0
+	for i, username := range unique {
+		results[i] = dto.BatchResult[dto.SkillResponse]{Index: i, Input: username}
+
+		if strings.EqualFold(endorser, username) {
+			results[i].Err = apperrors.ErrSelfEndorsement
+			continue
+		}
+
+		skill, err := s.repo.GetSkill(username, skillName)
+		if err == nil && category != "" && skill.Category != category {
+			err = apperrors.ErrSkillNotFound
+		}
+		if err != nil {
+			log.Debug("Skipping user without skill", "username", username, "error", err.Error())
+			results[i].Err = err
+			continue
+		}
+
+		skill, err = s.endorse(skill.Username, skill.SkillID, endorser)
+		if err != nil {
+			log.Error("Failed to save endorsement", "error", err.Error(), "username", username)
+			results[i].Err = err
+			continue
+		}
+
+		response := toSkillResponse(skill)
+		results[i].Success = true
+		results[i].Data = &response
+		endorsed++
+	}
+
+	log.Info("Bulk endorse completed", "endorsed", endorsed, "failed", len(unique)-endorsed, "duration", time.Since(start))
+	return results, nil
+}
+
+// TeamSkillMatrix pivots the skills usernames hold in category into a matrix
+// Columns are the sorted union of the members' skill names; a member without a skill gets an
+// empty cell. Rows follow the order of usernames with duplicates removed.
+func (s *SkillService) TeamSkillMatrix(category string, usernames []string) (*dto.TeamMatrix, error) {
+	log := logger.WithComponent("service").With("operation", "TeamSkillMatrix", "category", category, "count", len(usernames))
+	start := time.Now()
+
+	log.Info("Building team skills matrix")
+
+	if category == "" || len(usernames) == 0 {
+		log.Error("Team matrix request is missing category or users", "duration", time.Since(start))
+		return nil, pkgerrors.ErrRequiredField
+	}
+
+	if err := models.ValidateCategory(category); err != nil {
+		log.Info("Invalid category", "duration", time.Since(start))
+		return nil, err
+	}
+
+	unique := make([]string, 0, len(usernames))
+	seen := make(map[string]bool, len(usernames))
+	for _, username := range usernames {
+		if username == "" || seen[username] {
+			continue
+		}
+		seen[username] = true
+		unique = append(unique, username)
+	}
+
+	if len(unique) > maxTeamMatrixUsers {
+		log.Info("Team matrix exceeds per-request cap", "users", len(unique), "duration", time.Since(start))
+		return nil, apperrors.ErrTeamMatrixLimitExceeded
+	}
+
+	// Collect each member's levels by skill name, tracking the union of skill names
+	levels := make([]map[string]models.ProficiencyLevel, len(unique))
+	columns := make(map[string]bool)
+	for i, username := range unique {
+		if _, err := s.userRepo.GetUser(username); err != nil {
+			log.Error("User not found", "error", err.Error(), "username", username, "duration", time.Since(start))
+			return nil, err
+		}
+
+		skills, err := s.repo.ListSkillsForUser(username)
+		if err != nil {
+			log.Error("Failed to retrieve skills", "error", err.Error(), "username", username, "duration", time.Since(start))
+			return nil, err
+		}
+
+		levels[i] = make(map[string]models.ProficiencyLevel)
+		for _, skill := range skills {
+			if skill.Category != category {
+				continue
+			}
+			levels[i][skill.SkillName] = skill.ProficiencyLevel
+			columns[skill.SkillName] = true
+		}
+	}
+
+	matrix := &dto.TeamMatrix{
+		Category: category,
+		Skills:   make([]string, 0, len(columns)),
+		Rows:     make([]dto.TeamMatrixRow, len(unique)),
+	}
+	for name := range columns {
+		matrix.Skills = append(matrix.Skills, name)
+	}
+	sort.Strings(matrix.Skills)
+
+	for i, username := range unique {
+		row := dto.TeamMatrixRow{Username: username, Levels: make([]string, len(matrix.Skills))}
+		for j, name := range matrix.Skills {
+			row.Levels[j] = string(levels[i][name])
+		}
+		matrix.Rows[i] = row
+	}
+
+	log.Info("Team skills matrix built", "users", len(unique), "skills", len(matrix.Skills), "duration", time.Since(start))
+	return matrix, nil
+}
+
+// ListSkillsForUser retrieves all skills for a user
+// A non-zero usedSince keeps only skills whose last used date may fall on or after that instant.
+func (s *SkillService) ListSkillsForUser(ctx context.Context, username string, usedSince time.Time) ([]dto.SkillResponse, error) {
+	log := logger.WithComponent("service").With("operation", "ListSkillsForUser", "username", username)
+	start := time.Now()
+
+	log.Info("Retrieving skills for user")
+
+	// Check if user exists
+	if _, err := s.userRepo.GetUserWithContext(ctx, username); err != nil {
+		log.Error("User not found", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	skills, err := s.repo.ListSkillsForUserWithContext(ctx, username)
+	if err != nil {
+		log.Error("Failed to retrieve skills", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	// Convert to response DTOs
+	result := make([]dto.SkillResponse, 0, len(skills))
+	for _, skill := range skills {
+		if !usedSince.IsZero() && !dateutil.UsedSince(skill.LastUsedDate, usedSince) {
+			continue
+		}
+		result = append(result, toSkillResponse(skill))
+	}
+
+	log.Info("Skills retrieved successfully", "count", len(result), "duration", time.Since(start))
+	return result, nil
+}
+
+// ListSkillsForUsers retrieves the skills of several users at once, keyed by username
+// Queries fan out at most maxUserSkillQueries at a time. Unknown users are not an error: like
+// users without skills and archived users they map to an empty list. Blank and repeated usernames are ignored, and
+// at most maxBulkSkillsUsers distinct users may be requested.
+func (s *SkillService) ListSkillsForUsers(ctx context.Context, usernames []string) (map[string][]dto.SkillResponse, error) {
+	log := logger.WithComponent("service").With("operation", "ListSkillsForUsers", "count", len(usernames))
+	start := time.Now()
+
+	log.Info("Retrieving skills for users")
+
+	unique := make([]string, 0, len(usernames))
+	seen := make(map[string]bool, len(usernames))
+	for _, username := range usernames {
+		if username == "" || seen[username] {
+			continue
+		}
+		seen[username] = true
+		unique = append(unique, username)
+	}
+
+	if len(unique) == 0 {
+		log.Error("Request contains no usernames", "duration", time.Since(start))
+		return nil, pkgerrors.ErrRequiredField
+	}
+	if len(unique) > maxBulkSkillsUsers {
+		log.Info("Bulk skills request exceeds per-request cap", "users", len(unique), "duration", time.Since(start))
+		return nil, apperrors.ErrBulkSkillsLimitExceeded
+	}
+
+	// Skills are keyed by their owner, so a user who does not exist simply has none; those of
+	// archived users are dropped as in every other cross-user query
+	results := make([][]*models.UserSkill, len(unique))
+	errs := make([]error, len(unique))
+
+	var wg sync.WaitGroup
+	limit := make(chan struct{}, maxUserSkillQueries)
+	for i, username := range unique {
+		wg.Add(1)
+		go func(i int, username string) {
+			defer wg.Done()
+			limit <- struct{}{}
+			defer func() { <-limit }()
+
+			skills, err := s.repo.ListSkillsForUserWithContext(ctx, username)
+			if err == nil {
+				skills, err = s.excludeArchivedUsers(skills)
+			}
+			results[i], errs[i] = skills, err
+		}(i, username)
+	}
+	wg.Wait()
+
+	skillsByUser := make(map[string][]dto.SkillResponse, len(unique))
+	for i, username := range unique {
+		if errs[i] != nil {
+			log.Error("Failed to retrieve skills", "error", errs[i].Error(), "username", username, "duration", time.Since(start))
+			return nil, errs[i]
+		}
+		skills := make([]dto.SkillResponse, len(results[i]))
+		for j, skill := range results[i] {
+			skills[j] = toSkillResponse(skill)
+		}
+		skillsByUser[username] = skills
+	}
+
+	log.Info("Skills retrieved for users", "users", len(unique), "duration", time.Since(start))
+	return skillsByUser, nil
+}
+
+// FindStaleSkills returns a user's skills last used more than olderThan ago, oldest first
+// It only reads: nothing is changed on the stale skills. Skills whose last used date cannot
+// be parsed are logged and skipped.
+func (s *SkillService) FindStaleSkills(username string, olderThan time.Duration) ([]dto.SkillResponse, error) {
+	log := logger.WithComponent("service").With("operation", "FindStaleSkills", "username", username, "older_than", olderThan)
+	start := time.Now()
+
+	log.Info("Finding stale skills")
+
+	if _, err := s.userRepo.GetUser(username); err != nil {
+		log.Error("User not found", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	skills, err := s.repo.ListSkillsForUser(username)
+	if err != nil {
+		log.Error("Failed to retrieve skills", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-olderThan)
+	type staleSkill struct {
+		skill    *models.UserSkill
+		lastUsed time.Time
+	}
+	var stale []staleSkill
+	for _, skill := range skills {
+		lastUsed, err := dateutil.ParseDate(skill.LastUsedDate)
+		if err != nil {
+			log.Warn("Skipping skill with unreadable last used date", "skill_id", skill.SkillID, "last_used_date", skill.LastUsedDate)
+			continue
+		}
+		// The whole last used day must be before the cutoff
+		if lastUsed.Add(24 * time.Hour).After(cutoff) {
+			continue
+		}
+		stale = append(stale, staleSkill{skill: skill, lastUsed: lastUsed})
+	}
+
+	sort.Slice(stale, func(i, j int) bool {
+		if !stale[i].lastUsed.Equal(stale[j].lastUsed) {
+			return stale[i].lastUsed.Before(stale[j].lastUsed)
+		}
+		return stale[i].skill.SkillName < stale[j].skill.SkillName
+	})
+
+	result := make([]dto.SkillResponse, 0, len(stale))
+	for _, entry := range stale {
+		result = append(result, toSkillResponse(entry.skill))
+	}
+
+	log.Info("Stale skills found", "checked", len(skills), "stale", len(result), "duration", time.Since(start))
+	return result, nil
+}
+
+// ListUsersBySkill retrieves all users who have a specific skill in a category
+// With neither a limit nor a token every page is read, so the result is complete and the
+// returned token is empty; otherwise one page is returned with the token of the next.
+func (s *SkillService) ListUsersBySkill(category, skillName string, limit int, nextToken string) ([]dto.UserSkillResponse, string, error) {
+	log := logger.WithComponent("service").With("operation", "ListUsersBySkill", "category", category, "skill", skillName, "limit", limit)
+	start := time.Now()
+
+	log.Info("Retrieving users by skill")
+
+	if err := validateCategoryFilter(category); err != nil {
+		log.Info("Invalid category for users-by-skill query", "duration", time.Since(start))
+		return nil, "", err
+	}
+
+	var skills []*models.UserSkill
+	var next string
+	var err error
+	if limit == 0 && nextToken == "" {
+		skills, err = s.listAllUsersBySkill(category, skillName)
+	} else {
+		skills, next, err = s.repo.ListUsersBySkill(category, skillName, limit, nextToken)
+	}
+	if err != nil {
+		log.Error("Failed to retrieve users by skill", "error", err.Error(), "duration", time.Since(start))
+		return nil, "", err
+	}
+
+	// Archived users are dropped after paging, so a page may hold fewer than limit entries
+	skills, err = s.excludeArchivedUsers(skills)
+	if err != nil {
+		log.Error("Failed to filter archived users", "error", err.Error(), "duration", time.Since(start))
+		return nil, "", err
+	}
+
+	result := toUserSkillResponses(skills)
+
+	log.Info("Users with skill retrieved successfully", "category", category, "skill", skillName, "count", len(result), "has_more", next != "", "duration", time.Since(start))
+	return result, next, nil
+}
+
+// ListUsersBySkillAndLevel retrieves users with a skill at a specific proficiency level in a category
+func (s *SkillService) ListUsersBySkillAndLevel(category, skillName string, proficiencyLevel models.ProficiencyLevel) ([]dto.UserSkillResponse, error) {
+	log := logger.WithComponent("service").With("operation", "ListUsersBySkillAndLevel", "category", category, "skill", skillName, "level", proficiencyLevel)
+	start := time.Now()
+
+	log.Info("Retrieving users by skill and level")
+
+	if err := validateCategoryFilter(category); err != nil {
+		log.Info("Invalid category for users-by-skill query", "duration", time.Since(start))
+		return nil, err
+	}
+
+	skills, err := s.repo.ListUsersBySkillAndLevel(category, skillName, proficiencyLevel)
+	if err != nil {
+		log.Error("Failed to retrieve users by skill and level", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	skills, err = s.excludeArchivedUsers(skills)
+	if err != nil {
+		log.Error("Failed to filter archived users", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	result := toUserSkillResponses(skills)
+
+	log.Info("Users with skill and level retrieved successfully", "category", category, "skill", skillName, "level", proficiencyLevel, "count", len(result), "duration", time.Since(start))
+	return result, nil
+}
+
+// ListUsersBySkillAllCategories retrieves users with a skill when the category is unknown
+// It fans out one GSI query per allowed category, at most maxCategoryQueries at a time, and merges the results.
+// An empty proficiencyLevel matches every level.
+func (s *SkillService) ListUsersBySkillAllCategories(skillName string, proficiencyLevel models.ProficiencyLevel) ([]dto.UserSkillResponse, error) {
+	log := logger.WithComponent("service").With("operation", "ListUsersBySkillAllCategories", "skill", skillName, "level", proficiencyLevel)
+	start := time.Now()
+
+	log.Info("Retrieving users by skill across all categories")
+
+	categories := models.Categories()
+	results := make([][]*models.UserSkill, len(categories))
+	errs := make([]error, len(categories))
+
+	var wg sync.WaitGroup
+	limit := make(chan struct{}, maxCategoryQueries)
+	for i, category := range categories {
+		wg.Add(1)
+		go func(i int, category string) {
+			defer wg.Done()
+			limit <- struct{}{}
+			defer func() { <-limit }()
+
+			if proficiencyLevel != "" {
+				results[i], errs[i] = s.repo.ListUsersBySkillAndLevel(category, skillName, proficiencyLevel)
+			} else {
+				results[i], errs[i] = s.listAllUsersBySkill(category, skillName)
+			}
+		}(i, category)
+	}
+	wg.Wait()
+
+	var skills []*models.UserSkill
+	for i, categorySkills := range results {
+		if errs[i] != nil {
+			log.Error("Failed to retrieve users by skill", "error", errs[i].Error(), "category", categories[i], "duration", time.Since(start))
+			return nil, errs[i]
+		}
+		skills = append(skills, categorySkills...)
+	}
+
+	skills, err := s.excludeArchivedUsers(skills)
+	if err != nil {
+		log.Error("Failed to filter archived users", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	result := toUserSkillResponses(skills)
+
+	log.Info("Users with skill retrieved across categories", "categories", len(categories), "count", len(result), "duration", time.Since(start))
+	return result, nil
+}
+
+// ListRecentSkills returns the most recently updated skills across all users, newest first
+// limit defaults to 20 and is capped at 100. Archived users are dropped after the query,
+// so the feed may hold fewer than limit entries.
+func (s *SkillService) ListRecentSkills(limit int) ([]dto.RecentSkillResponse, error) {
+	log := logger.WithComponent("service").With("operation", "ListRecentSkills", "limit", limit)
+	start := time.Now()
+
+	log.Info("Retrieving recently updated skills")
+
+	if limit <= 0 {
+		limit = defaultRecentSkillsLimit
+	}
+	if limit > maxRecentSkillsLimit {
+		limit = maxRecentSkillsLimit
+	}
+
+	skills, err := s.repo.ListRecentSkills(limit)
+	if err != nil {
+		log.Error("Failed to retrieve recent skills", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	skills, err = s.excludeArchivedUsers(skills)
+	if err != nil {
+		log.Error("Failed to filter archived users", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	result := make([]dto.RecentSkillResponse, 0, len(skills))
+	for _, skill := range skills {
+		result = append(result, dto.RecentSkillResponse{
+			Username:         skill.Username,
+			SkillName:        skill.SkillName,
+			Category:         skill.Category,
+			ProficiencyLevel: string(skill.ProficiencyLevel),
+			UpdatedAt:        skill.UpdatedAt.Format(time.RFC3339),
+		})
+	}
+
+	log.Info("Recent skills retrieved successfully", "count", len(result), "duration", time.Since(start))
+	return result, nil
+}
+
+// RelatedSkills returns the skills most often held by users who also hold skillName in category
+// Results are sorted by how many of those users hold each skill, then by name, and capped at
+// limit (default 10, max 50). The input skill itself is never returned.
+//
+// Cost: one BySkill GSI query for at most maxRelatedSkillHolders holders, then one
+// ListSkillsForUser query per holder, so a popular skill costs up to 101 queries.
+// Only the first maxRelatedSkillHolders holders returned by the index are sampled.
+func (s *SkillService) RelatedSkills(category, skillName string, limit int) ([]dto.RelatedSkillResponse, error) {
+	log := logger.WithComponent("service").With("operation", "RelatedSkills", "category", category, "skill", skillName, "limit", limit)
+	start := time.Now()
+
+	log.Info("Finding related skills")
+
+	if err := validateCategoryFilter(category); err != nil {
+		log.Info("Invalid category for related skills", "duration", time.Since(start))
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = defaultRelatedSkillsLimit
+	}
+	if limit > maxRelatedSkillsLimit {
+		limit = maxRelatedSkillsLimit
+	}
+
+	holders, _, err := s.repo.ListUsersBySkill(category, skillName, maxRelatedSkillHolders, "")
+	if err != nil {
+		log.Error("Failed to retrieve users by skill", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	holders, err = s.excludeArchivedUsers(holders)
+	if err != nil {
+		log.Error("Failed to filter archived users", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	related := make(map[string]*dto.RelatedSkillResponse)
+	for _, holder := range holders {
+		skills, err := s.repo.ListSkillsForUser(holder.Username)
+		if err != nil {
+			log.Error("Failed to retrieve skills for user", "error", err.Error(), "username", holder.Username, "duration", time.Since(start))
+			return nil, err
+		}
+
+		for _, skill := range skills {
+			if skill.SkillID == holder.SkillID {
+				continue
+			}
+			entry, ok := related[skill.SkillID]
+			if !ok {
+				entry = &dto.RelatedSkillResponse{SkillName: skill.SkillName, Category: skill.Category}
+				related[skill.SkillID] = entry
+			}
+			entry.Users++
+		}
+	}
+
+	result := make([]dto.RelatedSkillResponse, 0, len(related))
+	for _, entry := range related {
+		result = append(result, *entry)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Users != result[j].Users {
+			return result[i].Users > result[j].Users
+		}
+		return result[i].SkillName < result[j].SkillName
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+
+	log.Info("Related skills found", "holders", len(holders), "count", len(result), "duration", time.Since(start))
+	return result, nil
+}
+
+// ListUsersByTag returns the users holding any active master skill carrying tag
+// Each user appears once, with the names of the tagged skills they hold, sorted by username.
+// Archived users are excluded, matching ListUsersBySkill.
+//
+// Cost: one catalog query for the tag, then one paginated BySkill GSI query per tagged skill.
+// Only the first maxTaggedSkills skills by skill ID are searched; Truncated reports when more exist.
+func (s *SkillService) ListUsersByTag(tag string) (*dto.TagUsersResponse, error) {
+	log := logger.WithComponent("service").With("operation", "ListUsersByTag", "tag", tag)
+	start := time.Now()
+
+	log.Info("Retrieving users by tag")
+
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		log.Error("Tag is required", "duration", time.Since(start))
+		return nil, pkgerrors.ErrRequiredField
+	}
+
+	tagged, err := s.masterSkillRepo.ListMasterSkillsFiltered("", []string{tag})
+	if err != nil {
+		log.Error("Failed to retrieve tagged master skills", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	active := make([]*models.Skill, 0, len(tagged))
+	for _, skill := range tagged {
+		if !skill.Deleted {
+			active = append(active, skill)
+		}
+	}
+	sort.Slice(active, func(i, j int) bool { return active[i].SkillID < active[j].SkillID })
+
+	result := &dto.TagUsersResponse{Tag: tag, Users: []dto.TagUserResponse{}}
+	if len(active) > maxTaggedSkills {
+		log.Warn("Tag matches more skills than are searched", "skills", len(active), "cap", maxTaggedSkills)
+		active = active[:maxTaggedSkills]
+		result.Truncated = true
+	}
+
+	skillsByUser := make(map[string][]string)
+	for _, master := range active {
+		holders, err := s.listAllUsersBySkill(master.Category, master.SkillName)
+		if err != nil {
+			log.Error("Failed to retrieve users by skill", "error", err.Error(), "skill_id", master.SkillID, "duration", time.Since(start))
+			return nil, err
+		}
+		if holders, err = s.excludeArchivedUsers(holders); err != nil {
+			log.Error("Failed to filter archived users", "error", err.Error(), "duration", time.Since(start))
+			return nil, err
+		}
+		for _, holder := range holders {
+			skillsByUser[holder.Username] = append(skillsByUser[holder.Username], master.SkillName)
+		}
+	}
+
+	for username, skills := range skillsByUser {
+		sort.Strings(skills)
+		result.Users = append(result.Users, dto.TagUserResponse{Username: username, Skills: skills})
+	}
+	sort.Slice(result.Users, func(i, j int) bool { return result.Users[i].Username < result.Users[j].Username })
+
+	log.Info("Users by tag retrieved successfully", "skills", len(active), "users", len(result.Users), "duration", time.Since(start))
+	return result, nil
+}
+
+// SkillGap compares username's skills against another user's, matching skills by skill ID
+// Missing lists the other user's skills that username lacks; Weaker lists shared skills the
+// other user holds at a higher proficiency level. Both are sorted by skill name and are empty,
+// never nil, when there is no gap.
+func (s *SkillService) SkillGap(username, against string) (*dto.SkillGapResponse, error) {
+	log := logger.WithComponent("service").With("operation", "SkillGap", "username", username, "against", against)
+	start := time.Now()
+
+	log.Info("Comparing skills between users")
+
+	mine, err := s.userSkillsByID(username)
+	if err != nil {
+		log.Error("Failed to retrieve skills", "error", err.Error(), "username", username, "duration", time.Since(start))
+		return nil, err
+	}
+	theirs, err := s.userSkillsByID(against)
+	if err != nil {
+		log.Error("Failed to retrieve skills", "error", err.Error(), "username", against, "duration", time.Since(start))
+		return nil, err
+	}
+
+	gap := &dto.SkillGapResponse{
+		Username: username,
+		Against:  against,
+		Missing:  []dto.SkillResponse{},
+		Weaker:   []dto.SkillGapLevelResponse{},
+	}
+	for skillID, their := range theirs {
+		my, ok := mine[skillID]
+		if !ok {
+			gap.Missing = append(gap.Missing, toSkillResponse(their))
+			continue
+		}
+		if their.ProficiencyLevel.Rank() > my.ProficiencyLevel.Rank() {
+			gap.Weaker = append(gap.Weaker, dto.SkillGapLevelResponse{
+				Skill:      their.SkillName,
+				Category:   their.Category,
+				YourLevel:  string(my.ProficiencyLevel),
+				TheirLevel: string(their.ProficiencyLevel),
+			})
+		}
+	}
+	sort.Slice(gap.Missing, func(i, j int) bool { return gap.Missing[i].SkillName < gap.Missing[j].SkillName })
+	sort.Slice(gap.Weaker, func(i, j int) bool { return gap.Weaker[i].Skill < gap.Weaker[j].Skill })
+
+	log.Info("Skill gap computed", "missing", len(gap.Missing), "weaker", len(gap.Weaker), "duration", time.Since(start))
+	return gap, nil
+}
+
+// userSkillsByID returns an existing user's skills keyed by skill ID
+func (s *SkillService) userSkillsByID(username string) (map[string]*models.UserSkill, error) {
+	if _, err := s.userRepo.GetUser(username); err != nil {
+		return nil, err
+	}
+
+	skills, err := s.repo.ListSkillsForUser(username)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*models.UserSkill, len(skills))
+	for _, skill := range skills {
+		byID[skill.SkillID] = skill
+	}
+	return byID, nil
+}
+
+// ProficiencyHistogram counts users holding a skill in category at each proficiency level
+// Every level is present in the result, with zero when no user holds the skill at that level.
+// Archived users are not counted, matching ListUsersBySkill.
+func (s *SkillService) ProficiencyHistogram(category, skillName string) (map[string]int, error) {
+	log := logger.WithComponent("service").With("operation", "ProficiencyHistogram", "category", category, "skill", skillName)
+	start := time.Now()
+
+	log.Info("Building proficiency histogram")
+
+	if err := validateCategoryFilter(category); err != nil {
+		log.Info("Invalid category for proficiency histogram", "duration", time.Since(start))
+		return nil, err
+	}
+
+	skills, err := s.listAllUsersBySkill(category, skillName)
+	if err != nil {
+		log.Error("Failed to retrieve users by skill", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	skills, err = s.excludeArchivedUsers(skills)
+	if err != nil {
+		log.Error("Failed to filter archived users", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	histogram := newProficiencyHistogram()
+	for _, skill := range skills {
+		histogram[string(skill.ProficiencyLevel)]++
+	}
+
+	log.Info("Proficiency histogram built", "users", len(skills), "duration", time.Since(start))
+	return histogram, nil
+}
+
+// ProficiencyHistogramAllCategories counts users holding a skill at each proficiency level across every category
+func (s *SkillService) ProficiencyHistogramAllCategories(skillName string) (map[string]int, error) {
+	log := logger.WithComponent("service").With("operation", "ProficiencyHistogramAllCategories", "skill", skillName)
+	start := time.Now()
+
+	log.Info("Building proficiency histogram across all categories")
+
+	users, err := s.ListUsersBySkillAllCategories(skillName, "")
+	if err != nil {
+		log.Error("Failed to retrieve users by skill", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	histogram := newProficiencyHistogram()
+	for _, user := range users {
+		histogram[user.ProficiencyLevel]++
+	}
+
+	log.Info("Proficiency histogram built", "users", len(users), "duration", time.Since(start))
+	return histogram, nil
+}
+
+// newProficiencyHistogram returns a histogram with a zero count for every proficiency level
+func newProficiencyHistogram() map[string]int {
+	histogram := make(map[string]int)
+	for _, level := range models.ProficiencyLevels() {
+		histogram[string(level)] = 0
+	}
+	return histogram
+}
+
+// validateCategoryFilter checks the category used as the users-by-skill GSI partition key
+func validateCategoryFilter(category string) error {
+	if category == "" {
+		return apperrors.ErrCategoryRequired
+	}
+	return models.ValidateCategory(category)
+}
+
+// listAllUsersBySkill follows pagination tokens until every user with the skill in category is loaded
+func (s *SkillService) listAllUsersBySkill(category, skillName string) ([]*models.UserSkill, error) {
+	var skills []*models.UserSkill
+	var token string
+	for {
+		page, next, err := s.repo.ListUsersBySkill(category, skillName, 0, token)
+		if err != nil {
+			return nil, err
+		}
+		skills = append(skills, page...)
+		if next == "" {
+			return skills, nil
+		}
+		token = next
+	}
+}
+
+// excludeArchivedUsers drops skills owned by archived accounts from cross-user results
+func (s *SkillService) excludeArchivedUsers(skills []*models.UserSkill) ([]*models.UserSkill, error) {
+	archived := make(map[string]bool)
+	result := make([]*models.UserSkill, 0, len(skills))
+	for _, skill := range skills {
+		isArchived, checked := archived[skill.Username]
+		if !checked {
+			user, err := s.userRepo.GetUser(skill.Username)
+			switch {
+			case err == nil:
+				isArchived = user.IsArchived()
+			case pkgerrors.Is(err, apperrors.ErrUserNotFound):
+				isArchived = false
+			default:
+				return nil, err
+			}
+			archived[skill.Username] = isArchived
+		}
+		if !isArchived {
+			result = append(result, skill)
+		}
+	}
+	return result, nil
+}
+
+// toUserSkillResponses converts user skills to cross-user response DTOs
+func toUserSkillResponses(skills []*models.UserSkill) []dto.UserSkillResponse {
+	result := make([]dto.UserSkillResponse, len(skills))
+	for i, skill := range skills {
+		result[i] = dto.UserSkillResponse{
+			Username:          skill.Username,
+			SkillName:         skill.SkillName,
+			ProficiencyLevel:  string(skill.ProficiencyLevel),
+			YearsOfExperience: skill.YearsOfExperience,
+			Endorsements:      skill.Endorsements,
+			LastUsedDate:      skill.LastUsedDate,
+		}
+	}
+	return result
+}
+
+// toSkillResponse converts a user skill to its response DTO
+func toSkillResponse(skill *models.UserSkill) dto.SkillResponse {
+	return dto.SkillResponse{
+		SkillName:         skill.SkillName,
+		ProficiencyLevel:  string(skill.ProficiencyLevel),
+		YearsOfExperience: skill.YearsOfExperience,
+		Endorsements:      skill.Endorsements,
+		LastUsedDate:      skill.LastUsedDate,
+		Notes:             skill.Notes,
+		PromotionEligible: skill.PromotionEligible,
+		CreatedAt:         skill.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:         skill.UpdatedAt.Format(time.RFC3339),
+	}
 }
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"time"
+
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/database"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/dto"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
+	"github.com/hackmajoris/glad-stack/pkg/logger"
+)
+
+// SkillSnapshotService handles skill snapshot and diff business logic
+type SkillSnapshotService struct {
+	skillRepo    database.SkillRepository
+	snapshotRepo database.SkillSnapshotRepository
+	userRepo     database.UserRepository
+}
+
+// NewSkillSnapshotService creates a new SkillSnapshotService
+func NewSkillSnapshotService(skillRepo database.SkillRepository, snapshotRepo database.SkillSnapshotRepository, userRepo database.UserRepository) *SkillSnapshotService {
+	return &SkillSnapshotService{
+		skillRepo:    skillRepo,
+		snapshotRepo: snapshotRepo,
+		userRepo:     userRepo,
+	}
+}
+
+// CreateSnapshot records the user's current skills
+func (s *SkillSnapshotService) CreateSnapshot(username string) (*models.SkillSnapshot, error) {
+	log := logger.WithComponent("service").With("operation", "CreateSnapshot", "username", username)
+	start := time.Now()
+
+	log.Info("Processing create skill snapshot request")
+
+	// Check if user exists
+	if _, err := s.userRepo.GetUser(username); err != nil {
+		log.Error("User not found", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	skills, err := s.skillRepo.ListSkillsForUser(username)
+	if err != nil {
+		log.Error("Failed to retrieve skills", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	snapshot := models.NewSkillSnapshot(username, skills)
+
+	if err := s.snapshotRepo.CreateSkillSnapshot(snapshot); err != nil {
+		log.Error("Failed to save skill snapshot", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	log.Info("Skill snapshot created successfully", "snapshot_id", snapshot.SnapshotID, "skills", len(snapshot.Skills), "duration", time.Since(start))
+	return snapshot, nil
+}
+
+// DiffSince compares the user's current skills with a previous snapshot
+func (s *SkillSnapshotService) DiffSince(username, snapshotID string) (*dto.SkillDiffResponse, error) {
+	log := logger.WithComponent("service").With("operation", "DiffSince", "username", username, "snapshot_id", snapshotID)
+	start := time.Now()
+
+	log.Info("Processing skill diff request")
+
+	snapshot, err := s.snapshotRepo.GetSkillSnapshot(username, snapshotID)
+	if err != nil {
+		log.Error("Failed to get skill snapshot", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	skills, err := s.skillRepo.ListSkillsForUser(username)
+	if err != nil {
+		log.Error("Failed to retrieve skills", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	diff := snapshot.Diff(skills)
+
+	// Convert to response DTO
+	result := &dto.SkillDiffResponse{
+		Username:   username,
+		SnapshotID: snapshot.SnapshotID,
+		SnapshotAt: snapshot.CreatedAt.Format(time.RFC3339),
+		Added:      toSnapshotSkillResponses(diff.Added),
+		Removed:    toSnapshotSkillResponses(diff.Removed),
+		Leveled:    make([]dto.SkillLevelChangeResponse, len(diff.Leveled)),
+	}
+	for i, change := range diff.Leveled {
+		result.Leveled[i] = dto.SkillLevelChangeResponse{
+			SkillID:   change.SkillID,
+			SkillName: change.SkillName,
+			From:      string(change.From),
+			To:        string(change.To),
+		}
+	}
+
+	log.Info("Skill diff computed successfully", "added", len(result.Added), "removed", len(result.Removed), "leveled", len(result.Leveled), "duration", time.Since(start))
+	return result, nil
+}
+
+// toSnapshotSkillResponses converts snapshot skills to response DTOs
+func toSnapshotSkillResponses(skills []models.SnapshotSkill) []dto.SnapshotSkillResponse {
+	result := make([]dto.SnapshotSkillResponse, len(skills))
+	for i, skill := range skills {
+		result[i] = dto.SnapshotSkillResponse{
+			SkillID:           skill.SkillID,
+			SkillName:         skill.SkillName,
+			ProficiencyLevel:  string(skill.ProficiencyLevel),
+			YearsOfExperience: skill.YearsOfExperience,
+		}
+	}
+	return result
+}
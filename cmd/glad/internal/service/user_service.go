@@ -1,6 +1,9 @@
 package service
 
 import (
+	"context"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/hackmajoris/glad-stack/cmd/glad/internal/database"
@@ -17,6 +20,7 @@ var (
 	ErrUserExists         = apperrors.ErrUserExists
 	ErrUserNotFound       = apperrors.ErrUserNotFound
 	ErrInvalidCredentials = apperrors.ErrInvalidCredentials
+	ErrAccountArchived    = apperrors.ErrAccountArchived
 	ErrInvalidUsername    = apperrors.ErrInvalidUsername
 	ErrInvalidName        = apperrors.ErrInvalidName
 	ErrInvalidPassword    = apperrors.ErrInvalidPassword
@@ -26,14 +30,29 @@ var (
 type UserService struct {
 	repo         database.UserRepository
 	tokenService *auth.TokenService
+	purge        *PurgeService
+}
+
+// UserServiceOption configures optional UserService behaviour
+type UserServiceOption func(*UserService)
+
+// WithPurgeService makes DeleteUser remove everything purge.PurgeUser does
+func WithPurgeService(purge *PurgeService) UserServiceOption {
+	return func(s *UserService) {
+		s.purge = purge
+	}
 }
 
 // NewUserService creates a new UserService
-func NewUserService(repo database.UserRepository, tokenService *auth.TokenService) *UserService {
-	return &UserService{
+func NewUserService(repo database.UserRepository, tokenService *auth.TokenService, opts ...UserServiceOption) *UserService {
+	s := &UserService{
 		repo:         repo,
 		tokenService: tokenService,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // RegisterResult contains the result of a registration
@@ -78,8 +97,9 @@ func (s *UserService) Register(username, name, password string) (*RegisterResult
 
 // LoginResult contains the result of a login
 type LoginResult struct {
-	AccessToken string
-	TokenType   string
+	AccessToken  string
+	RefreshToken string
+	TokenType    string
 }
 
 // Login authenticates a user and returns a token
@@ -106,6 +126,12 @@ func (s *UserService) Login(username, password string) (*LoginResult, error) {
 		return nil, apperrors.ErrInvalidCredentials
 	}
 
+	// Archived accounts keep their data but cannot obtain tokens
+	if user.IsArchived() {
+		log.Info("Login attempt on archived account", "duration", time.Since(start))
+		return nil, apperrors.ErrAccountArchived
+	}
+
 	// Generate JWT token
 	token, err := s.tokenService.GenerateToken(user)
 	if err != nil {
@@ -113,27 +139,82 @@ func (s *UserService) Login(username, password string) (*LoginResult, error) {
 		return nil, err
 	}
 
+	refreshToken, err := s.tokenService.GenerateRefreshToken(user)
+	if err != nil {
+		log.Error("Failed to generate refresh token", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
 	log.Info("User logged in successfully", "duration", time.Since(start))
+	return &LoginResult{
+		AccessToken:  token,
+		RefreshToken: refreshToken,
+		TokenType:    s.tokenService.TokenType(),
+	}, nil
+}
+
+// Refresh exchanges a refresh token for a new access token
+// Access tokens are rejected, and the account must still exist and not be archived.
+func (s *UserService) Refresh(refreshToken string) (*LoginResult, error) {
+	log := logger.WithComponent("service").With("operation", "Refresh")
+	start := time.Now()
+
+	log.Info("Processing token refresh request")
+
+	claims, err := s.tokenService.ValidateRefreshToken(refreshToken)
+	if err != nil {
+		log.Info("Invalid refresh token", "error", err.Error(), "duration", time.Since(start))
+		return nil, apperrors.ErrInvalidRefreshToken
+	}
+	log = log.With("username", claims.Username)
+
+	user, err := s.repo.GetUser(claims.Username)
+	if err != nil {
+		if pkgerrors.Is(err, apperrors.ErrUserNotFound) {
+			log.Info("Refresh token for non-existent user", "duration", time.Since(start))
+			return nil, apperrors.ErrInvalidRefreshToken
+		}
+		log.Error("Failed to retrieve user for refresh", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	if user.IsArchived() {
+		log.Info("Refresh attempt on archived account", "duration", time.Since(start))
+		return nil, apperrors.ErrAccountArchived
+	}
+
+	token, err := s.tokenService.GenerateToken(user)
+	if err != nil {
+		log.Error("Failed to generate JWT token", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	log.Info("Access token refreshed successfully", "duration", time.Since(start))
 	return &LoginResult{
 		AccessToken: token,
-		TokenType:   "Bearer",
+		TokenType:   s.tokenService.TokenType(),
 	}, nil
 }
 
 // UpdateUser updates a user's profile
-func (s *UserService) UpdateUser(username string, name *string, password *string) error {
+// A new email must not belong to another user, compared ignoring case. The write claims the
+// address, so of two concurrent updates to one address only the first succeeds.
+func (s *UserService) UpdateUser(username string, name *string, password *string, email *string) error {
 	log := logger.WithComponent("service").With("operation", "UpdateUser", "username", username)
 	start := time.Now()
 
 	log.Info("Processing update request")
 
 	// Get current user
-	user, err := s.repo.GetUser(username)
+	stored, err := s.repo.GetUser(username)
 	if err != nil {
 		log.Error("Failed to get user", "error", err.Error(), "duration", time.Since(start))
 		return err
 	}
 
+	// Changes go to a copy, so the user read stays as stored if the write is rejected
+	user := *stored
+
 	// Update user fields
 	if name != nil {
 		if err := user.UpdateName(*name); err != nil {
@@ -149,21 +230,229 @@ func (s *UserService) UpdateUser(username string, name *string, password *string
 		}
 	}
 
-	// Save updated user
-	if err := s.repo.UpdateUser(user); err != nil {
+	emailChanged := false
+	if email != nil && !strings.EqualFold(*email, user.Email) {
+		if err := user.UpdateEmail(*email); err != nil {
+			log.Error("Failed to update user email", "error", err.Error(), "duration", time.Since(start))
+			return err
+		}
+		emailChanged = true
+	}
+
+	// Save updated user; a new email is claimed in the same write
+	if emailChanged {
+		err = s.repo.UpdateUserEmail(&user, stored.Email)
+	} else {
+		err = s.repo.UpdateUser(&user)
+	}
+	if err != nil {
 		log.Error("Failed to save user", "error", err.Error(), "duration", time.Since(start))
 		return err
 	}
 
+	// TODO: push email changes to the Cognito user pool once sign-in moves there
+	if emailChanged {
+		log.Warn("Email updated in the profile only; Cognito is not yet synced")
+	}
+
 	log.Info("User updated successfully", "duration", time.Since(start))
 	return nil
 }
 
+// CheckAccountActive reports whether username may still use the tokens issued to them
+// A deactivated or deleted account fails with an error wrapping pkgerrors.ErrAccountInactive.
+func (s *UserService) CheckAccountActive(username string) error {
+	log := logger.WithComponent("service").With("operation", "CheckAccountActive", "username", username)
+	start := time.Now()
+
+	user, err := s.repo.GetUser(username)
+	if pkgerrors.Is(err, apperrors.ErrUserNotFound) {
+		log.Info("Token names a deleted account", "duration", time.Since(start))
+		return pkgerrors.Wrap(pkgerrors.ErrAccountInactive, "user not found")
+	}
+	if err != nil {
+		log.Error("Failed to get user", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
+	if user.IsArchived() {
+		log.Info("Token names an archived account", "duration", time.Since(start))
+		return pkgerrors.Wrap(pkgerrors.ErrAccountInactive, "account is archived")
+	}
+
+	log.Debug("Account is active", "duration", time.Since(start))
+	return nil
+}
+
+// DeactivateUser archives a user account without deleting it
+func (s *UserService) DeactivateUser(username string) error {
+	log := logger.WithComponent("service").With("operation", "DeactivateUser", "username", username)
+	start := time.Now()
+
+	log.Info("Processing deactivate request")
+
+	user, err := s.repo.GetUser(username)
+	if err != nil {
+		log.Error("Failed to get user", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
+
+	user.Archive()
+
+	if err := s.repo.UpdateUser(user); err != nil {
+		log.Error("Failed to save user", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
+
+	log.Info("User deactivated successfully", "duration", time.Since(start))
+	return nil
+}
+
+// DeleteUser permanently removes a user account and all of its skills
+// With WithPurgeService the endorsements given, snapshots, history and activity go too, exactly
+// as PurgeUser removes them; without it only the account and its skills are removed.
+func (s *UserService) DeleteUser(username string) error {
+	log := logger.WithComponent("service").With("operation", "DeleteUser", "username", username)
+	start := time.Now()
+
+	log.Info("Processing delete user request")
+
+	if s.purge != nil {
+		report, err := s.purge.PurgeUser(username, false)
+		if err != nil {
+			log.Error("Failed to purge user", "error", err.Error(), "duration", time.Since(start))
+			return err
+		}
+		log.Info("User deleted successfully", "endorsements", report.Endorsements, "skills", report.Skills, "duration", time.Since(start))
+		return nil
+	}
+
+	if err := s.repo.DeleteUserCascade(username); err != nil {
+		log.Error("Failed to delete user", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
+
+	log.Info("User deleted successfully", "duration", time.Since(start))
+	return nil
+}
+
+// ReactivateUser restores an archived user account
+func (s *UserService) ReactivateUser(username string) error {
+	log := logger.WithComponent("service").With("operation", "ReactivateUser", "username", username)
+	start := time.Now()
+
+	log.Info("Processing reactivate request")
+
+	user, err := s.repo.GetUser(username)
+	if err != nil {
+		log.Error("Failed to get user", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
+
+	user.Reactivate()
+
+	if err := s.repo.UpdateUser(user); err != nil {
+		log.Error("Failed to save user", "error", err.Error(), "duration", time.Since(start))
+		return err
+	}
+
+	log.Info("User reactivated successfully", "duration", time.Since(start))
+	return nil
+}
+
 // GetUser retrieves a user by username
 func (s *UserService) GetUser(username string) (*models.User, error) {
 	return s.repo.GetUser(username)
 }
 
+// ExportProfile returns username's profile and all of their skills as one document
+// Only the response DTOs are used, so the password hash is never part of an export.
+func (s *UserService) ExportProfile(username string, skillService *SkillService) (*dto.ProfileExportResponse, error) {
+	log := logger.WithComponent("service").With("operation", "ExportProfile", "username", username)
+	start := time.Now()
+
+	log.Info("Processing profile export request")
+
+	user, err := s.repo.GetUser(username)
+	if err != nil {
+		log.Error("User not found", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	skills, err := skillService.ListSkillsForUser(context.Background(), username, time.Time{})
+	if err != nil {
+		log.Error("Failed to retrieve skills", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	export := &dto.ProfileExportResponse{
+		User: dto.CurrentUserResponse{
+			Username:  user.Username,
+			Name:      user.Name,
+			CreatedAt: user.CreatedAt.Format(time.RFC3339),
+			UpdatedAt: user.UpdatedAt.Format(time.RFC3339),
+		},
+		Skills: skills,
+	}
+
+	log.Info("Profile exported successfully", "skills", len(skills), "duration", time.Since(start))
+	return export, nil
+}
+
+// Result size bounds for user search
+const (
+	defaultUserSearchLimit = 20
+	maxUserSearchLimit     = 100
+)
+
+// SearchUsers returns active users whose username or name contains query, ignoring case
+// Results are sorted by username and capped at limit (default 20, max 100).
+func (s *UserService) SearchUsers(query string, limit int) ([]dto.UserListResponse, error) {
+	log := logger.WithComponent("service").With("operation", "SearchUsers", "query", query, "limit", limit)
+	start := time.Now()
+
+	log.Info("Processing search users request")
+
+	if query == "" {
+		log.Info("Empty search query", "duration", time.Since(start))
+		return nil, pkgerrors.ErrRequiredField
+	}
+
+	if limit <= 0 {
+		limit = defaultUserSearchLimit
+	}
+	if limit > maxUserSearchLimit {
+		limit = maxUserSearchLimit
+	}
+
+	users, err := s.repo.SearchUsers(query)
+	if err != nil {
+		log.Error("Failed to search users", "error", err.Error(), "duration", time.Since(start))
+		return nil, err
+	}
+
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].Username < users[j].Username
+	})
+
+	// Convert to list items (without sensitive data), skipping archived accounts
+	result := make([]dto.UserListResponse, 0, len(users))
+	for _, user := range users {
+		if user.IsArchived() {
+			continue
+		}
+		if len(result) == limit {
+			break
+		}
+		result = append(result, dto.UserListResponse{
+			Username: user.Username,
+			Name:     user.Name,
+		})
+	}
+
+	log.Info("User search completed", "count", len(result), "duration", time.Since(start))
+	return result, nil
+}
+
 // ListUsers retrieves all users
 func (s *UserService) ListUsers() ([]dto.UserListResponse, error) {
 	log := logger.WithComponent("service").With("operation", "ListUsers")
@@ -177,13 +466,16 @@ func (s *UserService) ListUsers() ([]dto.UserListResponse, error) {
 		return nil, err
 	}
 
-	// Convert to list items (without sensitive data)
-	result := make([]dto.UserListResponse, len(users))
-	for i, user := range users {
-		result[i] = dto.UserListResponse{
+	// Convert to list items (without sensitive data), skipping archived accounts
+	result := make([]dto.UserListResponse, 0, len(users))
+	for _, user := range users {
+		if user.IsArchived() {
+			continue
+		}
+		result = append(result, dto.UserListResponse{
 			Username: user.Username,
 			Name:     user.Name,
-		}
+		})
 	}
 
 	log.Info("Users retrieved successfully", "count", len(result), "duration", time.Since(start))
@@ -0,0 +1,77 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
+)
+
+// DefaultPasswordMinLength is the shortest password accepted; the user model enforces it too
+const DefaultPasswordMinLength = 6
+
+// PasswordPolicy lists the rules a new password must satisfy
+// The zero value only requires DefaultPasswordMinLength characters.
+type PasswordPolicy struct {
+	MinLength     int // values below DefaultPasswordMinLength are raised to it
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+}
+
+// PasswordPolicyError lists every rule a password failed
+// It wraps apperrors.ErrInvalidPassword so callers can keep matching on that.
+type PasswordPolicyError struct {
+	Unmet []string // one message per failed rule
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return strings.Join(e.Unmet, "; ")
+}
+
+func (e *PasswordPolicyError) Unwrap() error {
+	return apperrors.ErrInvalidPassword
+}
+
+// Check returns a *PasswordPolicyError naming each rule password fails, or nil
+func (p PasswordPolicy) Check(password string) error {
+	minLength := max(p.MinLength, DefaultPasswordMinLength)
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+
+	var unmet []string
+	if len(password) < minLength {
+		unmet = append(unmet, fmt.Sprintf("password must be at least %d characters", minLength))
+	}
+	if p.RequireUpper && !hasUpper {
+		unmet = append(unmet, "password must contain an uppercase letter")
+	}
+	if p.RequireLower && !hasLower {
+		unmet = append(unmet, "password must contain a lowercase letter")
+	}
+	if p.RequireDigit && !hasDigit {
+		unmet = append(unmet, "password must contain a digit")
+	}
+	if p.RequireSymbol && !hasSymbol {
+		unmet = append(unmet, "password must contain a symbol")
+	}
+
+	if len(unmet) == 0 {
+		return nil
+	}
+	return &PasswordPolicyError{Unmet: unmet}
+}
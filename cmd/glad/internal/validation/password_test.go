@@ -0,0 +1,88 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+
+	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
+	pkgerrors "github.com/hackmajoris/glad-stack/pkg/errors"
+)
+
+func TestPasswordPolicy_Check(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   PasswordPolicy
+		password string
+		expected string // empty when the password is accepted
+	}{
+		{name: "default accepts six characters", password: "abcdef"},
+		{name: "default rejects five characters", password: "abcde", expected: "password must be at least 6 characters"},
+		{name: "min length below default is raised", policy: PasswordPolicy{MinLength: 4}, password: "abcde", expected: "password must be at least 6 characters"},
+		{name: "min length", policy: PasswordPolicy{MinLength: 10}, password: "abcdefghi", expected: "password must be at least 10 characters"},
+		{name: "min length met", policy: PasswordPolicy{MinLength: 10}, password: "abcdefghij"},
+		{name: "uppercase missing", policy: PasswordPolicy{RequireUpper: true}, password: "abcdef", expected: "password must contain an uppercase letter"},
+		{name: "uppercase present", policy: PasswordPolicy{RequireUpper: true}, password: "abcdeF"},
+		{name: "lowercase missing", policy: PasswordPolicy{RequireLower: true}, password: "ABCDEF", expected: "password must contain a lowercase letter"},
+		{name: "lowercase present", policy: PasswordPolicy{RequireLower: true}, password: "ABCDEf"},
+		{name: "digit missing", policy: PasswordPolicy{RequireDigit: true}, password: "abcdef", expected: "password must contain a digit"},
+		{name: "digit present", policy: PasswordPolicy{RequireDigit: true}, password: "abcde1"},
+		{name: "symbol missing", policy: PasswordPolicy{RequireSymbol: true}, password: "abcde1", expected: "password must contain a symbol"},
+		{name: "symbol present", policy: PasswordPolicy{RequireSymbol: true}, password: "abcde!"},
+		{
+			name:     "every unmet rule is reported",
+			policy:   PasswordPolicy{MinLength: 8, RequireUpper: true, RequireLower: true, RequireDigit: true, RequireSymbol: true},
+			password: "abc",
+			expected: "password must be at least 8 characters; password must contain an uppercase letter; password must contain a digit; password must contain a symbol",
+		},
+		{
+			name:     "all rules met",
+			policy:   PasswordPolicy{MinLength: 8, RequireUpper: true, RequireLower: true, RequireDigit: true, RequireSymbol: true},
+			password: "Str0ng-pass",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Check(tt.password)
+			if tt.expected == "" {
+				if err != nil {
+					t.Errorf("Expected password to be accepted, got %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected error %q, got nil", tt.expected)
+			}
+			if err.Error() != tt.expected {
+				t.Errorf("Expected error %q, got %q", tt.expected, err.Error())
+			}
+			if !errors.Is(err, apperrors.ErrInvalidPassword) {
+				t.Errorf("Expected error to wrap ErrInvalidPassword, got %v", err)
+			}
+		})
+	}
+}
+
+func TestValidator_PasswordPolicy(t *testing.T) {
+	v := New(WithPasswordPolicy(PasswordPolicy{MinLength: 8, RequireDigit: true}))
+
+	err := v.ValidateRegisterInput("validuser", "Valid Name", "password")
+	var fields pkgerrors.ValidationErrors
+	if !errors.As(err, &fields) {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+	if fields["password"] != "password must contain a digit" {
+		t.Errorf("Expected digit requirement for password, got %q", fields["password"])
+	}
+
+	weak := "short"
+	if err := v.ValidateOptionalPassword(&weak); err == nil || err.Error() != "password must be at least 8 characters; password must contain a digit" {
+		t.Errorf("Expected length and digit requirements, got %v", err)
+	}
+	if err := v.ValidateOptionalPassword(nil); err != nil {
+		t.Errorf("Expected omitted password to be accepted, got %v", err)
+	}
+	if err := v.ValidateRegisterInput("validuser", "Valid Name", "passw0rd"); err != nil {
+		t.Errorf("Expected compliant password to be accepted, got %v", err)
+	}
+}
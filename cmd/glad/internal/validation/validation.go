@@ -1,16 +1,43 @@
 package validation
 
 import (
+	"fmt"
+
 	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
 	pkgerrors "github.com/hackmajoris/glad-stack/pkg/errors"
 )
 
+// MinYearsByProficiency is the minimum years of experience accepted for each proficiency level
+// Levels without an entry have no minimum.
+var MinYearsByProficiency = map[models.ProficiencyLevel]int{
+	models.ProficiencyAdvanced: 3,
+	models.ProficiencyExpert:   5,
+}
+
 // Validator provides validation functionality
-type Validator struct{}
+type Validator struct {
+	passwordPolicy PasswordPolicy
+}
+
+// Option configures optional Validator behaviour
+type Option func(*Validator)
+
+// WithPasswordPolicy sets the rules new passwords must satisfy
+func WithPasswordPolicy(policy PasswordPolicy) Option {
+	return func(v *Validator) {
+		v.passwordPolicy = policy
+	}
+}
 
 // New creates a new Validator
-func New() *Validator {
-	return &Validator{}
+// Without options passwords only need DefaultPasswordMinLength characters.
+func New(opts ...Option) *Validator {
+	v := &Validator{}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
 }
 
 // ValidateUsername validates a username
@@ -35,15 +62,12 @@ func (v *Validator) ValidateName(name string) error {
 	return nil
 }
 
-// ValidatePassword validates a password
+// ValidatePassword validates a password against the password policy
 func (v *Validator) ValidatePassword(password string) error {
 	if password == "" {
 		return pkgerrors.ErrRequiredField
 	}
-	if len(password) < 6 {
-		return apperrors.ErrInvalidPassword
-	}
-	return nil
+	return v.passwordPolicy.Check(password)
 }
 
 // ValidateOptionalName validates an optional name (for updates)
@@ -62,33 +86,71 @@ func (v *Validator) ValidateOptionalPassword(password *string) error {
 	if password == nil {
 		return nil
 	}
-	if len(*password) < 6 {
-		return apperrors.ErrInvalidPassword
+	return v.passwordPolicy.Check(*password)
+}
+
+// ValidateOptionalEmail validates an optional email address (for updates)
+func (v *Validator) ValidateOptionalEmail(email *string) error {
+	if email == nil {
+		return nil
+	}
+	if !models.ValidEmail(*email) {
+		return apperrors.ErrInvalidEmail
 	}
 	return nil
 }
 
 // ValidateRegisterInput validates registration input
+// Every field is checked; failures are returned together as pkgerrors.ValidationErrors.
 func (v *Validator) ValidateRegisterInput(username, name, password string) error {
-	if err := v.ValidateUsername(username); err != nil {
-		return err
-	}
-	if err := v.ValidateName(name); err != nil {
-		return err
-	}
-	if err := v.ValidatePassword(password); err != nil {
-		return err
-	}
-	return nil
+	errs := pkgerrors.ValidationErrors{}
+	addFieldError(errs, "username", v.ValidateUsername(username))
+	addFieldError(errs, "name", v.ValidateName(name))
+	addFieldError(errs, "password", v.ValidatePassword(password))
+	return errs.Err()
+}
+
+// ValidateUpdateUserInput validates the optional fields of a user update
+// Every field is checked; failures are returned together as pkgerrors.ValidationErrors.
+func (v *Validator) ValidateUpdateUserInput(name, password, email *string) error {
+	errs := pkgerrors.ValidationErrors{}
+	addFieldError(errs, "name", v.ValidateOptionalName(name))
+	addFieldError(errs, "password", v.ValidateOptionalPassword(password))
+	addFieldError(errs, "email", v.ValidateOptionalEmail(email))
+	return errs.Err()
 }
 
 // ValidateLoginInput validates login input
+// Every field is checked; failures are returned together as pkgerrors.ValidationErrors.
 func (v *Validator) ValidateLoginInput(username, password string) error {
+	errs := pkgerrors.ValidationErrors{}
 	if username == "" {
-		return pkgerrors.ErrRequiredField
+		addFieldError(errs, "username", pkgerrors.ErrRequiredField)
 	}
 	if password == "" {
-		return pkgerrors.ErrRequiredField
+		addFieldError(errs, "password", pkgerrors.ErrRequiredField)
 	}
-	return nil
+	return errs.Err()
+}
+
+// addFieldError records err against field in errs; a nil err is ignored
+func addFieldError(errs pkgerrors.ValidationErrors, field string, err error) {
+	switch {
+	case err == nil:
+		return
+	case pkgerrors.Is(err, pkgerrors.ErrRequiredField):
+		errs.Add(field, "required")
+	default:
+		errs.Add(field, err.Error())
+	}
+}
+
+// ValidateSkillConsistency checks that years of experience meet the minimum for the proficiency level
+func (v *Validator) ValidateSkillConsistency(level models.ProficiencyLevel, years int) error {
+	minYears, ok := MinYearsByProficiency[level]
+	if !ok || years >= minYears {
+		return nil
+	}
+	return fmt.Errorf("%w: proficiency_level %s requires years_of_experience of at least %d, got %d",
+		apperrors.ErrInconsistentExperience, level, minYears, years)
 }
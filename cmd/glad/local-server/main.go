@@ -0,0 +1,172 @@
+// Command local-server serves the API over plain HTTP against the in-memory mock repository,
+// so it can be run and exercised without AWS.
+//
+// Usage:
+//
+//	go run ./cmd/glad/local-server [--host 127.0.0.1] [--port 8080]
+//
+// It listens on the loopback interface only unless --host says otherwise, since anyone who can
+// reach it can act as any user (see X-Local-User below).
+//
+// It lives under cmd/glad so it can share the application's internal packages, and builds the
+// router exactly as the Lambda does (see app.New), using the same environment configuration.
+// Each HTTP request is converted to the API Gateway proxy event the Lambda would receive.
+//
+// Protected routes can be called without logging in by sending X-Local-User: <username>; the
+// server then signs a token for that user as /login would, so users listed in ADMIN_USERNAMES
+// get the admin role. The user must be registered, since tokens of unknown or deactivated
+// accounts are refused. An Authorization header, when present, takes precedence.
+package main
+
+import (
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/app"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/database"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/router"
+	"github.com/hackmajoris/glad-stack/pkg/auth"
+	"github.com/hackmajoris/glad-stack/pkg/config"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// localUserHeader names the user a request acts as when it carries no token
+const localUserHeader = "X-Local-User"
+
+func main() {
+	host := flag.String("host", "127.0.0.1", "address to listen on; 0.0.0.0 exposes the server to the network")
+	port := flag.Int("port", 8080, "port to listen on")
+	flag.Parse()
+
+	cfg := config.Load()
+	r, err := app.New(cfg, database.NewMockRepository())
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+
+	addr := net.JoinHostPort(*host, strconv.Itoa(*port))
+	log.Printf("Serving the API with the mock repository on http://%s", addr)
+	log.Fatal(http.ListenAndServe(addr, newServer(r, auth.NewTokenService(cfg))))
+}
+
+// localUser is the identity named by the X-Local-User header
+type localUser string
+
+// GetUsername implements auth.User
+func (u localUser) GetUsername() string {
+	return string(u)
+}
+
+// newServer returns an http.Handler that routes requests through r like API Gateway
+// tokens signs the tokens for X-Local-User requests; it must share r's configuration.
+func newServer(r *router.Router, tokens *auth.TokenService) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		event, err := toProxyRequest(r, req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if username := req.Header.Get(localUserHeader); username != "" && req.Header.Get("Authorization") == "" {
+			token, err := tokens.GenerateToken(localUser(username))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			event.Headers["Authorization"] = "Bearer " + token
+		}
+
+		response, err := r.Route(event)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if err := writeProxyResponse(w, response); err != nil {
+			log.Printf("Failed to write response: %v", err)
+		}
+	})
+}
+
+// toProxyRequest converts an HTTP request to the event API Gateway would deliver
+// Resource and PathParameters come from the route matching the path; unmatched paths keep
+// the raw path as their resource so the router answers 404.
+func toProxyRequest(r *router.Router, req *http.Request) (events.APIGatewayProxyRequest, error) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return events.APIGatewayProxyRequest{}, fmt.Errorf("failed to read request body: %w", err)
+	}
+
+	headers := make(map[string]string, len(req.Header))
+	for name, values := range req.Header {
+		headers[name] = strings.Join(values, ",")
+	}
+
+	query := req.URL.Query()
+	var singleQuery map[string]string
+	var multiQuery map[string][]string
+	if len(query) > 0 {
+		singleQuery = make(map[string]string, len(query))
+		multiQuery = make(map[string][]string, len(query))
+		for name, values := range query {
+			// API Gateway keeps the last value in the single-value map
+			singleQuery[name] = values[len(values)-1]
+			multiQuery[name] = values
+		}
+	}
+
+	resource, params, ok := r.Match(req.URL.Path)
+	if !ok {
+		resource = req.URL.Path
+	}
+
+	return events.APIGatewayProxyRequest{
+		Resource:                        resource,
+		Path:                            req.URL.Path,
+		HTTPMethod:                      req.Method,
+		Headers:                         headers,
+		MultiValueHeaders:               req.Header,
+		QueryStringParameters:           singleQuery,
+		MultiValueQueryStringParameters: multiQuery,
+		PathParameters:                  params,
+		Body:                            string(body),
+		RequestContext: events.APIGatewayProxyRequestContext{
+			HTTPMethod:   req.Method,
+			ResourcePath: resource,
+			Path:         req.URL.Path,
+			Authorizer:   make(map[string]interface{}),
+		},
+	}, nil
+}
+
+// writeProxyResponse writes a Lambda proxy response as an HTTP response
+func writeProxyResponse(w http.ResponseWriter, response events.APIGatewayProxyResponse) error {
+	for name, value := range response.Headers {
+		w.Header().Set(name, value)
+	}
+	for name, values := range response.MultiValueHeaders {
+		for _, value := range values {
+			w.Header().Add(name, value)
+		}
+	}
+
+	body := []byte(response.Body)
+	if response.IsBase64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(response.Body)
+		if err != nil {
+			return fmt.Errorf("failed to decode response body: %w", err)
+		}
+		body = decoded
+	}
+
+	w.WriteHeader(response.StatusCode)
+	_, err := w.Write(body)
+	return err
+}
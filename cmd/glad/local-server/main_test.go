@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/app"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/database"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/dto"
+	"github.com/hackmajoris/glad-stack/pkg/auth"
+	"github.com/hackmajoris/glad-stack/pkg/config"
+)
+
+// newTestServer starts the local server against an empty mock repository with admin as the only admin
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	t.Setenv("ADMIN_USERNAMES", "admin")
+	cfg := config.Load()
+	r, err := app.New(cfg, database.NewMockRepository())
+	if err != nil {
+		t.Fatalf("Failed to build router: %v", err)
+	}
+
+	server := httptest.NewServer(newServer(r, auth.NewTokenService(cfg)))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// do sends a request as user (if non-empty) and returns the status code and body
+func do(t *testing.T, server *httptest.Server, method, path, user, body string) (int, string) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Failed to build request: %v", err)
+	}
+	if user != "" {
+		req.Header.Set(localUserHeader, user)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read response: %v", err)
+	}
+	return resp.StatusCode, string(data)
+}
+
+func TestLocalServer_RoutesLikeAPIGateway(t *testing.T) {
+	server := newTestServer(t)
+
+	if status, body := do(t, server, "POST", "/register", "", `{"username":"alice","name":"Alice","password":"password123"}`); status != 201 {
+		t.Fatalf("Expected status 201 registering, got %d: %s", status, body)
+	}
+
+	if status, body := do(t, server, "POST", "/master-skills", "alice", `{"skill_id":"golang","skill_name":"Go","category":"Programming"}`); status != 201 {
+		t.Fatalf("Expected status 201 creating a master skill, got %d: %s", status, body)
+	}
+
+	// Path parameters are filled from the matched route
+	if status, body := do(t, server, "POST", "/users/alice/skills", "alice", `{"skill_name":"golang","proficiency_level":"Beginner","years_of_experience":1}`); status != 201 {
+		t.Fatalf("Expected status 201 adding a skill, got %d: %s", status, body)
+	}
+	status, body := do(t, server, "GET", "/v1/users/alice/skills/golang", "alice", "")
+	if status != 200 {
+		t.Fatalf("Expected status 200 getting the skill, got %d: %s", status, body)
+	}
+	var skill dto.SkillResponse
+	if err := json.Unmarshal([]byte(body), &skill); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if skill.SkillName != "Go" || skill.ProficiencyLevel != "Beginner" {
+		t.Errorf("Expected a Beginner Go skill, got %s %s", skill.ProficiencyLevel, skill.SkillName)
+	}
+
+	// Query parameters reach the handler
+	if status, _ := do(t, server, "GET", "/master-skills?limit=0", "alice", ""); status != 400 {
+		t.Errorf("Expected status 400 for an invalid limit, got %d", status)
+	}
+
+	if status, _ := do(t, server, "GET", "/nowhere", "alice", ""); status != 404 {
+		t.Errorf("Expected status 404 for an unknown path, got %d", status)
+	}
+	if status, _ := do(t, server, "DELETE", "/health", "", ""); status != 405 {
+		t.Errorf("Expected status 405 for an unsupported method, got %d", status)
+	}
+}
+
+func TestLocalServer_LocalUserHeader(t *testing.T) {
+	server := newTestServer(t)
+	for _, username := range []string{"alice", "admin"} {
+		body := `{"username":"` + username + `","name":"Local User","password":"password123"}`
+		if status, body := do(t, server, "POST", "/register", "", body); status != 201 {
+			t.Fatalf("Expected status 201 registering %s, got %d: %s", username, status, body)
+		}
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		user           string
+		expectedStatus int
+	}{
+		{name: "no identity", path: "/protected", expectedStatus: 401},
+		{name: "local user", path: "/protected", user: "alice", expectedStatus: 200},
+		{name: "unregistered user", path: "/protected", user: "mallory", expectedStatus: 401},
+		{name: "admin route as a regular user", path: "/routes", user: "alice", expectedStatus: 403},
+		{name: "admin route as an admin", path: "/routes", user: "admin", expectedStatus: 200},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if status, body := do(t, server, "GET", tt.path, tt.user, ""); status != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.expectedStatus, status, body)
+			}
+		})
+	}
+}
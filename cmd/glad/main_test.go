@@ -25,7 +25,7 @@ func TestNotFoundHandler(t *testing.T) {
 		t.Errorf("Expected Content-Type application/json, got %s", response.Headers["Content-Type"])
 	}
 
-	expected := `{"error": "Not Found"}`
+	expected := `{"error": "Not Found", "code": "NOT_FOUND"}`
 	if response.Body != expected {
 		t.Errorf("Expected body %s, got %s", expected, response.Body)
 	}
@@ -38,7 +38,7 @@ func TestMethodNotAllowedHandler(t *testing.T) {
 		t.Errorf("Expected status 405, got %d", response.StatusCode)
 	}
 
-	expected := `{"error": "Method Not Allowed"}`
+	expected := `{"error": "Method Not Allowed", "code": "METHOD_NOT_ALLOWED"}`
 	if response.Body != expected {
 		t.Errorf("Expected body %s, got %s", expected, response.Body)
 	}
@@ -0,0 +1,60 @@
+// Command purge-user permanently removes a user and all of their data for data-retention requests.
+//
+// Usage:
+//
+//	go run ./cmd/glad/purge-user --username alice [--dry-run]
+//
+// It lives under cmd/glad so it can share the application's internal packages, and uses the
+// same configuration as the Lambda (DYNAMODB_TABLE, AWS_REGION, ENVIRONMENT, DB_MOCK, LOG_LEVEL).
+// See service.PurgeService.PurgeUser for what is removed and in which order.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/database"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/service"
+	"github.com/hackmajoris/glad-stack/pkg/config"
+	"github.com/hackmajoris/glad-stack/pkg/logger"
+)
+
+func main() {
+	username := flag.String("username", "", "user to purge (required)")
+	dryRun := flag.Bool("dry-run", false, "report what would be removed without removing it")
+	flag.Parse()
+
+	if *username == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cfg := config.Load()
+	if err := logger.Configure(cfg.Log.Level, cfg.Log.Format); err != nil {
+		log.Fatalf("Invalid logging configuration: %v", err)
+	}
+
+	repo := database.NewRepository(cfg)
+	if err := run(repo, *username, *dryRun, os.Stdout); err != nil {
+		log.Fatalf("Purge failed: %v", err)
+	}
+}
+
+// run purges username from repo and writes a summary of the removed entities to out
+func run(repo database.Repository, username string, dryRun bool, out io.Writer) error {
+	report, err := service.NewPurgeService(repo, repo, repo, repo, repo, repo).PurgeUser(username, dryRun)
+	if err != nil {
+		return err
+	}
+
+	verb := "Removed"
+	if report.DryRun {
+		verb = "Would remove"
+	}
+	_, err = fmt.Fprintf(out, "%s for user %s:\n  endorsements given: %d\n  snapshots: %d\n  skill history entries: %d\n  activity entries: %d\n  skills (with endorsements received): %d\n  user record: 1\n",
+		verb, report.Username, report.Endorsements, report.Snapshots, report.History, report.Activity, report.Skills)
+	return err
+}
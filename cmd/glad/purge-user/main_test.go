@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/database"
+	apperrors "github.com/hackmajoris/glad-stack/cmd/glad/internal/errors"
+	"github.com/hackmajoris/glad-stack/cmd/glad/internal/models"
+	pkgerrors "github.com/hackmajoris/glad-stack/pkg/errors"
+)
+
+// seedUser stores a user with two skills, one history entry, one activity entry and one snapshot
+func seedUser(t *testing.T, repo *database.MockRepository, username string) {
+	t.Helper()
+
+	user, _ := models.NewUser(username, "Test User", "password123")
+	if err := repo.CreateUser(user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	var skills []*models.UserSkill
+	for _, skillID := range []string{"go", "python"} {
+		skill, _ := models.NewUserSkill(username, skillID, skillID, "Programming", models.ProficiencyIntermediate, 3)
+		skill.AddEndorsement()
+		if err := repo.CreateSkill(skill); err != nil {
+			t.Fatalf("Failed to create skill: %v", err)
+		}
+		skills = append(skills, skill)
+	}
+
+	history := models.NewSkillHistory(username, "go", models.ProficiencyBeginner, models.ProficiencyIntermediate)
+	if err := repo.CreateSkillHistory(history); err != nil {
+		t.Fatalf("Failed to create skill history: %v", err)
+	}
+
+	if err := repo.CreateActivity(models.NewActivityLog(username, models.ActivitySkillAdded, "go", "go")); err != nil {
+		t.Fatalf("Failed to create activity: %v", err)
+	}
+
+	if err := repo.CreateSkillSnapshot(models.NewSkillSnapshot(username, skills)); err != nil {
+		t.Fatalf("Failed to create skill snapshot: %v", err)
+	}
+}
+
+// countEntities returns the number of skills, history entries and snapshots stored for username
+func countEntities(t *testing.T, repo *database.MockRepository, username string) (int, int, int) {
+	t.Helper()
+
+	skills, err := repo.ListSkillsForUser(username)
+	if err != nil {
+		t.Fatalf("Failed to list skills: %v", err)
+	}
+	history, err := repo.ListSkillHistoryForUser(username)
+	if err != nil {
+		t.Fatalf("Failed to list skill history: %v", err)
+	}
+	snapshots, err := repo.ListSkillSnapshots(username)
+	if err != nil {
+		t.Fatalf("Failed to list skill snapshots: %v", err)
+	}
+	return len(skills), len(history), len(snapshots)
+}
+
+func TestRun_PurgesOnlyTheUser(t *testing.T) {
+	repo := database.NewMockRepository()
+	seedUser(t, repo, "alice")
+	seedUser(t, repo, "alicia")
+
+	// alice endorses alicia's go skill
+	endorsed, _ := repo.GetSkill("alicia", "go")
+	endorsed.AddEndorsement()
+	if err := repo.UpdateSkill(endorsed); err != nil {
+		t.Fatalf("Failed to update skill: %v", err)
+	}
+	if err := repo.CreateEndorsement(models.NewEndorsement("alicia", "go", "alice")); err != nil {
+		t.Fatalf("Failed to create endorsement: %v", err)
+	}
+
+	// A dry run reports everything and removes nothing
+	var out bytes.Buffer
+	if err := run(repo, "alice", true, &out); err != nil {
+		t.Fatalf("Dry run failed: %v", err)
+	}
+	for _, line := range []string{"Would remove for user alice", "endorsements given: 1", "snapshots: 1", "skill history entries: 1", "activity entries: 1", "skills (with endorsements received): 2"} {
+		if !strings.Contains(out.String(), line) {
+			t.Errorf("Expected dry run output to contain %q, got:\n%s", line, out.String())
+		}
+	}
+	if skills, history, snapshots := countEntities(t, repo, "alice"); skills != 2 || history != 1 || snapshots != 1 {
+		t.Fatalf("Expected dry run to keep all data, got %d skills, %d history, %d snapshots", skills, history, snapshots)
+	}
+
+	out.Reset()
+	if err := run(repo, "alice", false, &out); err != nil {
+		t.Fatalf("Purge failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "Removed for user alice") {
+		t.Errorf("Expected purge summary, got:\n%s", out.String())
+	}
+
+	if _, err := repo.GetUser("alice"); !pkgerrors.Is(err, apperrors.ErrUserNotFound) {
+		t.Errorf("Expected user to be removed, got %v", err)
+	}
+	if skills, history, snapshots := countEntities(t, repo, "alice"); skills != 0 || history != 0 || snapshots != 0 {
+		t.Errorf("Expected no data left, got %d skills, %d history, %d snapshots", skills, history, snapshots)
+	}
+	if activity, _, _ := repo.ListActivity("alice", 0, ""); len(activity) != 0 {
+		t.Errorf("Expected no activity left, got %d entries", len(activity))
+	}
+
+	// A user whose name shares the prefix keeps everything
+	if _, err := repo.GetUser("alicia"); err != nil {
+		t.Errorf("Expected unrelated user to remain, got %v", err)
+	}
+	if skills, history, snapshots := countEntities(t, repo, "alicia"); skills != 2 || history != 1 || snapshots != 1 {
+		t.Errorf("Expected unrelated user's data to remain, got %d skills, %d history, %d snapshots", skills, history, snapshots)
+	}
+
+	// The endorsement alice gave is withdrawn
+	if skill, _ := repo.GetSkill("alicia", "go"); skill.Endorsements != 1 {
+		t.Errorf("Expected alice's endorsement to be withdrawn, got %d endorsements", skill.Endorsements)
+	}
+	if endorsements, _ := repo.ListEndorsements("alicia", "go"); len(endorsements) != 0 {
+		t.Errorf("Expected no endorsement records left, got %d", len(endorsements))
+	}
+
+	if err := run(repo, "alice", false, &out); !pkgerrors.Is(err, apperrors.ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound purging a missing user, got %v", err)
+	}
+}
@@ -66,6 +66,16 @@ func createLambdaResource(stack awscdk.Stack, id string, env string) awslambda.F
 	gladFunc.AddEnvironment(jsii.String("ENVIRONMENT"), jsii.String(env), nil)
 	gladFunc.AddEnvironment(jsii.String("DYNAMODB_TABLE"), tableName, nil)
 
+	// Restrict in-app CORS origins (e.g. the CloudFront domain); required before enabling CORS_ALLOW_CREDENTIALS
+	if corsOrigins, ok := stack.Node().TryGetContext(jsii.String("corsOrigins")).(string); ok && corsOrigins != "" {
+		gladFunc.AddEnvironment(jsii.String("CORS_ALLOWED_ORIGINS"), jsii.String(corsOrigins), nil)
+	}
+
+	// Usernames granted the admin role in issued tokens
+	if adminUsernames, ok := stack.Node().TryGetContext(jsii.String("adminUsernames")).(string); ok && adminUsernames != "" {
+		gladFunc.AddEnvironment(jsii.String("ADMIN_USERNAMES"), jsii.String(adminUsernames), nil)
+	}
+
 	// Grant Lambda access to DynamoDB table
 	gladFunc.AddToRolePolicy(awsiam.NewPolicyStatement(&awsiam.PolicyStatementProps{
 		Effect: awsiam.Effect_ALLOW,
@@ -76,6 +86,8 @@ func createLambdaResource(stack awscdk.Stack, id string, env string) awslambda.F
 			"dynamodb:DeleteItem",
 			"dynamodb:Query",
 			"dynamodb:Scan",
+			"dynamodb:DescribeTable",
+			"dynamodb:BatchWriteItem",
 		),
 		Resources: jsii.Strings(
 			*tableArn,
@@ -97,7 +109,7 @@ func createApiGatewayResource(stack awscdk.Stack, id string, gladFunc awslambda.
 			AllowOrigins:     jsii.Strings("*"),
 			AllowCredentials: jsii.Bool(true),
 			AllowHeaders:     jsii.Strings("Content-Type", "Authorization"),
-			AllowMethods:     jsii.Strings("GET", "POST", "DELETE", "PUT", "OPTIONS"),
+			AllowMethods:     jsii.Strings("GET", "POST", "DELETE", "PUT", "PATCH", "OPTIONS"),
 		},
 	})
 
@@ -115,39 +127,164 @@ func createApiGatewayResource(stack awscdk.Stack, id string, gladFunc awslambda.
 			*api.RestApiId())),
 	})
 
-	// Define API routes
-	registerResource := api.Root().AddResource(jsii.String("register"), nil)
+	// Define API routes; versioned routes mirror the unversioned ones for now
+	addApiRoutes(api.Root(), integration)
+	addApiRoutes(api.Root().AddResource(jsii.String("v1"), nil), integration)
+
+	// Create deployment
+	deployment := awsapigateway.NewDeployment(stack, jsii.String(id+"-api-deployment"), &awsapigateway.DeploymentProps{
+		Api:         api,
+		Description: jsii.String("Deployment triggered by Lambda changes"),
+	})
+	deployment.Node().AddDependency(gladFunc)
+
+	// Create stage with fixed logical ID
+	stage := awsapigateway.NewStage(stack, jsii.String(id+"-api-stage"), &awsapigateway.StageProps{
+		Deployment:           deployment,
+		StageName:            jsii.String("prod"),
+		ThrottlingBurstLimit: jsii.Number(200),
+		ThrottlingRateLimit:  jsii.Number(100),
+		LoggingLevel:         awsapigateway.MethodLoggingLevel_INFO,
+		DataTraceEnabled:     jsii.Bool(true),
+		MetricsEnabled:       jsii.Bool(true),
+	})
+
+	// Create UsagePlan
+	awsapigateway.NewUsagePlan(stack, jsii.String(id+"-api-gateway-usage-plan"), &awsapigateway.UsagePlanProps{
+		Name:        jsii.String(id + "-api-gateway-usage-plan"),
+		Description: jsii.String("Usage plan with rate limiting"),
+		Throttle: &awsapigateway.ThrottleSettings{
+			RateLimit:  jsii.Number(100),
+			BurstLimit: jsii.Number(200),
+		},
+		ApiStages: &[]*awsapigateway.UsagePlanPerApiStage{
+			{
+				Api:   api,
+				Stage: stage,
+			},
+		},
+	})
+
+	// Output the API URL
+	awscdk.NewCfnOutput(stack, jsii.String("ApiUrl"), &awscdk.CfnOutputProps{
+		Value:       jsii.String(fmt.Sprintf("https://%s.execute-api.%s.amazonaws.com/%s", *api.RestApiId(), *stack.Region(), *stage.StageName())),
+		Description: jsii.String("API Gateway endpoint URL"),
+		ExportName:  jsii.String("GladApiUrl"),
+	})
+
+}
+
+// addApiRoutes registers every API resource and method beneath root
+func addApiRoutes(root awsapigateway.IResource, integration awsapigateway.Integration) {
+	registerResource := root.AddResource(jsii.String("register"), nil)
 	registerResource.AddMethod(jsii.String("POST"), integration, &awsapigateway.MethodOptions{
 		AuthorizationType: awsapigateway.AuthorizationType_NONE,
 	})
 
-	loginResource := api.Root().AddResource(jsii.String("login"), nil)
+	loginResource := root.AddResource(jsii.String("login"), nil)
 	loginResource.AddMethod(jsii.String("POST"), integration, &awsapigateway.MethodOptions{
 		AuthorizationType: awsapigateway.AuthorizationType_NONE,
 	})
 
-	protectedResource := api.Root().AddResource(jsii.String("protected"), nil)
+	refreshResource := root.AddResource(jsii.String("refresh"), nil)
+	refreshResource.AddMethod(jsii.String("POST"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
+
+	healthResource := root.AddResource(jsii.String("health"), nil)
+	healthResource.AddMethod(jsii.String("GET"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
+
+	protectedResource := root.AddResource(jsii.String("protected"), nil)
 	protectedResource.AddMethod(jsii.String("GET"), integration, &awsapigateway.MethodOptions{
 		AuthorizationType: awsapigateway.AuthorizationType_NONE,
 	})
 
-	userResource := api.Root().AddResource(jsii.String("user"), nil)
+	userResource := root.AddResource(jsii.String("user"), nil)
 	userResource.AddMethod(jsii.String("PUT"), integration, &awsapigateway.MethodOptions{
 		AuthorizationType: awsapigateway.AuthorizationType_NONE,
 	})
 
-	usersResource := api.Root().AddResource(jsii.String("users"), nil)
+	usersResource := root.AddResource(jsii.String("users"), nil)
 	usersResource.AddMethod(jsii.String("GET"), integration, &awsapigateway.MethodOptions{
 		AuthorizationType: awsapigateway.AuthorizationType_NONE,
 	})
 
-	meResource := api.Root().AddResource(jsii.String("me"), nil)
+	meResource := root.AddResource(jsii.String("me"), nil)
 	meResource.AddMethod(jsii.String("GET"), integration, &awsapigateway.MethodOptions{
 		AuthorizationType: awsapigateway.AuthorizationType_NONE,
 	})
+	meResource.AddMethod(jsii.String("DELETE"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
+
+	meSkillsResource := meResource.AddResource(jsii.String("skills"), nil)
+	meSkillsResource.AddMethod(jsii.String("GET"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
+	meSkillsResource.AddMethod(jsii.String("POST"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
+	meSkillsResource.AddMethod(jsii.String("DELETE"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
+
+	meSkillResource := meSkillsResource.AddResource(jsii.String("{skillName}"), nil)
+	meSkillResource.AddMethod(jsii.String("DELETE"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
+
+	deactivateResource := meResource.AddResource(jsii.String("deactivate"), nil)
+	deactivateResource.AddMethod(jsii.String("POST"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
+
+	exportResource := meResource.AddResource(jsii.String("export"), nil)
+	exportResource.AddMethod(jsii.String("GET"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
+
+	// Admin Endpoints
+	adminResource := root.AddResource(jsii.String("admin"), nil)
+	adminUsersResource := adminResource.AddResource(jsii.String("users"), nil)
+	adminUserResource := adminUsersResource.AddResource(jsii.String("{username}"), nil)
+	reactivateResource := adminUserResource.AddResource(jsii.String("reactivate"), nil)
+	reactivateResource.AddMethod(jsii.String("POST"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
+
+	analyticsResource := root.AddResource(jsii.String("analytics"), nil)
+	teamMatrixResource := analyticsResource.AddResource(jsii.String("team-matrix"), nil)
+	teamMatrixResource.AddMethod(jsii.String("POST"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
+
+	tagsResource := root.AddResource(jsii.String("tags"), nil)
+	tagResource := tagsResource.AddResource(jsii.String("{tag}"), nil)
+	tagUsersResource := tagResource.AddResource(jsii.String("users"), nil)
+	tagUsersResource.AddMethod(jsii.String("GET"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
+
+	routesResource := root.AddResource(jsii.String("routes"), nil)
+	routesResource.AddMethod(jsii.String("GET"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
 
 	// Skill Management Endpoints
+	bulkSkillsResource := usersResource.AddResource(jsii.String("skills"), nil).AddResource(jsii.String("bulk"), nil)
+	bulkSkillsResource.AddMethod(jsii.String("POST"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
+
 	usersSkillsResource := usersResource.AddResource(jsii.String("{username}"), nil)
+
+	activityResource := usersSkillsResource.AddResource(jsii.String("activity"), nil)
+	activityResource.AddMethod(jsii.String("GET"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
+
 	skillsResource := usersSkillsResource.AddResource(jsii.String("skills"), nil)
 	skillsResource.AddMethod(jsii.String("POST"), integration, &awsapigateway.MethodOptions{
 		AuthorizationType: awsapigateway.AuthorizationType_NONE,
@@ -155,6 +292,34 @@ func createApiGatewayResource(stack awscdk.Stack, id string, gladFunc awslambda.
 	skillsResource.AddMethod(jsii.String("GET"), integration, &awsapigateway.MethodOptions{
 		AuthorizationType: awsapigateway.AuthorizationType_NONE,
 	})
+	skillsResource.AddMethod(jsii.String("DELETE"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
+
+	batchSkillsResource := skillsResource.AddResource(jsii.String("batch"), nil)
+	batchSkillsResource.AddMethod(jsii.String("POST"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
+
+	snapshotResource := skillsResource.AddResource(jsii.String("snapshot"), nil)
+	snapshotResource.AddMethod(jsii.String("POST"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
+
+	diffResource := skillsResource.AddResource(jsii.String("diff"), nil)
+	diffResource.AddMethod(jsii.String("GET"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
+
+	gapResource := skillsResource.AddResource(jsii.String("gap"), nil)
+	gapResource.AddMethod(jsii.String("GET"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
+
+	staleResource := skillsResource.AddResource(jsii.String("stale"), nil)
+	staleResource.AddMethod(jsii.String("GET"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
 
 	skillResource := skillsResource.AddResource(jsii.String("{skillName}"), nil)
 	skillResource.AddMethod(jsii.String("GET"), integration, &awsapigateway.MethodOptions{
@@ -163,20 +328,71 @@ func createApiGatewayResource(stack awscdk.Stack, id string, gladFunc awslambda.
 	skillResource.AddMethod(jsii.String("PUT"), integration, &awsapigateway.MethodOptions{
 		AuthorizationType: awsapigateway.AuthorizationType_NONE,
 	})
+	skillResource.AddMethod(jsii.String("PATCH"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
 	skillResource.AddMethod(jsii.String("DELETE"), integration, &awsapigateway.MethodOptions{
 		AuthorizationType: awsapigateway.AuthorizationType_NONE,
 	})
 
+	endorseResource := skillResource.AddResource(jsii.String("endorse"), nil)
+	endorseResource.AddMethod(jsii.String("POST"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
+	endorseResource.AddMethod(jsii.String("DELETE"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
+
+	endorsementsResource := skillResource.AddResource(jsii.String("endorsements"), nil)
+	endorsementsResource.AddMethod(jsii.String("GET"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
+
+	historyResource := skillResource.AddResource(jsii.String("history"), nil)
+	historyResource.AddMethod(jsii.String("GET"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
+
+	touchResource := skillResource.AddResource(jsii.String("touch"), nil)
+	touchResource.AddMethod(jsii.String("POST"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
+
+	promoteResource := skillResource.AddResource(jsii.String("promote"), nil)
+	promoteResource.AddMethod(jsii.String("POST"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
+
 	// Global skill query endpoint
-	skillsGlobalResource := api.Root().AddResource(jsii.String("skills"), nil)
+	skillsGlobalResource := root.AddResource(jsii.String("skills"), nil)
+	recentSkillsResource := skillsGlobalResource.AddResource(jsii.String("recent"), nil)
+	recentSkillsResource.AddMethod(jsii.String("GET"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
+
 	skillNameResource := skillsGlobalResource.AddResource(jsii.String("{skillName}"), nil)
 	usersWithSkillResource := skillNameResource.AddResource(jsii.String("users"), nil)
 	usersWithSkillResource.AddMethod(jsii.String("GET"), integration, &awsapigateway.MethodOptions{
 		AuthorizationType: awsapigateway.AuthorizationType_NONE,
 	})
 
+	skillLevelStatsResource := usersWithSkillResource.AddResource(jsii.String("stats"), nil)
+	skillLevelStatsResource.AddMethod(jsii.String("GET"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
+
+	relatedSkillsResource := skillNameResource.AddResource(jsii.String("related"), nil)
+	relatedSkillsResource.AddMethod(jsii.String("GET"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
+
+	endorseUsersResource := skillNameResource.AddResource(jsii.String("endorse-users"), nil)
+	endorseUsersResource.AddMethod(jsii.String("POST"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
+
 	// Master Skills Management Endpoints
-	masterSkillsResource := api.Root().AddResource(jsii.String("master-skills"), nil)
+	masterSkillsResource := root.AddResource(jsii.String("master-skills"), nil)
 	masterSkillsResource.AddMethod(jsii.String("POST"), integration, &awsapigateway.MethodOptions{
 		AuthorizationType: awsapigateway.AuthorizationType_NONE,
 	})
@@ -184,6 +400,31 @@ func createApiGatewayResource(stack awscdk.Stack, id string, gladFunc awslambda.
 		AuthorizationType: awsapigateway.AuthorizationType_NONE,
 	})
 
+	masterSkillsImportResource := masterSkillsResource.AddResource(jsii.String("import"), nil)
+	masterSkillsImportResource.AddMethod(jsii.String("POST"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
+
+	masterSkillsSearchResource := masterSkillsResource.AddResource(jsii.String("search"), nil)
+	masterSkillsSearchResource.AddMethod(jsii.String("GET"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
+
+	masterSkillsStatsResource := masterSkillsResource.AddResource(jsii.String("stats"), nil)
+	masterSkillsStatsResource.AddMethod(jsii.String("GET"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
+
+	suggestCategoryResource := masterSkillsResource.AddResource(jsii.String("suggest-category"), nil)
+	suggestCategoryResource.AddMethod(jsii.String("GET"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
+
+	masterSkillsFeaturedResource := masterSkillsResource.AddResource(jsii.String("featured"), nil)
+	masterSkillsFeaturedResource.AddMethod(jsii.String("GET"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
+
 	masterSkillResource := masterSkillsResource.AddResource(jsii.String("{skillID}"), nil)
 	masterSkillResource.AddMethod(jsii.String("GET"), integration, &awsapigateway.MethodOptions{
 		AuthorizationType: awsapigateway.AuthorizationType_NONE,
@@ -195,45 +436,34 @@ func createApiGatewayResource(stack awscdk.Stack, id string, gladFunc awslambda.
 		AuthorizationType: awsapigateway.AuthorizationType_NONE,
 	})
 
-	// Create deployment
-	deployment := awsapigateway.NewDeployment(stack, jsii.String(id+"-api-deployment"), &awsapigateway.DeploymentProps{
-		Api:         api,
-		Description: jsii.String("Deployment triggered by Lambda changes"),
+	masterSkillOverviewResource := masterSkillResource.AddResource(jsii.String("overview"), nil)
+	masterSkillOverviewResource.AddMethod(jsii.String("GET"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
 	})
-	deployment.Node().AddDependency(gladFunc)
 
-	// Create stage with fixed logical ID
-	stage := awsapigateway.NewStage(stack, jsii.String(id+"-api-stage"), &awsapigateway.StageProps{
-		Deployment:           deployment,
-		StageName:            jsii.String("prod"),
-		ThrottlingBurstLimit: jsii.Number(200),
-		ThrottlingRateLimit:  jsii.Number(100),
-		LoggingLevel:         awsapigateway.MethodLoggingLevel_INFO,
-		DataTraceEnabled:     jsii.Bool(true),
-		MetricsEnabled:       jsii.Bool(true),
+	masterSkillResyncResource := masterSkillResource.AddResource(jsii.String("resync"), nil)
+	masterSkillResyncResource.AddMethod(jsii.String("POST"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
 	})
 
-	// Create UsagePlan
-	awsapigateway.NewUsagePlan(stack, jsii.String(id+"-api-gateway-usage-plan"), &awsapigateway.UsagePlanProps{
-		Name:        jsii.String(id + "-api-gateway-usage-plan"),
-		Description: jsii.String("Usage plan with rate limiting"),
-		Throttle: &awsapigateway.ThrottleSettings{
-			RateLimit:  jsii.Number(100),
-			BurstLimit: jsii.Number(200),
-		},
-		ApiStages: &[]*awsapigateway.UsagePlanPerApiStage{
-			{
-				Api:   api,
-				Stage: stage,
-			},
-		},
+	masterSkillFeatureResource := masterSkillResource.AddResource(jsii.String("feature"), nil)
+	masterSkillFeatureResource.AddMethod(jsii.String("PUT"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
 	})
 
-	// Output the API URL
-	awscdk.NewCfnOutput(stack, jsii.String("ApiUrl"), &awscdk.CfnOutputProps{
-		Value:       jsii.String(fmt.Sprintf("https://%s.execute-api.%s.amazonaws.com/%s", *api.RestApiId(), *stack.Region(), *stage.StageName())),
-		Description: jsii.String("API Gateway endpoint URL"),
-		ExportName:  jsii.String("GladApiUrl"),
+	masterSkillUnfeatureResource := masterSkillResource.AddResource(jsii.String("unfeature"), nil)
+	masterSkillUnfeatureResource.AddMethod(jsii.String("PUT"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
+
+	masterSkillMergeIntoResource := masterSkillResource.AddResource(jsii.String("merge-into"), nil)
+	masterSkillMergeTargetResource := masterSkillMergeIntoResource.AddResource(jsii.String("{targetID}"), nil)
+	masterSkillMergeTargetResource.AddMethod(jsii.String("POST"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
 	})
 
+	categoriesResource := root.AddResource(jsii.String("categories"), nil)
+	categoriesResource.AddMethod(jsii.String("GET"), integration, &awsapigateway.MethodOptions{
+		AuthorizationType: awsapigateway.AuthorizationType_NONE,
+	})
 }
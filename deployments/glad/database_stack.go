@@ -58,6 +58,18 @@ func NewDatabaseStack(scope constructs.Construct, id string, props *DatabaseStac
 					},
 				},
 			},
+			{
+				// Recently updated skills feed; all user skills share one RecentFeed partition
+				IndexName: jsii.String("RecentlyUpdated"),
+				PartitionKey: &awsdynamodb.Attribute{
+					Name: jsii.String("RecentFeed"),
+					Type: awsdynamodb.AttributeType_STRING,
+				},
+				SortKey: &awsdynamodb.Attribute{
+					Name: jsii.String("UpdatedAt"),
+					Type: awsdynamodb.AttributeType_STRING,
+				},
+			},
 		},
 		PointInTimeRecovery: jsii.Bool(false),
 		DynamoStream:        awsdynamodb.StreamViewType_NEW_AND_OLD_IMAGES,
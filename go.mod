@@ -10,4 +10,7 @@ require (
 	github.com/golang-jwt/jwt/v5 v5.3.0
 )
 
-require github.com/jmespath/go-jmespath v0.4.0 // indirect
+require (
+	github.com/jmespath/go-jmespath v0.4.0 // indirect
+	golang.org/x/sys v0.38.0 // indirect
+)
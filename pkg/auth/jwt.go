@@ -20,53 +20,157 @@ type User interface {
 	GetUsername() string
 }
 
+// DefaultTokenType is the token type reported to clients when none is configured
+const DefaultTokenType = "Bearer"
+
+// supportedTokenTypes lists the token types that may be reported to clients
+var supportedTokenTypes = map[string]bool{
+	"Bearer": true,
+	"bearer": true,
+	"JWT":    true,
+}
+
+// ValidateTokenType reports whether tokenType may be reported to clients
+func ValidateTokenType(tokenType string) error {
+	if !supportedTokenTypes[tokenType] {
+		return pkgerrors.ErrUnsupportedTokenType
+	}
+	return nil
+}
+
+// Token uses distinguish access tokens from refresh tokens in the token_use claim
+const (
+	TokenUseAccess  = "access"
+	TokenUseRefresh = "refresh"
+)
+
+// defaultRefreshExpiry is the refresh token lifetime when none is configured
+const defaultRefreshExpiry = 7 * 24 * time.Hour
+
+// RoleAdmin is the role granted to users listed in the admin configuration
+const RoleAdmin = "admin"
+
 // JWTClaims represents the JWT claims
 type JWTClaims struct {
 	Username string `json:"username"`
+	Role     string `json:"role,omitempty"`
+	TokenUse string `json:"token_use,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// IsRefresh reports whether the claims belong to a refresh token
+func (c *JWTClaims) IsRefresh() bool {
+	return c.TokenUse == TokenUseRefresh
+}
+
+// IsAdmin reports whether the claims carry the admin role
+func (c *JWTClaims) IsAdmin() bool {
+	return c.Role == RoleAdmin
+}
+
 // TokenService handles JWT operations
 type TokenService struct {
-	secretKey []byte
-	expiry    time.Duration
+	keys          *signingKeys
+	keysErr       error // set when the signing configuration is unusable; every token is then refused
+	expiry        time.Duration
+	refreshExpiry time.Duration
+	tokenType     string
+	issuer        string
+	audience      string
+	admins        map[string]bool
 }
 
 // NewTokenService creates a new TokenService
+// Tokens are signed with RS256 when a key pair is configured and with the HS256 secret
+// otherwise. Callers should check the configuration with ValidateSigningConfig first: with an
+// unusable one the service fails closed, refusing to issue or accept any token.
 func NewTokenService(cfg *config.Config) *TokenService {
 	log := logger.WithComponent("auth")
 
-	if cfg.JWT.Secret == "default-secret-key" {
+	keys, keysErr := loadSigningKeys(cfg.JWT)
+	switch {
+	case keysErr != nil:
+		log.Error("Invalid JWT signing configuration - all tokens will be refused", "error", keysErr.Error())
+	case keys.method == jwt.SigningMethodRS256:
+		log.Info("JWT service initialized with RSA key pair", "signing_alg", keys.method.Alg())
+	case cfg.JWT.Secret == "default-secret-key":
 		log.Warn("Using default JWT secret - not suitable for production")
-	} else {
-		log.Info("JWT service initialized with custom secret")
+	default:
+		log.Info("JWT service initialized with custom secret", "signing_alg", keys.method.Alg())
+	}
+
+	admins := make(map[string]bool, len(cfg.Auth.AdminUsernames))
+	for _, username := range cfg.Auth.AdminUsernames {
+		admins[username] = true
+	}
+
+	tokenType := cfg.JWT.TokenType
+	if tokenType == "" {
+		tokenType = DefaultTokenType
+	}
+
+	refreshExpiry := cfg.JWT.RefreshExpiry
+	if refreshExpiry <= 0 {
+		refreshExpiry = defaultRefreshExpiry
 	}
 
 	return &TokenService{
-		secretKey: []byte(cfg.JWT.Secret),
-		expiry:    cfg.JWT.Expiry,
+		keys:          keys,
+		keysErr:       keysErr,
+		expiry:        cfg.JWT.Expiry,
+		refreshExpiry: refreshExpiry,
+		tokenType:     tokenType,
+		issuer:        cfg.JWT.Issuer,
+		audience:      cfg.JWT.Audience,
+		admins:        admins,
 	}
 }
 
-// GenerateToken creates a new JWT token for the user
+// TokenType returns the token type reported to clients alongside issued tokens
+func (ts *TokenService) TokenType() string {
+	return ts.tokenType
+}
+
+// GenerateToken creates a new JWT access token for the user
 func (ts *TokenService) GenerateToken(user User) (string, error) {
-	log := logger.WithComponent("auth").With("operation", "GenerateToken", "username", user.GetUsername())
+	return ts.generate(user, TokenUseAccess, ts.expiry)
+}
+
+// GenerateRefreshToken creates a longer-lived token that can only be exchanged for access tokens
+func (ts *TokenService) GenerateRefreshToken(user User) (string, error) {
+	return ts.generate(user, TokenUseRefresh, ts.refreshExpiry)
+}
+
+// generate signs a token of the given use that expires after lifetime
+func (ts *TokenService) generate(user User, tokenUse string, lifetime time.Duration) (string, error) {
+	log := logger.WithComponent("auth").With("operation", "GenerateToken", "username", user.GetUsername(), "token_use", tokenUse)
 	start := time.Now()
 
 	log.Debug("Starting JWT token generation")
 
-	expiry := time.Now().Add(ts.expiry)
+	if ts.keysErr != nil {
+		log.Error("Cannot sign JWT token", "error", ts.keysErr.Error(), "duration", time.Since(start))
+		return "", ts.keysErr
+	}
+
+	expiry := time.Now().Add(lifetime)
 	claims := JWTClaims{
 		Username: user.GetUsername(),
+		Role:     ts.roleFor(user.GetUsername()),
+		TokenUse: tokenUse,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expiry),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			Subject:   user.GetUsername(),
+			Issuer:    ts.issuer,
 		},
 	}
+	if ts.audience != "" {
+		claims.Audience = jwt.ClaimStrings{ts.audience}
+	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signedToken, err := token.SignedString(ts.secretKey)
+	token := jwt.NewWithClaims(ts.keys.method, claims)
+	signedToken, err := token.SignedString(ts.keys.signKey)
 	if err != nil {
 		log.Error("Failed to sign JWT token", "error", err.Error(), "duration", time.Since(start))
 		return "", err
@@ -77,19 +181,26 @@ func (ts *TokenService) GenerateToken(user User) (string, error) {
 }
 
 // ValidateToken validates and parses a JWT token
+// The token's alg header must name the configured algorithm exactly, so an HS256 token
+// cannot be verified with the RS256 public key used as an HMAC secret.
 func (ts *TokenService) ValidateToken(tokenString string) (*JWTClaims, error) {
 	log := logger.WithComponent("auth").With("operation", "ValidateToken")
 	start := time.Now()
 
 	log.Debug("Starting JWT token validation")
 
+	if ts.keysErr != nil {
+		log.Error("Cannot verify JWT token", "error", ts.keysErr.Error(), "duration", time.Since(start))
+		return nil, ErrInvalidToken
+	}
+
 	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			log.Error("Unexpected signing method", "method", token.Header["alg"])
+		if token.Method.Alg() != ts.keys.method.Alg() {
+			log.Error("Unexpected signing method", "method", token.Header["alg"], "expected", ts.keys.method.Alg())
 			return nil, pkgerrors.ErrInvalidToken
 		}
-		return ts.secretKey, nil
-	})
+		return ts.keys.verifyKey, nil
+	}, ts.parserOptions()...)
 
 	if err != nil {
 		log.Error("Failed to parse JWT token", "error", err.Error(), "duration", time.Since(start))
@@ -111,3 +222,39 @@ func (ts *TokenService) ValidateToken(tokenString string) (*JWTClaims, error) {
 	log.Info("JWT token validated successfully", "expires_at", claims.ExpiresAt.Time.Format(time.RFC3339), "duration", time.Since(start))
 	return claims, nil
 }
+
+// ValidateRefreshToken validates a JWT token and requires it to be a refresh token
+func (ts *TokenService) ValidateRefreshToken(tokenString string) (*JWTClaims, error) {
+	claims, err := ts.ValidateToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	if !claims.IsRefresh() {
+		logger.WithComponent("auth").With("operation", "ValidateRefreshToken", "username", claims.Username).Warn("Access token presented as refresh token")
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
+
+// parserOptions requires the configured algorithm, issuer and audience; unset issuer and
+// audience are not checked
+func (ts *TokenService) parserOptions() []jwt.ParserOption {
+	opts := []jwt.ParserOption{jwt.WithValidMethods([]string{ts.keys.method.Alg()})}
+	if ts.issuer != "" {
+		opts = append(opts, jwt.WithIssuer(ts.issuer))
+	}
+	if ts.audience != "" {
+		opts = append(opts, jwt.WithAudience(ts.audience))
+	}
+	return opts
+}
+
+// roleFor returns the role assigned to a username
+func (ts *TokenService) roleFor(username string) string {
+	if ts.admins[username] {
+		return RoleAdmin
+	}
+	return ""
+}
@@ -1,11 +1,18 @@
 package auth
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/hackmajoris/glad-stack/pkg/config"
+	pkgerrors "github.com/hackmajoris/glad-stack/pkg/errors"
 
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -73,8 +80,8 @@ func TestNewTokenService(t *testing.T) {
 
 			cfg := config.Load()
 			ts := NewTokenService(cfg)
-			if string(ts.secretKey) != tt.expected {
-				t.Errorf("Expected secret key %s, got %s", tt.expected, string(ts.secretKey))
+			if string(ts.keys.signKey.([]byte)) != tt.expected {
+				t.Errorf("Expected secret key %s, got %s", tt.expected, string(ts.keys.signKey.([]byte)))
 			}
 		})
 	}
@@ -95,7 +102,7 @@ func TestTokenService_GenerateToken(t *testing.T) {
 
 	// Verify token can be parsed
 	parsedToken, err := jwt.ParseWithClaims(token, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		return ts.secretKey, nil
+		return ts.keys.signKey, nil
 	})
 
 	if err != nil {
@@ -185,6 +192,38 @@ func TestTokenService_ValidateToken(t *testing.T) {
 	}
 }
 
+func TestTokenService_AdminRole(t *testing.T) {
+	cfg := testConfig()
+	cfg.Auth.AdminUsernames = []string{"admin"}
+	ts := NewTokenService(cfg)
+
+	tests := []struct {
+		username string
+		isAdmin  bool
+	}{
+		{username: "admin", isAdmin: true},
+		{username: "testuser", isAdmin: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.username, func(t *testing.T) {
+			token, err := ts.GenerateToken(&MockUser{Username: tt.username})
+			if err != nil {
+				t.Fatalf("Failed to generate token: %v", err)
+			}
+
+			claims, err := ts.ValidateToken(token)
+			if err != nil {
+				t.Fatalf("Failed to validate token: %v", err)
+			}
+
+			if claims.IsAdmin() != tt.isAdmin {
+				t.Errorf("Expected IsAdmin() = %v, got %v", tt.isAdmin, claims.IsAdmin())
+			}
+		})
+	}
+}
+
 func TestTokenService_ValidateExpiredToken(t *testing.T) {
 	ts := NewTokenService(testConfig())
 
@@ -199,7 +238,7 @@ func TestTokenService_ValidateExpiredToken(t *testing.T) {
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(ts.secretKey)
+	tokenString, err := token.SignedString(ts.keys.signKey)
 	if err != nil {
 		t.Fatalf("Failed to create expired token: %v", err)
 	}
@@ -240,3 +279,259 @@ func TestTokenService_ValidateTokenWithWrongSecret(t *testing.T) {
 		t.Error("Expected error when validating token with wrong secret, got nil")
 	}
 }
+
+func TestTokenService_IssuerAndAudience(t *testing.T) {
+	newService := func(issuer, audience string) *TokenService {
+		return NewTokenService(&config.Config{
+			JWT: config.JWTConfig{
+				Secret:   "test-secret",
+				Expiry:   24 * time.Hour,
+				Issuer:   issuer,
+				Audience: audience,
+			},
+		})
+	}
+	user := &MockUser{Username: "testuser"}
+
+	minter := newService("https://auth.example.com", "glad-api")
+	token, err := minter.GenerateToken(user)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		validator   *TokenService
+		expectError bool
+	}{
+		{name: "matching issuer and audience", validator: minter, expectError: false},
+		{name: "different issuer", validator: newService("https://other.example.com", "glad-api"), expectError: true},
+		{name: "different audience", validator: newService("https://auth.example.com", "other-api"), expectError: true},
+		{name: "checks not configured", validator: newService("", ""), expectError: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := tt.validator.ValidateToken(token)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if claims.Issuer != "https://auth.example.com" {
+				t.Errorf("Expected issuer https://auth.example.com, got %s", claims.Issuer)
+			}
+		})
+	}
+
+	// Tokens minted without an issuer are rejected once one is required
+	legacy, err := newService("", "").GenerateToken(user)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+	if _, err := minter.ValidateToken(legacy); err == nil {
+		t.Error("Expected error validating a token without issuer or audience, got nil")
+	}
+}
+
+func TestTokenService_RefreshToken(t *testing.T) {
+	ts := NewTokenService(&config.Config{
+		JWT: config.JWTConfig{
+			Secret:        "test-secret",
+			Expiry:        15 * time.Minute,
+			RefreshExpiry: 48 * time.Hour,
+		},
+	})
+	user := &MockUser{Username: "testuser"}
+
+	refreshToken, err := ts.GenerateRefreshToken(user)
+	if err != nil {
+		t.Fatalf("Failed to generate refresh token: %v", err)
+	}
+	accessToken, err := ts.GenerateToken(user)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	claims, err := ts.ValidateRefreshToken(refreshToken)
+	if err != nil {
+		t.Fatalf("Unexpected error validating refresh token: %v", err)
+	}
+	if claims.TokenUse != TokenUseRefresh {
+		t.Errorf("Expected token_use %s, got %s", TokenUseRefresh, claims.TokenUse)
+	}
+	if lifetime := claims.ExpiresAt.Sub(claims.IssuedAt.Time); lifetime != 48*time.Hour {
+		t.Errorf("Expected refresh token lifetime 48h, got %v", lifetime)
+	}
+
+	if _, err := ts.ValidateRefreshToken(accessToken); err == nil {
+		t.Error("Expected error validating an access token as refresh token, got nil")
+	}
+
+	accessClaims, err := ts.ValidateToken(accessToken)
+	if err != nil {
+		t.Fatalf("Unexpected error validating access token: %v", err)
+	}
+	if accessClaims.TokenUse != TokenUseAccess || accessClaims.IsRefresh() {
+		t.Errorf("Expected access token_use, got %q", accessClaims.TokenUse)
+	}
+}
+
+func TestValidateTokenType(t *testing.T) {
+	for _, tokenType := range []string{"Bearer", "bearer", "JWT"} {
+		if err := ValidateTokenType(tokenType); err != nil {
+			t.Errorf("Expected %q to be accepted, got %v", tokenType, err)
+		}
+	}
+	for _, tokenType := range []string{"", "Basic", "BEARER"} {
+		if err := ValidateTokenType(tokenType); err == nil {
+			t.Errorf("Expected %q to be rejected", tokenType)
+		}
+	}
+}
+
+// rsaKeyPEMs generates an RSA key pair and returns it PEM-encoded
+func rsaKeyPEMs(t *testing.T) (privatePEM, publicPEM string) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key: %v", err)
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("Failed to marshal public key: %v", err)
+	}
+
+	privatePEM = string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+	publicPEM = string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+	return privatePEM, publicPEM
+}
+
+func TestTokenService_RS256(t *testing.T) {
+	privatePEM, publicPEM := rsaKeyPEMs(t)
+	ts := NewTokenService(&config.Config{
+		JWT: config.JWTConfig{
+			Secret:     "test-secret-key",
+			Expiry:     time.Hour,
+			PrivateKey: privatePEM,
+			PublicKey:  publicPEM,
+		},
+	})
+	user := &MockUser{Username: "testuser"}
+
+	token, err := ts.GenerateToken(user)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &JWTClaims{})
+	if err != nil {
+		t.Fatalf("Failed to parse token: %v", err)
+	}
+	if parsed.Header["alg"] != "RS256" {
+		t.Errorf("Expected alg RS256, got %v", parsed.Header["alg"])
+	}
+
+	claims, err := ts.ValidateToken(token)
+	if err != nil {
+		t.Fatalf("Expected RS256 token to validate, got %v", err)
+	}
+	if claims.Username != "testuser" {
+		t.Errorf("Expected username testuser, got %s", claims.Username)
+	}
+
+	// A token signed by a different key pair is rejected
+	otherPrivate, otherPublic := rsaKeyPEMs(t)
+	other := NewTokenService(&config.Config{JWT: config.JWTConfig{Expiry: time.Hour, PrivateKey: otherPrivate, PublicKey: otherPublic}})
+	if _, err := other.ValidateToken(token); err == nil {
+		t.Error("Expected token signed with another key to be rejected")
+	}
+
+	// HS256 services do not accept RS256 tokens either
+	if _, err := NewTokenService(testConfig()).ValidateToken(token); err == nil {
+		t.Error("Expected HS256 service to reject an RS256 token")
+	}
+}
+
+func TestTokenService_RS256RejectsHS256Tokens(t *testing.T) {
+	privatePEM, publicPEM := rsaKeyPEMs(t)
+	ts := NewTokenService(&config.Config{
+		JWT: config.JWTConfig{
+			Secret:     "test-secret-key",
+			Expiry:     time.Hour,
+			PrivateKey: privatePEM,
+			PublicKey:  publicPEM,
+		},
+	})
+
+	claims := JWTClaims{
+		Username: "attacker",
+		Role:     RoleAdmin,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+
+	// Neither the shared secret nor the public key (the classic alg-confusion trick) may be used as an HMAC key
+	for name, secret := range map[string][]byte{"shared secret": []byte("test-secret-key"), "public key": []byte(publicPEM)} {
+		t.Run(name, func(t *testing.T) {
+			token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+			if err != nil {
+				t.Fatalf("Failed to sign token: %v", err)
+			}
+			if _, err := ts.ValidateToken(token); err == nil {
+				t.Error("Expected HS256 token to be rejected when RS256 is configured")
+			}
+		})
+	}
+}
+
+func TestValidateSigningConfig(t *testing.T) {
+	privatePEM, publicPEM := rsaKeyPEMs(t)
+	_, otherPublic := rsaKeyPEMs(t)
+
+	tests := []struct {
+		name    string
+		cfg     config.JWTConfig
+		wantErr bool
+	}{
+		{name: "HS256 by default", cfg: config.JWTConfig{Secret: "secret"}},
+		{name: "explicit HS256", cfg: config.JWTConfig{Secret: "secret", SigningAlg: "HS256"}},
+		{name: "RS256 from key pair", cfg: config.JWTConfig{PrivateKey: privatePEM, PublicKey: publicPEM}},
+		{name: "explicit RS256", cfg: config.JWTConfig{SigningAlg: "RS256", PrivateKey: privatePEM, PublicKey: publicPEM}},
+		{name: "escaped newlines", cfg: config.JWTConfig{PrivateKey: strings.ReplaceAll(privatePEM, "\n", `\n`), PublicKey: strings.ReplaceAll(publicPEM, "\n", `\n`)}},
+		{name: "private key only", cfg: config.JWTConfig{PrivateKey: privatePEM}, wantErr: true},
+		{name: "public key only", cfg: config.JWTConfig{PublicKey: publicPEM}, wantErr: true},
+		{name: "RS256 without keys", cfg: config.JWTConfig{SigningAlg: "RS256", Secret: "secret"}, wantErr: true},
+		{name: "HS256 with keys", cfg: config.JWTConfig{SigningAlg: "HS256", PrivateKey: privatePEM, PublicKey: publicPEM}, wantErr: true},
+		{name: "mismatched pair", cfg: config.JWTConfig{PrivateKey: privatePEM, PublicKey: otherPublic}, wantErr: true},
+		{name: "malformed key", cfg: config.JWTConfig{PrivateKey: "not a key", PublicKey: publicPEM}, wantErr: true},
+		{name: "unsupported algorithm", cfg: config.JWTConfig{SigningAlg: "none"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSigningConfig(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateSigningConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, pkgerrors.ErrInvalidSigningConfig) {
+				t.Errorf("Expected error to wrap ErrInvalidSigningConfig, got %v", err)
+			}
+		})
+	}
+
+	// An unusable configuration refuses every token rather than falling back to HS256
+	ts := NewTokenService(&config.Config{JWT: config.JWTConfig{Secret: "secret", PrivateKey: privatePEM}})
+	if _, err := ts.GenerateToken(&MockUser{Username: "testuser"}); err == nil {
+		t.Error("Expected token generation to fail with an unusable configuration")
+	}
+	token, _ := NewTokenService(&config.Config{JWT: config.JWTConfig{Secret: "secret", Expiry: time.Hour}}).GenerateToken(&MockUser{Username: "testuser"})
+	if _, err := ts.ValidateToken(token); err == nil {
+		t.Error("Expected token validation to fail with an unusable configuration")
+	}
+}
@@ -0,0 +1,144 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	pkgerrors "github.com/hackmajoris/glad-stack/pkg/errors"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Supported password hashing algorithms
+const (
+	PasswordHasherBcrypt   = "bcrypt"
+	PasswordHasherArgon2id = "argon2id"
+)
+
+// argon2idPrefix identifies argon2id hashes in PHC string format
+const argon2idPrefix = "$argon2id$"
+
+// ErrUnknownPasswordHasher is returned for unsupported PASSWORD_HASHER values
+var ErrUnknownPasswordHasher = pkgerrors.ErrUnknownPasswordHasher
+
+// PasswordHasher hashes and verifies passwords
+// Hashes are self-describing, so any hasher's output can be checked with VerifyPassword.
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Verify(hash, password string) bool
+}
+
+// NewPasswordHasher returns the hasher for the named algorithm
+func NewPasswordHasher(algorithm string) (PasswordHasher, error) {
+	switch strings.ToLower(algorithm) {
+	case "", PasswordHasherBcrypt:
+		return BcryptHasher{Cost: bcrypt.DefaultCost}, nil
+	case PasswordHasherArgon2id:
+		return NewArgon2idHasher(), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownPasswordHasher, algorithm)
+	}
+}
+
+// VerifyPassword checks password against hash using the algorithm encoded in the hash
+func VerifyPassword(hash, password string) bool {
+	if strings.HasPrefix(hash, argon2idPrefix) {
+		return Argon2idHasher{}.Verify(hash, password)
+	}
+	return BcryptHasher{}.Verify(hash, password)
+}
+
+// BcryptHasher hashes passwords with bcrypt
+type BcryptHasher struct {
+	Cost int
+}
+
+// Hash returns a bcrypt hash of password
+func (h BcryptHasher) Hash(password string) (string, error) {
+	cost := h.Cost
+	if cost == 0 {
+		cost = bcrypt.DefaultCost
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// Verify reports whether password matches the bcrypt hash
+func (h BcryptHasher) Verify(hash, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// Argon2idHasher hashes passwords with argon2id, encoding parameters in the PHC string format
+type Argon2idHasher struct {
+	Time    uint32
+	Memory  uint32 // KiB
+	Threads uint8
+	KeyLen  uint32
+	SaltLen uint32
+}
+
+// NewArgon2idHasher creates an Argon2idHasher with the OWASP-recommended minimum parameters
+func NewArgon2idHasher() Argon2idHasher {
+	return Argon2idHasher{
+		Time:    2,
+		Memory:  19 * 1024,
+		Threads: 1,
+		KeyLen:  32,
+		SaltLen: 16,
+	}
+}
+
+// Hash returns an argon2id hash of password as $argon2id$v=19$m=<m>,t=<t>,p=<p>$<salt>$<key>
+func (h Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.Time, h.Memory, h.Threads, h.KeyLen)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix, argon2.Version, h.Memory, h.Time, h.Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Verify reports whether password matches the argon2id hash, using the parameters stored in it
+func (h Argon2idHasher) Verify(hash, password string) bool {
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", salt, key
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != PasswordHasherArgon2id {
+		return false
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false
+	}
+
+	var memory, time uint32
+	var threads uint8
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &time, &threads); err != nil {
+		return false
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return false
+	}
+
+	candidate := argon2.IDKey([]byte(password), salt, time, memory, threads, uint32(len(key)))
+	return subtle.ConstantTimeCompare(key, candidate) == 1
+}
@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+
+	pkgerrors "github.com/hackmajoris/glad-stack/pkg/errors"
+)
+
+func TestNewPasswordHasher(t *testing.T) {
+	tests := []struct {
+		name      string
+		algorithm string
+		prefix    string
+		wantErr   bool
+	}{
+		{name: "default is bcrypt", algorithm: "", prefix: "$2a$"},
+		{name: "bcrypt", algorithm: "bcrypt", prefix: "$2a$"},
+		{name: "argon2id", algorithm: "ARGON2ID", prefix: "$argon2id$v=19$"},
+		{name: "unknown", algorithm: "md5", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hasher, err := NewPasswordHasher(tt.algorithm)
+			if tt.wantErr {
+				if !pkgerrors.Is(err, ErrUnknownPasswordHasher) {
+					t.Errorf("Expected ErrUnknownPasswordHasher, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			hash, err := hasher.Hash("password123")
+			if err != nil {
+				t.Fatalf("Hash() error = %v", err)
+			}
+			if !strings.HasPrefix(hash, tt.prefix) {
+				t.Errorf("Expected hash with prefix %q, got %q", tt.prefix, hash)
+			}
+			if !hasher.Verify(hash, "password123") {
+				t.Error("Expected hasher to verify its own hash")
+			}
+			if !VerifyPassword(hash, "password123") {
+				t.Error("Expected VerifyPassword to verify the hash")
+			}
+			if VerifyPassword(hash, "wrongpassword") {
+				t.Error("Expected VerifyPassword to reject a wrong password")
+			}
+		})
+	}
+}
+
+func TestArgon2idHasher_VerifyMalformed(t *testing.T) {
+	hasher := NewArgon2idHasher()
+	for _, hash := range []string{
+		"",
+		"$argon2id$",
+		"$argon2id$v=18$m=19456,t=2,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=x,t=2,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=19456,t=2,p=1$!!!$a2V5",
+	} {
+		if hasher.Verify(hash, "password123") {
+			t.Errorf("Expected malformed hash %q to be rejected", hash)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hackmajoris/glad-stack/pkg/config"
+	pkgerrors "github.com/hackmajoris/glad-stack/pkg/errors"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Supported token signing algorithms
+const (
+	SigningAlgHS256 = "HS256"
+	SigningAlgRS256 = "RS256"
+)
+
+// signingKeys holds the method and keys tokens are signed and verified with
+type signingKeys struct {
+	method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+// ValidateSigningConfig reports whether the JWT signing configuration is usable
+// RS256 needs both keys, and they must form a pair; HS256 must not be combined with keys.
+func ValidateSigningConfig(cfg config.JWTConfig) error {
+	_, err := loadSigningKeys(cfg)
+	return err
+}
+
+// loadSigningKeys resolves the signing algorithm and parses its keys
+// Without an explicit algorithm, RS256 is used when either key is set and HS256 otherwise.
+func loadSigningKeys(cfg config.JWTConfig) (*signingKeys, error) {
+	hasKeys := cfg.PrivateKey != "" || cfg.PublicKey != ""
+
+	alg := cfg.SigningAlg
+	if alg == "" {
+		alg = SigningAlgHS256
+		if hasKeys {
+			alg = SigningAlgRS256
+		}
+	}
+
+	switch alg {
+	case SigningAlgHS256:
+		if hasKeys {
+			return nil, fmt.Errorf("%w: RSA keys are set but the signing algorithm is HS256", pkgerrors.ErrInvalidSigningConfig)
+		}
+		secret := []byte(cfg.Secret)
+		return &signingKeys{method: jwt.SigningMethodHS256, signKey: secret, verifyKey: secret}, nil
+
+	case SigningAlgRS256:
+		if cfg.PrivateKey == "" || cfg.PublicKey == "" {
+			return nil, fmt.Errorf("%w: RS256 needs both JWT_PRIVATE_KEY and JWT_PUBLIC_KEY", pkgerrors.ErrInvalidSigningConfig)
+		}
+		privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(pemBytes(cfg.PrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf("%w: private key: %v", pkgerrors.ErrInvalidSigningConfig, err)
+		}
+		publicKey, err := jwt.ParseRSAPublicKeyFromPEM(pemBytes(cfg.PublicKey))
+		if err != nil {
+			return nil, fmt.Errorf("%w: public key: %v", pkgerrors.ErrInvalidSigningConfig, err)
+		}
+		if !privateKey.PublicKey.Equal(publicKey) {
+			return nil, fmt.Errorf("%w: the public key does not match the private key", pkgerrors.ErrInvalidSigningConfig)
+		}
+		return &signingKeys{method: jwt.SigningMethodRS256, signKey: privateKey, verifyKey: publicKey}, nil
+
+	default:
+		return nil, fmt.Errorf("%w: unsupported signing algorithm %q, use HS256 or RS256", pkgerrors.ErrInvalidSigningConfig, alg)
+	}
+}
+
+// pemBytes accepts PEM with real newlines or with \n escapes, as single-line environment
+// variables often carry it
+func pemBytes(value string) []byte {
+	return []byte(strings.ReplaceAll(value, `\n`, "\n"))
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -11,19 +12,40 @@ type Config struct {
 	JWT         JWTConfig
 	Database    DatabaseConfig
 	LocalServer ServerConfig
+	CORS        CORSConfig
+	Auth        AuthConfig
+	Password    PasswordPolicyConfig
+	RateLimit   RateLimitConfig
+	Request     RequestConfig
+	Metrics     MetricsConfig
+	Log         LogConfig
+	Promotion   PromotionConfig
+	Skills      SkillsConfig
 }
 
 // JWTConfig holds JWT-related configuration
 type JWTConfig struct {
-	Secret     string
-	Expiry     time.Duration
-	SigningAlg string
+	Secret         string
+	Expiry         time.Duration
+	RefreshExpiry  time.Duration
+	SigningAlg     string // HS256 or RS256; empty picks RS256 when keys are set and HS256 otherwise
+	PrivateKey     string // PEM RSA private key used to sign RS256 tokens
+	PublicKey      string // PEM RSA public key used to verify RS256 tokens
+	TokenType      string // token_type reported to clients on login
+	Issuer         string // iss claim set and required on tokens; empty skips the check
+	Audience       string // aud claim set and required on tokens; empty skips the check
+	CookieFallback bool   // accept the token from the access_token cookie
 }
 
 // DatabaseConfig holds database-related configuration
 type DatabaseConfig struct {
-	TableName string
-	Region    string
+	TableName            string
+	BySkillIndex         string // GSI for cross-user skill queries
+	RecentlyUpdatedIndex string // GSI for the recent skills feed
+	Region               string
+	SlowQueryThreshold   time.Duration // queries slower than this are logged at Warn; 0 disables
+	LogConsumedCapacity  bool          // log consumed capacity of queries, puts and gets; requesting it adds cost
+	MaxWriteAttempts     int           // tries per throttled write, including the first; below 1 disables retrying
 }
 
 // ServerConfig holds server-related configuration
@@ -32,17 +54,86 @@ type ServerConfig struct {
 	Port        int
 }
 
+// CORSConfig holds CORS-related configuration
+type CORSConfig struct {
+	AllowedOrigins   []string
+	AllowCredentials bool
+}
+
+// AuthConfig holds authorization-related configuration
+type AuthConfig struct {
+	AdminUsernames     []string // users granted the admin role when their token is issued
+	PasswordHasher     string   // algorithm for new password hashes: bcrypt or argon2id
+	ConfirmDestructive bool     // require destructive requests to echo the target id in a confirm field
+}
+
+// PasswordPolicyConfig holds the rules new passwords must satisfy
+type PasswordPolicyConfig struct {
+	MinLength     int // the user model never accepts fewer than 6
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+}
+
+// RateLimitConfig holds request rate limiting configuration
+type RateLimitConfig struct {
+	PerMinute int // write requests allowed per caller per minute; 0 disables limiting
+}
+
+// RequestConfig holds incoming request limits
+type RequestConfig struct {
+	MaxBodyBytes int           // larger request bodies are rejected with 413
+	Timeout      time.Duration // deadline for the DynamoDB calls on the request hot path; 0 disables
+}
+
+// MetricsConfig holds in-process metrics configuration
+type MetricsConfig struct {
+	Enabled bool // record request metrics and serve them at GET /metrics; meant for the local server
+}
+
+// LogConfig holds logging configuration; empty values keep the ENVIRONMENT-based defaults
+type LogConfig struct {
+	Level     string // minimum level: debug, info, warn or error
+	Format    string // json or text
+	AccessLog bool   // write one structured line per request
+}
+
+// PromotionConfig holds endorsement-based proficiency promotion configuration
+type PromotionConfig struct {
+	AutoPromote bool  // promote skills automatically instead of only flagging eligibility
+	Thresholds  []int // endorsements required to reach Intermediate, Advanced and Expert
+}
+
+// SkillsConfig holds skill validation configuration
+type SkillsConfig struct {
+	NameMinLengths     map[string]int // minimum skill name length per category; others use the global rule
+	DefaultProficiency string         // proficiency level given to added skills that omit one
+}
+
 // Load loads configuration from environment variables with defaults
 func Load() *Config {
 	return &Config{
 		JWT: JWTConfig{
-			Secret:     getEnv("JWT_SECRET", "default-secret-key"),
-			Expiry:     getDurationEnv("JWT_EXPIRY", 24*time.Hour),
-			SigningAlg: getEnv("JWT_SIGNING_ALG", "HS256"),
+			Secret:         getEnv("JWT_SECRET", "default-secret-key"),
+			Expiry:         getDurationEnv("JWT_EXPIRY", 24*time.Hour),
+			RefreshExpiry:  getDurationEnv("JWT_REFRESH_EXPIRY", 7*24*time.Hour),
+			SigningAlg:     getEnv("JWT_SIGNING_ALG", ""),
+			PrivateKey:     getEnv("JWT_PRIVATE_KEY", ""),
+			PublicKey:      getEnv("JWT_PUBLIC_KEY", ""),
+			TokenType:      getEnv("JWT_TOKEN_TYPE", "Bearer"),
+			Issuer:         getEnv("JWT_ISSUER", ""),
+			Audience:       getEnv("JWT_AUDIENCE", ""),
+			CookieFallback: getBoolEnv("JWT_COOKIE_FALLBACK", false),
 		},
 		Database: DatabaseConfig{
-			TableName: getEnv("DYNAMODB_TABLE", "entities-table"),
-			Region:    getEnv("AWS_REGION", "us-east-1"),
+			TableName:            getEnv("DYNAMODB_TABLE", "entities-table"),
+			BySkillIndex:         getEnv("DYNAMODB_GSI_BY_SKILL", "BySkill"),
+			RecentlyUpdatedIndex: getEnv("DYNAMODB_GSI_RECENTLY_UPDATED", "RecentlyUpdated"),
+			Region:               getEnv("AWS_REGION", "us-east-1"),
+			SlowQueryThreshold:   getDurationEnv("DYNAMODB_SLOW_QUERY_THRESHOLD", 500*time.Millisecond),
+			LogConsumedCapacity:  getBoolEnv("DDB_LOG_CAPACITY", false),
+			MaxWriteAttempts:     getIntEnv("DYNAMODB_MAX_WRITE_ATTEMPTS", 3),
 		},
 
 		// local testing only
@@ -50,6 +141,45 @@ func Load() *Config {
 			Environment: getEnv("ENVIRONMENT", "development"),
 			Port:        getIntEnv("PORT", 8080),
 		},
+		CORS: CORSConfig{
+			AllowedOrigins:   getListEnv("CORS_ALLOWED_ORIGINS", getListEnv("CORS_ORIGINS", []string{"*"})), // CORS_ORIGINS is the legacy name
+			AllowCredentials: getBoolEnv("CORS_ALLOW_CREDENTIALS", false),
+		},
+		Auth: AuthConfig{
+			AdminUsernames:     getListEnv("ADMIN_USERNAMES", nil),
+			PasswordHasher:     getEnv("PASSWORD_HASHER", "bcrypt"),
+			ConfirmDestructive: getBoolEnv("CONFIRM_DESTRUCTIVE", true),
+		},
+		Password: PasswordPolicyConfig{
+			MinLength:     getIntEnv("PASSWORD_MIN_LENGTH", 6),
+			RequireUpper:  getBoolEnv("PASSWORD_REQUIRE_UPPER", false),
+			RequireLower:  getBoolEnv("PASSWORD_REQUIRE_LOWER", false),
+			RequireDigit:  getBoolEnv("PASSWORD_REQUIRE_DIGIT", false),
+			RequireSymbol: getBoolEnv("PASSWORD_REQUIRE_SYMBOL", false),
+		},
+		RateLimit: RateLimitConfig{
+			PerMinute: getIntEnv("RATE_LIMIT_PER_MINUTE", 60),
+		},
+		Request: RequestConfig{
+			MaxBodyBytes: getIntEnv("MAX_REQUEST_BODY_BYTES", 1<<20),
+			Timeout:      getDurationEnv("REQUEST_TIMEOUT", 10*time.Second),
+		},
+		Metrics: MetricsConfig{
+			Enabled: getBoolEnv("METRICS_ENABLED", false),
+		},
+		Log: LogConfig{
+			Level:     getEnv("LOG_LEVEL", ""),
+			Format:    getEnv("LOG_FORMAT", ""),
+			AccessLog: getBoolEnv("LOG_ACCESS", true),
+		},
+		Promotion: PromotionConfig{
+			AutoPromote: getBoolEnv("AUTO_PROMOTE", false),
+			Thresholds:  getIntListEnv("PROMOTION_THRESHOLDS", []int{5, 15, 30}),
+		},
+		Skills: SkillsConfig{
+			NameMinLengths:     getIntMapEnv("SKILL_NAME_MIN_LENGTHS", nil),
+			DefaultProficiency: getEnv("SKILL_DEFAULT_PROFICIENCY", "Beginner"),
+		},
 	}
 }
 
@@ -87,3 +217,71 @@ func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
 	}
 	return defaultValue
 }
+
+func getBoolEnv(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
+// getListEnv parses a comma-separated environment variable, ignoring empty entries
+func getListEnv(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	if len(result) == 0 {
+		return defaultValue
+	}
+	return result
+}
+
+// getIntListEnv parses a comma-separated list of integers, falling back to defaultValue if any entry is invalid
+func getIntListEnv(key string, defaultValue []int) []int {
+	items := getListEnv(key, nil)
+	if items == nil {
+		return defaultValue
+	}
+
+	result := make([]int, 0, len(items))
+	for _, item := range items {
+		intValue, err := strconv.Atoi(item)
+		if err != nil {
+			return defaultValue
+		}
+		result = append(result, intValue)
+	}
+	return result
+}
+
+// getIntMapEnv parses a comma-separated list of key=integer pairs, falling back to defaultValue if any entry is invalid
+func getIntMapEnv(key string, defaultValue map[string]int) map[string]int {
+	items := getListEnv(key, nil)
+	if items == nil {
+		return defaultValue
+	}
+
+	result := make(map[string]int, len(items))
+	for _, item := range items {
+		name, value, ok := strings.Cut(item, "=")
+		if !ok {
+			return defaultValue
+		}
+		intValue, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return defaultValue
+		}
+		result[strings.TrimSpace(name)] = intValue
+	}
+	return result
+}
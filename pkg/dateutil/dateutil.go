@@ -0,0 +1,73 @@
+// Package dateutil centralizes calendar-date handling so that stored dates are always UTC
+// and client-provided dates can be interpreted in the client's time zone.
+package dateutil
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	_ "time/tzdata" // the Lambda base image does not ship zoneinfo
+
+	pkgerrors "github.com/hackmajoris/glad-stack/pkg/errors"
+)
+
+// DateLayout is the ISO 8601 calendar date format used for stored dates
+const DateLayout = "2006-01-02"
+
+// Today returns the current UTC calendar date
+func Today() string {
+	return FormatDate(time.Now())
+}
+
+// FormatDate returns the UTC calendar date of t
+func FormatDate(t time.Time) string {
+	return t.UTC().Format(DateLayout)
+}
+
+// LoadLocation resolves an IANA time zone name, defaulting to UTC when tz is empty
+func LoadLocation(tz string) (*time.Location, error) {
+	if tz == "" {
+		return time.UTC, nil
+	}
+
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", pkgerrors.ErrInvalidTimeZone, tz)
+	}
+	return loc, nil
+}
+
+// StartOfDay returns the instant date begins in loc, converted to UTC
+func StartOfDay(date string, loc *time.Location) (time.Time, error) {
+	t, err := time.ParseInLocation(DateLayout, date, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s", pkgerrors.ErrInvalidDate, date)
+	}
+	return t.UTC(), nil
+}
+
+// ParseDate reads a stored date as the UTC calendar day it names
+// Besides DateLayout it accepts RFC 3339 timestamps, which some older records hold, and
+// surrounding whitespace; a timestamp is reduced to its UTC day.
+func ParseDate(date string) (time.Time, error) {
+	date = strings.TrimSpace(date)
+	if t, err := time.Parse(DateLayout, date); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, date); err == nil {
+		t = t.UTC()
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+	}
+	return time.Time{}, fmt.Errorf("%w: %s", pkgerrors.ErrInvalidDate, date)
+}
+
+// UsedSince reports whether a stored UTC date may fall on or after the instant since
+// A stored date only records the UTC day, so it counts as long as any part of that day
+// is at or after since; this avoids dropping dates that straddle the caller's midnight.
+func UsedSince(date string, since time.Time) bool {
+	start, err := StartOfDay(date, time.UTC)
+	if err != nil {
+		return false
+	}
+	return start.Add(24 * time.Hour).After(since)
+}
@@ -0,0 +1,85 @@
+package dateutil
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	pkgerrors "github.com/hackmajoris/glad-stack/pkg/errors"
+)
+
+func TestFormatDate_NormalizesToUTC(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+
+	// 22:30 on Jan 9 in New York is already Jan 10 in UTC
+	local := time.Date(2026, 1, 9, 22, 30, 0, 0, newYork)
+	if got := FormatDate(local); got != "2026-01-10" {
+		t.Errorf("Expected 2026-01-10, got %s", got)
+	}
+}
+
+func TestUsedSince_MidnightBoundaries(t *testing.T) {
+	tests := []struct {
+		name      string
+		stored    string // UTC date of last use
+		usedSince string
+		tz        string
+		expected  bool
+	}{
+		// New York midnight on Jan 10 is 05:00 UTC on Jan 10
+		{name: "New York: same UTC day overlaps", stored: "2026-01-10", usedSince: "2026-01-10", tz: "America/New_York", expected: true},
+		{name: "New York: previous UTC day ends before local midnight", stored: "2026-01-09", usedSince: "2026-01-10", tz: "America/New_York", expected: false},
+		// Tokyo midnight on Jan 10 is 15:00 UTC on Jan 9
+		{name: "Tokyo: previous UTC day overlaps local day", stored: "2026-01-09", usedSince: "2026-01-10", tz: "Asia/Tokyo", expected: true},
+		{name: "Tokyo: two UTC days earlier does not", stored: "2026-01-08", usedSince: "2026-01-10", tz: "Asia/Tokyo", expected: false},
+		{name: "UTC default: previous day does not", stored: "2026-01-09", usedSince: "2026-01-10", expected: false},
+		{name: "UTC default: same day does", stored: "2026-01-10", usedSince: "2026-01-10", expected: true},
+		{name: "unparseable stored date", stored: "01/10/2026", usedSince: "2026-01-10", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc, err := LoadLocation(tt.tz)
+			if err != nil {
+				t.Fatalf("Failed to load location: %v", err)
+			}
+			since, err := StartOfDay(tt.usedSince, loc)
+			if err != nil {
+				t.Fatalf("Failed to parse date: %v", err)
+			}
+
+			if got := UsedSince(tt.stored, since); got != tt.expected {
+				t.Errorf("Expected UsedSince(%s, %s) = %v, got %v", tt.stored, since, tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	want := time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)
+	for _, date := range []string{"2026-03-14", " 2026-03-14 ", "2026-03-14T09:30:00Z", "2026-03-14T23:30:00-00:00", "2026-03-15T01:00:00+02:00"} {
+		got, err := ParseDate(date)
+		if err != nil {
+			t.Errorf("ParseDate(%q) error = %v", date, err)
+			continue
+		}
+		if !got.Equal(want) {
+			t.Errorf("ParseDate(%q) = %v, want %v", date, got, want)
+		}
+	}
+
+	for _, date := range []string{"", "yesterday", "2026-13-01", "14/03/2026"} {
+		if _, err := ParseDate(date); !errors.Is(err, pkgerrors.ErrInvalidDate) {
+			t.Errorf("ParseDate(%q) expected ErrInvalidDate, got %v", date, err)
+		}
+	}
+}
+
+func TestParsingErrors(t *testing.T) {
+	if _, err := LoadLocation("Mars/Olympus_Mons"); !errors.Is(err, pkgerrors.ErrInvalidTimeZone) {
+		t.Errorf("Expected ErrInvalidTimeZone, got %v", err)
+	}
+	if _, err := StartOfDay("2026-13-01", time.UTC); !errors.Is(err, pkgerrors.ErrInvalidDate) {
+		t.Errorf("Expected ErrInvalidDate, got %v", err)
+	}
+}
@@ -6,4 +6,11 @@ import "errors"
 var (
 	ErrInvalidToken = errors.New("invalid token")
 	ErrTokenExpired = errors.New("token expired")
+	ErrNoIdentity   = errors.New("request carries no usable identity claims")
+
+	ErrAccountInactive = errors.New("account is deactivated or deleted")
+
+	ErrUnknownPasswordHasher = errors.New("unknown password hasher")
+	ErrUnsupportedTokenType  = errors.New("unsupported token type: use Bearer, bearer or JWT")
+	ErrInvalidSigningConfig  = errors.New("invalid token signing configuration")
 )
@@ -10,6 +10,11 @@ func Is(err, target error) bool {
 	return errors.Is(err, target)
 }
 
+// As finds the first error in err's chain that matches target
+func As(err error, target any) bool {
+	return errors.As(err, target)
+}
+
 // Wrap wraps an error with additional context
 func Wrap(err error, message string) error {
 	if err == nil {
@@ -3,13 +3,19 @@ package errors
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 )
 
 // Generic validation errors - reusable across all apps
 var (
-	ErrInvalidInput  = errors.New("invalid input")
-	ErrRequiredField = errors.New("required field missing")
-	ErrInvalidLength = errors.New("invalid field length")
+	ErrInvalidInput   = errors.New("invalid input")
+	ErrRequiredField  = errors.New("required field missing")
+	ErrInvalidLength  = errors.New("invalid field length")
+	ErrImmutableField = errors.New("field cannot be changed")
+
+	ErrInvalidDate     = errors.New("date must be formatted as YYYY-MM-DD")
+	ErrInvalidTimeZone = errors.New("unknown time zone")
 )
 
 // FieldValidationError provides detailed validation error information for specific fields
@@ -26,3 +32,35 @@ func (v *FieldValidationError) Error() string {
 	}
 	return fmt.Sprintf("validation failed: %s", v.Message)
 }
+
+// ValidationErrors collects field-level validation failures, keyed by field name
+type ValidationErrors map[string]string
+
+// Add records message for field, keeping the first failure reported for it
+func (v ValidationErrors) Add(field, message string) {
+	if _, exists := v[field]; !exists {
+		v[field] = message
+	}
+}
+
+// Err returns v as an error, or nil when no field failed
+func (v ValidationErrors) Err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return v
+}
+
+func (v ValidationErrors) Error() string {
+	fields := make([]string, 0, len(v))
+	for field := range v {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	details := make([]string, len(fields))
+	for i, field := range fields {
+		details[i] = field + ": " + v[field]
+	}
+	return "validation failed: " + strings.Join(details, "; ")
+}
@@ -2,8 +2,11 @@ package logger
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"log/slog"
 	"os"
+	"strings"
 )
 
 // Logger wraps slog.Logger to intercept log calls
@@ -13,6 +16,19 @@ type Logger struct {
 
 var Log *Logger
 
+// Output formats accepted by Configure
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// level is shared by every handler Configure builds, so loggers derived before a
+// level change follow it too
+var level = new(slog.LevelVar)
+
+// output is where log records are written; tests redirect it
+var output io.Writer = os.Stdout
+
 func sendToThirdParty(level, msg string, args ...any) {
 	// TODO: Implement your third-party integration
 }
@@ -24,6 +40,10 @@ func (l *Logger) Info(msg string, args ...any) {
 }
 
 func (l *Logger) Debug(msg string, args ...any) {
+	// Debug lines are frequent; skip building them when the level filters them out
+	if !l.Enabled(context.Background(), slog.LevelDebug) {
+		return
+	}
 	sendToThirdParty("DEBUG", msg, args...)
 	l.Logger.Debug("🔍"+msg, args...)
 }
@@ -64,38 +84,75 @@ func init() {
 		env = "development"
 	}
 
-	var slogLogger *slog.Logger
 	if env == "production" {
 		// JSON format for production (better for AWS CloudWatch)
-		slogLogger = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-			Level: slog.LevelInfo,
-		}))
+		level.Set(slog.LevelInfo)
+		Log = newLogger(FormatJSON)
 	} else {
 		// Human-readable format for development
-		slogLogger = slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
-			Level: slog.LevelDebug,
-		}))
+		level.Set(slog.LevelDebug)
+		Log = newLogger(FormatText)
 	}
+}
+
+// Configure sets the minimum level (debug, info, warn or error) and the output
+// format (json or text); an empty value keeps the environment default.
+// Loggers obtained afterwards use the new format, and every logger follows the new level.
+func Configure(levelName, format string) error {
+	var minLevel slog.Level
+	if levelName != "" {
+		if err := minLevel.UnmarshalText([]byte(levelName)); err != nil {
+			return fmt.Errorf("invalid log level %q: must be debug, info, warn or error", levelName)
+		}
+	}
+
+	format = strings.ToLower(format)
+	switch format {
+	case "", FormatJSON, FormatText:
+	default:
+		return fmt.Errorf("invalid log format %q: must be %s or %s", format, FormatJSON, FormatText)
+	}
+
+	if levelName != "" {
+		level.Set(minLevel)
+	}
+	if format != "" {
+		Log = newLogger(format)
+	}
+	return nil
+}
+
+// newLogger creates a Logger writing to output in format at the shared level
+func newLogger(format string) *Logger {
+	options := &slog.HandlerOptions{Level: level}
+	if format == FormatJSON {
+		return &Logger{Logger: slog.New(slog.NewJSONHandler(output, options))}
+	}
+	return &Logger{Logger: slog.New(slog.NewTextHandler(output, options))}
+}
 
-	Log = &Logger{Logger: slogLogger}
+// With returns a Logger that includes args in every record
+// It keeps the Logger wrapper, so derived loggers log through the same methods.
+func (l *Logger) With(args ...any) *Logger {
+	return &Logger{Logger: l.Logger.With(args...)}
 }
 
 // WithComponent returns a logger with a component field
 func WithComponent(component string) *Logger {
-	return &Logger{Logger: Log.With("component", component)}
+	return Log.With("component", component)
 }
 
 // WithUser returns a logger with user context
 func WithUser(username string) *Logger {
-	return &Logger{Logger: Log.With("user", username)}
+	return Log.With("user", username)
 }
 
 // WithError returns a logger with error context
 func WithError(err error) *Logger {
-	return &Logger{Logger: Log.With("error", err.Error())}
+	return Log.With("error", err.Error())
 }
 
 // WithRequest returns a logger with request context
 func WithRequest(requestId string) *Logger {
-	return &Logger{Logger: Log.With("request_id", requestId)}
+	return Log.With("request_id", requestId)
 }
@@ -0,0 +1,117 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// captureOutput redirects log output to a buffer and restores the logger, level and output afterwards
+func captureOutput(t *testing.T) *bytes.Buffer {
+	t.Helper()
+
+	var buf bytes.Buffer
+	originalLog, originalLevel, originalOutput := Log, level.Level(), output
+	output = &buf
+	t.Cleanup(func() {
+		Log, output = originalLog, originalOutput
+		level.Set(originalLevel)
+	})
+	return &buf
+}
+
+func TestConfigure_Level(t *testing.T) {
+	tests := []struct {
+		name      string
+		level     string
+		wantDebug bool
+		wantInfo  bool
+		wantWarn  bool
+	}{
+		{name: "debug", level: "debug", wantDebug: true, wantInfo: true, wantWarn: true},
+		{name: "info suppresses debug", level: "info", wantInfo: true, wantWarn: true},
+		{name: "upper case", level: "WARN", wantWarn: true},
+		{name: "error", level: "error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := captureOutput(t)
+			if err := Configure(tt.level, FormatText); err != nil {
+				t.Fatalf("Configure returned unexpected error: %v", err)
+			}
+
+			log := WithComponent("test").With("operation", "Level")
+			log.Debug("debug line")
+			log.Info("info line")
+			log.Warn("warn line")
+
+			logs := buf.String()
+			for msg, want := range map[string]bool{"debug line": tt.wantDebug, "info line": tt.wantInfo, "warn line": tt.wantWarn} {
+				if got := strings.Contains(logs, msg); got != want {
+					t.Errorf("Expected %q logged = %v, got %v in %q", msg, want, got, logs)
+				}
+			}
+		})
+	}
+}
+
+func TestConfigure_LevelAppliesToExistingLoggers(t *testing.T) {
+	buf := captureOutput(t)
+	if err := Configure("debug", FormatText); err != nil {
+		t.Fatalf("Configure returned unexpected error: %v", err)
+	}
+
+	log := WithComponent("test")
+	if err := Configure("info", ""); err != nil {
+		t.Fatalf("Configure returned unexpected error: %v", err)
+	}
+	log.Debug("debug line")
+
+	if buf.Len() != 0 {
+		t.Errorf("Expected debug to be suppressed on a logger created before the change, got %q", buf.String())
+	}
+}
+
+func TestConfigure_JSONFormat(t *testing.T) {
+	buf := captureOutput(t)
+	if err := Configure("info", "json"); err != nil {
+		t.Fatalf("Configure returned unexpected error: %v", err)
+	}
+
+	WithComponent("test").With("operation", "JSON").Info("first", "count", 2)
+	WithUser("alice").Error("second")
+
+	scanner := bufio.NewScanner(buf)
+	lines := 0
+	for scanner.Scan() {
+		lines++
+		var record map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("Expected JSON log line, got %q: %v", scanner.Text(), err)
+		}
+		if record[slog.MessageKey] == nil || record[slog.LevelKey] == nil {
+			t.Errorf("Expected msg and level fields, got %v", record)
+		}
+	}
+	if lines != 2 {
+		t.Errorf("Expected 2 log lines, got %d", lines)
+	}
+}
+
+func TestConfigure_RejectsInvalidValues(t *testing.T) {
+	captureOutput(t)
+
+	if err := Configure("verbose", ""); err == nil {
+		t.Error("Expected an error for an unknown level")
+	}
+	if err := Configure("", "xml"); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+	if err := Configure("", ""); err != nil {
+		t.Errorf("Expected empty values to keep the defaults, got %v", err)
+	}
+}
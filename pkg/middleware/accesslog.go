@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/hackmajoris/glad-stack/pkg/logger"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// AccessLogMiddleware writes one structured log line per request
+// The line carries the method, path, route, status, duration, username and response size,
+// so it can be filtered and aggregated with CloudWatch Logs Insights. Request and response
+// bodies, headers and query strings are never logged, as they may hold personal data or tokens.
+type AccessLogMiddleware struct{}
+
+// NewAccessLogMiddleware creates an AccessLogMiddleware
+// It should be the outermost middleware so the duration covers the whole request.
+func NewAccessLogMiddleware() *AccessLogMiddleware {
+	log := logger.WithComponent("middleware")
+	log.Info("Access log middleware initialized")
+
+	return &AccessLogMiddleware{}
+}
+
+// Handler wraps a handler and logs the request once it completes
+// The username comes from the identity claims the auth middleware stores in the request's authorizer
+// map; the map is created up front so claims added further down the chain are visible here.
+// Unauthenticated requests are logged with an empty username.
+func (m *AccessLogMiddleware) Handler(next HandlerFunc) HandlerFunc {
+	return func(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		if request.RequestContext.Authorizer == nil {
+			request.RequestContext.Authorizer = make(map[string]interface{})
+		}
+
+		start := time.Now()
+		response, err := next(request)
+		duration := time.Since(start)
+
+		username := ""
+		if identity, err := ExtractIdentity(request); err == nil {
+			username = identity.Username
+		}
+
+		args := []any{
+			"method", request.HTTPMethod,
+			"path", request.Path,
+			"route", request.Resource,
+			"status", response.StatusCode,
+			"duration_ms", duration.Milliseconds(),
+			"username", username,
+			"bytes_out", len(response.Body),
+			"request_id", request.RequestContext.RequestID,
+		}
+		if err != nil {
+			args = append(args, "error", err.Error())
+		}
+
+		logger.WithComponent("access").Info("Request completed", args...)
+		return response, err
+	}
+}
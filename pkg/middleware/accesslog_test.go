@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/hackmajoris/glad-stack/pkg/auth"
+	"github.com/hackmajoris/glad-stack/pkg/logger"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+func TestAccessLogMiddleware_LogsRequestFields(t *testing.T) {
+	var buf bytes.Buffer
+	original := logger.Log
+	logger.Log = &logger.Logger{Logger: slog.New(slog.NewJSONHandler(&buf, nil))}
+	t.Cleanup(func() { logger.Log = original })
+
+	// The inner handler stores claims the way AuthMiddleware does
+	handler := NewAccessLogMiddleware().Handler(func(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		request.RequestContext.Authorizer["claims"] = &auth.JWTClaims{Username: "alice"}
+		return events.APIGatewayProxyResponse{StatusCode: 201, Body: `{"skill_name":"Go"}`}, nil
+	})
+
+	_, err := handler(events.APIGatewayProxyRequest{
+		HTTPMethod:     "POST",
+		Path:           "/users/alice/skills",
+		Resource:       "/users/{username}/skills",
+		Body:           `{"password":"secret-password"}`,
+		RequestContext: events.APIGatewayProxyRequestContext{RequestID: "req-1"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var line map[string]any
+	for _, raw := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(raw), &entry); err == nil && entry["component"] == "access" {
+			line = entry
+		}
+	}
+	if line == nil {
+		t.Fatalf("Expected an access log line, got %s", buf.String())
+	}
+
+	expected := map[string]any{
+		"level":      "INFO",
+		"method":     "POST",
+		"path":       "/users/alice/skills",
+		"route":      "/users/{username}/skills",
+		"status":     float64(201),
+		"username":   "alice",
+		"bytes_out":  float64(len(`{"skill_name":"Go"}`)),
+		"request_id": "req-1",
+	}
+	for key, want := range expected {
+		if line[key] != want {
+			t.Errorf("Expected %s=%v, got %v", key, want, line[key])
+		}
+	}
+	if _, ok := line["duration_ms"]; !ok {
+		t.Error("Expected duration_ms to be logged")
+	}
+
+	if strings.Contains(buf.String(), "secret-password") {
+		t.Errorf("Expected the request body not to be logged, got %s", buf.String())
+	}
+}
+
+func TestAccessLogMiddleware_AnonymousAndFailedRequests(t *testing.T) {
+	var buf bytes.Buffer
+	original := logger.Log
+	logger.Log = &logger.Logger{Logger: slog.New(slog.NewTextHandler(&buf, nil))}
+	t.Cleanup(func() { logger.Log = original })
+
+	handler := NewAccessLogMiddleware().Handler(func(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		return events.APIGatewayProxyResponse{}, errors.New("handler failed")
+	})
+
+	if _, err := handler(events.APIGatewayProxyRequest{HTTPMethod: "GET", Path: "/health"}); err == nil {
+		t.Fatal("Expected the handler error to be returned")
+	}
+
+	output := buf.String()
+	for _, want := range []string{"component=access", "path=/health", `username=""`, `error="handler failed"`} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected log to contain %s, got %s", want, output)
+		}
+	}
+}
@@ -15,19 +15,52 @@ import (
 // HandlerFunc is the function signature for route handlers
 type HandlerFunc func(events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)
 
+// accessTokenCookie is the cookie browser clients may use to carry the JWT
+const accessTokenCookie = "access_token"
+
+// AccountCheck reports whether the account a valid token names may still use it
+// It returns an error wrapping pkgerrors.ErrAccountInactive for accounts deactivated or deleted
+// since the token was issued; any other error means the account could not be checked.
+type AccountCheck func(username string) error
+
 // AuthMiddleware provides JWT authentication middleware
 type AuthMiddleware struct {
-	tokenService *auth.TokenService
+	tokenService   *auth.TokenService
+	cookieFallback bool
+	accountCheck   AccountCheck
 }
 
-// NewAuthMiddleware creates a new AuthMiddleware
-func NewAuthMiddleware(tokenService *auth.TokenService) *AuthMiddleware {
-	log := logger.WithComponent("middleware")
-	log.Info("Auth middleware initialized")
+// AuthOption configures optional AuthMiddleware behaviour
+type AuthOption func(*AuthMiddleware)
 
-	return &AuthMiddleware{
+// WithCookieFallback reads the token from the access_token cookie when no Authorization header is sent
+func WithCookieFallback(enabled bool) AuthOption {
+	return func(m *AuthMiddleware) {
+		m.cookieFallback = enabled
+	}
+}
+
+// WithAccountCheck runs check for every token that passes validation, so deactivating or
+// deleting an account revokes the tokens already issued to it
+func WithAccountCheck(check AccountCheck) AuthOption {
+	return func(m *AuthMiddleware) {
+		m.accountCheck = check
+	}
+}
+
+// NewAuthMiddleware creates a new AuthMiddleware
+func NewAuthMiddleware(tokenService *auth.TokenService, opts ...AuthOption) *AuthMiddleware {
+	m := &AuthMiddleware{
 		tokenService: tokenService,
 	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	log := logger.WithComponent("middleware")
+	log.Info("Auth middleware initialized", "cookie_fallback", m.cookieFallback, "account_check", m.accountCheck != nil)
+
+	return m
 }
 
 // ValidateJWT wraps a handler with JWT validation
@@ -39,6 +72,9 @@ func (m *AuthMiddleware) ValidateJWT(next HandlerFunc) HandlerFunc {
 		log.Debug("Starting JWT validation for request")
 
 		token := extractTokenFromHeader(request.Headers)
+		if token == "" && m.cookieFallback {
+			token = extractTokenFromCookie(request.Headers)
+		}
 		if token == "" {
 			log.Warn("Missing authorization token in request", "duration", time.Since(start))
 			return unauthorizedResponse("Missing authorization token"), nil
@@ -62,6 +98,24 @@ func (m *AuthMiddleware) ValidateJWT(next HandlerFunc) HandlerFunc {
 		}
 
 		log = log.With("username", claims.Username)
+
+		// Refresh tokens may only be exchanged at /refresh
+		if claims.IsRefresh() {
+			log.Warn("Refresh token presented to protected route", "duration", time.Since(start))
+			return unauthorizedResponse("Refresh tokens cannot be used for this request"), nil
+		}
+
+		if m.accountCheck != nil {
+			if err := m.accountCheck(claims.Username); err != nil {
+				if pkgerrors.Is(err, pkgerrors.ErrAccountInactive) {
+					log.Warn("Token of inactive account rejected", "duration", time.Since(start))
+					return unauthorizedResponse("Account is no longer active"), nil
+				}
+				log.Error("Account check failed", "error", err.Error(), "duration", time.Since(start))
+				return internalErrorResponse(), nil
+			}
+		}
+
 		log.Debug("JWT validation successful, adding claims to context")
 
 		// Add claims to request context
@@ -80,34 +134,99 @@ func (m *AuthMiddleware) RequireAuth() func(HandlerFunc) HandlerFunc {
 	return m.ValidateJWT
 }
 
+// RequireAdmin returns a middleware function that validates the JWT and only admits admins
+func (m *AuthMiddleware) RequireAdmin() func(HandlerFunc) HandlerFunc {
+	return func(next HandlerFunc) HandlerFunc {
+		return m.ValidateJWT(func(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			log := logger.WithComponent("middleware").With("operation", "RequireAdmin", "path", request.Path, "method", request.HTTPMethod)
+
+			identity, err := ExtractIdentity(request)
+			if err != nil || !identity.IsAdmin {
+				log.Warn("Non-admin request to admin route")
+				return forbiddenResponse("Admin access required"), nil
+			}
+
+			log.Debug("Admin access granted", "username", identity.Username)
+			return next(request)
+		})
+	}
+}
+
 // extractTokenFromHeader extracts the JWT token from the Authorization header
+// The scheme is matched case-insensitively and only Bearer is accepted
 func extractTokenFromHeader(headers map[string]string) string {
 	log := logger.WithComponent("middleware").With("operation", "extractToken")
 
-	authHeader := headers["Authorization"]
+	// API Gateway sometimes normalizes headers to lowercase
+	authHeader := strings.TrimSpace(getHeader(headers, "Authorization"))
 	if authHeader == "" {
-		// Try lowercase (API Gateway sometimes normalizes headers)
-		authHeader = headers["authorization"]
-		if authHeader != "" {
-			log.Debug("Found authorization header (lowercase)")
-		}
-	} else {
-		log.Debug("Found Authorization header (capitalized)")
+		log.Debug("No authorization header found")
+		return ""
 	}
 
-	if authHeader == "" {
-		log.Debug("No authorization header found")
+	scheme, token, found := strings.Cut(authHeader, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		log.Debug("Unsupported authorization scheme", "scheme", scheme)
 		return ""
 	}
 
-	parts := strings.Split(authHeader, "Bearer ")
-	if len(parts) != 2 {
-		log.Debug("Invalid authorization header format", "header", authHeader)
+	token = strings.TrimSpace(token)
+	if token == "" {
+		log.Debug("Empty bearer token")
 		return ""
 	}
 
 	log.Debug("JWT token extracted successfully")
-	return parts[1]
+	return token
+}
+
+// extractTokenFromCookie extracts the JWT token from the access_token cookie
+func extractTokenFromCookie(headers map[string]string) string {
+	log := logger.WithComponent("middleware").With("operation", "extractTokenFromCookie")
+
+	cookieHeader := getHeader(headers, "Cookie")
+	if cookieHeader == "" {
+		log.Debug("No cookie header found")
+		return ""
+	}
+
+	cookies, err := http.ParseCookie(cookieHeader)
+	if err != nil {
+		log.Debug("Invalid cookie header", "error", err.Error())
+		return ""
+	}
+
+	for _, cookie := range cookies {
+		if cookie.Name == accessTokenCookie {
+			log.Debug("JWT token extracted from cookie")
+			return strings.TrimSpace(cookie.Value)
+		}
+	}
+
+	log.Debug("Access token cookie not found")
+	return ""
+}
+
+// forbiddenResponse creates a standardized forbidden response
+func forbiddenResponse(message string) events.APIGatewayProxyResponse {
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusForbidden,
+		Headers: map[string]string{
+			"Content-Type": "application/json",
+		},
+		Body: `{"error": "` + message + `", "code": "FORBIDDEN"}`,
+	}
+}
+
+// internalErrorResponse creates a standardized internal server error response
+func internalErrorResponse() events.APIGatewayProxyResponse {
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusInternalServerError,
+		Headers: map[string]string{
+			"Content-Type": "application/json",
+		},
+		Body: `{"error": "Internal server error", "code": "INTERNAL_SERVER_ERROR"}`,
+	}
 }
 
 // unauthorizedResponse creates a standardized unauthorized response
@@ -117,6 +236,6 @@ func unauthorizedResponse(message string) events.APIGatewayProxyResponse {
 		Headers: map[string]string{
 			"Content-Type": "application/json",
 		},
-		Body: `{"error": "` + message + `"}`,
+		Body: `{"error": "` + message + `", "code": "UNAUTHORIZED"}`,
 	}
 }
@@ -1,11 +1,13 @@
 package middleware
 
 import (
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/hackmajoris/glad-stack/pkg/auth"
 	"github.com/hackmajoris/glad-stack/pkg/config"
+	pkgerrors "github.com/hackmajoris/glad-stack/pkg/errors"
 
 	"github.com/aws/aws-lambda-go/events"
 )
@@ -54,6 +56,11 @@ func TestAuthMiddleware_ValidateJWT(t *testing.T) {
 		t.Fatalf("Failed to generate token: %v", err)
 	}
 
+	refreshToken, err := tokenService.GenerateRefreshToken(user)
+	if err != nil {
+		t.Fatalf("Failed to generate refresh token: %v", err)
+	}
+
 	// Mock handler that should be called on success
 	mockHandler := func(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 		// Verify claims were injected
@@ -100,7 +107,7 @@ func TestAuthMiddleware_ValidateJWT(t *testing.T) {
 			name:           "missing authorization header",
 			headers:        map[string]string{},
 			expectedStatus: 401,
-			expectedBody:   `{"error": "Missing authorization token"}`,
+			expectedBody:   `{"error": "Missing authorization token", "code": "UNAUTHORIZED"}`,
 		},
 		{
 			name: "invalid authorization header format",
@@ -108,7 +115,7 @@ func TestAuthMiddleware_ValidateJWT(t *testing.T) {
 				"Authorization": "InvalidFormat " + validToken,
 			},
 			expectedStatus: 401,
-			expectedBody:   `{"error": "Missing authorization token"}`,
+			expectedBody:   `{"error": "Missing authorization token", "code": "UNAUTHORIZED"}`,
 		},
 		{
 			name: "invalid token",
@@ -116,7 +123,7 @@ func TestAuthMiddleware_ValidateJWT(t *testing.T) {
 				"Authorization": "Bearer invalid.token.here",
 			},
 			expectedStatus: 401,
-			expectedBody:   `{"error": "Invalid or expired token"}`,
+			expectedBody:   `{"error": "Invalid or expired token", "code": "UNAUTHORIZED"}`,
 		},
 		{
 			name: "empty token",
@@ -124,7 +131,15 @@ func TestAuthMiddleware_ValidateJWT(t *testing.T) {
 				"Authorization": "Bearer ",
 			},
 			expectedStatus: 401,
-			expectedBody:   `{"error": "Missing authorization token"}`,
+			expectedBody:   `{"error": "Missing authorization token", "code": "UNAUTHORIZED"}`,
+		},
+		{
+			name: "refresh token",
+			headers: map[string]string{
+				"Authorization": "Bearer " + refreshToken,
+			},
+			expectedStatus: 401,
+			expectedBody:   `{"error": "Refresh tokens cannot be used for this request", "code": "UNAUTHORIZED"}`,
 		},
 	}
 
@@ -206,6 +221,34 @@ func TestExtractTokenFromHeader(t *testing.T) {
 			},
 			expected: "token with spaces",
 		},
+		{
+			name: "mixed-case bearer scheme",
+			headers: map[string]string{
+				"Authorization": "bEaReR abc123",
+			},
+			expected: "abc123",
+		},
+		{
+			name: "surrounding whitespace is trimmed",
+			headers: map[string]string{
+				"Authorization": "  Bearer   abc123  ",
+			},
+			expected: "abc123",
+		},
+		{
+			name: "token containing the scheme",
+			headers: map[string]string{
+				"Authorization": "Bearer abc Bearer def",
+			},
+			expected: "abc Bearer def",
+		},
+		{
+			name: "unsupported scheme",
+			headers: map[string]string{
+				"Authorization": "Token abc123",
+			},
+			expected: "",
+		},
 	}
 
 	for _, tt := range tests {
@@ -218,6 +261,162 @@ func TestExtractTokenFromHeader(t *testing.T) {
 	}
 }
 
+func TestAuthMiddleware_CookieFallback(t *testing.T) {
+	tokenService := auth.NewTokenService(testConfig())
+	validToken, err := tokenService.GenerateToken(&MockUser{Username: "testuser"})
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	okHandler := func(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		return events.APIGatewayProxyResponse{StatusCode: 200}, nil
+	}
+
+	tests := []struct {
+		name           string
+		cookieFallback bool
+		headers        map[string]string
+		expectedStatus int
+	}{
+		{
+			name:           "cookie accepted when fallback enabled",
+			cookieFallback: true,
+			headers:        map[string]string{"Cookie": "theme=dark; access_token=" + validToken},
+			expectedStatus: 200,
+		},
+		{
+			name:           "lowercase cookie header accepted",
+			cookieFallback: true,
+			headers:        map[string]string{"cookie": "access_token=" + validToken},
+			expectedStatus: 200,
+		},
+		{
+			name:           "cookie ignored when fallback disabled",
+			cookieFallback: false,
+			headers:        map[string]string{"Cookie": "access_token=" + validToken},
+			expectedStatus: 401,
+		},
+		{
+			name:           "authorization header takes precedence",
+			cookieFallback: true,
+			headers:        map[string]string{"Authorization": "Bearer " + validToken, "Cookie": "access_token=invalid"},
+			expectedStatus: 200,
+		},
+		{
+			name:           "unsupported scheme without cookie is rejected",
+			cookieFallback: true,
+			headers:        map[string]string{"Authorization": "Basic " + validToken},
+			expectedStatus: 401,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			middleware := NewAuthMiddleware(tokenService, WithCookieFallback(tt.cookieFallback))
+
+			response, err := middleware.ValidateJWT(okHandler)(events.APIGatewayProxyRequest{Headers: tt.headers})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if response.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, response.StatusCode)
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware_AccountCheck(t *testing.T) {
+	tokenService := auth.NewTokenService(testConfig())
+
+	okHandler := func(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		return events.APIGatewayProxyResponse{StatusCode: 200}, nil
+	}
+	check := func(username string) error {
+		switch username {
+		case "archived":
+			return pkgerrors.Wrap(pkgerrors.ErrAccountInactive, "account is archived")
+		case "unreachable":
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+	middleware := NewAuthMiddleware(tokenService, WithAccountCheck(check))
+
+	tests := []struct {
+		name           string
+		username       string
+		expectedStatus int
+	}{
+		{name: "active account", username: "testuser", expectedStatus: 200},
+		{name: "inactive account", username: "archived", expectedStatus: 401},
+		{name: "account check failure", username: "unreachable", expectedStatus: 500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := tokenService.GenerateToken(&MockUser{Username: tt.username})
+			if err != nil {
+				t.Fatalf("Failed to generate token: %v", err)
+			}
+
+			response, err := middleware.ValidateJWT(okHandler)(events.APIGatewayProxyRequest{
+				Headers: map[string]string{"Authorization": "Bearer " + token},
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if response.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.expectedStatus, response.StatusCode, response.Body)
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware_RequireAdmin(t *testing.T) {
+	cfg := testConfig()
+	cfg.Auth.AdminUsernames = []string{"admin"}
+	tokenService := auth.NewTokenService(cfg)
+	middleware := NewAuthMiddleware(tokenService)
+
+	okHandler := func(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		return events.APIGatewayProxyResponse{StatusCode: 200}, nil
+	}
+
+	tests := []struct {
+		name           string
+		username       string
+		expectedStatus int
+	}{
+		{name: "admin is admitted", username: "admin", expectedStatus: 200},
+		{name: "regular user is forbidden", username: "testuser", expectedStatus: 403},
+		{name: "missing token is unauthorized", username: "", expectedStatus: 401},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := map[string]string{}
+			if tt.username != "" {
+				token, err := tokenService.GenerateToken(&MockUser{Username: tt.username})
+				if err != nil {
+					t.Fatalf("Failed to generate token: %v", err)
+				}
+				headers["Authorization"] = "Bearer " + token
+			}
+
+			response, err := middleware.RequireAdmin()(okHandler)(events.APIGatewayProxyRequest{Headers: headers})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if response.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, response.StatusCode)
+			}
+		})
+	}
+}
+
 func TestUnauthorizedResponse(t *testing.T) {
 	message := "Test error message"
 	response := unauthorizedResponse(message)
@@ -230,7 +429,7 @@ func TestUnauthorizedResponse(t *testing.T) {
 		t.Errorf("Expected Content-Type application/json, got %s", response.Headers["Content-Type"])
 	}
 
-	expectedBody := `{"error": "Test error message"}`
+	expectedBody := `{"error": "Test error message", "code": "UNAUTHORIZED"}`
 	if response.Body != expectedBody {
 		t.Errorf("Expected body %s, got %s", expectedBody, response.Body)
 	}
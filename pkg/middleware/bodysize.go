@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/hackmajoris/glad-stack/pkg/logger"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// DefaultMaxBodyBytes is the request body limit used when none is configured
+const DefaultMaxBodyBytes = 1 << 20 // 1MB
+
+// BodySizeLimitMiddleware rejects requests whose body exceeds a maximum size
+type BodySizeLimitMiddleware struct {
+	maxBytes int
+}
+
+// NewBodySizeLimitMiddleware creates a BodySizeLimitMiddleware allowing bodies of up to maxBytes
+// A non-positive maxBytes falls back to DefaultMaxBodyBytes.
+func NewBodySizeLimitMiddleware(maxBytes int) *BodySizeLimitMiddleware {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBodyBytes
+	}
+
+	log := logger.WithComponent("middleware")
+	log.Info("Body size limit middleware initialized", "max_bytes", maxBytes)
+
+	return &BodySizeLimitMiddleware{maxBytes: maxBytes}
+}
+
+// Handler wraps a handler and answers 413 before it runs when the body is too large
+// Both the declared Content-Length and the actual body length are checked, so a client
+// cannot get past the limit by understating the header.
+func (m *BodySizeLimitMiddleware) Handler(next HandlerFunc) HandlerFunc {
+	return func(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		size := len(request.Body)
+		if declared, err := strconv.Atoi(getHeader(request.Headers, "Content-Length")); err == nil && declared > size {
+			size = declared
+		}
+
+		if size > m.maxBytes {
+			log := logger.WithComponent("middleware").With("operation", "BodySizeLimit", "path", request.Path)
+			log.Warn("Request body too large", "size", size, "max_bytes", m.maxBytes)
+			return payloadTooLargeResponse(), nil
+		}
+
+		return next(request)
+	}
+}
+
+// payloadTooLargeResponse creates a standardized 413 response
+func payloadTooLargeResponse() events.APIGatewayProxyResponse {
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusRequestEntityTooLarge,
+		Headers: map[string]string{
+			"Content-Type": "application/json",
+		},
+		Body: `{"error": "Request body too large", "code": "REQUEST_ENTITY_TOO_LARGE"}`,
+	}
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+func TestBodySizeLimitMiddleware_Handler(t *testing.T) {
+	okHandler := func(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		return events.APIGatewayProxyResponse{StatusCode: 200}, nil
+	}
+
+	tests := []struct {
+		name           string
+		body           string
+		headers        map[string]string
+		expectedStatus int
+	}{
+		{name: "body at exactly the limit", body: strings.Repeat("a", 16), expectedStatus: 200},
+		{name: "oversize body", body: strings.Repeat("a", 17), expectedStatus: 413},
+		{name: "empty body", expectedStatus: 200},
+		{name: "oversize Content-Length", headers: map[string]string{"Content-Length": "17"}, expectedStatus: 413},
+		{name: "lowercase content-length", headers: map[string]string{"content-length": "1024"}, expectedStatus: 413},
+		{name: "understated Content-Length", body: strings.Repeat("a", 17), headers: map[string]string{"Content-Length": "2"}, expectedStatus: 413},
+		{name: "invalid Content-Length ignored", body: "{}", headers: map[string]string{"Content-Length": "lots"}, expectedStatus: 200},
+	}
+
+	handler := NewBodySizeLimitMiddleware(16).Handler(okHandler)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := handler(events.APIGatewayProxyRequest{HTTPMethod: "POST", Body: tt.body, Headers: tt.headers})
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+			if response.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, response.StatusCode)
+			}
+		})
+	}
+}
+
+func TestNewBodySizeLimitMiddleware_Default(t *testing.T) {
+	if m := NewBodySizeLimitMiddleware(0); m.maxBytes != DefaultMaxBodyBytes {
+		t.Errorf("Expected default limit %d, got %d", DefaultMaxBodyBytes, m.maxBytes)
+	}
+}
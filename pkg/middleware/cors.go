@@ -0,0 +1,139 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/hackmajoris/glad-stack/pkg/logger"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// ErrWildcardOriginNotAllowed is returned when "*" is configured as a CORS origin in production
+var ErrWildcardOriginNotAllowed = errors.New("wildcard CORS origin is not allowed in production with credentials enabled")
+
+const wildcardOrigin = "*"
+
+// Preflight response values
+const (
+	corsAllowedMethods = "GET, POST, PUT, PATCH, DELETE, OPTIONS"
+	corsAllowedHeaders = "Content-Type, Authorization"
+	corsMaxAge         = "600"
+)
+
+// CORSMiddleware adds CORS headers to responses based on an origin allowlist
+type CORSMiddleware struct {
+	allowedOrigins   map[string]bool
+	allowAll         bool
+	allowCredentials bool
+}
+
+// NewCORSMiddleware creates a new CORSMiddleware.
+// The wildcard origin "*" is rejected in production when credentials are enabled.
+func NewCORSMiddleware(origins []string, allowCredentials, production bool) (*CORSMiddleware, error) {
+	log := logger.WithComponent("middleware")
+
+	m := &CORSMiddleware{
+		allowedOrigins:   make(map[string]bool),
+		allowCredentials: allowCredentials,
+	}
+
+	for _, origin := range origins {
+		if origin == wildcardOrigin {
+			if production && allowCredentials {
+				log.Error("Refusing wildcard CORS origin in production")
+				return nil, ErrWildcardOriginNotAllowed
+			}
+			m.allowAll = true
+			continue
+		}
+		m.allowedOrigins[origin] = true
+	}
+
+	log.Info("CORS middleware initialized", "origins", origins, "allow_credentials", allowCredentials)
+	return m, nil
+}
+
+// Handler wraps a handler and adds CORS headers to its response
+// Preflight requests (OPTIONS with Access-Control-Request-Method) are answered directly with
+// 204 and never reach the wrapped handler; a disallowed origin gets no CORS headers.
+func (m *CORSMiddleware) Handler(next HandlerFunc) HandlerFunc {
+	return func(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		if request.HTTPMethod == http.MethodOptions && getHeader(request.Headers, "Access-Control-Request-Method") != "" {
+			return events.APIGatewayProxyResponse{StatusCode: http.StatusNoContent, Headers: m.PreflightHeaders(request)}, nil
+		}
+
+		allowOrigin := m.allowedOrigin(getHeader(request.Headers, "Origin"))
+
+		response, err := next(request)
+		if err != nil {
+			return response, err
+		}
+
+		if allowOrigin == "" {
+			return response, nil
+		}
+
+		if response.Headers == nil {
+			response.Headers = make(map[string]string)
+		}
+		m.setOriginHeaders(response.Headers, allowOrigin)
+
+		return response, nil
+	}
+}
+
+// PreflightHeaders returns the CORS headers answering an OPTIONS request
+// The map is empty when the request's origin is not in the allowlist.
+func (m *CORSMiddleware) PreflightHeaders(request events.APIGatewayProxyRequest) map[string]string {
+	headers := make(map[string]string)
+	allowOrigin := m.allowedOrigin(getHeader(request.Headers, "Origin"))
+	if allowOrigin == "" {
+		return headers
+	}
+
+	allowHeaders := getHeader(request.Headers, "Access-Control-Request-Headers")
+	if allowHeaders == "" {
+		allowHeaders = corsAllowedHeaders
+	}
+	headers["Access-Control-Allow-Methods"] = corsAllowedMethods
+	headers["Access-Control-Allow-Headers"] = allowHeaders
+	headers["Access-Control-Max-Age"] = corsMaxAge
+	m.setOriginHeaders(headers, allowOrigin)
+	return headers
+}
+
+// setOriginHeaders sets Access-Control-Allow-Origin and, for a concrete origin, Vary and
+// Access-Control-Allow-Credentials. Credentials are never allowed with the wildcard origin,
+// which browsers reject.
+func (m *CORSMiddleware) setOriginHeaders(headers map[string]string, allowOrigin string) {
+	headers["Access-Control-Allow-Origin"] = allowOrigin
+	if allowOrigin == wildcardOrigin {
+		return
+	}
+
+	headers["Vary"] = "Origin"
+	if m.allowCredentials {
+		headers["Access-Control-Allow-Credentials"] = "true"
+	}
+}
+
+// allowedOrigin returns the value for Access-Control-Allow-Origin, or "" if the origin is not allowed
+func (m *CORSMiddleware) allowedOrigin(origin string) string {
+	if origin != "" && m.allowedOrigins[origin] {
+		return origin
+	}
+	if m.allowAll {
+		return wildcardOrigin
+	}
+	return ""
+}
+
+// getHeader looks up a header by its canonical and lowercase names
+func getHeader(headers map[string]string, name string) string {
+	if value := headers[name]; value != "" {
+		return value
+	}
+	return headers[strings.ToLower(name)]
+}
@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+func okHandler(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return events.APIGatewayProxyResponse{StatusCode: 200}, nil
+}
+
+func TestCORSMiddleware_Handler(t *testing.T) {
+	tests := []struct {
+		name          string
+		origins       []string
+		requestOrigin string
+		expectedACAO  string
+		expectedACAC  string
+	}{
+		{
+			name:          "allowed origin is echoed",
+			origins:       []string{"https://app.example.com"},
+			requestOrigin: "https://app.example.com",
+			expectedACAO:  "https://app.example.com",
+			expectedACAC:  "true",
+		},
+		{
+			name:          "disallowed origin is omitted",
+			origins:       []string{"https://app.example.com"},
+			requestOrigin: "https://evil.example.com",
+			expectedACAO:  "",
+		},
+		{
+			name:          "wildcard allows any origin outside production",
+			origins:       []string{"*"},
+			requestOrigin: "https://any.example.com",
+			expectedACAO:  "*",
+			expectedACAC:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cors, err := NewCORSMiddleware(tt.origins, true, false)
+			if err != nil {
+				t.Fatalf("Failed to create CORS middleware: %v", err)
+			}
+
+			request := events.APIGatewayProxyRequest{
+				Headers: map[string]string{"origin": tt.requestOrigin},
+			}
+
+			response, err := cors.Handler(okHandler)(request)
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+
+			if got := response.Headers["Access-Control-Allow-Origin"]; got != tt.expectedACAO {
+				t.Errorf("Expected Access-Control-Allow-Origin '%s', got '%s'", tt.expectedACAO, got)
+			}
+			if got := response.Headers["Access-Control-Allow-Credentials"]; got != tt.expectedACAC {
+				t.Errorf("Expected Access-Control-Allow-Credentials '%s', got '%s'", tt.expectedACAC, got)
+			}
+		})
+	}
+}
+
+func TestCORSMiddleware_Preflight(t *testing.T) {
+	cors, err := NewCORSMiddleware([]string{"https://app.example.com"}, true, true)
+	if err != nil {
+		t.Fatalf("Failed to create CORS middleware: %v", err)
+	}
+
+	called := false
+	next := func(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		called = true
+		return events.APIGatewayProxyResponse{StatusCode: 405}, nil
+	}
+
+	tests := []struct {
+		name           string
+		origin         string
+		expectedACAO   string
+		expectedMethod string
+	}{
+		{name: "allowed origin", origin: "https://app.example.com", expectedACAO: "https://app.example.com", expectedMethod: "GET, POST, PUT, PATCH, DELETE, OPTIONS"},
+		{name: "disallowed origin", origin: "https://evil.example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := cors.Handler(next)(events.APIGatewayProxyRequest{
+				HTTPMethod: "OPTIONS",
+				Headers: map[string]string{
+					"Origin":                         tt.origin,
+					"Access-Control-Request-Method":  "PUT",
+					"Access-Control-Request-Headers": "authorization, content-type",
+				},
+			})
+			if err != nil {
+				t.Fatalf("Handler returned unexpected error: %v", err)
+			}
+
+			if called {
+				t.Error("Expected preflight to be answered without calling the wrapped handler")
+			}
+			if response.StatusCode != 204 {
+				t.Errorf("Expected status 204, got %d", response.StatusCode)
+			}
+			if got := response.Headers["Access-Control-Allow-Origin"]; got != tt.expectedACAO {
+				t.Errorf("Expected Access-Control-Allow-Origin '%s', got '%s'", tt.expectedACAO, got)
+			}
+			if got := response.Headers["Access-Control-Allow-Methods"]; got != tt.expectedMethod {
+				t.Errorf("Expected Access-Control-Allow-Methods '%s', got '%s'", tt.expectedMethod, got)
+			}
+			if tt.expectedACAO != "" && response.Headers["Access-Control-Allow-Headers"] != "authorization, content-type" {
+				t.Errorf("Expected requested headers to be allowed, got '%s'", response.Headers["Access-Control-Allow-Headers"])
+			}
+		})
+	}
+}
+
+func TestNewCORSMiddleware_ProductionRejectsWildcard(t *testing.T) {
+	_, err := NewCORSMiddleware([]string{"*"}, true, true)
+	if !errors.Is(err, ErrWildcardOriginNotAllowed) {
+		t.Errorf("Expected ErrWildcardOriginNotAllowed, got %v", err)
+	}
+
+	_, err = NewCORSMiddleware([]string{"https://app.example.com"}, true, true)
+	if err != nil {
+		t.Errorf("Expected explicit origin to be accepted in production, got %v", err)
+	}
+}
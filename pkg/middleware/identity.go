@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/hackmajoris/glad-stack/pkg/auth"
+	pkgerrors "github.com/hackmajoris/glad-stack/pkg/errors"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// Identity is the authenticated caller, independent of how the request was authenticated
+type Identity struct {
+	Username string
+	Email    string
+	Sub      string
+	IsAdmin  bool
+}
+
+// Cognito claim names as passed through by an API Gateway Cognito authorizer
+const (
+	cognitoUsernameClaim = "cognito:username"
+	cognitoGroupsClaim   = "cognito:groups"
+)
+
+// ExtractIdentity reads the caller's identity from the request's authorizer claims
+// Two shapes are understood: the *auth.JWTClaims stored by AuthMiddleware, and the string map
+// an API Gateway Cognito authorizer provides, where admins belong to the "admin" group.
+// It fails with ErrNoIdentity when neither is present or no username can be found.
+func ExtractIdentity(request events.APIGatewayProxyRequest) (Identity, error) {
+	var identity Identity
+
+	switch claims := request.RequestContext.Authorizer["claims"].(type) {
+	case *auth.JWTClaims:
+		identity = Identity{
+			Username: claims.Username,
+			Sub:      claims.Subject,
+			IsAdmin:  claims.IsAdmin(),
+		}
+	case map[string]interface{}:
+		identity = Identity{
+			Username: claimString(claims, cognitoUsernameClaim),
+			Email:    claimString(claims, "email"),
+			Sub:      claimString(claims, "sub"),
+			IsAdmin:  hasGroup(claims[cognitoGroupsClaim], auth.RoleAdmin),
+		}
+		if identity.Username == "" {
+			identity.Username = claimString(claims, "username")
+		}
+	}
+
+	if identity.Username == "" {
+		return Identity{}, pkgerrors.ErrNoIdentity
+	}
+	return identity, nil
+}
+
+// claimString returns a string claim, or "" when it is missing or not a string
+func claimString(claims map[string]interface{}, name string) string {
+	value, _ := claims[name].(string)
+	return value
+}
+
+// hasGroup reports whether the cognito:groups claim contains group
+// API Gateway passes the groups as a string, either comma separated or in the bracketed
+// "[admin users]" form; a decoded JSON array is accepted too.
+func hasGroup(groups interface{}, group string) bool {
+	var names []string
+	switch value := groups.(type) {
+	case string:
+		names = strings.FieldsFunc(strings.Trim(value, "[]"), func(r rune) bool {
+			return r == ',' || r == ' '
+		})
+	case []interface{}:
+		for _, item := range value {
+			if name, ok := item.(string); ok {
+				names = append(names, name)
+			}
+		}
+	case []string:
+		names = value
+	}
+
+	for _, name := range names {
+		if name == group {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hackmajoris/glad-stack/pkg/auth"
+	pkgerrors "github.com/hackmajoris/glad-stack/pkg/errors"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func requestWithClaims(claims interface{}) events.APIGatewayProxyRequest {
+	return events.APIGatewayProxyRequest{
+		RequestContext: events.APIGatewayProxyRequestContext{
+			Authorizer: map[string]interface{}{"claims": claims},
+		},
+	}
+}
+
+func TestExtractIdentity(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  events.APIGatewayProxyRequest
+		expected Identity
+	}{
+		{
+			name: "JWT claims",
+			request: requestWithClaims(&auth.JWTClaims{
+				Username:         "alice",
+				Role:             auth.RoleAdmin,
+				RegisteredClaims: jwt.RegisteredClaims{Subject: "alice"},
+			}),
+			expected: Identity{Username: "alice", Sub: "alice", IsAdmin: true},
+		},
+		{
+			name:     "JWT claims without role",
+			request:  requestWithClaims(&auth.JWTClaims{Username: "bob"}),
+			expected: Identity{Username: "bob"},
+		},
+		{
+			name: "Cognito claims with comma separated groups",
+			request: requestWithClaims(map[string]interface{}{
+				"cognito:username": "alice",
+				"email":            "alice@example.com",
+				"sub":              "0b1c-42",
+				"cognito:groups":   "users,admin",
+			}),
+			expected: Identity{Username: "alice", Email: "alice@example.com", Sub: "0b1c-42", IsAdmin: true},
+		},
+		{
+			name: "Cognito claims with bracketed groups",
+			request: requestWithClaims(map[string]interface{}{
+				"cognito:username": "alice",
+				"cognito:groups":   "[admin users]",
+			}),
+			expected: Identity{Username: "alice", IsAdmin: true},
+		},
+		{
+			name: "Cognito claims outside the admin group",
+			request: requestWithClaims(map[string]interface{}{
+				"cognito:username": "bob",
+				"cognito:groups":   "administrators",
+			}),
+			expected: Identity{Username: "bob"},
+		},
+		{
+			name: "Cognito access token claims use username",
+			request: requestWithClaims(map[string]interface{}{
+				"username": "carol",
+				"sub":      "7f3e",
+			}),
+			expected: Identity{Username: "carol", Sub: "7f3e"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity, err := ExtractIdentity(tt.request)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if identity != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, identity)
+			}
+		})
+	}
+}
+
+func TestExtractIdentity_MissingClaims(t *testing.T) {
+	tests := []struct {
+		name    string
+		request events.APIGatewayProxyRequest
+	}{
+		{name: "no authorizer", request: events.APIGatewayProxyRequest{}},
+		{name: "unknown claims type", request: requestWithClaims("alice")},
+		{name: "JWT claims without username", request: requestWithClaims(&auth.JWTClaims{})},
+		{name: "Cognito claims without username", request: requestWithClaims(map[string]interface{}{"email": "alice@example.com"})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ExtractIdentity(tt.request); !errors.Is(err, pkgerrors.ErrNoIdentity) {
+				t.Errorf("Expected ErrNoIdentity, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hackmajoris/glad-stack/pkg/logger"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// latencySamples is how many recent request durations each route keeps for its quantiles
+const latencySamples = 512
+
+// metricsQuantiles are the latency quantiles reported per route
+var metricsQuantiles = []float64{0.5, 0.95}
+
+// routeKey identifies a route by method and resource pattern, so path parameters do not add series
+type routeKey struct {
+	method string
+	route  string
+}
+
+// routeMetrics holds the counters and recent latencies of one route
+type routeMetrics struct {
+	requests     uint64
+	clientErrors uint64 // 4xx responses
+	serverErrors uint64 // 5xx responses and handler errors
+	latencySum   time.Duration
+	latencies    [latencySamples]time.Duration // ring buffer of the most recent durations
+	next         int                           // ring buffer write position
+}
+
+// MetricsRegistry collects per-route request counts, error counts and latencies in memory
+// Counters live as long as the process, so on Lambda they only cover one warm instance;
+// it is meant for the local server. All methods are safe for concurrent use.
+type MetricsRegistry struct {
+	mutex  sync.Mutex
+	routes map[routeKey]*routeMetrics
+}
+
+// NewMetricsRegistry creates an empty MetricsRegistry
+func NewMetricsRegistry() *MetricsRegistry {
+	return &MetricsRegistry{routes: make(map[routeKey]*routeMetrics)}
+}
+
+// Observe records one request to route; only a route's first request allocates
+func (m *MetricsRegistry) Observe(method, route string, statusCode int, failed bool, duration time.Duration) {
+	key := routeKey{method: method, route: route}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	metrics, ok := m.routes[key]
+	if !ok {
+		metrics = &routeMetrics{}
+		m.routes[key] = metrics
+	}
+
+	metrics.requests++
+	switch {
+	case failed || statusCode >= 500:
+		metrics.serverErrors++
+	case statusCode >= 400:
+		metrics.clientErrors++
+	}
+	metrics.latencySum += duration
+	metrics.latencies[metrics.next] = duration
+	metrics.next = (metrics.next + 1) % latencySamples
+}
+
+// Render writes the collected metrics in the Prometheus text exposition format
+// Latency quantiles are computed over each route's most recent latencySamples requests.
+func (m *MetricsRegistry) Render() string {
+	m.mutex.Lock()
+	keys := make([]routeKey, 0, len(m.routes))
+	snapshot := make(map[routeKey]routeMetrics, len(m.routes))
+	for key, metrics := range m.routes {
+		keys = append(keys, key)
+		snapshot[key] = *metrics
+	}
+	m.mutex.Unlock()
+
+	slices.SortFunc(keys, func(a, b routeKey) int {
+		if c := strings.Compare(a.route, b.route); c != 0 {
+			return c
+		}
+		return strings.Compare(a.method, b.method)
+	})
+
+	var b strings.Builder
+	b.WriteString("# HELP glad_http_requests_total Requests handled, by route.\n")
+	b.WriteString("# TYPE glad_http_requests_total counter\n")
+	for _, key := range keys {
+		fmt.Fprintf(&b, "glad_http_requests_total{%s} %d\n", key.labels(), snapshot[key].requests)
+	}
+
+	b.WriteString("# HELP glad_http_request_errors_total Requests answered with an error status, by route and status class.\n")
+	b.WriteString("# TYPE glad_http_request_errors_total counter\n")
+	for _, key := range keys {
+		metrics := snapshot[key]
+		fmt.Fprintf(&b, "glad_http_request_errors_total{%s,class=\"4xx\"} %d\n", key.labels(), metrics.clientErrors)
+		fmt.Fprintf(&b, "glad_http_request_errors_total{%s,class=\"5xx\"} %d\n", key.labels(), metrics.serverErrors)
+	}
+
+	b.WriteString("# HELP glad_http_request_duration_seconds Request latency, by route.\n")
+	b.WriteString("# TYPE glad_http_request_duration_seconds summary\n")
+	for _, key := range keys {
+		metrics := snapshot[key]
+		samples := metrics.latencies[:min(metrics.requests, latencySamples)]
+		slices.Sort(samples)
+		for _, q := range metricsQuantiles {
+			fmt.Fprintf(&b, "glad_http_request_duration_seconds{%s,quantile=\"%g\"} %g\n", key.labels(), q, quantile(samples, q).Seconds())
+		}
+		fmt.Fprintf(&b, "glad_http_request_duration_seconds_sum{%s} %g\n", key.labels(), metrics.latencySum.Seconds())
+		fmt.Fprintf(&b, "glad_http_request_duration_seconds_count{%s} %d\n", key.labels(), metrics.requests)
+	}
+
+	return b.String()
+}
+
+// Handler serves the collected metrics as GET /metrics
+func (m *MetricsRegistry) Handler(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusOK,
+		Headers: map[string]string{
+			"Content-Type": "text/plain; version=0.0.4",
+		},
+		Body: m.Render(),
+	}, nil
+}
+
+// labels formats the key as Prometheus labels
+func (k routeKey) labels() string {
+	return fmt.Sprintf("method=%q,route=%q", k.method, k.route)
+}
+
+// quantile returns the nearest-rank q quantile of sorted samples, or 0 when there are none
+func quantile(sorted []time.Duration, q float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(q*float64(len(sorted)))) - 1
+	return sorted[max(rank, 0)]
+}
+
+// MetricsMiddleware records every request in a MetricsRegistry
+type MetricsMiddleware struct {
+	registry *MetricsRegistry
+}
+
+// NewMetricsMiddleware creates a MetricsMiddleware recording into registry
+func NewMetricsMiddleware(registry *MetricsRegistry) *MetricsMiddleware {
+	log := logger.WithComponent("middleware")
+	log.Info("Metrics middleware initialized")
+
+	return &MetricsMiddleware{registry: registry}
+}
+
+// Handler wraps a handler and records its route, outcome and duration
+// Requests are grouped by resource pattern (e.g. /users/{username}), not by concrete path.
+func (m *MetricsMiddleware) Handler(next HandlerFunc) HandlerFunc {
+	return func(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		start := time.Now()
+		response, err := next(request)
+		m.registry.Observe(request.HTTPMethod, request.Resource, response.StatusCode, err != nil, time.Since(start))
+		return response, err
+	}
+}
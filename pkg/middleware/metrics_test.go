@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+func TestMetricsRegistry_Render(t *testing.T) {
+	registry := NewMetricsRegistry()
+	for i := 1; i <= 20; i++ {
+		registry.Observe("GET", "/users/{username}", 200, false, time.Duration(i)*time.Millisecond)
+	}
+	registry.Observe("POST", "/login", 401, false, 5*time.Millisecond)
+	registry.Observe("POST", "/login", 500, false, 5*time.Millisecond)
+	registry.Observe("POST", "/login", 0, true, 5*time.Millisecond)
+
+	output := registry.Render()
+
+	expected := []string{
+		"# TYPE glad_http_requests_total counter",
+		`glad_http_requests_total{method="GET",route="/users/{username}"} 20`,
+		`glad_http_requests_total{method="POST",route="/login"} 3`,
+		`glad_http_request_errors_total{method="GET",route="/users/{username}",class="5xx"} 0`,
+		`glad_http_request_errors_total{method="POST",route="/login",class="4xx"} 1`,
+		`glad_http_request_errors_total{method="POST",route="/login",class="5xx"} 2`,
+		"# TYPE glad_http_request_duration_seconds summary",
+		`glad_http_request_duration_seconds{method="GET",route="/users/{username}",quantile="0.5"} 0.01`,
+		`glad_http_request_duration_seconds{method="GET",route="/users/{username}",quantile="0.95"} 0.019`,
+		`glad_http_request_duration_seconds_sum{method="GET",route="/users/{username}"} 0.21`,
+		`glad_http_request_duration_seconds_count{method="GET",route="/users/{username}"} 20`,
+	}
+	for _, line := range expected {
+		if !strings.Contains(output, line+"\n") {
+			t.Errorf("Expected output to contain %q, got:\n%s", line, output)
+		}
+	}
+
+	// Routes are sorted so scrapes are stable
+	if strings.Index(output, `route="/login"`) > strings.Index(output, `route="/users/{username}"`) {
+		t.Errorf("Expected routes in sorted order, got:\n%s", output)
+	}
+}
+
+func TestMetricsRegistry_QuantilesUseRecentSamples(t *testing.T) {
+	registry := NewMetricsRegistry()
+	for i := 0; i < latencySamples; i++ {
+		registry.Observe("GET", "/health", 200, false, time.Second)
+	}
+	for i := 0; i < latencySamples; i++ {
+		registry.Observe("GET", "/health", 200, false, time.Millisecond)
+	}
+
+	output := registry.Render()
+	if !strings.Contains(output, `glad_http_request_duration_seconds{method="GET",route="/health",quantile="0.95"} 0.001`+"\n") {
+		t.Errorf("Expected older samples to be overwritten, got:\n%s", output)
+	}
+}
+
+func TestMetricsMiddleware_Handler(t *testing.T) {
+	registry := NewMetricsRegistry()
+	mw := NewMetricsMiddleware(registry)
+
+	failing := mw.Handler(func(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		return events.APIGatewayProxyResponse{}, errors.New("boom")
+	})
+	ok := mw.Handler(func(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		return events.APIGatewayProxyResponse{StatusCode: 200}, nil
+	})
+
+	request := events.APIGatewayProxyRequest{HTTPMethod: "GET", Resource: "/users/{username}", Path: "/users/alice"}
+	if _, err := failing(request); err == nil {
+		t.Error("Expected handler error to be passed through")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, _ = ok(request)
+		}()
+		go func() {
+			defer wg.Done()
+			_ = registry.Render()
+		}()
+	}
+	wg.Wait()
+
+	response, _ := registry.Handler(events.APIGatewayProxyRequest{})
+	if response.StatusCode != 200 {
+		t.Errorf("Expected status 200, got %d", response.StatusCode)
+	}
+	if response.Headers["Content-Type"] != "text/plain; version=0.0.4" {
+		t.Errorf("Expected Prometheus text content type, got %q", response.Headers["Content-Type"])
+	}
+	for _, line := range []string{
+		`glad_http_requests_total{method="GET",route="/users/{username}"} 51`,
+		`glad_http_request_errors_total{method="GET",route="/users/{username}",class="5xx"} 1`,
+	} {
+		if !strings.Contains(response.Body, line+"\n") {
+			t.Errorf("Expected output to contain %q, got:\n%s", line, response.Body)
+		}
+	}
+	if strings.Contains(response.Body, "/users/alice") {
+		t.Error("Expected concrete paths not to be used as route labels")
+	}
+}
@@ -0,0 +1,156 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hackmajoris/glad-stack/pkg/logger"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// RateLimitStore tracks request allowances per caller key.
+// Implementations may be in-memory or backed by shared storage such as DynamoDB.
+type RateLimitStore interface {
+	// Allow consumes one request for key and reports whether it may proceed.
+	// When denied, it returns how long the caller should wait before retrying.
+	Allow(key string) (bool, time.Duration)
+}
+
+// RateLimitMiddleware rejects callers that exceed their request allowance
+type RateLimitMiddleware struct {
+	store RateLimitStore
+}
+
+// NewRateLimitMiddleware creates a RateLimitMiddleware backed by an in-memory token bucket store
+func NewRateLimitMiddleware(perMinute int) *RateLimitMiddleware {
+	return NewRateLimitMiddlewareWithStore(NewMemoryRateLimitStore(perMinute))
+}
+
+// NewRateLimitMiddlewareWithStore creates a RateLimitMiddleware backed by the given store
+func NewRateLimitMiddlewareWithStore(store RateLimitStore) *RateLimitMiddleware {
+	log := logger.WithComponent("middleware")
+	log.Info("Rate limit middleware initialized")
+
+	return &RateLimitMiddleware{store: store}
+}
+
+// Handler wraps a handler and enforces the rate limit for the calling user.
+// It must run after JWT validation to key by username; otherwise the source IP is used.
+func (m *RateLimitMiddleware) Handler(next HandlerFunc) HandlerFunc {
+	return func(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		key := rateLimitKey(request)
+		log := logger.WithComponent("middleware").With("operation", "RateLimit", "key", key, "path", request.Path)
+
+		allowed, retryAfter := m.store.Allow(key)
+		if !allowed {
+			log.Warn("Rate limit exceeded", "retry_after", retryAfter)
+			return tooManyRequestsResponse(retryAfter), nil
+		}
+
+		return next(request)
+	}
+}
+
+// rateLimitKey identifies the caller by username, falling back to source IP
+func rateLimitKey(request events.APIGatewayProxyRequest) string {
+	if identity, err := ExtractIdentity(request); err == nil {
+		return "user:" + identity.Username
+	}
+	return "ip:" + request.RequestContext.Identity.SourceIP
+}
+
+// tooManyRequestsResponse creates a standardized 429 response with a Retry-After header
+func tooManyRequestsResponse(retryAfter time.Duration) events.APIGatewayProxyResponse {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusTooManyRequests,
+		Headers: map[string]string{
+			"Content-Type": "application/json",
+			"Retry-After":  strconv.Itoa(seconds),
+		},
+		Body: `{"error": "Rate limit exceeded", "code": "TOO_MANY_REQUESTS"}`,
+	}
+}
+
+// bucketIdleTTL is how long a bucket refills for before it is full again. From then on it holds
+// nothing a new bucket would not, so buckets idle for this long are dropped.
+const bucketIdleTTL = time.Minute
+
+// tokenBucket holds the remaining allowance for a single key
+type tokenBucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// MemoryRateLimitStore is a token bucket store kept in process memory.
+// State is per Lambda container, so limits are approximate across concurrent instances.
+// Buckets idle for bucketIdleTTL are evicted, so the store only holds recent callers.
+type MemoryRateLimitStore struct {
+	mutex     sync.Mutex
+	buckets   map[string]*tokenBucket
+	capacity  float64
+	rate      float64 // tokens refilled per second
+	now       func() time.Time
+	lastSweep time.Time
+}
+
+// NewMemoryRateLimitStore creates an in-memory store allowing perMinute requests per key
+func NewMemoryRateLimitStore(perMinute int) *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets:  make(map[string]*tokenBucket),
+		capacity: float64(perMinute),
+		rate:     float64(perMinute) / 60,
+		now:      time.Now,
+	}
+}
+
+// Allow consumes one token from key's bucket, refilling it for the time elapsed since the last call
+func (s *MemoryRateLimitStore) Allow(key string) (bool, time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+	s.evictIdle(now)
+
+	bucket, exists := s.buckets[key]
+	if !exists {
+		bucket = &tokenBucket{tokens: s.capacity, lastSeen: now}
+		s.buckets[key] = bucket
+	}
+
+	bucket.tokens = math.Min(s.capacity, bucket.tokens+now.Sub(bucket.lastSeen).Seconds()*s.rate)
+	bucket.lastSeen = now
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+
+	if s.rate <= 0 {
+		return false, time.Minute
+	}
+	wait := time.Duration((1 - bucket.tokens) / s.rate * float64(time.Second))
+	return false, wait
+}
+
+// evictIdle drops buckets unused for bucketIdleTTL, scanning at most once per bucketIdleTTL
+// so the cost per request stays constant on average. Callers must hold the mutex.
+func (s *MemoryRateLimitStore) evictIdle(now time.Time) {
+	if now.Sub(s.lastSweep) < bucketIdleTTL {
+		return
+	}
+	for key, bucket := range s.buckets {
+		if now.Sub(bucket.lastSeen) >= bucketIdleTTL {
+			delete(s.buckets, key)
+		}
+	}
+	s.lastSweep = now
+}
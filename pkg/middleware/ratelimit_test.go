@@ -0,0 +1,124 @@
+package middleware
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hackmajoris/glad-stack/pkg/auth"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+func TestMemoryRateLimitStore_Allow(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryRateLimitStore(2)
+	store.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if allowed, _ := store.Allow("user:alice"); !allowed {
+			t.Fatalf("Expected request %d to be allowed", i+1)
+		}
+	}
+
+	allowed, retryAfter := store.Allow("user:alice")
+	if allowed {
+		t.Fatal("Expected third request to be rejected")
+	}
+	if retryAfter != 30*time.Second {
+		t.Errorf("Expected retry after 30s, got %v", retryAfter)
+	}
+
+	if allowed, _ := store.Allow("user:bob"); !allowed {
+		t.Error("Expected other keys to have their own bucket")
+	}
+
+	now = now.Add(30 * time.Second)
+	if allowed, _ := store.Allow("user:alice"); !allowed {
+		t.Error("Expected request to be allowed after refill")
+	}
+}
+
+func TestMemoryRateLimitStore_EvictsIdleBuckets(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryRateLimitStore(2)
+	store.now = func() time.Time { return now }
+
+	for _, key := range []string{"ip:10.0.0.1", "ip:10.0.0.2", "user:alice"} {
+		store.Allow(key)
+	}
+
+	now = now.Add(bucketIdleTTL / 2)
+	store.Allow("user:alice")
+	store.Allow("user:alice")
+	if allowed, _ := store.Allow("user:alice"); allowed {
+		t.Fatal("Expected alice to have used up her allowance")
+	}
+
+	now = now.Add(bucketIdleTTL / 2)
+	store.Allow("user:bob")
+	if len(store.buckets) != 2 {
+		t.Errorf("Expected the idle buckets to be evicted, got %d buckets", len(store.buckets))
+	}
+	if _, kept := store.buckets["user:alice"]; !kept {
+		t.Error("Expected the recently used bucket to be kept")
+	}
+}
+
+func TestRateLimitMiddleware_Handler(t *testing.T) {
+	okHandler := func(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		return events.APIGatewayProxyResponse{StatusCode: 200}, nil
+	}
+
+	tests := []struct {
+		name    string
+		request func(i int) events.APIGatewayProxyRequest
+	}{
+		{
+			name: "keyed by claims username",
+			request: func(i int) events.APIGatewayProxyRequest {
+				// Source IP varies but the username is the same
+				request := events.APIGatewayProxyRequest{
+					RequestContext: events.APIGatewayProxyRequestContext{
+						Authorizer: map[string]interface{}{"claims": &auth.JWTClaims{Username: "testuser"}},
+					},
+				}
+				request.RequestContext.Identity.SourceIP = fmt.Sprintf("10.0.0.%d", i+1)
+				return request
+			},
+		},
+		{
+			name: "falls back to source IP",
+			request: func(i int) events.APIGatewayProxyRequest {
+				request := events.APIGatewayProxyRequest{}
+				request.RequestContext.Identity.SourceIP = "10.0.0.1"
+				return request
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewRateLimitMiddleware(1).Handler(okHandler)
+
+			response, err := handler(tt.request(0))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if response.StatusCode != 200 {
+				t.Fatalf("Expected first request to succeed, got %d", response.StatusCode)
+			}
+
+			response, _ = handler(tt.request(1))
+			if response.StatusCode != 429 {
+				t.Fatalf("Expected status 429, got %d", response.StatusCode)
+			}
+			if response.Headers["Retry-After"] != "60" {
+				t.Errorf("Expected Retry-After 60, got %q", response.Headers["Retry-After"])
+			}
+			if response.Body != `{"error": "Rate limit exceeded", "code": "TOO_MANY_REQUESTS"}` {
+				t.Errorf("Unexpected body: %s", response.Body)
+			}
+		})
+	}
+}